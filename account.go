@@ -101,6 +101,24 @@ func (c *Client) Account() (*AccountInfo, error) {
 	return resp, err
 }
 
+// AccountCommission get current account commission rates for the symbol
+func (c *Client) AccountCommission(req *AccountCommissionReq) (*AccountCommission, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, ErrEmptySymbol
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointAccountCommission, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &AccountCommission{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
 // OrderRateLimit get the user's current order count usage for all intervals.
 func (c *Client) OrderRateLimit() ([]*RateLimit, error) {
 	res, err := c.Do(fasthttp.MethodGet, EndpointRateLimit, nil, true, false)
@@ -84,10 +84,19 @@ func (s *mockedAccountTestSuite) TestAccount() {
 			TakerCommission:  15,
 			BuyerCommission:  0,
 			SellerCommission: 0,
-			CanTrade:         true,
-			CanWithdraw:      true,
-			CanDeposit:       true,
-			AccountType:      binance.PermissionTypeSpot,
+			CommissionRates: binance.CommissionRates{
+				Maker:  "0.00150000",
+				Taker:  "0.00150000",
+				Buyer:  "0.00000000",
+				Seller: "0.00000000",
+			},
+			CanTrade:                   true,
+			CanWithdraw:                true,
+			CanDeposit:                 true,
+			RequireSelfTradePrevention: true,
+			UpdateTime:                 int64(rand.Uint32()),
+			AccountType:                binance.PermissionTypeSpot,
+			UID:                        int64(rand.Uint32()),
 			Balances: []*binance.Balance{{
 				Asset:  "SNM",
 				Free:   "1",
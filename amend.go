@@ -0,0 +1,75 @@
+package binance
+
+import (
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+	"github.com/xenking/decimal"
+)
+
+// AmendOrderKeepPriority reduces the quantity of an open order without losing its priority in the order book
+func (c *Client) AmendOrderKeepPriority(req *AmendOrderReq) (*AmendOrder, error) {
+	if err := validateAmendOrderReq(req); err != nil {
+		return nil, err
+	}
+	res, err := c.Do(fasthttp.MethodPut, EndpointOrderAmend, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &AmendOrder{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// OrderAmendments get all amendments of a single order
+func (c *Client) OrderAmendments(req *OrderAmendmentsReq) ([]*OrderAmendment, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, ErrEmptySymbol
+	}
+	if req.OrderID == 0 {
+		return nil, ErrEmptyOrderID
+	}
+	if req.Limit < 0 || req.Limit > MaxAmendmentsLimit {
+		req.Limit = DefaultAmendmentsLimit
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointOrderAmendments, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*OrderAmendment
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+func validateAmendOrderReq(req *AmendOrderReq) error {
+	switch {
+	case req == nil:
+		return ErrNilRequest
+	case req.Symbol == "":
+		return ErrEmptySymbol
+	case req.OrderID == 0 && req.OrigClientOrderID == "":
+		return ErrEmptyOrderID
+	case req.NewQty == "":
+		return ErrEmptyQuantity
+	}
+	newQty, err := decimal.NewFromString(req.NewQty)
+	if err != nil || !newQty.IsPositive() {
+		return ErrInvalidQuantity
+	}
+	if req.CurrentQty == "" {
+		return nil
+	}
+	currentQty, err := decimal.NewFromString(req.CurrentQty)
+	if err != nil {
+		return ErrInvalidQuantity
+	}
+	if !newQty.LessThan(currentQty) {
+		return ErrAmendQtyNotDecreased
+	}
+
+	return nil
+}
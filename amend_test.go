@@ -0,0 +1,128 @@
+package binance_test
+
+import (
+	"math/rand"
+
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+)
+
+type mockedAmendTestSuite struct {
+	mockedTestSuite
+}
+
+func (s *mockedAmendTestSuite) TestAmendOrderKeepPriority() {
+	var expected *binance.AmendOrder
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(fasthttp.MethodPut, method)
+		s.Require().Equal(binance.EndpointOrderAmend, endpoint)
+		s.Require().IsType(&binance.AmendOrderReq{}, data)
+		req := data.(*binance.AmendOrderReq)
+		expected = &binance.AmendOrder{
+			TransactTime: int64(rand.Uint32()),
+			ExecutionID:  int64(rand.Uint32()),
+			AmendedOrder: binance.AmendedOrder{
+				Symbol:             req.Symbol,
+				OrderID:            req.OrderID,
+				OrderListID:        -1,
+				OrigClientOrderID:  "original-id",
+				ClientOrderID:      req.NewClientOrderID,
+				Price:              "0.1",
+				Qty:                req.NewQty,
+				ExecutedQty:        "0",
+				PreventedQty:       "0",
+				QuoteOrderQty:      "0",
+				CumulativeQuoteQty: "0",
+				Status:             binance.OrderStatusNew,
+				TimeInForce:        binance.TimeInForceGTC,
+				Type:               binance.OrderTypeLimit,
+				Side:               binance.OrderSideSell,
+				WorkingTime:        int64(rand.Uint32()),
+			},
+		}
+		return json.Marshal(expected)
+	}
+
+	actual, e := s.client.AmendOrderKeepPriority(&binance.AmendOrderReq{
+		Symbol:           "LTCBTC",
+		OrderID:          int64(rand.Uint32()),
+		NewClientOrderID: "amended-id",
+		NewQty:           "0.5",
+		CurrentQty:       "1",
+	})
+	s.Require().NoError(e)
+	s.Require().EqualValues(expected, actual)
+	s.Require().Nil(actual.ListStatus)
+}
+
+func (s *mockedAmendTestSuite) TestAmendOrderKeepPriorityList() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		return []byte(`{"transactTime":1741669661670,"executionId":22,"amendedOrder":{"symbol":"BTCUSDT","orderId":9,"orderListId":1,` +
+			`"origClientOrderId":"xbxXh5SSwaHS7oUEOCI88B","clientOrderId":"e7bde2V2lQvn8Ed9UyH8RL","price":"0.00000000","qty":"4.00000000",` +
+			`"executedQty":"0.00000000","preventedQty":"0.00000000","quoteOrderQty":"0.00000000","cumulativeQuoteQty":"0.00000000",` +
+			`"status":"NEW","timeInForce":"GTC","type":"STOP_LOSS","side":"SELL","stopPrice":"1.00000000","workingTime":-1,` +
+			`"selfTradePreventionMode":"NONE"},"listStatus":{"orderListId":1,"contingencyType":"OCO","listOrderStatus":"EXECUTING",` +
+			`"listClientOrderId":"8Tmjgm5zvOkJMZRCJjOdgf","symbol":"BTCUSDT","orders":[{"symbol":"BTCUSDT","orderId":9,` +
+			`"clientOrderId":"e7bde2V2lQvn8Ed9UyH8RL"},{"symbol":"BTCUSDT","orderId":8,"clientOrderId":"ajOn5Yj2h0OjeCsEEGbNSi"}]}}`), nil
+	}
+
+	actual, e := s.client.AmendOrderKeepPriority(&binance.AmendOrderReq{
+		Symbol:            "BTCUSDT",
+		OrigClientOrderID: "xbxXh5SSwaHS7oUEOCI88B",
+		NewQty:            "4",
+	})
+	s.Require().NoError(e)
+	s.Require().Equal("4.00000000", actual.AmendedOrder.Qty)
+	s.Require().NotNil(actual.ListStatus)
+	s.Require().Equal(binance.ContingencyTypeOCO, actual.ListStatus.ContingencyType)
+	s.Require().Len(actual.ListStatus.Orders, 2)
+}
+
+func (s *mockedAmendTestSuite) TestAmendOrderKeepPriorityValidation() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.FailNow("request must not be sent")
+		return nil, nil
+	}
+
+	_, e := s.client.AmendOrderKeepPriority(&binance.AmendOrderReq{Symbol: "LTCBTC", NewQty: "1"})
+	s.Require().ErrorIs(e, binance.ErrEmptyOrderID)
+
+	_, e = s.client.AmendOrderKeepPriority(&binance.AmendOrderReq{Symbol: "LTCBTC", OrderID: 1, NewQty: "0"})
+	s.Require().ErrorIs(e, binance.ErrInvalidQuantity)
+
+	_, e = s.client.AmendOrderKeepPriority(&binance.AmendOrderReq{Symbol: "LTCBTC", OrderID: 1, NewQty: "2", CurrentQty: "1.5"})
+	s.Require().ErrorIs(e, binance.ErrAmendQtyNotDecreased)
+}
+
+func (s *mockedAmendTestSuite) TestOrderAmendments() {
+	var expected []*binance.OrderAmendment
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(fasthttp.MethodGet, method)
+		s.Require().Equal(binance.EndpointOrderAmendments, endpoint)
+		s.Require().IsType(&binance.OrderAmendmentsReq{}, data)
+		req := data.(*binance.OrderAmendmentsReq)
+		s.Require().Equal(binance.DefaultAmendmentsLimit, req.Limit)
+		expected = []*binance.OrderAmendment{
+			{
+				Symbol:            req.Symbol,
+				OrderID:           req.OrderID,
+				ExecutionID:       int64(rand.Uint32()),
+				OrigClientOrderID: "original-id",
+				NewClientOrderID:  "amended-id",
+				OrigQty:           "1",
+				NewQty:            "0.5",
+				Time:              int64(rand.Uint32()),
+			},
+		}
+		return json.Marshal(expected)
+	}
+
+	actual, e := s.client.OrderAmendments(&binance.OrderAmendmentsReq{Symbol: "LTCBTC", OrderID: 1, Limit: 5000})
+	s.Require().NoError(e)
+	s.Require().EqualValues(expected, actual)
+
+	_, e = s.client.OrderAmendments(&binance.OrderAmendmentsReq{Symbol: "LTCBTC"})
+	s.Require().ErrorIs(e, binance.ErrEmptyOrderID)
+}
@@ -0,0 +1,288 @@
+package backtest
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/paper"
+	"github.com/xenking/binance-api/replay"
+)
+
+const DefaultEquityInterval = time.Minute
+
+var (
+	ErrEmptySymbols    = errors.New("symbols are empty")
+	ErrNilStrategy     = errors.New("strategy is nil")
+	ErrNilSource       = errors.New("source is nil")
+	ErrInvalidShare    = errors.New("volume share must be in (0, 1]")
+	ErrNegativeLatency = errors.New("latency is negative")
+)
+
+// Config configures the backtest
+type Config struct {
+	Symbols         []*binance.SymbolInfo      // Symbols are the traded symbols, orders are validated against their filters
+	Balances        map[string]decimal.Decimal // Balances are the initial free balances by asset
+	MakerCommission decimal.Decimal            // MakerCommission is the maker rate, e.g. 0.001
+	TakerCommission decimal.Decimal            // TakerCommission is the taker rate, e.g. 0.001
+	// QuoteAsset is the asset the equity is valued in, defaults to the quote asset of the first symbol.
+	// Assets without the replayed price in the quote asset aren't valued
+	QuoteAsset string
+	// Latency is the time the request takes to reach the exchange,
+	// the replayed market moves on while the request is in flight
+	Latency time.Duration
+	// VolumeShare is the share of the replayed quantities available to the simulated orders, defaults to 1.
+	// The share below 1 models the queue position and competing orders, orders larger than the share are filled partially
+	VolumeShare    decimal.Decimal
+	EquityInterval time.Duration // EquityInterval is the interval of the equity curve points
+}
+
+func (c Config) defaults() Config {
+	if c.QuoteAsset == "" && len(c.Symbols) > 0 {
+		c.QuoteAsset = c.Symbols[0].QuoteAsset
+	}
+	if c.VolumeShare.IsZero() {
+		c.VolumeShare = decimal.NewFromInt(1)
+	}
+	if c.EquityInterval == 0 {
+		c.EquityInterval = DefaultEquityInterval
+	}
+
+	return c
+}
+
+func (c Config) validate() error {
+	switch {
+	case len(c.Symbols) == 0:
+		return ErrEmptySymbols
+	case c.VolumeShare.Sign() <= 0 || c.VolumeShare.GreaterThan(decimal.NewFromInt(1)):
+		return ErrInvalidShare
+	case c.Latency < 0:
+		return ErrNegativeLatency
+	}
+
+	return nil
+}
+
+// Strategy is run against the replayed market data.
+// The client trades on the simulated exchange with the same requests as the live one:
+// OrderReq, OCOReq, CancelOrderReq and the queries of orders, trades and account
+type Strategy interface {
+	// Start is called before the first event
+	Start(client *binance.Client) error
+	// OnEvent is called for each replayed event including the gap markers
+	OnEvent(e *replay.Event) error
+}
+
+// TradeHandler is implemented by the strategies which are notified of their trades,
+// the trades are reported before the first event following them
+type TradeHandler interface {
+	OnTrade(t *binance.AccountTrade) error
+}
+
+// Backtester replays the source through the simulated matching engine and the strategy.
+// Orders are matched by the paper trading client against the order books built from the replayed events,
+// resting orders are filled as makers at their limit price when the replayed price crosses it
+type Backtester struct {
+	source   replay.Source
+	strategy Strategy
+	config   Config
+	market   *market
+	paper    *paper.Client
+	client   *binance.Client
+
+	now        int64
+	next       *replay.Event // next is the event read from the source ahead of the time
+	pending    []*replay.Event
+	reported   int
+	equity     []EquityPoint
+	nextSample int64
+}
+
+// New creates the backtest of the strategy
+func New(source replay.Source, strategy Strategy, config Config) (*Backtester, error) {
+	cfg := config.defaults()
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	switch {
+	case source == nil:
+		return nil, ErrNilSource
+	case strategy == nil:
+		return nil, ErrNilStrategy
+	}
+
+	b := &Backtester{source: source, strategy: strategy, config: cfg}
+	b.market = newMarket(cfg.Symbols, cfg.VolumeShare, b.clock)
+	b.paper = paper.NewClient(b.market, paper.Config{
+		Balances:        cfg.Balances,
+		MakerCommission: cfg.MakerCommission,
+		TakerCommission: cfg.TakerCommission,
+		Symbols:         cfg.Symbols,
+		Now: func() time.Time {
+			return time.UnixMilli(b.now)
+		},
+	})
+	b.client = binance.NewCustomClient(&exchange{b: b})
+
+	return b, nil
+}
+
+// Run replays the source until its end and returns the backtest result.
+// The strategy errors stop the backtest
+func (b *Backtester) Run(ctx context.Context) (*Result, error) {
+	if err := b.strategy.Start(b.client); err != nil {
+		return nil, errors.Wrap(err, "start strategy")
+	}
+	handler, _ := b.strategy.(TradeHandler)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var e *replay.Event
+		if len(b.pending) > 0 {
+			e = b.pending[0]
+			b.pending = b.pending[1:]
+		} else {
+			var err error
+			if e, err = b.read(); err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, err
+			}
+			if err = b.apply(e); err != nil {
+				return nil, err
+			}
+		}
+
+		if handler != nil {
+			if err := b.report(handler, e.Time); err != nil {
+				return nil, err
+			}
+		}
+		if err := b.strategy.OnEvent(e); err != nil {
+			return nil, err
+		}
+	}
+	if handler != nil {
+		if err := b.report(handler, b.now); err != nil {
+			return nil, err
+		}
+	}
+	b.sample(b.now, true)
+
+	return b.result(), nil
+}
+
+// Time returns the current time of the backtest
+func (b *Backtester) Time() time.Time {
+	return time.UnixMilli(b.now)
+}
+
+// read returns the next event of the source
+func (b *Backtester) read() (*replay.Event, error) {
+	if b.next != nil {
+		e := b.next
+		b.next = nil
+		return e, nil
+	}
+
+	return b.source.Next()
+}
+
+// apply moves the market to the event and fills the open orders against it
+func (b *Backtester) apply(e *replay.Event) error {
+	if e.Time > b.now {
+		b.now = e.Time
+	}
+	books, err := b.market.apply(e)
+	if err != nil {
+		return errors.Wrapf(err, "%s %s event", e.Symbol, e.Stream)
+	}
+	for _, d := range books {
+		b.paper.UpdateBook(e.Symbol, d)
+	}
+	b.sample(b.now, false)
+
+	return nil
+}
+
+// advance applies the events up to the time, they are delivered to the strategy later
+func (b *Backtester) advance(to int64) error {
+	for {
+		e, err := b.read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if e.Time > to {
+			b.next = e
+			break
+		}
+		if err = b.apply(e); err != nil {
+			return err
+		}
+		b.pending = append(b.pending, e)
+	}
+	if to > b.now {
+		b.now = to
+	}
+
+	return nil
+}
+
+// report notifies the handler of the trades executed until the time
+func (b *Backtester) report(h TradeHandler, until int64) error {
+	trades := b.paper.Trades()
+	for ; b.reported < len(trades); b.reported++ {
+		t := trades[b.reported]
+		if t.Time > until {
+			break
+		}
+		if err := h.OnTrade(t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *Backtester) clock() int64 {
+	return b.now
+}
+
+// exchange delays the requests of the strategy by the latency and executes them on the paper client
+type exchange struct {
+	b *Backtester
+}
+
+func (e *exchange) Do(method, endpoint string, data interface{}, sign, stream bool) ([]byte, error) {
+	if latency := e.b.config.Latency.Milliseconds(); latency > 0 {
+		if err := e.b.advance(e.b.now + latency); err != nil {
+			return nil, err
+		}
+	}
+
+	return e.b.paper.Do(method, endpoint, data, sign, stream)
+}
+
+func (e *exchange) SetWindow(int) {}
+
+func (e *exchange) UsedWeight() map[string]int64 {
+	return map[string]int64{}
+}
+
+func (e *exchange) OrderCount() map[string]int64 {
+	return map[string]int64{}
+}
+
+func (e *exchange) RetryAfter() int64 {
+	return 0
+}
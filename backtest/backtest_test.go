@@ -0,0 +1,326 @@
+package backtest_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/backtest"
+	"github.com/xenking/binance-api/recorder"
+	"github.com/xenking/binance-api/replay"
+	"github.com/xenking/binance-api/ws"
+)
+
+func TestBacktest(t *testing.T) {
+	suite.Run(t, new(backtestTestSuite))
+}
+
+type sliceSource []*replay.Event
+
+func (s *sliceSource) Next() (*replay.Event, error) {
+	if len(*s) == 0 {
+		return nil, io.EOF
+	}
+	e := (*s)[0]
+	*s = (*s)[1:]
+
+	return e, nil
+}
+
+// strategy calls the functions in place of the strategy methods
+type strategy struct {
+	client  *binance.Client
+	onEvent func(e *replay.Event) error
+	onTrade func(t *binance.AccountTrade) error
+	log     []string
+}
+
+func (s *strategy) Start(client *binance.Client) error {
+	s.client = client
+	return nil
+}
+
+func (s *strategy) OnEvent(e *replay.Event) error {
+	s.log = append(s.log, "event")
+	if s.onEvent == nil {
+		return nil
+	}
+	return s.onEvent(e)
+}
+
+func (s *strategy) OnTrade(t *binance.AccountTrade) error {
+	s.log = append(s.log, "trade "+t.Price)
+	if s.onTrade == nil {
+		return nil
+	}
+	return s.onTrade(t)
+}
+
+type backtestTestSuite struct {
+	suite.Suite
+	config backtest.Config
+}
+
+func (s *backtestTestSuite) SetupTest() {
+	s.config = backtest.Config{
+		Symbols: []*binance.SymbolInfo{{
+			Symbol:     "BTCUSDT",
+			Status:     binance.SymbolStatusTrading,
+			BaseAsset:  "BTC",
+			QuoteAsset: "USDT",
+			OrderTypes: []binance.OrderType{
+				binance.OrderTypeLimit, binance.OrderTypeLimitMaker, binance.OrderTypeMarket,
+				binance.OrderTypeStopLoss, binance.OrderTypeStopLossLimit,
+				binance.OrderTypeTakeProfit, binance.OrderTypeTakeProfitLimit,
+			},
+			OCOAllowed: true,
+			Filters: []binance.SymbolInfoFilter{
+				{Type: binance.FilterTypeLotSize, MinQty: "0.001", MaxQty: "1000", StepSize: "0.001"},
+			},
+		}},
+		Balances:        map[string]decimal.Decimal{"USDT": decimal.NewFromInt(1000)},
+		MakerCommission: decimal.Zero,
+		TakerCommission: decimal.RequireFromString("0.001"),
+	}
+}
+
+func trade(t int64, price, qty string) *replay.Event {
+	return &replay.Event{
+		Time:   t,
+		Symbol: "BTCUSDT",
+		Stream: recorder.StreamTrades,
+		Value: &ws.TradeUpdate{
+			EventType: ws.UpdateTypeTrades, Symbol: "BTCUSDT", Time: t, TradeTime: t, Price: price, Quantity: qty,
+		},
+	}
+}
+
+func (s *backtestTestSuite) run(st *strategy, events ...*replay.Event) *backtest.Result {
+	source := sliceSource(events)
+	b, err := backtest.New(&source, st, s.config)
+	s.Require().NoError(err)
+	r, err := b.Run(context.Background())
+	s.Require().NoError(err)
+
+	return r
+}
+
+func (s *backtestTestSuite) TestMarketAndLimit() {
+	st := &strategy{}
+	st.onEvent = func(e *replay.Event) error {
+		if e.Time != 1000 {
+			return nil
+		}
+		resp, err := st.client.NewOrderFull(&binance.OrderReq{
+			Symbol: "BTCUSDT", Side: binance.OrderSideBuy, Type: binance.OrderTypeMarket, Quantity: "1",
+		})
+		s.Require().NoError(err)
+		s.Require().Equal(binance.OrderStatusFilled, resp.Status)
+
+		_, err = st.client.NewOrder(&binance.OrderReq{
+			Symbol: "BTCUSDT", Side: binance.OrderSideSell, Type: binance.OrderTypeLimit,
+			TimeInForce: binance.TimeInForceGTC, Quantity: "0.999", Price: "108",
+		})
+		s.Require().NoError(err)
+		return nil
+	}
+	r := s.run(st, trade(1000, "100", "1"), trade(2000, "105", "0.5"), trade(3000, "110", "2"))
+
+	s.Require().Len(r.Trades, 2)
+	s.Require().Equal("100", r.Trades[0].Price)
+	s.Require().False(r.Trades[0].Maker)
+	s.Require().Equal("108", r.Trades[1].Price)
+	s.Require().True(r.Trades[1].Maker)
+	s.Require().EqualValues(3000, r.Trades[1].Time)
+	s.Require().Equal([]string{"event", "trade 100", "event", "trade 108", "event"}, st.log)
+
+	s.Require().Len(r.Equity, 2)
+	s.Require().Equal("1000", r.Stats.StartEquity.String())
+	s.Require().Equal("1007.892", r.Stats.EndEquity.String())
+	s.Require().InDelta(0.007892, r.Stats.Return, 1e-9)
+	s.Require().Equal(1, r.Stats.RoundTrips)
+	s.Require().Equal(1, r.Stats.Wins)
+	s.Require().Equal("7.892", r.Stats.RealizedPnL.Round(6).String())
+	s.Require().Equal("0.1", r.Stats.Commission.String())
+	s.Require().Equal("207.892", r.Stats.Volume.String())
+}
+
+func (s *backtestTestSuite) TestFilters() {
+	st := &strategy{}
+	st.onEvent = func(e *replay.Event) error {
+		_, err := st.client.NewOrder(&binance.OrderReq{
+			Symbol: "BTCUSDT", Side: binance.OrderSideBuy, Type: binance.OrderTypeMarket, Quantity: "0.0001",
+		})
+		apiErr := &binance.APIError{}
+		s.Require().ErrorAs(err, &apiErr)
+		s.Require().Equal(binance.ErrorCodeFilterFailure, apiErr.Code)
+
+		_, err = st.client.NewOrder(&binance.OrderReq{
+			Symbol: "BTCUSDT", Side: binance.OrderSideBuy, Type: binance.OrderTypeLimitMaker, Quantity: "1", Price: "101",
+		})
+		s.Require().ErrorAs(err, &apiErr)
+		s.Require().Equal(binance.ErrorCodeNewOrderRejected, apiErr.Code)
+		return nil
+	}
+	r := s.run(st, trade(1000, "100", "1"))
+	s.Require().Empty(r.Trades)
+}
+
+func (s *backtestTestSuite) TestPartialFill() {
+	s.config.VolumeShare = decimal.RequireFromString("0.5")
+	st := &strategy{}
+	st.onEvent = func(e *replay.Event) error {
+		if e.Time != 1000 {
+			return nil
+		}
+		resp, err := st.client.NewOrderFull(&binance.OrderReq{
+			Symbol: "BTCUSDT", Side: binance.OrderSideBuy, Type: binance.OrderTypeMarket, Quantity: "1",
+		})
+		s.Require().NoError(err)
+		s.Require().Equal(binance.OrderStatusExpired, resp.Status)
+		s.Require().Equal("0.5", resp.ExecutedQty)
+
+		_, err = st.client.NewOrder(&binance.OrderReq{
+			Symbol: "BTCUSDT", Side: binance.OrderSideBuy, Type: binance.OrderTypeLimit,
+			TimeInForce: binance.TimeInForceGTC, Quantity: "1", Price: "99",
+		})
+		s.Require().NoError(err)
+		return nil
+	}
+	r := s.run(st, trade(1000, "100", "1"), trade(2000, "98", "0.6"), trade(3000, "97", "2"))
+
+	s.Require().Len(r.Trades, 3)
+	s.Require().Equal("0.3", r.Trades[1].Qty)
+	s.Require().Equal("0.7", r.Trades[2].Qty)
+	s.Require().Equal("99", r.Trades[2].Price)
+}
+
+func (s *backtestTestSuite) TestLatency() {
+	s.config.Latency = 1500 * time.Millisecond
+	st := &strategy{}
+	var orderTime int64
+	st.onEvent = func(e *replay.Event) error {
+		if e.Time != 1000 {
+			return nil
+		}
+		resp, err := st.client.NewOrderFull(&binance.OrderReq{
+			Symbol: "BTCUSDT", Side: binance.OrderSideBuy, Type: binance.OrderTypeMarket, Quantity: "0.5",
+		})
+		s.Require().NoError(err)
+		orderTime = resp.TransactTime
+		return nil
+	}
+	r := s.run(st, trade(1000, "100", "1"), trade(2000, "120", "1"), trade(3000, "130", "1"))
+
+	// the order reaches the exchange after the price moved
+	s.Require().EqualValues(2500, orderTime)
+	s.Require().Len(r.Trades, 1)
+	s.Require().Equal("120", r.Trades[0].Price)
+	// the events received while the request was in flight are delivered before the trade
+	s.Require().Equal([]string{"event", "event", "trade 120", "event"}, st.log)
+}
+
+func (s *backtestTestSuite) TestOCO() {
+	s.config.Balances = map[string]decimal.Decimal{"BTC": decimal.NewFromInt(1)}
+	st := &strategy{}
+	var list *binance.OCOOrder
+	st.onEvent = func(e *replay.Event) error {
+		if e.Time != 1000 {
+			return nil
+		}
+		var err error
+		list, err = st.client.NewOCO(&binance.OCOReq{
+			Symbol: "BTCUSDT", Side: binance.OrderSideSell, Quantity: "1",
+			Price: "110", StopPrice: "95", StopLimitPrice: "94", StopLimitTimeInForce: binance.TimeInForceGTC,
+		})
+		s.Require().NoError(err)
+		return nil
+	}
+	r := s.run(st, trade(1000, "100", "1"), trade(2000, "97", "1"), trade(3000, "94.5", "2"), trade(4000, "111", "2"))
+
+	s.Require().Len(r.Trades, 1)
+	s.Require().Equal("94.5", r.Trades[0].Price)
+	s.Require().False(r.Trades[0].Maker)
+	for _, o := range list.Orders {
+		q, err := st.client.QueryOrder(&binance.QueryOrderReq{Symbol: "BTCUSDT", OrderID: int64(o.OrderID)})
+		s.Require().NoError(err)
+		if q.Type == binance.OrderTypeLimitMaker {
+			s.Require().Equal(binance.OrderStatusExpired, q.Status)
+		} else {
+			s.Require().Equal(binance.OrderStatusFilled, q.Status)
+		}
+	}
+	s.Require().Zero(r.Stats.RoundTrips)
+	s.Require().Equal("94.4055", r.Stats.EndEquity.String())
+}
+
+func (s *backtestTestSuite) TestKlines() {
+	st := &strategy{}
+	st.onEvent = func(e *replay.Event) error {
+		if e.Time != 1000 {
+			return nil
+		}
+		_, err := st.client.NewOrder(&binance.OrderReq{
+			Symbol: "BTCUSDT", Side: binance.OrderSideBuy, Type: binance.OrderTypeTakeProfitLimit,
+			TimeInForce: binance.TimeInForceGTC, Quantity: "1", Price: "92", StopPrice: "92",
+		})
+		s.Require().NoError(err)
+		return nil
+	}
+	k := &ws.KlinesUpdate{EventType: ws.UpdateTypeKline, Time: 60000, Symbol: "BTCUSDT"}
+	k.Kline.OpenPrice, k.Kline.High, k.Kline.Low, k.Kline.ClosePrice = "100", "110", "90", "105"
+	k.Kline.Volume, k.Kline.Final = "4", true
+	r := s.run(st, trade(1000, "100", "1"), &replay.Event{Time: 60000, Symbol: "BTCUSDT", Stream: recorder.StreamKlines, Value: k})
+
+	// the bullish bar visits the low first
+	s.Require().Len(r.Trades, 1)
+	s.Require().Equal("90", r.Trades[0].Price)
+	s.Require().Equal("1", r.Trades[0].Qty)
+	s.Require().Len(r.Equity, 2)
+	s.Require().Equal("1014.895", r.Stats.EndEquity.String())
+}
+
+func (s *backtestTestSuite) TestRunningKlines() {
+	st := &strategy{}
+	st.onEvent = func(e *replay.Event) error {
+		if e.Time != 1000 {
+			return nil
+		}
+		_, err := st.client.NewOrder(&binance.OrderReq{
+			Symbol: "BTCUSDT", Side: binance.OrderSideBuy, Type: binance.OrderTypeLimit,
+			TimeInForce: binance.TimeInForceGTC, Quantity: "3", Price: "92",
+		})
+		s.Require().NoError(err)
+		return nil
+	}
+	kline := func(t int64, volume string, final bool) *replay.Event {
+		k := &ws.KlinesUpdate{EventType: ws.UpdateTypeKline, Time: t, Symbol: "BTCUSDT"}
+		k.Kline.OpenPrice, k.Kline.High, k.Kline.Low, k.Kline.ClosePrice = "100", "110", "90", "105"
+		k.Kline.Volume, k.Kline.Final = volume, final
+		return &replay.Event{Time: t, Symbol: "BTCUSDT", Stream: recorder.StreamKlines, Value: k}
+	}
+	r := s.run(st, trade(1000, "100", "1"),
+		kline(20000, "2", false), kline(40000, "3", false), kline(59000, "4", false), kline(60000, "4", true))
+
+	// only the final bar offers its volume
+	s.Require().Len(r.Trades, 1)
+	s.Require().Equal("92", r.Trades[0].Price)
+	s.Require().Equal("1", r.Trades[0].Qty)
+}
+
+func (s *backtestTestSuite) TestConfig() {
+	source := sliceSource(nil)
+	_, err := backtest.New(&source, &strategy{}, backtest.Config{})
+	s.Require().ErrorIs(err, backtest.ErrEmptySymbols)
+	s.config.Latency = -time.Second
+	_, err = backtest.New(&source, &strategy{}, s.config)
+	s.Require().ErrorIs(err, backtest.ErrNegativeLatency)
+	s.config.Latency = 0
+	_, err = backtest.New(&source, nil, s.config)
+	s.Require().ErrorIs(err, backtest.ErrNilStrategy)
+}
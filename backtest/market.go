@@ -0,0 +1,327 @@
+package backtest
+
+import (
+	"sort"
+
+	"github.com/go-faster/errors"
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/replay"
+	"github.com/xenking/binance-api/ws"
+)
+
+// ErrNoMarketData is returned for the public endpoints which aren't served from the replayed data
+var ErrNoMarketData = errors.New("endpoint is not served by the backtest")
+
+var errInvalidSymbol = &binance.APIError{Code: binance.ErrorCodeBadSymbol, Msg: "Invalid symbol."}
+
+// market builds the order books of the symbols from the replayed events.
+// Depth updates are applied to the local book, other events are turned into the single level books:
+// trades offer the traded quantity at the trade price on both sides, final klines walk the open, high, low and close prices.
+// The in-progress klines are skipped, they repeat the running bar which is replayed once it's final
+type market struct {
+	symbols map[string]*binance.SymbolInfo
+	share   decimal.Decimal
+	now     func() int64
+
+	books  map[string]*binance.Depth
+	depths map[string]*diffBook
+	prices map[string]decimal.Decimal
+}
+
+func newMarket(symbols []*binance.SymbolInfo, share decimal.Decimal, now func() int64) *market {
+	m := &market{
+		symbols: make(map[string]*binance.SymbolInfo, len(symbols)),
+		share:   share,
+		now:     now,
+		books:   make(map[string]*binance.Depth),
+		depths:  make(map[string]*diffBook),
+		prices:  make(map[string]decimal.Decimal),
+	}
+	for _, s := range symbols {
+		m.symbols[s.Symbol] = s
+	}
+
+	return m
+}
+
+// apply returns the books of the event in the order they should be matched
+func (m *market) apply(e *replay.Event) ([]*binance.Depth, error) {
+	s, ok := m.symbols[e.Symbol]
+	if !ok || e.Gap {
+		return nil, nil
+	}
+
+	var books []*binance.Depth
+	switch u := e.Value.(type) {
+	case *ws.TradeUpdate:
+		b, err := m.level(s, u.Price, u.Quantity, u.Price, u.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		books = append(books, b)
+	case *ws.AggTradeUpdate:
+		b, err := m.level(s, u.Price, u.Quantity, u.Price, u.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		books = append(books, b)
+	case *ws.IndividualBookTickerUpdate:
+		b, err := m.level(s, u.BidPrice, u.BidQty, u.AskPrice, u.AskQty)
+		if err != nil {
+			return nil, err
+		}
+		books = append(books, b)
+	case *ws.DepthUpdate:
+		d, ok := m.depths[s.Symbol]
+		if !ok {
+			d = newDiffBook()
+			m.depths[s.Symbol] = d
+		}
+		d.apply(u)
+		books = append(books, d.depth(func(qty decimal.Decimal) decimal.Decimal {
+			return m.quantity(s, qty)
+		}))
+	case *ws.KlinesUpdate:
+		if !u.Kline.Final {
+			return nil, nil
+		}
+		var err error
+		if books, err = m.kline(s, u); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, nil
+	}
+
+	last := books[len(books)-1]
+	m.books[s.Symbol] = last
+	if p, ok := mid(last); ok {
+		m.prices[s.Symbol] = p
+	}
+
+	return books, nil
+}
+
+// kline walks the prices of the bar, the bullish bar visits the low before the high.
+// Each of the four prices offers the quarter of the bar volume
+func (m *market) kline(s *binance.SymbolInfo, u *ws.KlinesUpdate) ([]*binance.Depth, error) {
+	k := &u.Kline
+	volume, err := decimal.NewFromString(k.Volume)
+	if err != nil {
+		return nil, errors.Wrap(err, "kline volume")
+	}
+	qty := m.quantity(s, volume.Div(decimal.NewFromInt(4))).String()
+
+	open, err := decimal.NewFromString(k.OpenPrice)
+	if err != nil {
+		return nil, errors.Wrap(err, "kline open price")
+	}
+	closePrice, err := decimal.NewFromString(k.ClosePrice)
+	if err != nil {
+		return nil, errors.Wrap(err, "kline close price")
+	}
+	path := []string{k.OpenPrice, k.High, k.Low, k.ClosePrice}
+	if closePrice.GreaterThan(open) {
+		path[1], path[2] = k.Low, k.High
+	}
+	books := make([]*binance.Depth, 0, len(path))
+	for _, p := range path {
+		b, err := m.level(s, p, qty, p, qty)
+		if err != nil {
+			return nil, err
+		}
+		books = append(books, b)
+	}
+
+	return books, nil
+}
+
+// level creates the book of the single bid and ask levels scaled by the volume share
+func (m *market) level(s *binance.SymbolInfo, bidPrice, bidQty, askPrice, askQty string) (*binance.Depth, error) {
+	b := &binance.Depth{LastUpdateID: m.now()}
+	for _, l := range []struct {
+		price, qty string
+		side       *[]binance.DepthElem
+	}{{bidPrice, bidQty, &b.Bids}, {askPrice, askQty, &b.Asks}} {
+		price, err := decimal.NewFromString(l.price)
+		if err != nil {
+			return nil, errors.Wrap(err, "price")
+		}
+		qty, err := decimal.NewFromString(l.qty)
+		if err != nil {
+			return nil, errors.Wrap(err, "quantity")
+		}
+		if qty = m.quantity(s, qty); qty.Sign() > 0 {
+			*l.side = append(*l.side, binance.DepthElem{Price: price, Quantity: qty})
+		}
+	}
+
+	return b, nil
+}
+
+// quantity scales the replayed quantity by the volume share rounded down to the step size
+func (m *market) quantity(s *binance.SymbolInfo, qty decimal.Decimal) decimal.Decimal {
+	qty = qty.Mul(m.share)
+	normalized, err := s.NormalizeQuantity(qty.String(), binance.RoundingModeDown, false)
+	if err != nil {
+		return qty
+	}
+	d, err := decimal.NewFromString(normalized)
+	if err != nil {
+		return qty
+	}
+
+	return d
+}
+
+// price returns the last price of the base asset in the quote asset
+func (m *market) price(base, quote string) (decimal.Decimal, bool) {
+	for name, s := range m.symbols {
+		if s.BaseAsset == base && s.QuoteAsset == quote {
+			p, ok := m.prices[name]
+			return p, ok
+		}
+	}
+
+	return decimal.Zero, false
+}
+
+func mid(d *binance.Depth) (decimal.Decimal, bool) {
+	switch {
+	case len(d.Bids) > 0 && len(d.Asks) > 0:
+		return d.Bids[0].Price.Add(d.Asks[0].Price).Div(decimal.NewFromInt(2)), true
+	case len(d.Bids) > 0:
+		return d.Bids[0].Price, true
+	case len(d.Asks) > 0:
+		return d.Asks[0].Price, true
+	}
+
+	return decimal.Zero, false
+}
+
+// Do serves the exchange info, time and order books of the replayed market to the paper client
+func (m *market) Do(method, endpoint string, data interface{}, _, _ bool) ([]byte, error) {
+	if method != fasthttp.MethodGet {
+		return nil, errors.Wrapf(ErrNoMarketData, "%s %s", method, endpoint)
+	}
+	switch endpoint {
+	case binance.EndpointTime:
+		return json.Marshal(&binance.ServerTime{ServerTime: m.now()})
+	case binance.EndpointExchangeInfo:
+		info := &binance.ExchangeInfo{Timezone: "UTC", ServerTime: m.now()}
+		for _, s := range m.symbols {
+			info.Symbols = append(info.Symbols, s)
+		}
+		sort.Slice(info.Symbols, func(i, j int) bool {
+			return info.Symbols[i].Symbol < info.Symbols[j].Symbol
+		})
+		return json.Marshal(info)
+	case binance.EndpointDepth:
+		req, ok := data.(*binance.DepthReq)
+		if !ok || req == nil {
+			return nil, binance.ErrNilRequest
+		}
+		if _, ok = m.symbols[req.Symbol]; !ok {
+			return nil, errInvalidSymbol
+		}
+		b, ok := m.books[req.Symbol]
+		if !ok {
+			b = &binance.Depth{}
+		}
+		return json.Marshal(&depthResp{
+			LastUpdateID: b.LastUpdateID,
+			Bids:         depthLevels(b.Bids, req.Limit),
+			Asks:         depthLevels(b.Asks, req.Limit),
+		})
+	}
+
+	return nil, errors.Wrapf(ErrNoMarketData, "%s %s", method, endpoint)
+}
+
+func (m *market) SetWindow(int) {}
+
+func (m *market) UsedWeight() map[string]int64 {
+	return map[string]int64{}
+}
+
+func (m *market) OrderCount() map[string]int64 {
+	return map[string]int64{}
+}
+
+func (m *market) RetryAfter() int64 {
+	return 0
+}
+
+// depthResp is the wire format of the order book, levels are encoded as [price, quantity] pairs
+type depthResp struct {
+	LastUpdateID int64       `json:"lastUpdateId"`
+	Bids         [][2]string `json:"bids"`
+	Asks         [][2]string `json:"asks"`
+}
+
+func depthLevels(levels []binance.DepthElem, limit int) [][2]string {
+	if limit > 0 && len(levels) > limit {
+		levels = levels[:limit]
+	}
+	resp := make([][2]string, 0, len(levels))
+	for _, l := range levels {
+		resp = append(resp, [2]string{l.Price.String(), l.Quantity.String()})
+	}
+
+	return resp
+}
+
+// diffBook is the local order book built from the depth updates without the snapshot
+type diffBook struct {
+	updateID int64
+	bids     map[string]binance.DepthElem
+	asks     map[string]binance.DepthElem
+}
+
+func newDiffBook() *diffBook {
+	return &diffBook{bids: make(map[string]binance.DepthElem), asks: make(map[string]binance.DepthElem)}
+}
+
+func (b *diffBook) apply(u *ws.DepthUpdate) {
+	b.updateID = u.FinalUpdateID
+	for _, side := range []struct {
+		levels []binance.DepthElem
+		book   map[string]binance.DepthElem
+	}{{u.Bids, b.bids}, {u.Asks, b.asks}} {
+		for _, l := range side.levels {
+			key := l.Price.String()
+			if l.Quantity.Sign() <= 0 {
+				delete(side.book, key)
+				continue
+			}
+			side.book[key] = l
+		}
+	}
+}
+
+// depth returns the sorted book with the quantities scaled by the scale function
+func (b *diffBook) depth(scale func(decimal.Decimal) decimal.Decimal) *binance.Depth {
+	d := &binance.Depth{LastUpdateID: b.updateID}
+	for _, l := range b.bids {
+		if qty := scale(l.Quantity); qty.Sign() > 0 {
+			d.Bids = append(d.Bids, binance.DepthElem{Price: l.Price, Quantity: qty})
+		}
+	}
+	for _, l := range b.asks {
+		if qty := scale(l.Quantity); qty.Sign() > 0 {
+			d.Asks = append(d.Asks, binance.DepthElem{Price: l.Price, Quantity: qty})
+		}
+	}
+	sort.Slice(d.Bids, func(i, j int) bool {
+		return d.Bids[i].Price.GreaterThan(d.Bids[j].Price)
+	})
+	sort.Slice(d.Asks, func(i, j int) bool {
+		return d.Asks[i].Price.LessThan(d.Asks[j].Price)
+	})
+
+	return d
+}
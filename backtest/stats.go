@@ -0,0 +1,201 @@
+package backtest
+
+import (
+	"math"
+
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/binance-api"
+)
+
+// EquityPoint is the value of all balances in the quote asset at the time
+type EquityPoint struct {
+	Time   int64
+	Equity decimal.Decimal
+}
+
+// Result is the outcome of the backtest
+type Result struct {
+	Trades   []*binance.AccountTrade // Trades are the executed trades in the order of execution
+	Equity   []EquityPoint           // Equity is the equity curve sampled every EquityInterval
+	Balances []*binance.Balance      // Balances are the final balances
+	Stats    Stats
+}
+
+// Stats are the summary statistics of the backtest.
+// Money values are in the quote asset, round trips are matched by the FIFO order of buys and sells of each symbol
+type Stats struct {
+	StartEquity decimal.Decimal
+	EndEquity   decimal.Decimal
+	Return      float64 // Return is the relative change of the equity
+	MaxDrawdown float64 // MaxDrawdown is the largest relative decline of the equity from its peak
+	Sharpe      float64 // Sharpe is the mean of the equity curve returns divided by their deviation, not annualized
+	Trades      int
+	Volume      decimal.Decimal // Volume is the traded quote volume
+	Commission  decimal.Decimal // Commission is the paid commission valued at the trade price
+	RoundTrips  int             // RoundTrips is the number of sells closing the bought quantity
+	Wins        int             // Wins is the number of round trips with the positive realized PnL
+	WinRate     float64
+	RealizedPnL decimal.Decimal // RealizedPnL is the PnL of the round trips net of commissions
+}
+
+// sample appends the equity point once per interval, the final point is always appended
+func (b *Backtester) sample(now int64, final bool) {
+	if !final && now < b.nextSample {
+		return
+	}
+	interval := b.config.EquityInterval.Milliseconds()
+	if interval > 0 {
+		b.nextSample = now - now%interval + interval
+	}
+	p := EquityPoint{Time: now, Equity: b.valuation()}
+	if n := len(b.equity); n > 0 && b.equity[n-1].Time == now {
+		b.equity[n-1] = p
+		return
+	}
+	b.equity = append(b.equity, p)
+}
+
+// valuation returns the value of the balances in the quote asset at the last replayed prices
+func (b *Backtester) valuation() decimal.Decimal {
+	total := decimal.Zero
+	for _, balance := range b.paper.Balances() {
+		free, err := decimal.NewFromString(balance.Free)
+		if err != nil {
+			continue
+		}
+		locked, err := decimal.NewFromString(balance.Locked)
+		if err != nil {
+			continue
+		}
+		amount := free.Add(locked)
+		if balance.Asset == b.config.QuoteAsset {
+			total = total.Add(amount)
+			continue
+		}
+		if p, ok := b.market.price(balance.Asset, b.config.QuoteAsset); ok {
+			total = total.Add(amount.Mul(p))
+		}
+	}
+
+	return total
+}
+
+func (b *Backtester) result() *Result {
+	r := &Result{
+		Trades:   b.paper.Trades(),
+		Equity:   b.equity,
+		Balances: b.paper.Balances(),
+	}
+	r.Stats = b.stats(r)
+
+	return r
+}
+
+// lot is the received quantity of the buy waiting for the sell, the price includes the commission
+type lot struct {
+	qty   decimal.Decimal
+	price decimal.Decimal
+}
+
+func (b *Backtester) stats(r *Result) Stats {
+	s := Stats{
+		Trades:      len(r.Trades),
+		Volume:      decimal.Zero,
+		Commission:  decimal.Zero,
+		RealizedPnL: decimal.Zero,
+	}
+	if n := len(r.Equity); n > 0 {
+		s.StartEquity, s.EndEquity = r.Equity[0].Equity, r.Equity[n-1].Equity
+	}
+	if s.StartEquity.Sign() > 0 {
+		s.Return = s.EndEquity.Sub(s.StartEquity).Div(s.StartEquity).InexactFloat64()
+	}
+	s.MaxDrawdown, s.Sharpe = curveStats(r.Equity)
+
+	lots := make(map[string][]lot)
+	for _, t := range r.Trades {
+		price, _ := decimal.NewFromString(t.Price)
+		qty, _ := decimal.NewFromString(t.Qty)
+		quoteQty, _ := decimal.NewFromString(t.QuoteQty)
+		commission, _ := decimal.NewFromString(t.Commission)
+		s.Volume = s.Volume.Add(quoteQty)
+
+		// the commission is taken from the received asset, the base asset of buys is valued at the trade price
+		if t.Buyer {
+			s.Commission = s.Commission.Add(commission.Mul(price))
+			if received := qty.Sub(commission); received.Sign() > 0 {
+				lots[t.Symbol] = append(lots[t.Symbol], lot{qty: received, price: quoteQty.Div(received)})
+			}
+			continue
+		}
+		s.Commission = s.Commission.Add(commission)
+		open := lots[t.Symbol]
+		if len(open) == 0 {
+			continue
+		}
+		pnl := commission.Neg()
+		for qty.Sign() > 0 && len(open) > 0 {
+			l := &open[0]
+			matched := decimal.Min(qty, l.qty)
+			pnl = pnl.Add(price.Sub(l.price).Mul(matched))
+			qty = qty.Sub(matched)
+			if l.qty = l.qty.Sub(matched); l.qty.Sign() <= 0 {
+				open = open[1:]
+			}
+		}
+		lots[t.Symbol] = open
+		s.RoundTrips++
+		if pnl.Sign() > 0 {
+			s.Wins++
+		}
+		s.RealizedPnL = s.RealizedPnL.Add(pnl)
+	}
+	if s.RoundTrips > 0 {
+		s.WinRate = float64(s.Wins) / float64(s.RoundTrips)
+	}
+
+	return s
+}
+
+// curveStats returns the maximal drawdown and the Sharpe ratio of the equity curve
+func curveStats(curve []EquityPoint) (maxDrawdown, sharpe float64) {
+	var (
+		peak    float64
+		returns []float64
+	)
+	for i, p := range curve {
+		v := p.Equity.InexactFloat64()
+		if v > peak {
+			peak = v
+		}
+		if peak > 0 {
+			maxDrawdown = math.Max(maxDrawdown, (peak-v)/peak)
+		}
+		if i == 0 {
+			continue
+		}
+		if prev := curve[i-1].Equity.InexactFloat64(); prev > 0 {
+			returns = append(returns, v/prev-1)
+		}
+	}
+	if len(returns) < 2 {
+		return maxDrawdown, 0
+	}
+
+	var mean float64
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= float64(len(returns))
+	var variance float64
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	deviation := math.Sqrt(variance / float64(len(returns)-1))
+	if deviation == 0 {
+		return maxDrawdown, 0
+	}
+
+	return maxDrawdown, mean / deviation
+}
@@ -14,6 +14,7 @@ const (
 
 type Client struct {
 	RestClient
+	withdraw bool
 }
 
 // NewClient creates a new binance client with key and secret
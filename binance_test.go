@@ -27,6 +27,15 @@ func TestMockClient(t *testing.T) {
 	suite.Run(t, new(mockedAccountTestSuite))
 	suite.Run(t, new(mockedOrderTestSuite))
 	suite.Run(t, new(mockedOCOTestSuite))
+	suite.Run(t, new(mockedSORTestSuite))
+	suite.Run(t, new(mockedAmendTestSuite))
+	suite.Run(t, new(mockedCommissionTestSuite))
+	suite.Run(t, new(mockedWalletTestSuite))
+	suite.Run(t, new(mockedSubAccountTestSuite))
+	suite.Run(t, new(mockedEarnTestSuite))
+	suite.Run(t, new(mockedConvertTestSuite))
+	suite.Run(t, new(filtersTestSuite))
+	suite.Run(t, new(clientOrderIDTestSuite))
 }
 
 type baseTestSuite struct {
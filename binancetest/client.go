@@ -0,0 +1,31 @@
+package binancetest
+
+// ResponseFunc answers the request sent by the client
+type ResponseFunc func(method, endpoint string, data interface{}, sign, stream bool) ([]byte, error)
+
+// MockClient is the RestClient answering the requests with the Response function without the network,
+// e.g. to check the request parameters in the unit tests of the clients
+type MockClient struct {
+	Response ResponseFunc
+	Window   int // Window is the last response window set by the client
+}
+
+func (m *MockClient) Do(method, endpoint string, data interface{}, sign, stream bool) ([]byte, error) {
+	return m.Response(method, endpoint, data, sign, stream)
+}
+
+func (m *MockClient) SetWindow(window int) {
+	m.Window = window
+}
+
+func (m *MockClient) UsedWeight() map[string]int64 {
+	return map[string]int64{}
+}
+
+func (m *MockClient) OrderCount() map[string]int64 {
+	return map[string]int64{}
+}
+
+func (m *MockClient) RetryAfter() int64 {
+	return 0
+}
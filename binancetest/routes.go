@@ -0,0 +1,548 @@
+package binancetest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/google/go-querystring/query"
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/binance-api"
+)
+
+type security int
+
+const (
+	securityNone security = iota
+	securityAPIKey
+	securitySigned
+)
+
+type handler func(s *Server, method, endpoint string, params url.Values) (interface{}, error)
+
+var routes = map[string]handler{
+	routeKey(fasthttp.MethodGet, binance.EndpointPing):             ping,
+	routeKey(fasthttp.MethodGet, binance.EndpointTime):             serverTime,
+	routeKey(fasthttp.MethodGet, binance.EndpointExchangeInfo):     exchangeInfo,
+	routeKey(fasthttp.MethodGet, binance.EndpointDepth):            depth,
+	routeKey(fasthttp.MethodGet, binance.EndpointTrades):           trades,
+	routeKey(fasthttp.MethodGet, binance.EndpointHistoricalTrades): trades,
+	routeKey(fasthttp.MethodGet, binance.EndpointAggTrades):        aggTrades,
+	routeKey(fasthttp.MethodGet, binance.EndpointKlines):           klines,
+	routeKey(fasthttp.MethodGet, binance.EndpointUIKlines):         klines,
+	routeKey(fasthttp.MethodGet, binance.EndpointAvgPrice):         avgPrice,
+	routeKey(fasthttp.MethodGet, binance.EndpointTicker24h):        ticker,
+	routeKey(fasthttp.MethodGet, binance.EndpointTicker):           ticker,
+	routeKey(fasthttp.MethodGet, binance.EndpointTickerPrice):      tickerPrice,
+	routeKey(fasthttp.MethodGet, binance.EndpointTickerBook):       bookTicker,
+
+	routeKey(fasthttp.MethodPost, binance.EndpointOrder):              private(func() interface{} { return &binance.OrderReq{} }),
+	routeKey(fasthttp.MethodGet, binance.EndpointOrder):               private(func() interface{} { return &binance.QueryOrderReq{} }),
+	routeKey(fasthttp.MethodDelete, binance.EndpointOrder):            private(func() interface{} { return &binance.CancelOrderReq{} }),
+	routeKey(fasthttp.MethodPost, binance.EndpointOrderTest):          private(func() interface{} { return &binance.OrderReq{} }),
+	routeKey(fasthttp.MethodPost, binance.EndpointCancelReplaceOrder): private(func() interface{} { return &binance.CancelReplaceOrderReq{} }),
+	routeKey(fasthttp.MethodGet, binance.EndpointOpenOrders):          private(func() interface{} { return &binance.OpenOrdersReq{} }),
+	routeKey(fasthttp.MethodDelete, binance.EndpointOpenOrders):       private(func() interface{} { return &binance.CancelOpenOrdersReq{} }),
+	routeKey(fasthttp.MethodGet, binance.EndpointOrdersAll):           private(func() interface{} { return &binance.AllOrdersReq{} }),
+	routeKey(fasthttp.MethodPost, binance.EndpointOCOOrder):           private(func() interface{} { return &binance.OCOReq{} }),
+	routeKey(fasthttp.MethodGet, binance.EndpointOCOOrders):           private(func() interface{} { return &binance.QueryOCOReq{} }),
+	routeKey(fasthttp.MethodDelete, binance.EndpointOCOOrders):        private(func() interface{} { return &binance.CancelOCOReq{} }),
+	routeKey(fasthttp.MethodGet, binance.EndpointOCOOrdersAll):        private(func() interface{} { return &binance.AllOCOReq{} }),
+	routeKey(fasthttp.MethodGet, binance.EndpointOpenOCOOrders):       private(nil),
+	routeKey(fasthttp.MethodPost, binance.EndpointSOROrder):           private(func() interface{} { return &binance.SOROrderReq{} }),
+	routeKey(fasthttp.MethodPost, binance.EndpointSOROrderTest):       private(func() interface{} { return &binance.SOROrderReq{} }),
+	routeKey(fasthttp.MethodGet, binance.EndpointMyAllocations):       private(func() interface{} { return &binance.MyAllocationsReq{} }),
+	routeKey(fasthttp.MethodGet, binance.EndpointAccount):             private(nil),
+	routeKey(fasthttp.MethodGet, binance.EndpointAccountTrades):       private(func() interface{} { return &binance.AccountTradesReq{} }),
+	routeKey(fasthttp.MethodGet, binance.EndpointAccountCommission):   accountCommission,
+	routeKey(fasthttp.MethodGet, binance.EndpointRateLimit):           orderRateLimit,
+	routeKey(fasthttp.MethodGet, binance.EndpointMyPreventedMatches):  myPreventedMatches,
+
+	routeKey(fasthttp.MethodPost, binance.EndpointDataStream):   newDataStream,
+	routeKey(fasthttp.MethodPut, binance.EndpointDataStream):    keepAliveDataStream,
+	routeKey(fasthttp.MethodDelete, binance.EndpointDataStream): closeDataStream,
+}
+
+// securities are the endpoints served without the signature, other endpoints are signed whether routed or not.
+// Remark: historical trades are public as the client requests them without the API key
+var securities = map[string]security{
+	binance.EndpointPing:             securityNone,
+	binance.EndpointTime:             securityNone,
+	binance.EndpointExchangeInfo:     securityNone,
+	binance.EndpointDepth:            securityNone,
+	binance.EndpointTrades:           securityNone,
+	binance.EndpointHistoricalTrades: securityNone,
+	binance.EndpointAggTrades:        securityNone,
+	binance.EndpointKlines:           securityNone,
+	binance.EndpointUIKlines:         securityNone,
+	binance.EndpointAvgPrice:         securityNone,
+	binance.EndpointTicker24h:        securityNone,
+	binance.EndpointTicker:           securityNone,
+	binance.EndpointTickerPrice:      securityNone,
+	binance.EndpointTickerBook:       securityNone,
+	binance.EndpointDataStream:       securityAPIKey,
+}
+
+func endpointSecurity(endpoint string) security {
+	if sec, ok := securities[endpoint]; ok {
+		return sec
+	}
+
+	return securitySigned
+}
+
+func routeKey(method, endpoint string) string {
+	return method + " " + endpoint
+}
+
+var (
+	errMandatorySymbol  = &binance.APIError{Code: binance.ErrorCodeMandatoryParam, Msg: "Mandatory parameter 'symbol' was not sent, was empty/null, or malformed."}
+	errInvalidSymbol    = &binance.APIError{Code: binance.ErrorCodeBadSymbol, Msg: "Invalid symbol."}
+	errInvalidListenKey = &binance.APIError{Code: binance.ErrorCodeInvalidListenKey, Msg: "This listenKey does not exist."}
+)
+
+func ping(*Server, string, string, url.Values) (interface{}, error) {
+	return struct{}{}, nil
+}
+
+func serverTime(*Server, string, string, url.Values) (interface{}, error) {
+	return &binance.ServerTime{ServerTime: time.Now().UnixMilli()}, nil
+}
+
+func exchangeInfo(s *Server, _, _ string, params url.Values) (interface{}, error) {
+	var names []string
+	if symbol := params.Get("symbol"); symbol != "" {
+		names = append(names, symbol)
+	}
+	if symbols := params.Get("symbols"); symbols != "" {
+		list, err := parseList(symbols)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, list...)
+	}
+
+	resp := &binance.ExchangeInfo{
+		Timezone:   "UTC",
+		ServerTime: time.Now().UnixMilli(),
+		Symbols:    make([]*binance.SymbolInfo, 0, len(s.config.Symbols)),
+	}
+	for _, info := range s.config.Symbols {
+		if len(names) == 0 || contains(names, info.Symbol) {
+			resp.Symbols = append(resp.Symbols, info)
+		}
+	}
+	if len(names) > 0 && len(resp.Symbols) != len(names) {
+		return nil, errInvalidSymbol
+	}
+
+	return resp, nil
+}
+
+// depthResp is the order book in the wire format, DepthElem is decoded from [price, qty] pairs
+type depthResp struct {
+	LastUpdateID int64       `json:"lastUpdateId"`
+	Bids         [][2]string `json:"bids"`
+	Asks         [][2]string `json:"asks"`
+}
+
+func depth(s *Server, _, _ string, params url.Values) (interface{}, error) {
+	d, err := s.book(params.Get("symbol"))
+	if err != nil {
+		return nil, err
+	}
+	limit, _ := strconv.Atoi(params.Get("limit"))
+	if limit <= 0 || limit > binance.MaxDepthLimit {
+		limit = binance.DefaultDepthLimit
+	}
+
+	return &depthResp{
+		LastUpdateID: d.LastUpdateID,
+		Bids:         depthLevels(d.Bids, limit),
+		Asks:         depthLevels(d.Asks, limit),
+	}, nil
+}
+
+func depthLevels(levels []binance.DepthElem, limit int) [][2]string {
+	if len(levels) > limit {
+		levels = levels[:limit]
+	}
+	resp := make([][2]string, 0, len(levels))
+	for _, l := range levels {
+		resp = append(resp, [2]string{l.Price.String(), l.Quantity.String()})
+	}
+
+	return resp
+}
+
+type symbolPrice struct {
+	Symbol string `json:"symbol"`
+	Price  string `json:"price"`
+}
+
+// tickerPrice returns the mid price of the order book
+func tickerPrice(s *Server, _, _ string, params url.Values) (interface{}, error) {
+	symbol := params.Get("symbol")
+	if symbol != "" {
+		return s.price(symbol)
+	}
+	resp := make([]*symbolPrice, 0)
+	for _, symbol := range s.bookSymbols() {
+		p, err := s.price(symbol)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, p)
+	}
+
+	return resp, nil
+}
+
+func (s *Server) price(symbol string) (*symbolPrice, error) {
+	d, err := s.book(symbol)
+	if err != nil {
+		return nil, err
+	}
+	var bid, ask decimal.Decimal
+	if len(d.Bids) > 0 {
+		bid = d.Bids[0].Price
+	}
+	if len(d.Asks) > 0 {
+		ask = d.Asks[0].Price
+	}
+	price := bid.Add(ask).Div(decimal.NewFromInt(2))
+	if bid.Sign() == 0 || ask.Sign() == 0 {
+		price = bid.Add(ask)
+	}
+
+	return &symbolPrice{Symbol: symbol, Price: price.String()}, nil
+}
+
+func bookTicker(s *Server, _, _ string, params url.Values) (interface{}, error) {
+	symbol := params.Get("symbol")
+	if symbol != "" {
+		return s.bookTicker(symbol)
+	}
+	resp := make([]*binance.BookTicker, 0)
+	for _, symbol := range s.bookSymbols() {
+		t, err := s.bookTicker(symbol)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, t)
+	}
+
+	return resp, nil
+}
+
+func (s *Server) bookTicker(symbol string) (*binance.BookTicker, error) {
+	d, err := s.book(symbol)
+	if err != nil {
+		return nil, err
+	}
+	resp := &binance.BookTicker{Symbol: symbol, BidPrice: "0", BidQty: "0", AskPrice: "0", AskQty: "0"}
+	if len(d.Bids) > 0 {
+		resp.BidPrice, resp.BidQty = d.Bids[0].Price.String(), d.Bids[0].Quantity.String()
+	}
+	if len(d.Asks) > 0 {
+		resp.AskPrice, resp.AskQty = d.Asks[0].Price.String(), d.Asks[0].Quantity.String()
+	}
+
+	return resp, nil
+}
+
+// book returns the order book set by SetDepth, known symbols without the book have an empty one
+func (s *Server) book(symbol string) (*binance.Depth, error) {
+	if symbol == "" {
+		return nil, errMandatorySymbol
+	}
+	s.mu.Lock()
+	d, ok := s.depths[symbol]
+	s.mu.Unlock()
+	if ok {
+		return d, nil
+	}
+	for _, info := range s.config.Symbols {
+		if info.Symbol == symbol {
+			return &binance.Depth{}, nil
+		}
+	}
+
+	return nil, errInvalidSymbol
+}
+
+func (s *Server) bookSymbols() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	symbols := make([]string, 0, len(s.depths))
+	for symbol := range s.depths {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	return symbols
+}
+
+// private decodes the request params and forwards the request to the matching engine
+func private(newReq func() interface{}) handler {
+	return func(s *Server, method, endpoint string, params url.Values) (interface{}, error) {
+		var req interface{}
+		if newReq != nil {
+			req = newReq()
+			if err := decodeParams(params, req); err != nil {
+				return nil, err
+			}
+		}
+
+		return s.engine.Do(method, endpoint, req, true, false)
+	}
+}
+
+func accountCommission(s *Server, _, _ string, params url.Values) (interface{}, error) {
+	symbol := params.Get("symbol")
+	if _, err := s.book(symbol); err != nil {
+		return nil, err
+	}
+	zero := binance.CommissionRates{Maker: "0", Taker: "0", Buyer: "0", Seller: "0"}
+
+	return &binance.AccountCommission{
+		Symbol: symbol,
+		StandardCommission: binance.CommissionRates{
+			Maker:  s.config.MakerCommission.String(),
+			Taker:  s.config.TakerCommission.String(),
+			Buyer:  "0",
+			Seller: "0",
+		},
+		SpecialCommission: zero,
+		TaxCommission:     zero,
+		Discount:          binance.CommissionDiscount{Discount: "0"},
+	}, nil
+}
+
+// orderRateLimits are the order placement limits, their counts are the orders received within the interval
+var orderRateLimits = []binance.RateLimit{
+	{Type: binance.RateLimitTypeOrders, Interval: binance.RateLimitIntervalSecond, IntervalNum: 10, Limit: 100},
+	{Type: binance.RateLimitTypeOrders, Interval: binance.RateLimitIntervalDay, IntervalNum: 1, Limit: 200000},
+}
+
+var rateLimitIntervals = map[binance.RateLimitInterval]time.Duration{
+	binance.RateLimitIntervalSecond: time.Second,
+	binance.RateLimitIntervalMinute: time.Minute,
+	binance.RateLimitIntervalHour:   time.Hour,
+	binance.RateLimitIntervalDay:    24 * time.Hour,
+}
+
+// orderEndpoints are the endpoints placing orders counted by the order rate limits
+var orderEndpoints = map[string]struct{}{
+	binance.EndpointOrder:              {},
+	binance.EndpointCancelReplaceOrder: {},
+	binance.EndpointOCOOrder:           {},
+	binance.EndpointOrderListOCO:       {},
+	binance.EndpointOrderListOTO:       {},
+	binance.EndpointOrderListOTOCO:     {},
+	binance.EndpointSOROrder:           {},
+}
+
+func orderRateLimit(s *Server, _, _ string, _ url.Values) (interface{}, error) {
+	now := time.Now()
+	requests := s.Requests()
+	resp := make([]*binance.RateLimit, 0, len(orderRateLimits))
+	for _, limit := range orderRateLimits {
+		limit := limit
+		since := now.Add(-time.Duration(limit.IntervalNum) * rateLimitIntervals[limit.Interval])
+		for _, req := range requests {
+			if _, ok := orderEndpoints[req.Endpoint]; ok && req.Method == fasthttp.MethodPost && req.Time.After(since) {
+				limit.Count++
+			}
+		}
+		resp = append(resp, &limit)
+	}
+
+	return resp, nil
+}
+
+// myPreventedMatches is always empty, orders are matched against the order book only and never trade with each other
+func myPreventedMatches(s *Server, _, _ string, params url.Values) (interface{}, error) {
+	if _, err := s.book(params.Get("symbol")); err != nil {
+		return nil, err
+	}
+
+	return make([]*binance.AccountTrade, 0), nil
+}
+
+func newDataStream(s *Server, _, _ string, _ url.Values) (interface{}, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	key := hex.EncodeToString(buf)
+	s.mu.Lock()
+	s.listenKeys[key] = struct{}{}
+	s.mu.Unlock()
+
+	return &binance.DataStream{ListenKey: key}, nil
+}
+
+func keepAliveDataStream(s *Server, _, _ string, params url.Values) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.listenKeys[params.Get("listenKey")]; !ok {
+		return nil, errInvalidListenKey
+	}
+
+	return struct{}{}, nil
+}
+
+func closeDataStream(s *Server, _, _ string, params url.Values) (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := params.Get("listenKey")
+	if _, ok := s.listenKeys[key]; !ok {
+		return nil, errInvalidListenKey
+	}
+	delete(s.listenKeys, key)
+
+	return struct{}{}, nil
+}
+
+// market serves the exchange info and order books of the server to the matching engine
+type market struct {
+	s *Server
+}
+
+func (m *market) Do(method, endpoint string, data interface{}, _, _ bool) ([]byte, error) {
+	handle, ok := routes[routeKey(method, endpoint)]
+	if !ok || endpointSecurity(endpoint) != securityNone {
+		return nil, errors.Errorf("%s %s is not a market data endpoint", method, endpoint)
+	}
+	params, err := query.Values(data)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := handle(m.s, method, endpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(resp)
+}
+
+func (m *market) SetWindow(int) {}
+
+func (m *market) UsedWeight() map[string]int64 {
+	return map[string]int64{}
+}
+
+func (m *market) OrderCount() map[string]int64 {
+	return map[string]int64{}
+}
+
+func (m *market) RetryAfter() int64 {
+	return 0
+}
+
+// decodeParams sets the fields of the request struct by their url tags
+func decodeParams(params url.Values, req interface{}) error {
+	v := reflect.ValueOf(req).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		// embedded requests, e.g. the order of the cancel-replace request, share the params
+		if t.Field(i).Anonymous && t.Field(i).Type.Kind() == reflect.Struct {
+			if err := decodeParams(params, v.Field(i).Addr().Interface()); err != nil {
+				return err
+			}
+			continue
+		}
+		name := strings.Split(t.Field(i).Tag.Get("url"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		value := params.Get(name)
+		if value == "" {
+			continue
+		}
+		if err := setField(v.Field(i), value); err != nil {
+			return &binance.APIError{Code: binance.ErrorCodeMandatoryParam, Msg: "Illegal characters found in parameter '" + name + "'."}
+		}
+	}
+
+	return nil
+}
+
+func setField(f reflect.Value, value string) error {
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		f.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		f.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		f.SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		f.SetBool(b)
+	case reflect.Slice:
+		list, err := parseList(value)
+		if err != nil {
+			return err
+		}
+		s := reflect.MakeSlice(f.Type(), len(list), len(list))
+		for i, item := range list {
+			if err = setField(s.Index(i), item); err != nil {
+				return err
+			}
+		}
+		f.Set(s)
+	default:
+		return errors.Errorf("unsupported field kind %s", f.Kind())
+	}
+
+	return nil
+}
+
+// parseList parses the list param, lists with multiple items are sent as json arrays
+func parseList(value string) ([]string, error) {
+	if !strings.HasPrefix(value, "[") {
+		return []string{value}, nil
+	}
+	var list []string
+	err := json.Unmarshal([]byte(value), &list)
+
+	return list, err
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,412 @@
+package binancetest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/paper"
+	"github.com/xenking/binance-api/ws"
+)
+
+const (
+	DefaultAPIKey    = "test-api-key"
+	DefaultAPISecret = "test-api-secret"
+)
+
+// Config configures the mock server
+type Config struct {
+	APIKey          string
+	APISecret       string
+	Symbols         []*binance.SymbolInfo      // Symbols are served by the exchange info endpoint and accepted for trading
+	Balances        map[string]decimal.Decimal // Balances are the initial free balances of the account
+	MakerCommission decimal.Decimal
+	TakerCommission decimal.Decimal
+}
+
+func (c Config) defaults() Config {
+	if c.APIKey == "" {
+		c.APIKey = DefaultAPIKey
+	}
+	if c.APISecret == "" {
+		c.APISecret = DefaultAPISecret
+	}
+
+	return c
+}
+
+// Response is the scripted response of the endpoint
+type Response struct {
+	Status     int               // Status defaults to 200 or 400 when Error is set
+	Body       interface{}       // Body is marshaled to json unless it's []byte or string
+	Error      *binance.APIError // Error is returned as the body when set
+	Latency    time.Duration     // Latency delays the response
+	Header     map[string]string
+	RetryAfter time.Duration // RetryAfter sets the Retry-After header
+}
+
+// Request is the request received by the server
+type Request struct {
+	Method   string
+	Endpoint string
+	Params   url.Values
+	APIKey   string
+	Time     time.Time
+}
+
+type script struct {
+	method    string
+	endpoint  string
+	responses []Response
+}
+
+// Server is an in-process Binance spot API server.
+// Private requests are verified with the API key and signature, orders are matched by the paper trading engine
+// against the order books set by SetDepth. Market data is served from the order books and the trades of the engine.
+// Order amendments and the orderList/oco, orderList/oto and orderList/otoco endpoints aren't implemented.
+// Responses of any endpoint can be scripted, e.g. to inject errors, latency and rate limits,
+// scripted responses of private endpoints are returned to authorized requests only.
+// Remark: REST API is served over TLS, use Client to get a client trusting the server certificate
+type Server struct {
+	config Config
+	rest   *httptest.Server
+	stream *httptest.Server
+	engine *paper.Client
+
+	mu         sync.Mutex
+	scripts    []*script
+	latency    time.Duration
+	requests   []Request
+	weight     int64
+	weightTime int64
+	depths     map[string]*binance.Depth
+	listenKeys map[string]struct{}
+	streams    map[string]*marketStream
+	conns      map[net.Conn]struct{}
+}
+
+// NewServer starts the server, it must be closed by Close
+func NewServer(config Config) *Server {
+	cfg := config.defaults()
+	s := &Server{
+		config:     cfg,
+		depths:     make(map[string]*binance.Depth),
+		listenKeys: make(map[string]struct{}),
+		streams:    make(map[string]*marketStream),
+		conns:      make(map[net.Conn]struct{}),
+	}
+	s.engine = paper.NewClient(&market{s: s}, paper.Config{
+		Balances:        cfg.Balances,
+		MakerCommission: cfg.MakerCommission,
+		TakerCommission: cfg.TakerCommission,
+		Symbols:         cfg.Symbols,
+	})
+	s.rest = httptest.NewTLSServer(s)
+	s.stream = httptest.NewServer(http.HandlerFunc(s.serveStream))
+
+	return s
+}
+
+// Close stops the server and closes the opened streams
+func (s *Server) Close() {
+	s.mu.Lock()
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+	s.stream.Close()
+	s.rest.Close()
+}
+
+// URL returns the REST API base url
+func (s *Server) URL() string {
+	return s.rest.URL
+}
+
+// StreamURL returns the websocket streams prefix for ws.NewCustomClient
+func (s *Server) StreamURL() string {
+	return "ws" + strings.TrimPrefix(s.stream.URL, "http") + "/ws/"
+}
+
+// RestClient returns the client signing requests with the configured keys
+func (s *Server) RestClient() binance.RestClient {
+	return s.RestClientWithKeys(s.config.APIKey, s.config.APISecret)
+}
+
+// RestClientWithKeys returns the client signing requests with the given keys
+func (s *Server) RestClientWithKeys(apiKey, apiSecret string) binance.RestClient {
+	pool := x509.NewCertPool()
+	pool.AddCert(s.rest.Certificate())
+
+	return binance.NewCustomRestClient(binance.RestClientConfig{
+		APIKey:    apiKey,
+		APISecret: apiSecret,
+		HTTPClient: &fasthttp.HostClient{
+			Addr:                     s.rest.Listener.Addr().String(),
+			IsTLS:                    true,
+			NoDefaultUserAgentHeader: true,
+			// httptest certificates are issued for example.com
+			TLSConfig: &tls.Config{RootCAs: pool, ServerName: "example.com", MinVersion: tls.VersionTLS12},
+		},
+	})
+}
+
+// Client returns the API client connected to the server
+func (s *Server) Client() *binance.Client {
+	return binance.NewCustomClient(s.RestClient())
+}
+
+// StreamClient returns the websocket client connected to the server
+func (s *Server) StreamClient() *ws.Client {
+	return ws.NewCustomClient(s.StreamURL(), nil)
+}
+
+// Script queues the responses of the endpoint, queued responses are returned before the default handling.
+// Empty method or endpoint matches any request. Requests failing the API key or signature check are rejected
+// without taking the queued response
+func (s *Server) Script(method, endpoint string, responses ...Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scripts = append(s.scripts, &script{method: method, endpoint: endpoint, responses: responses})
+}
+
+// InjectError fails the next n requests of the endpoint with the API error
+func (s *Server) InjectError(method, endpoint string, n int, err *binance.APIError) {
+	responses := make([]Response, n)
+	for i := range responses {
+		responses[i] = Response{Error: err}
+	}
+	s.Script(method, endpoint, responses...)
+}
+
+// TooManyRequests responds to the next n requests with 429 status and the Retry-After header
+func (s *Server) TooManyRequests(n int, retryAfter time.Duration) {
+	responses := make([]Response, n)
+	for i := range responses {
+		responses[i] = Response{
+			Status:     http.StatusTooManyRequests,
+			Error:      &binance.APIError{Code: binance.ErrorCodeTooManyRequests, Msg: "Too many requests; please use the websocket for live updates."},
+			RetryAfter: retryAfter,
+		}
+	}
+	s.Script("", "", responses...)
+}
+
+// SetLatency delays every response
+func (s *Server) SetLatency(latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = latency
+}
+
+// Requests returns the requests received by the REST API
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// SetDepth sets the order book of the symbol and matches the open orders against it
+func (s *Server) SetDepth(symbol string, depth *binance.Depth) {
+	s.mu.Lock()
+	s.depths[symbol] = depth
+	s.mu.Unlock()
+	s.engine.UpdateBook(symbol, depth)
+}
+
+// Deposit credits the account balance
+func (s *Server) Deposit(asset string, amount decimal.Decimal) {
+	s.engine.Deposit(asset, amount)
+}
+
+// ServeHTTP serves the REST API
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	// signed payload is the query string followed by the body
+	payload := r.URL.RawQuery + string(body)
+	params, err := url.ParseQuery(payload)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	apiKey := r.Header.Get(binance.HeaderAPIKey)
+	endpoint := r.URL.Path
+
+	// requests are authorized by the endpoint security before the scripted responses are returned
+	apiErr := s.authorize(endpointSecurity(endpoint), apiKey, payload, params)
+	req := Request{Method: r.Method, Endpoint: endpoint, Params: params, APIKey: apiKey, Time: time.Now()}
+	resp, scripted, latency, weight := s.receive(req, apiErr == nil)
+	w.Header().Set("X-Mbx-Used-Weight-1m", strconv.FormatInt(weight, 10))
+	if latency > 0 {
+		time.Sleep(latency)
+	}
+
+	if apiErr != nil {
+		status := http.StatusBadRequest
+		if apiErr.Code == binance.ErrorCodeRejectedMBXKey {
+			status = http.StatusUnauthorized
+		}
+		writeError(w, status, apiErr)
+		return
+	}
+	if scripted {
+		writeScripted(w, resp)
+		return
+	}
+	handle, ok := routes[routeKey(r.Method, endpoint)]
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.Errorf("%s %s is not implemented", r.Method, endpoint))
+		return
+	}
+
+	res, err := handle(s, r.Method, endpoint, params)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+// receive records the request and pops the scripted response of the authorized request
+func (s *Server) receive(req Request, authorized bool) (Response, bool, time.Duration, int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, req)
+	minute := req.Time.Unix() / 60
+	if minute != s.weightTime {
+		s.weightTime, s.weight = minute, 0
+	}
+	s.weight++
+	if !authorized {
+		return Response{}, false, s.latency, s.weight
+	}
+
+	for i, sc := range s.scripts {
+		if (sc.method != "" && sc.method != req.Method) || (sc.endpoint != "" && sc.endpoint != req.Endpoint) {
+			continue
+		}
+		resp := sc.responses[0]
+		sc.responses = sc.responses[1:]
+		if len(sc.responses) == 0 {
+			s.scripts = append(s.scripts[:i], s.scripts[i+1:]...)
+		}
+		return resp, true, s.latency + resp.Latency, s.weight
+	}
+
+	return Response{}, false, s.latency, s.weight
+}
+
+// authorize verifies the API key, the timestamp and the signature of the request
+func (s *Server) authorize(sec security, apiKey, payload string, params url.Values) *binance.APIError {
+	if sec == securityNone {
+		return nil
+	}
+	if apiKey != s.config.APIKey {
+		return &binance.APIError{Code: binance.ErrorCodeRejectedMBXKey, Msg: "Invalid API-key, IP, or permissions for action."}
+	}
+	if sec == securityAPIKey {
+		return nil
+	}
+
+	idx := strings.LastIndex(payload, "signature=")
+	if idx < 0 {
+		return &binance.APIError{Code: binance.ErrorCodeMandatoryParam, Msg: "Mandatory parameter 'signature' was not sent, was empty/null, or malformed."}
+	}
+	signed := strings.TrimSuffix(payload[:idx], "&")
+	mac := hmac.New(sha256.New, []byte(s.config.APISecret))
+	mac.Write([]byte(signed))
+	expected := hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(params.Get("signature"))) {
+		return &binance.APIError{Code: binance.ErrorCodeInvalidSignature, Msg: "Signature for this request is not valid."}
+	}
+
+	timestamp, err := strconv.ParseInt(params.Get("timestamp"), 10, 64)
+	if err != nil {
+		return &binance.APIError{Code: binance.ErrorCodeMandatoryParam, Msg: "Mandatory parameter 'timestamp' was not sent, was empty/null, or malformed."}
+	}
+	window := int64(binance.DefaultResponseWindow)
+	if w, err := strconv.ParseInt(params.Get("recvWindow"), 10, 64); err == nil && w > 0 {
+		window = w
+	}
+	now := time.Now().UnixMilli()
+	if timestamp > now+1000 || now-timestamp > window {
+		return &binance.APIError{Code: binance.ErrorCodeInvalidTimestamp, Msg: "Timestamp for this request is outside of the recvWindow."}
+	}
+
+	return nil
+}
+
+func writeScripted(w http.ResponseWriter, resp Response) {
+	for k, v := range resp.Header {
+		w.Header().Set(k, v)
+	}
+	if resp.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.FormatInt(int64(resp.RetryAfter/time.Second), 10))
+	}
+	status := resp.Status
+	body := resp.Body
+	if resp.Error != nil {
+		body = resp.Error
+		if status == 0 {
+			status = http.StatusBadRequest
+		}
+	}
+	if status == 0 {
+		status = http.StatusOK
+	}
+	writeJSON(w, status, body)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	var apiErr *binance.APIError
+	if !errors.As(err, &apiErr) {
+		apiErr = &binance.APIError{Code: binance.ErrorCodeUnknown, Msg: err.Error()}
+	}
+	writeJSON(w, status, apiErr)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	var (
+		buf []byte
+		err error
+	)
+	switch b := body.(type) {
+	case []byte:
+		buf = b
+	case string:
+		buf = []byte(b)
+	case nil:
+		buf = []byte("{}")
+	default:
+		if buf, err = json.Marshal(b); err != nil {
+			status = http.StatusInternalServerError
+			buf, _ = json.Marshal(&binance.APIError{Code: binance.ErrorCodeUnknown, Msg: err.Error()})
+		}
+	}
+	w.Header().Set("Content-Type", binance.HeaderTypeJSON)
+	w.WriteHeader(status)
+	_, _ = w.Write(buf)
+}
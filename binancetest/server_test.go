@@ -0,0 +1,365 @@
+package binancetest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/valyala/fasthttp"
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/binancetest"
+	"github.com/xenking/binance-api/ws"
+)
+
+func TestServer(t *testing.T) {
+	suite.Run(t, new(serverTestSuite))
+}
+
+type serverTestSuite struct {
+	suite.Suite
+	server *binancetest.Server
+	client *binance.Client
+}
+
+func (s *serverTestSuite) SetupTest() {
+	s.server = binancetest.NewServer(binancetest.Config{
+		Symbols: []*binance.SymbolInfo{{
+			Symbol:     "BTCUSDT",
+			Status:     binance.SymbolStatusTrading,
+			BaseAsset:  "BTC",
+			QuoteAsset: "USDT",
+			OrderTypes: []binance.OrderType{binance.OrderTypeLimit, binance.OrderTypeMarket},
+			Filters: []binance.SymbolInfoFilter{
+				{Type: binance.FilterTypeLotSize, MinQty: "0.001", MaxQty: "1000", StepSize: "0.001"},
+			},
+		}},
+		Balances:        map[string]decimal.Decimal{"USDT": decimal.NewFromInt(1000)},
+		TakerCommission: decimal.RequireFromString("0.001"),
+	})
+	s.server.SetDepth("BTCUSDT", &binance.Depth{
+		LastUpdateID: 1,
+		Bids:         []binance.DepthElem{{Price: decimal.NewFromInt(99), Quantity: decimal.NewFromInt(5)}},
+		Asks:         []binance.DepthElem{{Price: decimal.NewFromInt(100), Quantity: decimal.NewFromInt(5)}},
+	})
+	s.client = s.server.Client()
+}
+
+func (s *serverTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *serverTestSuite) TestMarketData() {
+	s.Require().NoError(s.client.Ping())
+
+	depth, err := s.client.Depth(&binance.DepthReq{Symbol: "BTCUSDT"})
+	s.Require().NoError(err)
+	s.Require().Len(depth.Asks, 1)
+	s.Require().Equal("100", depth.Asks[0].Price.String())
+
+	price, err := s.client.Price(&binance.TickerPriceReq{Symbol: "BTCUSDT"})
+	s.Require().NoError(err)
+	s.Require().Equal("99.5", price.Price)
+
+	info, err := s.client.ExchangeInfo(&binance.ExchangeInfoReq{Symbol: "BTCUSDT"})
+	s.Require().NoError(err)
+	s.Require().NotNil(info.Symbol("BTCUSDT"))
+
+	_, err = s.client.Depth(&binance.DepthReq{Symbol: "ETHUSDT"})
+	s.requireAPIError(err, binance.ErrorCodeBadSymbol)
+}
+
+func (s *serverTestSuite) TestOrders() {
+	resp, err := s.client.NewOrderFull(&binance.OrderReq{
+		Symbol:   "BTCUSDT",
+		Side:     binance.OrderSideBuy,
+		Type:     binance.OrderTypeMarket,
+		Quantity: "1",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(binance.OrderStatusFilled, resp.Status)
+	s.Require().Equal("100", resp.CummulativeQuoteQty)
+
+	_, err = s.client.NewOrder(&binance.OrderReq{
+		Symbol:      "BTCUSDT",
+		Side:        binance.OrderSideSell,
+		Type:        binance.OrderTypeLimit,
+		TimeInForce: binance.TimeInForceGTC,
+		Quantity:    "0.5",
+		Price:       "105",
+	})
+	s.Require().NoError(err)
+	orders, err := s.client.OpenOrders(&binance.OpenOrdersReq{Symbol: "BTCUSDT"})
+	s.Require().NoError(err)
+	s.Require().Len(orders, 1)
+
+	// the next book fills the resting order
+	s.server.SetDepth("BTCUSDT", &binance.Depth{
+		Bids: []binance.DepthElem{{Price: decimal.NewFromInt(106), Quantity: decimal.NewFromInt(1)}},
+	})
+	order, err := s.client.QueryOrder(&binance.QueryOrderReq{Symbol: "BTCUSDT", OrderID: orders[0].OrderID})
+	s.Require().NoError(err)
+	s.Require().Equal(binance.OrderStatusFilled, order.Status)
+
+	account, err := s.client.Account()
+	s.Require().NoError(err)
+	for _, b := range account.Balances {
+		if b.Asset == "USDT" {
+			s.Require().Equal("952.5", b.Free)
+		}
+	}
+}
+
+func (s *serverTestSuite) TestSignature() {
+	client := binance.NewCustomClient(s.server.RestClientWithKeys(binancetest.DefaultAPIKey, "wrong"))
+	_, err := client.Account()
+	s.requireAPIError(err, binance.ErrorCodeInvalidSignature)
+
+	client = binance.NewCustomClient(s.server.RestClientWithKeys("wrong", binancetest.DefaultAPISecret))
+	_, err = client.Account()
+	s.requireAPIError(err, binance.ErrorCodeRejectedMBXKey)
+}
+
+func (s *serverTestSuite) TestScript() {
+	s.server.Script(fasthttp.MethodGet, binance.EndpointTime, binancetest.Response{Body: &binance.ServerTime{ServerTime: 42}})
+	t, err := s.client.Time()
+	s.Require().NoError(err)
+	s.Require().EqualValues(42, t.ServerTime)
+
+	t, err = s.client.Time()
+	s.Require().NoError(err)
+	s.Require().NotEqualValues(42, t.ServerTime)
+
+	s.server.InjectError(fasthttp.MethodGet, binance.EndpointAccount, 1, &binance.APIError{Code: binance.ErrorCodeTimeout, Msg: "timeout"})
+	_, err = s.client.Account()
+	s.requireAPIError(err, binance.ErrorCodeTimeout)
+	_, err = s.client.Account()
+	s.Require().NoError(err)
+
+	rest := s.server.RestClient()
+	s.server.TooManyRequests(1, 30*time.Second)
+	_, err = binance.NewCustomClient(rest).Time()
+	s.requireAPIError(err, binance.ErrorCodeTooManyRequests)
+	s.Require().EqualValues(30, rest.RetryAfter())
+	s.Require().EqualValues(len(s.server.Requests()), rest.UsedWeight()["1m"])
+}
+
+func (s *serverTestSuite) TestScriptAuthorization() {
+	s.server.Script(fasthttp.MethodGet, binance.EndpointOrderAmendments, binancetest.Response{
+		Body: []*binance.OrderAmendment{{Symbol: "BTCUSDT", OrderID: 1, ExecutionID: 2}},
+	})
+	req := &binance.OrderAmendmentsReq{Symbol: "BTCUSDT", OrderID: 1}
+
+	// the scripted response of the signed endpoint isn't returned to the unauthorized request
+	client := binance.NewCustomClient(s.server.RestClientWithKeys(binancetest.DefaultAPIKey, "wrong"))
+	_, err := client.OrderAmendments(req)
+	s.requireAPIError(err, binance.ErrorCodeInvalidSignature)
+
+	amendments, err := s.client.OrderAmendments(req)
+	s.Require().NoError(err)
+	s.Require().Len(amendments, 1)
+	s.Require().EqualValues(2, amendments[0].ExecutionID)
+
+	// unrouted endpoints are rejected after the authorization
+	_, err = client.OrderAmendments(req)
+	s.requireAPIError(err, binance.ErrorCodeInvalidSignature)
+	_, err = s.client.OrderAmendments(req)
+	s.Require().Error(err)
+}
+
+func (s *serverTestSuite) TestMarketTrades() {
+	_, err := s.client.NewOrderFull(&binance.OrderReq{
+		Symbol:   "BTCUSDT",
+		Side:     binance.OrderSideBuy,
+		Type:     binance.OrderTypeMarket,
+		Quantity: "2",
+	})
+	s.Require().NoError(err)
+	s.server.SetDepth("BTCUSDT", &binance.Depth{
+		Bids: []binance.DepthElem{{Price: decimal.NewFromInt(99), Quantity: decimal.NewFromInt(5)}},
+		Asks: []binance.DepthElem{
+			{Price: decimal.NewFromInt(101), Quantity: decimal.NewFromInt(1)},
+			{Price: decimal.NewFromInt(102), Quantity: decimal.NewFromInt(1)},
+		},
+	})
+	_, err = s.client.NewOrderFull(&binance.OrderReq{
+		Symbol:   "BTCUSDT",
+		Side:     binance.OrderSideBuy,
+		Type:     binance.OrderTypeMarket,
+		Quantity: "2",
+	})
+	s.Require().NoError(err)
+
+	trades, err := s.client.Trades(&binance.TradeReq{Symbol: "BTCUSDT"})
+	s.Require().NoError(err)
+	s.Require().Len(trades, 3)
+	s.Require().Equal("102", trades[2].Price)
+	s.Require().False(trades[2].IsBuyerMaker)
+
+	trades, err = s.client.HistoricalTrades(&binance.HistoricalTradeReq{Symbol: "BTCUSDT", FromID: trades[1].ID, Limit: 1})
+	s.Require().NoError(err)
+	s.Require().Len(trades, 1)
+	s.Require().Equal("101", trades[0].Price)
+
+	aggs, err := s.client.AggregatedTrades(&binance.AggregatedTradeReq{Symbol: "BTCUSDT"})
+	s.Require().NoError(err)
+	s.Require().Len(aggs, 3)
+	s.Require().Equal("2", aggs[0].Quantity)
+
+	klines, err := s.client.Klines(&binance.KlinesReq{Symbol: "BTCUSDT", Interval: binance.KlineInterval1day})
+	s.Require().NoError(err)
+	s.Require().Len(klines, 1)
+	s.Require().Equal("100", klines[0].OpenPrice.String())
+	s.Require().Equal("102", klines[0].ClosePrice.String())
+	s.Require().Equal("4", klines[0].Volume.String())
+	s.Require().Equal("4", klines[0].TakerBuyBaseAssetVolume.String())
+	s.Require().Equal(3, klines[0].Trades)
+
+	_, err = s.client.Klines(&binance.KlinesReq{Symbol: "BTCUSDT", Interval: "2d"})
+	s.requireAPIError(err, binance.ErrorCodeBadInterval)
+
+	avg, err := s.client.AvgPrice(&binance.AvgPriceReq{Symbol: "BTCUSDT"})
+	s.Require().NoError(err)
+	s.Require().Equal("100.75", avg.Price)
+
+	stat, err := s.client.Ticker24h(&binance.Ticker24hReq{Symbol: "BTCUSDT"})
+	s.Require().NoError(err)
+	s.Require().Equal("2", stat.PriceChange)
+	s.Require().Equal("2", stat.PriceChangePercent)
+	s.Require().Equal("403", stat.QuoteVolume)
+	s.Require().Equal("101", stat.AskPrice)
+	s.Require().Equal(3, stat.Count)
+
+	rolling, err := s.client.Ticker(&binance.TickerReq{Symbol: "BTCUSDT", WindowSize: "1h"})
+	s.Require().NoError(err)
+	s.Require().Equal("102", rolling.HighPrice)
+	s.Require().Equal("100.75", rolling.WeightedAvgPrice)
+
+	prevented, err := s.client.MyPreventedMatches(&binance.AccountTradesReq{Symbol: "BTCUSDT"})
+	s.Require().NoError(err)
+	s.Require().Empty(prevented)
+}
+
+func (s *serverTestSuite) TestOrderHistory() {
+	order, err := s.client.NewOrder(&binance.OrderReq{
+		Symbol:      "BTCUSDT",
+		Side:        binance.OrderSideBuy,
+		Type:        binance.OrderTypeLimit,
+		TimeInForce: binance.TimeInForceGTC,
+		Quantity:    "1",
+		Price:       "90",
+	})
+	s.Require().NoError(err)
+
+	replaced, err := s.client.CancelReplaceOrder(&binance.CancelReplaceOrderReq{
+		OrderReq: binance.OrderReq{
+			Symbol:      "BTCUSDT",
+			Side:        binance.OrderSideBuy,
+			Type:        binance.OrderTypeLimit,
+			TimeInForce: binance.TimeInForceGTC,
+			Quantity:    "1",
+			Price:       "95",
+		},
+		CancelOrderID: order.OrderID,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(binance.CancelReplaceResultSuccess, replaced.CancelResult)
+	s.Require().Equal(binance.OrderStatusCanceled, replaced.CancelResponse.Status)
+	s.Require().Equal("95", replaced.NewOrderResponse.Price)
+
+	_, err = s.client.CancelReplaceOrder(&binance.CancelReplaceOrderReq{
+		OrderReq: binance.OrderReq{
+			Symbol:   "BTCUSDT",
+			Side:     binance.OrderSideBuy,
+			Type:     binance.OrderTypeMarket,
+			Quantity: "1",
+		},
+		CancelOrderID: order.OrderID,
+	})
+	s.requireAPIError(err, binance.ErrorCodeCancelRejected)
+
+	sor, err := s.client.NewSOROrder(&binance.SOROrderReq{
+		Symbol:   "BTCUSDT",
+		Side:     binance.OrderSideBuy,
+		Type:     binance.OrderTypeMarket,
+		Quantity: "1",
+	})
+	s.Require().NoError(err)
+	s.Require().True(sor.UsedSor)
+	s.Require().Equal(binance.OrderStatusFilled, sor.Status)
+
+	orders, err := s.client.AllOrders(&binance.AllOrdersReq{Symbol: "BTCUSDT"})
+	s.Require().NoError(err)
+	s.Require().Len(orders, 3)
+	s.Require().Equal(binance.OrderStatusCanceled, orders[0].Status)
+	s.Require().Equal(binance.OrderStatusNew, orders[1].Status)
+
+	allocations, err := s.client.MyAllocations(&binance.MyAllocationsReq{Symbol: "BTCUSDT"})
+	s.Require().NoError(err)
+	s.Require().Len(allocations, 1)
+	s.Require().Equal(sor.OrderID, allocations[0].OrderID)
+
+	limits, err := s.client.OrderRateLimit()
+	s.Require().NoError(err)
+	s.Require().Len(limits, 2)
+	// the rejected cancel-replace is counted as well
+	s.Require().Equal(4, limits[0].Count)
+
+	lists, err := s.client.AllOCO(nil)
+	s.Require().NoError(err)
+	s.Require().Empty(lists)
+}
+
+func (s *serverTestSuite) TestUserDataStream() {
+	key, err := s.client.DataStream()
+	s.Require().NoError(err)
+	s.Require().NoError(s.client.DataStreamKeepAlive(key))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	stream, err := s.server.StreamClient().AccountInfo(ctx, key)
+	s.Require().NoError(err)
+	defer stream.Close()
+
+	// the subscription is registered by the handler after the upgrade
+	s.Require().Eventually(func() bool {
+		s.server.Deposit("BTC", decimal.NewFromInt(1))
+		s.Require().NoError(stream.NetConn().SetReadDeadline(time.Now().Add(50 * time.Millisecond)))
+		eventType, _, err := stream.Read()
+		return err == nil && eventType == ws.AccountUpdateEventTypeBalanceUpdate
+	}, time.Second, 10*time.Millisecond)
+
+	s.Require().NoError(s.client.DataStreamClose(key))
+	s.requireAPIError(s.client.DataStreamKeepAlive(key), binance.ErrorCodeInvalidListenKey)
+}
+
+func (s *serverTestSuite) TestMarketStream() {
+	s.Require().NoError(s.server.Publish("btcusdt@trade", &ws.TradeUpdate{
+		EventType: ws.UpdateTypeTrades,
+		Symbol:    "BTCUSDT",
+		Price:     "100",
+		Quantity:  "1",
+		TradeID:   1,
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	stream, err := s.server.StreamClient().Trades(ctx, "BTCUSDT")
+	s.Require().NoError(err)
+	defer stream.Close()
+
+	s.Require().NoError(stream.NetConn().SetReadDeadline(time.Now().Add(time.Second)))
+	trade, err := stream.Read()
+	s.Require().NoError(err)
+	s.Require().Equal("BTCUSDT", trade.Symbol)
+	s.Require().EqualValues(1, trade.TradeID)
+}
+
+func (s *serverTestSuite) requireAPIError(err error, code int) {
+	s.Require().Error(err)
+	apiErr, ok := err.(*binance.APIError)
+	s.Require().True(ok, err.Error())
+	s.Require().Equal(code, apiErr.Code)
+}
@@ -0,0 +1,149 @@
+package binancetest
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	gobws "github.com/gobwas/ws"
+	"github.com/gobwas/ws/wsutil"
+	"github.com/segmentio/encoding/json"
+)
+
+// StreamBuffer is the number of events buffered for each stream subscriber,
+// the subscriber is disconnected when it falls behind
+const StreamBuffer = 256
+
+// marketStream holds the subscribers of the market data stream and the events published before any subscriber
+type marketStream struct {
+	subscribers map[net.Conn]chan []byte
+	pending     [][]byte
+}
+
+func (s *Server) marketStream(name string) *marketStream {
+	ms, ok := s.streams[name]
+	if !ok {
+		ms = &marketStream{subscribers: make(map[net.Conn]chan []byte)}
+		s.streams[name] = ms
+	}
+
+	return ms
+}
+
+// Publish sends the event to the subscribers of the stream, e.g. "btcusdt@trade".
+// The event is marshaled to json unless it's []byte, events published before the first subscriber are buffered.
+// Remark: ws update events have to be marshaled with the event type as the first field
+func (s *Server) Publish(stream string, event interface{}) error {
+	payload, ok := event.([]byte)
+	if !ok {
+		var err error
+		if payload, err = json.Marshal(event); err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ms := s.marketStream(stream)
+	if len(ms.subscribers) == 0 {
+		if len(ms.pending) < StreamBuffer {
+			ms.pending = append(ms.pending, payload)
+		}
+		return nil
+	}
+	for conn, events := range ms.subscribers {
+		select {
+		case events <- payload:
+		default:
+			delete(ms.subscribers, conn)
+			close(events)
+		}
+	}
+
+	return nil
+}
+
+// serveStream serves the user data stream of the issued listen keys and the market data streams
+func (s *Server) serveStream(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/ws/")
+	conn, _, _, err := gobws.UpgradeHTTP(r, w)
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	s.conns[conn] = struct{}{}
+	_, userData := s.listenKeys[name]
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		conn.Close()
+	}()
+
+	if userData {
+		s.serveUserData(conn)
+		return
+	}
+	s.serveMarket(name, conn)
+}
+
+func (s *Server) serveUserData(conn net.Conn) {
+	info := s.engine.AccountInfo()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		discard(conn)
+		info.Conn.Close()
+	}()
+
+	for {
+		payload, err := info.Conn.ReadRaw()
+		if err != nil {
+			break
+		}
+		if err = wsutil.WriteServerText(conn, payload); err != nil {
+			break
+		}
+	}
+	conn.Close()
+	<-done
+}
+
+func (s *Server) serveMarket(name string, conn net.Conn) {
+	events := make(chan []byte, StreamBuffer)
+	s.mu.Lock()
+	ms := s.marketStream(name)
+	for _, payload := range ms.pending {
+		events <- payload
+	}
+	ms.pending = nil
+	ms.subscribers[conn] = events
+	s.mu.Unlock()
+
+	go func() {
+		discard(conn)
+		s.mu.Lock()
+		if _, ok := ms.subscribers[conn]; ok {
+			delete(ms.subscribers, conn)
+			close(events)
+		}
+		s.mu.Unlock()
+	}()
+
+	for payload := range events {
+		if err := wsutil.WriteServerText(conn, payload); err != nil {
+			break
+		}
+	}
+}
+
+// discard reads the client frames until the connection is closed, the client replies with pongs to every message
+func discard(conn net.Conn) {
+	for {
+		if _, _, err := wsutil.ReadClientData(conn); err != nil {
+			return
+		}
+	}
+}
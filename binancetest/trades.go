@@ -0,0 +1,409 @@
+package binancetest
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/binance-api"
+)
+
+// avgPriceMins is the interval of the average price in minutes
+const avgPriceMins = 5
+
+var (
+	errInvalidInterval   = &binance.APIError{Code: binance.ErrorCodeBadInterval, Msg: "Invalid interval."}
+	errInvalidWindowSize = &binance.APIError{Code: binance.ErrorCodeMandatoryParam, Msg: "Invalid windowSize."}
+)
+
+// trades returns the trades of the symbol executed by the matching engine, they are the market trades of the server
+func (s *Server) trades(symbol string) ([]*binance.AccountTrade, error) {
+	if _, err := s.book(symbol); err != nil {
+		return nil, err
+	}
+	var resp []*binance.AccountTrade
+	for _, t := range s.engine.Trades() {
+		if t.Symbol == symbol {
+			resp = append(resp, t)
+		}
+	}
+
+	return resp, nil
+}
+
+// trades serves the recent and the historical trades
+func trades(s *Server, _, _ string, params url.Values) (interface{}, error) {
+	list, err := s.trades(params.Get("symbol"))
+	if err != nil {
+		return nil, err
+	}
+	fromID := int64Param(params, "fromId")
+	limit := limitParam(params, binance.DefaultTradesLimit, binance.MaxTradesLimit)
+
+	resp := make([]*binance.Trade, 0, len(list))
+	for _, t := range list {
+		if t.ID < fromID {
+			continue
+		}
+		resp = append(resp, &binance.Trade{
+			ID:           t.ID,
+			Price:        t.Price,
+			Qty:          t.Qty,
+			QuoteQty:     t.QuoteQty,
+			Time:         t.Time,
+			IsBuyerMaker: t.Buyer == t.Maker,
+			IsBestMatch:  t.BestMatch,
+		})
+	}
+	if len(resp) > limit {
+		// the oldest trades are returned starting from the id, otherwise the most recent ones
+		if fromID != 0 {
+			resp = resp[:limit]
+		} else {
+			resp = resp[len(resp)-limit:]
+		}
+	}
+
+	return resp, nil
+}
+
+// aggTrades aggregates the fills of the same order at the same price and time, the aggregate id is its first trade id
+func aggTrades(s *Server, _, _ string, params url.Values) (interface{}, error) {
+	list, err := s.trades(params.Get("symbol"))
+	if err != nil {
+		return nil, err
+	}
+	fromID, startTime, endTime := int64Param(params, "fromId"), int64Param(params, "startTime"), int64Param(params, "endTime")
+	limit := limitParam(params, binance.DefaultTradesLimit, binance.MaxTradesLimit)
+
+	resp := make([]*binance.AggregatedTrade, 0, len(list))
+	var prev *binance.AccountTrade
+	for _, t := range list {
+		if prev != nil && t.OrderID == prev.OrderID && t.Price == prev.Price && t.Time == prev.Time {
+			agg := resp[len(resp)-1]
+			agg.Quantity = decimal.RequireFromString(agg.Quantity).Add(decimal.RequireFromString(t.Qty)).String()
+			agg.LastTradeID = t.ID
+			prev = t
+			continue
+		}
+		prev = t
+		switch {
+		case t.ID < fromID,
+			startTime != 0 && t.Time < startTime,
+			endTime != 0 && t.Time > endTime:
+			prev = nil
+			continue
+		}
+		resp = append(resp, &binance.AggregatedTrade{
+			TradeID:      t.ID,
+			Price:        t.Price,
+			Quantity:     t.Qty,
+			FirstTradeID: t.ID,
+			LastTradeID:  t.ID,
+			Timestamp:    t.Time,
+			Maker:        t.Buyer == t.Maker,
+			BestMatch:    t.BestMatch,
+		})
+	}
+	if len(resp) > limit {
+		if fromID != 0 || startTime != 0 {
+			resp = resp[:limit]
+		} else {
+			resp = resp[len(resp)-limit:]
+		}
+	}
+
+	return resp, nil
+}
+
+type candle struct {
+	openTime   int64
+	closeTime  int64
+	open       decimal.Decimal
+	high       decimal.Decimal
+	low        decimal.Decimal
+	close      decimal.Decimal
+	volume     decimal.Decimal
+	quote      decimal.Decimal
+	takerBase  decimal.Decimal
+	takerQuote decimal.Decimal
+	trades     int
+}
+
+// klines serves the klines and the UI klines, only the intervals with trades are returned
+func klines(s *Server, _, _ string, params url.Values) (interface{}, error) {
+	list, err := s.trades(params.Get("symbol"))
+	if err != nil {
+		return nil, err
+	}
+	interval := binance.KlineInterval(params.Get("interval"))
+	if interval.Duration() == 0 && interval != binance.KlineInterval1month {
+		return nil, errInvalidInterval
+	}
+	startTime, endTime := int64Param(params, "startTime"), int64Param(params, "endTime")
+	limit := limitParam(params, binance.DefaultKlinesLimit, binance.MaxKlinesLimit)
+
+	var candles []*candle
+	for _, t := range list {
+		openTime, closeTime := klineBounds(interval, t.Time)
+		if (startTime != 0 && openTime < startTime) || (endTime != 0 && openTime > endTime) {
+			continue
+		}
+		price, qty, quoteQty := decimal.RequireFromString(t.Price), decimal.RequireFromString(t.Qty), decimal.RequireFromString(t.QuoteQty)
+		var c *candle
+		if len(candles) > 0 && candles[len(candles)-1].openTime == openTime {
+			c = candles[len(candles)-1]
+		} else {
+			c = &candle{openTime: openTime, closeTime: closeTime, open: price, high: price, low: price}
+			candles = append(candles, c)
+		}
+		c.high, c.low, c.close = decimal.Max(c.high, price), decimal.Min(c.low, price), price
+		c.volume, c.quote = c.volume.Add(qty), c.quote.Add(quoteQty)
+		// the taker is the buyer unless the buyer is the maker
+		if t.Buyer != t.Maker {
+			c.takerBase, c.takerQuote = c.takerBase.Add(qty), c.takerQuote.Add(quoteQty)
+		}
+		c.trades++
+	}
+	if len(candles) > limit {
+		if startTime != 0 {
+			candles = candles[:limit]
+		} else {
+			candles = candles[len(candles)-limit:]
+		}
+	}
+
+	resp := make([][]interface{}, 0, len(candles))
+	for _, c := range candles {
+		resp = append(resp, []interface{}{
+			c.openTime, c.open.String(), c.high.String(), c.low.String(), c.close.String(), c.volume.String(),
+			c.closeTime, c.quote.String(), c.trades, c.takerBase.String(), c.takerQuote.String(), "0",
+		})
+	}
+
+	return resp, nil
+}
+
+// klineBounds returns the open and close time of the kline containing the time,
+// weeks start on Monday and months are calendar months
+func klineBounds(interval binance.KlineInterval, ms int64) (int64, int64) {
+	d := interval.Duration().Milliseconds()
+	if d == 0 {
+		t := time.UnixMilli(ms).UTC()
+		open := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return open.UnixMilli(), open.AddDate(0, 1, 0).UnixMilli() - 1
+	}
+	var offset int64
+	if interval == binance.KlineInterval1week {
+		// the epoch is Thursday, the first Monday is 4 days later
+		offset = (4 * 24 * time.Hour).Milliseconds()
+	}
+	open := ms - ((ms-offset)%d+d)%d
+
+	return open, open + d - 1
+}
+
+// avgPrice returns the average price of the recent trades or the mid price of the book without them
+func avgPrice(s *Server, _, _ string, params url.Values) (interface{}, error) {
+	symbol := params.Get("symbol")
+	list, err := s.trades(symbol)
+	if err != nil {
+		return nil, err
+	}
+	since := time.Now().Add(-avgPriceMins * time.Minute).UnixMilli()
+	volume, quote := decimal.Zero, decimal.Zero
+	for _, t := range list {
+		if t.Time >= since {
+			volume = volume.Add(decimal.RequireFromString(t.Qty))
+			quote = quote.Add(decimal.RequireFromString(t.QuoteQty))
+		}
+	}
+	if volume.Sign() == 0 {
+		p, err := s.price(symbol)
+		if err != nil {
+			return nil, err
+		}
+		return &binance.AvgPrice{Mins: avgPriceMins, Price: p.Price}, nil
+	}
+
+	return &binance.AvgPrice{Mins: avgPriceMins, Price: quote.Div(volume).String()}, nil
+}
+
+// ticker serves the 24 hour and the rolling window statistics
+func ticker(s *Server, _, endpoint string, params url.Values) (interface{}, error) {
+	window := 24 * time.Hour
+	if size := params.Get("windowSize"); size != "" && endpoint == binance.EndpointTicker {
+		var err error
+		if window, err = parseWindowSize(size); err != nil {
+			return nil, err
+		}
+	}
+	mini := params.Get("type") == string(binance.TickerRespTypeMini)
+	if symbol := params.Get("symbol"); symbol != "" {
+		return s.tickerStat(symbol, endpoint, window, mini)
+	}
+
+	var names []string
+	switch symbols := params.Get("symbols"); {
+	case symbols != "":
+		list, err := parseList(symbols)
+		if err != nil {
+			return nil, err
+		}
+		names = list
+	case endpoint == binance.EndpointTicker:
+		return nil, errMandatorySymbol
+	default:
+		for _, info := range s.config.Symbols {
+			names = append(names, info.Symbol)
+		}
+	}
+	resp := make([]interface{}, 0, len(names))
+	for _, symbol := range names {
+		stat, err := s.tickerStat(symbol, endpoint, window, mini)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, stat)
+	}
+
+	return resp, nil
+}
+
+func parseWindowSize(size string) (time.Duration, error) {
+	if !binance.TickerWindowSize(size).IsValid() {
+		return 0, errInvalidWindowSize
+	}
+	n, err := strconv.Atoi(size[:len(size)-1])
+	if err != nil {
+		return 0, errInvalidWindowSize
+	}
+	unit := 24 * time.Hour
+	switch size[len(size)-1] {
+	case 'm':
+		unit = time.Minute
+	case 'h':
+		unit = time.Hour
+	}
+
+	return time.Duration(n) * unit, nil
+}
+
+// tickerStat returns the statistics of the trades within the window in the format of the endpoint
+func (s *Server) tickerStat(symbol, endpoint string, window time.Duration, mini bool) (interface{}, error) {
+	list, err := s.trades(symbol)
+	if err != nil {
+		return nil, err
+	}
+	book, err := s.bookTicker(symbol)
+	if err != nil {
+		return nil, err
+	}
+	closeTime := time.Now().UnixMilli()
+	openTime := closeTime - window.Milliseconds()
+
+	var (
+		open, high, low, last, lastQty = decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero, decimal.Zero
+		prevClose, volume, quote       = decimal.Zero, decimal.Zero, decimal.Zero
+		firstID, lastID                = int64(-1), int64(-1)
+		count                          int
+	)
+	for _, t := range list {
+		price := decimal.RequireFromString(t.Price)
+		if t.Time < openTime {
+			prevClose = price
+			continue
+		}
+		if count == 0 {
+			open, high, low, firstID = price, price, price, t.ID
+		}
+		high, low, last, lastID = decimal.Max(high, price), decimal.Min(low, price), price, t.ID
+		lastQty = decimal.RequireFromString(t.Qty)
+		volume = volume.Add(lastQty)
+		quote = quote.Add(decimal.RequireFromString(t.QuoteQty))
+		count++
+	}
+	change, changePercent, weightedAvg := last.Sub(open), decimal.Zero, decimal.Zero
+	if open.Sign() > 0 {
+		changePercent = change.Div(open).Mul(decimal.NewFromInt(100)).Round(3)
+	}
+	if volume.Sign() > 0 {
+		weightedAvg = quote.Div(volume)
+	}
+
+	switch {
+	case mini:
+		return &binance.TickerStatMini{
+			Symbol:      symbol,
+			OpenPrice:   open.String(),
+			HighPrice:   high.String(),
+			LowPrice:    low.String(),
+			LastPrice:   last.String(),
+			Volume:      volume.String(),
+			QuoteVolume: quote.String(),
+			OpenTime:    openTime,
+			CloseTime:   closeTime,
+			FirstID:     firstID,
+			LastID:      lastID,
+			Count:       count,
+		}, nil
+	case endpoint == binance.EndpointTicker:
+		return &binance.TickerStat{
+			Symbol:             symbol,
+			PriceChange:        change.String(),
+			PriceChangePercent: changePercent.String(),
+			WeightedAvgPrice:   weightedAvg.String(),
+			OpenPrice:          open.String(),
+			HighPrice:          high.String(),
+			LowPrice:           low.String(),
+			LastPrice:          last.String(),
+			Volume:             volume.String(),
+			QuoteVolume:        quote.String(),
+			OpenTime:           openTime,
+			CloseTime:          closeTime,
+			FirstID:            firstID,
+			LastID:             lastID,
+			Count:              count,
+		}, nil
+	}
+
+	return &binance.TickerStatFull{
+		Symbol:             symbol,
+		PriceChange:        change.String(),
+		PriceChangePercent: changePercent.String(),
+		WeightedAvgPrice:   weightedAvg.String(),
+		PrevClosePrice:     prevClose.String(),
+		LastPrice:          last.String(),
+		LastQty:            lastQty.String(),
+		BidPrice:           book.BidPrice,
+		AskPrice:           book.AskPrice,
+		OpenPrice:          open.String(),
+		HighPrice:          high.String(),
+		LowPrice:           low.String(),
+		Volume:             volume.String(),
+		QuoteVolume:        quote.String(),
+		OpenTime:           openTime,
+		CloseTime:          closeTime,
+		FirstID:            firstID,
+		LastID:             lastID,
+		Count:              count,
+	}, nil
+}
+
+func int64Param(params url.Values, name string) int64 {
+	n, _ := strconv.ParseInt(params.Get(name), 10, 64)
+
+	return n
+}
+
+// limitParam returns the limit param, missing and out of range limits are replaced by the default one
+func limitParam(params url.Values, def, maxLimit int) int {
+	limit, _ := strconv.Atoi(params.Get("limit"))
+	if limit <= 0 || limit > maxLimit {
+		return def
+	}
+
+	return limit
+}
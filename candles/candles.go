@@ -0,0 +1,358 @@
+package candles
+
+import (
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/ws"
+)
+
+// Kind is the rule closing the bar
+type Kind string
+
+const (
+	KindTime   Kind = "time"   // KindTime bars cover the fixed Interval aligned to the epoch and the Offset
+	KindVolume Kind = "volume" // KindVolume bars close once the base volume reaches the Threshold
+	KindDollar Kind = "dollar" // KindDollar bars close once the quote volume reaches the Threshold
+	KindTick   Kind = "tick"   // KindTick bars close once the number of trades reaches the Threshold
+)
+
+var (
+	ErrKind       = errors.New("unknown bar kind")
+	ErrInterval   = errors.New("interval must be positive")
+	ErrThreshold  = errors.New("threshold must be positive")
+	ErrOutOfOrder = errors.New("trade is older than the current bar")
+	// ErrSourceInterval is returned for the source kline which doesn't fit into a single time bar
+	ErrSourceInterval = errors.New("kline doesn't fit into the bar interval")
+)
+
+// Config configures the bar builder
+type Config struct {
+	Kind      Kind
+	Interval  time.Duration   // Interval is the length of time bars, e.g. binance.KlineInterval1min.Duration() * 2
+	Offset    time.Duration   // Offset shifts the time bars from the epoch, e.g. 4 days start weekly bars on Mondays
+	Threshold decimal.Decimal // Threshold is the size of volume, dollar and tick bars
+	// EmitEmpty emits the time bars without trades at the previous close price,
+	// otherwise the intervals without trades are skipped
+	EmitEmpty bool
+}
+
+func (c Config) validate() error {
+	switch c.Kind {
+	case KindTime:
+		if c.Interval.Milliseconds() <= 0 {
+			return ErrInterval
+		}
+	case KindVolume, KindDollar, KindTick:
+		if c.Threshold.Sign() <= 0 {
+			return ErrThreshold
+		}
+	default:
+		return errors.Wrap(ErrKind, string(c.Kind))
+	}
+
+	return nil
+}
+
+// Builder aggregates trades or lower interval klines into bars.
+// Bars are returned by the call which completes them: time bars are completed by the first trade after
+// the bar end, by the source kline closing at the bar end or by Advance, other bars by the trade reaching the threshold.
+// The trade reaching the threshold is not split between bars, so the bar size may exceed the threshold
+type Builder struct {
+	config   Config
+	interval int64
+	offset   int64
+
+	bar  *binance.Kline // bar is the current bar, nil until the first trade
+	size decimal.Decimal
+	last *binance.Kline // last is the last completed bar
+}
+
+// New creates the bar builder
+func New(config Config) (*Builder, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	return &Builder{
+		config:   config,
+		interval: config.Interval.Milliseconds(),
+		offset:   config.Offset.Milliseconds(),
+		size:     decimal.Zero,
+	}, nil
+}
+
+// AddTrade adds the trade and returns the completed bars
+func (b *Builder) AddTrade(u *ws.TradeUpdate) ([]*binance.Kline, error) {
+	price, qty, err := parseTrade(u.Price, u.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.add(u.TradeTime, price, qty, 1, !u.Maker)
+}
+
+// AddAggTrade adds the aggregated trade and returns the completed bars,
+// the trade counts all trades it aggregates
+func (b *Builder) AddAggTrade(u *ws.AggTradeUpdate) ([]*binance.Kline, error) {
+	price, qty, err := parseTrade(u.Price, u.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	trades := int(u.LastBreakDownTradeID - u.FirstBreakDownTradeID + 1)
+	if trades < 1 {
+		trades = 1
+	}
+
+	return b.add(u.TradeTime, price, qty, trades, !u.Maker)
+}
+
+// AddKline adds the lower interval kline and returns the completed bars.
+// The kline must be final, it is added as a whole, so the time bar interval must be its multiple
+func (b *Builder) AddKline(k *binance.Kline) ([]*binance.Kline, error) {
+	if b.config.Kind == KindTime && b.start(k.OpenTime) != b.start(k.CloseTime) {
+		return nil, ErrSourceInterval
+	}
+	closed, err := b.begin(k.OpenTime, k.CloseTime, k.OpenPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	bar := b.bar
+	bar.HighPrice = decimal.Max(bar.HighPrice, k.HighPrice)
+	bar.LowPrice = decimal.Min(bar.LowPrice, k.LowPrice)
+	bar.ClosePrice = k.ClosePrice
+	bar.Volume = bar.Volume.Add(k.Volume)
+	bar.QuoteAssetVolume = bar.QuoteAssetVolume.Add(k.QuoteAssetVolume)
+	bar.TakerBuyBaseAssetVolume = bar.TakerBuyBaseAssetVolume.Add(k.TakerBuyBaseAssetVolume)
+	bar.TakerBuyQuoteAssetVolume = bar.TakerBuyQuoteAssetVolume.Add(k.TakerBuyQuoteAssetVolume)
+	bar.Trades += k.Trades
+
+	// the source kline closing with the time bar completes it
+	if b.config.Kind == KindTime && k.CloseTime >= bar.CloseTime {
+		return append(closed, b.complete()), nil
+	}
+
+	return b.grow(closed, k.Volume, k.QuoteAssetVolume, k.Trades), nil
+}
+
+// AddKlineUpdate adds the final kline of the stream, the updates of the open kline are skipped
+func (b *Builder) AddKlineUpdate(u *ws.KlinesUpdate) ([]*binance.Kline, error) {
+	if !u.Kline.Final {
+		return nil, nil
+	}
+	k, err := KlineFromUpdate(u)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.AddKline(k)
+}
+
+// Advance completes the time bars ended before the time, e.g. by the timer when no trades arrive
+func (b *Builder) Advance(now time.Time) []*binance.Kline {
+	if b.config.Kind != KindTime {
+		return nil
+	}
+
+	return b.advance(now.UnixMilli())
+}
+
+// Current returns the copy of the incomplete bar or nil
+func (b *Builder) Current() *binance.Kline {
+	if b.bar == nil {
+		return nil
+	}
+	bar := *b.bar
+
+	return &bar
+}
+
+// Flush completes the current bar regardless of its end and returns it or nil
+func (b *Builder) Flush() *binance.Kline {
+	if b.bar == nil {
+		return nil
+	}
+
+	return b.complete()
+}
+
+func (b *Builder) add(t int64, price, qty decimal.Decimal, trades int, takerBuy bool) ([]*binance.Kline, error) {
+	closed, err := b.begin(t, t, price)
+	if err != nil {
+		return nil, err
+	}
+
+	bar := b.bar
+	quote := price.Mul(qty)
+	bar.HighPrice = decimal.Max(bar.HighPrice, price)
+	bar.LowPrice = decimal.Min(bar.LowPrice, price)
+	bar.ClosePrice = price
+	bar.Volume = bar.Volume.Add(qty)
+	bar.QuoteAssetVolume = bar.QuoteAssetVolume.Add(quote)
+	if takerBuy {
+		bar.TakerBuyBaseAssetVolume = bar.TakerBuyBaseAssetVolume.Add(qty)
+		bar.TakerBuyQuoteAssetVolume = bar.TakerBuyQuoteAssetVolume.Add(quote)
+	}
+	bar.Trades += trades
+
+	return b.grow(closed, qty, quote, trades), nil
+}
+
+// begin completes the time bars ended before the time and opens the bar containing it,
+// other bars are opened by the first trade and closed at the last one
+func (b *Builder) begin(t, end int64, price decimal.Decimal) ([]*binance.Kline, error) {
+	if b.stale(t) {
+		return nil, ErrOutOfOrder
+	}
+	if b.config.Kind != KindTime {
+		if b.bar == nil {
+			b.bar = b.open(t, end, price)
+		}
+		b.bar.CloseTime = end
+		return nil, nil
+	}
+
+	closed := b.advance(t)
+	if b.bar == nil {
+		start := b.start(t)
+		b.bar = b.open(start, start+b.interval-1, price)
+	}
+
+	return closed, nil
+}
+
+// grow adds the size of the trades to the volume, dollar and tick bars and completes the bar reaching the threshold
+func (b *Builder) grow(closed []*binance.Kline, qty, quote decimal.Decimal, trades int) []*binance.Kline {
+	switch b.config.Kind {
+	case KindVolume:
+		b.size = b.size.Add(qty)
+	case KindDollar:
+		b.size = b.size.Add(quote)
+	case KindTick:
+		b.size = b.size.Add(decimal.NewFromInt(int64(trades)))
+	default:
+		return closed
+	}
+	if b.size.GreaterThanOrEqual(b.config.Threshold) {
+		closed = append(closed, b.complete())
+	}
+
+	return closed
+}
+
+// advance completes the time bar ended before the time and the empty bars after it
+func (b *Builder) advance(t int64) []*binance.Kline {
+	var closed []*binance.Kline
+	if b.bar != nil {
+		if t <= b.bar.CloseTime {
+			return nil
+		}
+		closed = append(closed, b.complete())
+	}
+	if !b.config.EmitEmpty || b.last == nil {
+		return closed
+	}
+	for start := b.last.CloseTime + 1; start+b.interval-1 < t; start += b.interval {
+		bar := b.open(start, start+b.interval-1, b.last.ClosePrice)
+		b.last = bar
+		closed = append(closed, bar)
+	}
+
+	return closed
+}
+
+// stale checks whether the time belongs to the completed bars
+func (b *Builder) stale(t int64) bool {
+	if b.config.Kind == KindTime {
+		if b.bar != nil {
+			return t < b.bar.OpenTime
+		}
+		return b.last != nil && t <= b.last.CloseTime
+	}
+	if b.bar != nil {
+		return t < b.bar.CloseTime
+	}
+
+	return b.last != nil && t < b.last.CloseTime
+}
+
+// start returns the open time of the time bar containing the time
+func (b *Builder) start(t int64) int64 {
+	shifted := t - b.offset
+	mod := shifted % b.interval
+	if mod < 0 {
+		mod += b.interval
+	}
+
+	return t - mod
+}
+
+func (b *Builder) open(openTime, closeTime int64, price decimal.Decimal) *binance.Kline {
+	return &binance.Kline{
+		OpenPrice:                price,
+		HighPrice:                price,
+		LowPrice:                 price,
+		ClosePrice:               price,
+		Volume:                   decimal.Zero,
+		QuoteAssetVolume:         decimal.Zero,
+		TakerBuyBaseAssetVolume:  decimal.Zero,
+		TakerBuyQuoteAssetVolume: decimal.Zero,
+		OpenTime:                 openTime,
+		CloseTime:                closeTime,
+	}
+}
+
+func (b *Builder) complete() *binance.Kline {
+	bar := b.bar
+	b.bar, b.last, b.size = nil, bar, decimal.Zero
+
+	return bar
+}
+
+func parseTrade(price, qty string) (decimal.Decimal, decimal.Decimal, error) {
+	p, err := decimal.NewFromString(price)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, errors.Wrap(err, "trade price")
+	}
+	q, err := decimal.NewFromString(qty)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, errors.Wrap(err, "trade quantity")
+	}
+
+	return p, q, nil
+}
+
+// KlineFromUpdate converts the kline of the stream update to the REST kline
+func KlineFromUpdate(u *ws.KlinesUpdate) (*binance.Kline, error) {
+	k := &u.Kline
+	resp := &binance.Kline{OpenTime: k.StartTime, CloseTime: k.EndTime, Trades: k.Trades}
+	for _, f := range []struct {
+		dst *decimal.Decimal
+		src string
+	}{
+		{&resp.OpenPrice, k.OpenPrice},
+		{&resp.HighPrice, k.High},
+		{&resp.LowPrice, k.Low},
+		{&resp.ClosePrice, k.ClosePrice},
+		{&resp.Volume, k.Volume},
+		{&resp.QuoteAssetVolume, k.VolumeQuote},
+		{&resp.TakerBuyBaseAssetVolume, k.VolumeActiveBuy},
+		{&resp.TakerBuyQuoteAssetVolume, k.VolumeQuoteActiveBuy},
+	} {
+		if f.src == "" {
+			*f.dst = decimal.Zero
+			continue
+		}
+		d, err := decimal.NewFromString(f.src)
+		if err != nil {
+			return nil, errors.Wrap(err, "kline update")
+		}
+		*f.dst = d
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,189 @@
+package candles_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/candles"
+	"github.com/xenking/binance-api/ws"
+)
+
+func TestCandles(t *testing.T) {
+	suite.Run(t, new(candlesTestSuite))
+}
+
+type candlesTestSuite struct {
+	suite.Suite
+}
+
+func trade(t int64, price, qty string, maker bool) *ws.TradeUpdate {
+	return &ws.TradeUpdate{EventType: ws.UpdateTypeTrades, Symbol: "BTCUSDT", Time: t, TradeTime: t, Price: price, Quantity: qty, Maker: maker}
+}
+
+func (s *candlesTestSuite) builder(config candles.Config) *candles.Builder {
+	b, err := candles.New(config)
+	s.Require().NoError(err)
+
+	return b
+}
+
+func (s *candlesTestSuite) add(b *candles.Builder, trades ...*ws.TradeUpdate) []*binance.Kline {
+	var resp []*binance.Kline
+	for _, t := range trades {
+		bars, err := b.AddTrade(t)
+		s.Require().NoError(err)
+		resp = append(resp, bars...)
+	}
+
+	return resp
+}
+
+func (s *candlesTestSuite) TestTimeBars() {
+	b := s.builder(candles.Config{Kind: candles.KindTime, Interval: 2 * binance.KlineInterval1min.Duration(), EmitEmpty: true})
+
+	bars := s.add(b,
+		trade(0, "100", "1", false),
+		trade(30000, "105", "2", true),
+		trade(119999, "95", "1", false),
+	)
+	s.Require().Empty(bars)
+	s.Require().Equal("95", b.Current().ClosePrice.String())
+
+	bars = s.add(b, trade(120000, "101", "1", false))
+	s.Require().Len(bars, 1)
+	bar := bars[0]
+	s.Require().EqualValues(0, bar.OpenTime)
+	s.Require().EqualValues(119999, bar.CloseTime)
+	s.Require().Equal("100", bar.OpenPrice.String())
+	s.Require().Equal("105", bar.HighPrice.String())
+	s.Require().Equal("95", bar.LowPrice.String())
+	s.Require().Equal("95", bar.ClosePrice.String())
+	s.Require().Equal("4", bar.Volume.String())
+	s.Require().Equal("405", bar.QuoteAssetVolume.String())
+	s.Require().Equal("2", bar.TakerBuyBaseAssetVolume.String())
+	s.Require().Equal("195", bar.TakerBuyQuoteAssetVolume.String())
+	s.Require().Equal(3, bar.Trades)
+
+	// the intervals without trades are filled with the previous close
+	bars = s.add(b, trade(600000, "110", "1", false))
+	s.Require().Len(bars, 4)
+	s.Require().EqualValues(120000, bars[0].OpenTime)
+	for i, bar := range bars[1:] {
+		s.Require().EqualValues(240000+int64(i)*120000, bar.OpenTime)
+		s.Require().Equal("101", bar.OpenPrice.String())
+		s.Require().Equal("101", bar.ClosePrice.String())
+		s.Require().True(bar.Volume.IsZero())
+		s.Require().Zero(bar.Trades)
+	}
+
+	bars = b.Advance(time.UnixMilli(720000))
+	s.Require().Len(bars, 1)
+	s.Require().EqualValues(719999, bars[0].CloseTime)
+	s.Require().Nil(b.Flush())
+
+	_, err := b.AddTrade(trade(700000, "100", "1", false))
+	s.Require().ErrorIs(err, candles.ErrOutOfOrder)
+}
+
+func (s *candlesTestSuite) TestOffset() {
+	b := s.builder(candles.Config{Kind: candles.KindTime, Interval: binance.KlineInterval1week.Duration(), Offset: 4 * 24 * time.Hour})
+	monday := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	s.add(b, trade(monday.Add(50*time.Hour).UnixMilli(), "100", "1", false))
+	bar := b.Flush()
+	s.Require().EqualValues(monday.UnixMilli(), bar.OpenTime)
+	s.Require().EqualValues(monday.Add(7*24*time.Hour).UnixMilli()-1, bar.CloseTime)
+}
+
+func (s *candlesTestSuite) TestVolumeBars() {
+	b := s.builder(candles.Config{Kind: candles.KindVolume, Threshold: decimal.NewFromInt(3)})
+
+	var bars []*binance.Kline
+	for i, qty := range []string{"1", "1", "2", "1"} {
+		resp, err := b.AddAggTrade(&ws.AggTradeUpdate{
+			TradeTime: int64(i+1) * 1000, Price: "10", Quantity: qty, FirstBreakDownTradeID: int64(i * 10), LastBreakDownTradeID: int64(i*10 + 1),
+		})
+		s.Require().NoError(err)
+		bars = append(bars, resp...)
+	}
+	// the trade reaching the threshold isn't split
+	s.Require().Len(bars, 1)
+	s.Require().Equal("4", bars[0].Volume.String())
+	s.Require().EqualValues(1000, bars[0].OpenTime)
+	s.Require().EqualValues(3000, bars[0].CloseTime)
+	s.Require().Equal(6, bars[0].Trades)
+
+	bar := b.Flush()
+	s.Require().Equal("1", bar.Volume.String())
+	s.Require().EqualValues(4000, bar.OpenTime)
+	s.Require().EqualValues(4000, bar.CloseTime)
+}
+
+func (s *candlesTestSuite) TestDollarAndTickBars() {
+	b := s.builder(candles.Config{Kind: candles.KindDollar, Threshold: decimal.NewFromInt(250)})
+	bars := s.add(b, trade(1, "100", "1", false), trade(2, "100", "2", false), trade(3, "100", "1", false))
+	s.Require().Len(bars, 1)
+	s.Require().Equal("300", bars[0].QuoteAssetVolume.String())
+
+	b = s.builder(candles.Config{Kind: candles.KindTick, Threshold: decimal.NewFromInt(2)})
+	bars = s.add(b, trade(1, "100", "1", false), trade(2, "101", "2", false), trade(3, "102", "1", false), trade(4, "99", "1", false))
+	s.Require().Len(bars, 2)
+	s.Require().Equal("101", bars[0].ClosePrice.String())
+	s.Require().Equal("102", bars[1].OpenPrice.String())
+	s.Require().Equal("99", bars[1].LowPrice.String())
+}
+
+func (s *candlesTestSuite) TestKlines() {
+	b := s.builder(candles.Config{Kind: candles.KindTime, Interval: 5 * time.Minute})
+
+	update := func(i int64, final bool) *ws.KlinesUpdate {
+		u := &ws.KlinesUpdate{EventType: ws.UpdateTypeKline}
+		k := &u.Kline
+		k.StartTime, k.EndTime, k.Interval, k.Final = i*60000, i*60000+59999, binance.KlineInterval1min, final
+		k.OpenPrice, k.ClosePrice, k.High, k.Low = "10", "11", "12", "9"
+		k.Volume, k.VolumeQuote, k.VolumeActiveBuy, k.VolumeQuoteActiveBuy, k.Trades = "1", "10", "0.5", "5", 2
+		if i == 2 {
+			k.High = "20"
+		}
+		return u
+	}
+	var bars []*binance.Kline
+	for i := int64(0); i < 5; i++ {
+		// the updates of the open kline are skipped
+		resp, err := b.AddKlineUpdate(update(i, false))
+		s.Require().NoError(err)
+		s.Require().Empty(resp)
+		resp, err = b.AddKlineUpdate(update(i, true))
+		s.Require().NoError(err)
+		bars = append(bars, resp...)
+	}
+	// the bar is completed by the source kline closing at its end
+	s.Require().Len(bars, 1)
+	bar := bars[0]
+	s.Require().EqualValues(0, bar.OpenTime)
+	s.Require().EqualValues(299999, bar.CloseTime)
+	s.Require().Equal("20", bar.HighPrice.String())
+	s.Require().Equal("5", bar.Volume.String())
+	s.Require().Equal("50", bar.QuoteAssetVolume.String())
+	s.Require().Equal("2.5", bar.TakerBuyBaseAssetVolume.String())
+	s.Require().Equal("25", bar.TakerBuyQuoteAssetVolume.String())
+	s.Require().Equal(10, bar.Trades)
+
+	b = s.builder(candles.Config{Kind: candles.KindTime, Interval: 90 * time.Second})
+	_, err := b.AddKline(&binance.Kline{OpenTime: 60000, CloseTime: 119999})
+	s.Require().ErrorIs(err, candles.ErrSourceInterval)
+}
+
+func (s *candlesTestSuite) TestConfig() {
+	_, err := candles.New(candles.Config{Kind: candles.KindTime})
+	s.Require().ErrorIs(err, candles.ErrInterval)
+	_, err = candles.New(candles.Config{Kind: candles.KindVolume})
+	s.Require().ErrorIs(err, candles.ErrThreshold)
+	_, err = candles.New(candles.Config{Kind: "range"})
+	s.Require().ErrorIs(err, candles.ErrKind)
+	s.Require().Zero(binance.KlineInterval1month.Duration())
+}
@@ -0,0 +1,166 @@
+package cassette
+
+import (
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/google/go-querystring/query"
+	"github.com/segmentio/encoding/json"
+)
+
+// Redacted replaces the values of the redacted params and response fields
+const Redacted = "REDACTED"
+
+// DefaultRedactedKeys are the params and response fields holding secrets
+var DefaultRedactedKeys = []string{"listenKey", "apiKey", "secretKey", "signature"}
+
+// ignoredParams are added by the signing client and never take part in matching
+var ignoredParams = []string{"timestamp", "signature", "recvWindow"}
+
+// ErrNoInteraction is returned by the player when the cassette has no unused interaction matching the request
+var ErrNoInteraction = errors.New("no recorded interaction matches the request")
+
+// Config configures the recorder and the player
+type Config struct {
+	RedactedKeys []string // RedactedKeys are redacted in addition to DefaultRedactedKeys
+	Realtime     bool     // Realtime replays websocket frames with the recorded delays, otherwise frames are available immediately
+	Now          func() time.Time
+}
+
+func (c Config) defaults() Config {
+	if c.Now == nil {
+		c.Now = time.Now
+	}
+	c.RedactedKeys = append(append([]string{}, DefaultRedactedKeys...), c.RedactedKeys...)
+
+	return c
+}
+
+// Cassette holds the recorded REST interactions and websocket streams
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+	Streams      []*Stream      `json:"streams"`
+}
+
+// Interaction is the recorded RestClient call
+type Interaction struct {
+	Method     string           `json:"method"`
+	Endpoint   string           `json:"endpoint"`
+	Params     string           `json:"params"` // Params are sorted urlencoded params without timestamp and signature
+	Sign       bool             `json:"sign"`
+	Stream     bool             `json:"stream"`
+	Response   string           `json:"response,omitempty"` // Response is kept as is, some decoders depend on the exact formatting
+	Error      *Error           `json:"error,omitempty"`
+	UsedWeight map[string]int64 `json:"usedWeight,omitempty"`
+	OrderCount map[string]int64 `json:"orderCount,omitempty"`
+	RetryAfter int64            `json:"retryAfter,omitempty"`
+	Time       int64            `json:"time"`     // Time is the request time in ms
+	Duration   time.Duration    `json:"duration"` // Duration is the response latency
+}
+
+// Error is the recorded error, API errors are replayed as *binance.APIError
+type Error struct {
+	API  bool   `json:"api"`
+	Code int    `json:"code,omitempty"`
+	Msg  string `json:"msg"`
+}
+
+// Stream is the recorded websocket connection
+type Stream struct {
+	Name   string   `json:"name"`
+	Time   int64    `json:"time"` // Time is the connection time in ms
+	Frames []*Frame `json:"frames"`
+}
+
+// Frame is the websocket frame received from the server
+type Frame struct {
+	Offset time.Duration `json:"offset"` // Offset is the receive time since the connection time
+	OpCode byte          `json:"opCode"`
+	Fin    bool          `json:"fin"`
+	Text   string        `json:"text,omitempty"`   // Text is the payload of text frames
+	Binary []byte        `json:"binary,omitempty"` // Binary is the payload of other frames
+}
+
+// Load reads the cassette file
+func Load(path string) (*Cassette, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := &Cassette{}
+	if err = json.Unmarshal(buf, c); err != nil {
+		return nil, errors.Wrap(err, "decode cassette")
+	}
+
+	return c, nil
+}
+
+// Save writes the cassette file
+func (c *Cassette) Save(path string) error {
+	buf, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, buf, 0o600)
+}
+
+// redactor normalizes params and removes secrets from recorded payloads
+type redactor struct {
+	keys   map[string]struct{}
+	fields *regexp.Regexp
+}
+
+func newRedactor(keys []string) *redactor {
+	r := &redactor{keys: make(map[string]struct{}, len(keys))}
+	quoted := make([]string, 0, len(keys))
+	for _, k := range keys {
+		r.keys[k] = struct{}{}
+		quoted = append(quoted, regexp.QuoteMeta(k))
+	}
+	r.fields = regexp.MustCompile(`"(` + strings.Join(quoted, "|") + `)"(\s*:\s*)"[^"]*"`)
+
+	return r
+}
+
+// params returns the sorted urlencoded params of the request data with ignored params removed and secrets redacted
+func (r *redactor) params(data interface{}) (string, error) {
+	var (
+		values url.Values
+		err    error
+	)
+	switch d := data.(type) {
+	case url.Values:
+		values = make(url.Values, len(d))
+		for k, v := range d {
+			values[k] = v
+		}
+	case string:
+		if values, err = url.ParseQuery(d); err != nil {
+			return "", err
+		}
+	default:
+		if values, err = query.Values(data); err != nil {
+			return "", err
+		}
+	}
+	for _, k := range ignoredParams {
+		values.Del(k)
+	}
+	for k := range values {
+		if _, ok := r.keys[k]; ok {
+			values[k] = []string{Redacted}
+		}
+	}
+
+	return values.Encode(), nil
+}
+
+// payload replaces the values of redacted json string fields
+func (r *redactor) payload(buf []byte) []byte {
+	return r.fields.ReplaceAll(buf, []byte(`"$1"$2"`+Redacted+`"`))
+}
@@ -0,0 +1,167 @@
+package cassette_test
+
+import (
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-faster/errors"
+	"github.com/gobwas/ws/wsutil"
+	"github.com/stretchr/testify/suite"
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/cassette"
+	"github.com/xenking/binance-api/ws"
+)
+
+func TestCassette(t *testing.T) {
+	suite.Run(t, new(cassetteTestSuite))
+}
+
+type mockedClient struct {
+	Response func(method, endpoint string, data interface{}, sign, stream bool) ([]byte, error)
+}
+
+func (m *mockedClient) Do(method, endpoint string, data interface{}, sign, stream bool) ([]byte, error) {
+	return m.Response(method, endpoint, data, sign, stream)
+}
+
+func (m *mockedClient) UsedWeight() map[string]int64 {
+	return map[string]int64{"1m": 10}
+}
+
+func (m *mockedClient) OrderCount() map[string]int64 {
+	return map[string]int64{}
+}
+
+func (m *mockedClient) RetryAfter() int64 {
+	return 0
+}
+
+func (m *mockedClient) SetWindow(_ int) {}
+
+type cassetteTestSuite struct {
+	suite.Suite
+	path string
+}
+
+func (s *cassetteTestSuite) SetupTest() {
+	s.path = filepath.Join(s.T().TempDir(), "cassette.json")
+}
+
+func (s *cassetteTestSuite) TestRest() {
+	upstream := &mockedClient{Response: func(method, endpoint string, data interface{}, sign, stream bool) ([]byte, error) {
+		switch endpoint {
+		case binance.EndpointDepth:
+			return []byte(`{"lastUpdateId":1,"bids":[["99.00","5"]],"asks":[["100.00","1"]]}`), nil
+		case binance.EndpointDataStream:
+			if method == fasthttp.MethodPost {
+				return []byte(`{"listenKey":"secret-listen-key"}`), nil
+			}
+			return []byte(`{}`), nil
+		case binance.EndpointAccount:
+			return nil, &binance.APIError{Code: binance.ErrorCodeInvalidTimestamp, Msg: "Timestamp for this request is outside of the recvWindow."}
+		}
+		s.FailNow("unexpected request", endpoint)
+		return nil, nil
+	}}
+	recorder := cassette.NewRecorder(upstream, cassette.Config{})
+	client := binance.NewCustomClient(recorder)
+
+	depth, err := client.Depth(&binance.DepthReq{Symbol: "BTCUSDT", Limit: 5})
+	s.Require().NoError(err)
+	key, err := client.DataStream()
+	s.Require().NoError(err)
+	s.Require().Equal("secret-listen-key", key)
+	s.Require().NoError(client.DataStreamKeepAlive(key))
+	_, err = client.Account()
+	s.Require().Error(err)
+	s.Require().NoError(recorder.Save(s.path))
+
+	buf, err := os.ReadFile(s.path)
+	s.Require().NoError(err)
+	s.Require().NotContains(string(buf), "secret-listen-key")
+
+	c, err := cassette.Load(s.path)
+	s.Require().NoError(err)
+	s.Require().Len(c.Interactions, 4)
+	player := cassette.NewPlayer(c, cassette.Config{})
+	client = binance.NewCustomClient(player)
+
+	// the replay doesn't depend on the order of different requests
+	key, err = client.DataStream()
+	s.Require().NoError(err)
+	s.Require().Equal(cassette.Redacted, key)
+	s.Require().NoError(client.DataStreamKeepAlive(key))
+
+	replayed, err := client.Depth(&binance.DepthReq{Symbol: "BTCUSDT", Limit: 5})
+	s.Require().NoError(err)
+	s.Require().Equal(depth, replayed)
+	s.Require().EqualValues(10, player.UsedWeight()["1m"])
+
+	_, err = client.Account()
+	var apiErr *binance.APIError
+	s.Require().True(errors.As(err, &apiErr))
+	s.Require().Equal(binance.ErrorCodeInvalidTimestamp, apiErr.Code)
+	s.Require().Empty(player.Unused())
+
+	// every interaction is replayed once
+	_, err = client.Depth(&binance.DepthReq{Symbol: "BTCUSDT", Limit: 5})
+	s.Require().ErrorIs(err, cassette.ErrNoInteraction)
+}
+
+func (s *cassetteTestSuite) TestStream() {
+	server, client := net.Pipe()
+	go func() {
+		defer server.Close()
+		// the client replies with pongs
+		go func() {
+			_, _ = io.Copy(io.Discard, server)
+		}()
+		for _, payload := range []string{
+			`{"e":"trade","s":"BTCUSDT","p":"100","q":"1","t":1}`,
+			`{"e":"trade","s":"BTCUSDT","p":"101","q":"2","t":2}`,
+		} {
+			if err := wsutil.WriteServerText(server, []byte(payload)); err != nil {
+				return
+			}
+		}
+	}()
+
+	recorder := cassette.NewRecorder(&mockedClient{}, cassette.Config{})
+	trades := &ws.Trades{Conn: ws.NewConn(client)}
+	trades.Conn = recorder.Conn("btcusdt@trade", trades.Conn)
+	var recorded []*ws.TradeUpdate
+	for i := 0; i < 2; i++ {
+		u, err := trades.Read()
+		s.Require().NoError(err)
+		recorded = append(recorded, u)
+	}
+	s.Require().NoError(trades.Close())
+	s.Require().NoError(recorder.Save(s.path))
+
+	c, err := cassette.Load(s.path)
+	s.Require().NoError(err)
+	s.Require().Len(c.Streams, 1)
+	s.Require().Len(c.Streams[0].Frames, 2)
+	s.Require().True(strings.HasPrefix(c.Streams[0].Frames[0].Text, `{"e":"trade"`))
+
+	player := cassette.NewPlayer(c, cassette.Config{})
+	conn, err := player.Conn("btcusdt@trade")
+	s.Require().NoError(err)
+	trades = &ws.Trades{Conn: conn}
+	for _, expected := range recorded {
+		u, err := trades.Read()
+		s.Require().NoError(err)
+		s.Require().Equal(expected, u)
+	}
+	_, err = trades.Read()
+	s.Require().ErrorIs(err, io.EOF)
+
+	_, err = player.Conn("btcusdt@trade")
+	s.Require().ErrorIs(err, cassette.ErrNoInteraction)
+}
@@ -0,0 +1,156 @@
+package cassette
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	gobws "github.com/gobwas/ws"
+)
+
+// recordConn parses the frames read from the server connection and records them
+type recordConn struct {
+	net.Conn
+	recorder *Recorder
+	stream   *Stream
+	start    time.Time
+
+	mu   sync.Mutex
+	buf  []byte
+	text bool
+}
+
+func (c *recordConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	if n > 0 {
+		c.mu.Lock()
+		c.buf = append(c.buf, p[:n]...)
+		c.parse()
+		c.mu.Unlock()
+	}
+
+	return n, err
+}
+
+// parse records the complete frames of the buffer
+func (c *recordConn) parse() {
+	for len(c.buf) > 0 {
+		r := bytes.NewReader(c.buf)
+		h, err := gobws.ReadHeader(r)
+		if err != nil {
+			// the header isn't received yet
+			return
+		}
+		headerLen := len(c.buf) - r.Len()
+		if int64(r.Len()) < h.Length {
+			return
+		}
+		payload := append([]byte{}, c.buf[headerLen:headerLen+int(h.Length)]...)
+		c.buf = c.buf[headerLen+int(h.Length):]
+		if h.Masked {
+			gobws.Cipher(payload, h.Mask, 0)
+		}
+
+		// continuation frames keep the type of the fragmented message
+		if h.OpCode == gobws.OpText || h.OpCode == gobws.OpBinary {
+			c.text = h.OpCode == gobws.OpText
+		}
+		f := &Frame{Offset: c.recorder.since(c.start), OpCode: byte(h.OpCode), Fin: h.Fin}
+		if h.OpCode == gobws.OpText || (h.OpCode == gobws.OpContinuation && c.text) {
+			f.Text = string(payload)
+		} else {
+			f.Binary = payload
+		}
+		c.recorder.addFrame(c.stream, f)
+	}
+}
+
+// replayConn serves the recorded frames, writes of the client are discarded
+type replayConn struct {
+	frames   []*Frame
+	realtime bool
+	start    time.Time
+
+	mu     sync.Mutex
+	buf    bytes.Buffer
+	closed bool
+}
+
+func (c *replayConn) Read(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for c.buf.Len() == 0 {
+		if c.closed {
+			return 0, net.ErrClosed
+		}
+		if len(c.frames) == 0 {
+			return 0, io.EOF
+		}
+		f := c.frames[0]
+		c.frames = c.frames[1:]
+		if c.realtime {
+			time.Sleep(time.Until(c.start.Add(f.Offset)))
+		}
+		payload := f.Binary
+		if f.Text != "" {
+			payload = []byte(f.Text)
+		}
+		frame := gobws.NewFrame(gobws.OpCode(f.OpCode), f.Fin, payload)
+		if err := gobws.WriteFrame(&c.buf, frame); err != nil {
+			return 0, err
+		}
+	}
+
+	return c.buf.Read(p)
+}
+
+func (c *replayConn) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return 0, net.ErrClosed
+	}
+
+	return len(p), nil
+}
+
+func (c *replayConn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+
+	return nil
+}
+
+func (c *replayConn) LocalAddr() net.Addr {
+	return replayAddr{}
+}
+
+func (c *replayConn) RemoteAddr() net.Addr {
+	return replayAddr{}
+}
+
+func (c *replayConn) SetDeadline(time.Time) error {
+	return nil
+}
+
+func (c *replayConn) SetReadDeadline(time.Time) error {
+	return nil
+}
+
+func (c *replayConn) SetWriteDeadline(time.Time) error {
+	return nil
+}
+
+type replayAddr struct{}
+
+func (replayAddr) Network() string {
+	return "cassette"
+}
+
+func (replayAddr) String() string {
+	return "cassette"
+}
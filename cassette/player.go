@@ -0,0 +1,142 @@
+package cassette
+
+import (
+	"sync"
+
+	"github.com/go-faster/errors"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/ws"
+)
+
+// Player is a RestClient replaying the recorded interactions.
+// The request is answered by the first unused interaction with the same method, endpoint and params,
+// timestamp and signature params are ignored
+type Player struct {
+	cassette *Cassette
+	config   Config
+	redactor *redactor
+
+	mu         sync.Mutex
+	used       map[*Interaction]struct{}
+	streams    map[*Stream]struct{}
+	usedWeight map[string]int64
+	orderCount map[string]int64
+	retryAfter int64
+}
+
+// NewPlayer creates a player of the cassette
+func NewPlayer(c *Cassette, config Config) *Player {
+	cfg := config.defaults()
+
+	return &Player{
+		cassette:   c,
+		config:     cfg,
+		redactor:   newRedactor(cfg.RedactedKeys),
+		used:       make(map[*Interaction]struct{}),
+		streams:    make(map[*Stream]struct{}),
+		usedWeight: map[string]int64{},
+		orderCount: map[string]int64{},
+	}
+}
+
+// Do replays the recorded response or error of the matching interaction
+func (p *Player) Do(method, endpoint string, data interface{}, _, _ bool) ([]byte, error) {
+	params, err := p.redactor.params(data)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, i := range p.cassette.Interactions {
+		if _, ok := p.used[i]; ok || i.Method != method || i.Endpoint != endpoint || i.Params != params {
+			continue
+		}
+		p.used[i] = struct{}{}
+		if i.UsedWeight != nil {
+			p.usedWeight = i.UsedWeight
+		}
+		if i.OrderCount != nil {
+			p.orderCount = i.OrderCount
+		}
+		p.retryAfter = i.RetryAfter
+		if i.Error != nil {
+			if i.Error.API {
+				return nil, &binance.APIError{Code: i.Error.Code, Msg: i.Error.Msg}
+			}
+			return nil, errors.New(i.Error.Msg)
+		}
+
+		return []byte(i.Response), nil
+	}
+
+	return nil, errors.Wrapf(ErrNoInteraction, "%s %s?%s", method, endpoint, params)
+}
+
+// SetWindow does nothing, requests are matched without the timestamp
+func (p *Player) SetWindow(int) {}
+
+// UsedWeight returns the weight recorded by the last replayed interaction
+func (p *Player) UsedWeight() map[string]int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.usedWeight
+}
+
+// OrderCount returns the order count recorded by the last replayed interaction
+func (p *Player) OrderCount() map[string]int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.orderCount
+}
+
+func (p *Player) RetryAfter() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.retryAfter
+}
+
+// Conn opens the first unused recorded stream with the name, e.g.
+//
+//	trades := &ws.Trades{Conn: conn}
+//
+// The connection is closed with io.EOF after the last recorded frame
+func (p *Player) Conn(name string) (ws.Conn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, s := range p.cassette.Streams {
+		if _, ok := p.streams[s]; ok || s.Name != name {
+			continue
+		}
+		p.streams[s] = struct{}{}
+
+		return ws.NewConn(&replayConn{
+			frames:   s.Frames,
+			realtime: p.config.Realtime,
+			start:    p.config.Now(),
+		}), nil
+	}
+
+	return ws.Conn{}, errors.Wrapf(ErrNoInteraction, "stream %s", name)
+}
+
+// Unused returns the interactions which weren't replayed
+func (p *Player) Unused() []*Interaction {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var resp []*Interaction
+	for _, i := range p.cassette.Interactions {
+		if _, ok := p.used[i]; !ok {
+			resp = append(resp, i)
+		}
+	}
+
+	return resp
+}
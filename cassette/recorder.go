@@ -0,0 +1,142 @@
+package cassette
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/ws"
+)
+
+// Recorder is a RestClient recording the calls of the upstream client into the cassette.
+// Websocket connections are recorded by Conn.
+// Remark: the API key and signatures are added below the RestClient layer and never reach the cassette,
+// params and response fields listed in the redacted keys are replaced by Redacted
+type Recorder struct {
+	upstream binance.RestClient
+	config   Config
+	redactor *redactor
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder creates a recorder on top of the upstream client
+func NewRecorder(upstream binance.RestClient, config Config) *Recorder {
+	cfg := config.defaults()
+
+	return &Recorder{
+		upstream: upstream,
+		config:   cfg,
+		redactor: newRedactor(cfg.RedactedKeys),
+	}
+}
+
+// Do invokes the upstream client and records the call
+func (r *Recorder) Do(method, endpoint string, data interface{}, sign, stream bool) ([]byte, error) {
+	params, err := r.redactor.params(data)
+	if err != nil {
+		return nil, err
+	}
+	start := r.config.Now()
+	res, err := r.upstream.Do(method, endpoint, data, sign, stream)
+	i := &Interaction{
+		Method:     method,
+		Endpoint:   endpoint,
+		Params:     params,
+		Sign:       sign,
+		Stream:     stream,
+		UsedWeight: r.upstream.UsedWeight(),
+		OrderCount: r.upstream.OrderCount(),
+		RetryAfter: r.upstream.RetryAfter(),
+		Time:       start.UnixMilli(),
+		Duration:   r.config.Now().Sub(start),
+	}
+	if err != nil {
+		i.Error = newError(err)
+	} else if len(res) > 0 {
+		i.Response = string(r.redactor.payload(append([]byte{}, res...)))
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, i)
+	r.mu.Unlock()
+
+	return res, err
+}
+
+func newError(err error) *Error {
+	var apiErr *binance.APIError
+	if errors.As(err, &apiErr) {
+		return &Error{API: true, Code: apiErr.Code, Msg: apiErr.Msg}
+	}
+
+	return &Error{Msg: err.Error()}
+}
+
+func (r *Recorder) SetWindow(window int) {
+	r.upstream.SetWindow(window)
+}
+
+func (r *Recorder) UsedWeight() map[string]int64 {
+	return r.upstream.UsedWeight()
+}
+
+func (r *Recorder) OrderCount() map[string]int64 {
+	return r.upstream.OrderCount()
+}
+
+func (r *Recorder) RetryAfter() int64 {
+	return r.upstream.RetryAfter()
+}
+
+// Conn records the frames received by the websocket connection under the stream name,
+// the returned connection replaces the original one, e.g.
+//
+//	trades.Conn = recorder.Conn("btcusdt@trade", trades.Conn)
+func (r *Recorder) Conn(name string, conn ws.Conn) ws.Conn {
+	now := r.config.Now()
+	s := &Stream{Name: name, Time: now.UnixMilli()}
+	r.mu.Lock()
+	r.cassette.Streams = append(r.cassette.Streams, s)
+	r.mu.Unlock()
+
+	return ws.NewConn(&recordConn{Conn: conn.NetConn(), recorder: r, stream: s, start: now})
+}
+
+func (r *Recorder) addFrame(s *Stream, f *Frame) {
+	if f.Text != "" {
+		f.Text = string(r.redactor.payload([]byte(f.Text)))
+	}
+	r.mu.Lock()
+	s.Frames = append(s.Frames, f)
+	r.mu.Unlock()
+}
+
+// Cassette returns the copy of the recorded cassette
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c := &Cassette{
+		Interactions: append([]*Interaction{}, r.cassette.Interactions...),
+		Streams:      make([]*Stream, 0, len(r.cassette.Streams)),
+	}
+	for _, s := range r.cassette.Streams {
+		c.Streams = append(c.Streams, &Stream{Name: s.Name, Time: s.Time, Frames: append([]*Frame{}, s.Frames...)})
+	}
+
+	return c
+}
+
+// Save writes the recorded cassette file
+func (r *Recorder) Save(path string) error {
+	return r.Cassette().Save(path)
+}
+
+// since returns the offset from the connection time
+func (r *Recorder) since(start time.Time) time.Duration {
+	return r.config.Now().Sub(start)
+}
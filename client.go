@@ -39,6 +39,7 @@ func NewRestClient(key, secret string) RestClient {
 		apikey: key,
 		hmac:   hmac.New(sha256.New, s2b(secret)),
 		client: newHTTPClient(),
+		host:   BaseHost,
 		window: DefaultResponseWindow,
 	}
 }
@@ -50,20 +51,27 @@ func NewRestClientHTTP2(key, secret string) (RestClient, error) {
 		apikey: key,
 		hmac:   hmac.New(sha256.New, s2b(secret)),
 		client: c,
+		host:   BaseHost,
 		window: DefaultResponseWindow,
 	}, err
 }
 
 type RestClientConfig struct {
-	APIKey         string
-	APISecret      string
-	HTTPClient     *fasthttp.HostClient
+	APIKey     string
+	APISecret  string
+	HTTPClient *fasthttp.HostClient
+	// Host is the API host sent with requests, e.g. fapi.binance.com for futures. Default is BaseHost.
+	// The default HTTPClient connects to the host on the 443 port
+	Host           string
 	ResponseWindow int
 }
 
 func (c RestClientConfig) defaults() RestClientConfig {
+	if c.Host == "" {
+		c.Host = BaseHost
+	}
 	if c.HTTPClient == nil {
-		c.HTTPClient = newHTTPClient()
+		c.HTTPClient = newHostClient(c.Host, c.Host+":443")
 	}
 	if c.ResponseWindow == 0 {
 		c.ResponseWindow = DefaultResponseWindow
@@ -79,6 +87,7 @@ func NewCustomRestClient(config RestClientConfig) RestClient {
 		apikey: c.APIKey,
 		hmac:   hmac.New(sha256.New, s2b(c.APISecret)),
 		client: c.HTTPClient,
+		host:   c.Host,
 		window: c.ResponseWindow,
 	}
 }
@@ -88,6 +97,7 @@ type restClient struct {
 	apikey     string
 	hmac       hash.Hash
 	client     *fasthttp.HostClient
+	host       string
 	window     int
 	usedWeight sync.Map
 	orderCount sync.Map
@@ -120,14 +130,19 @@ func newHTTP2Client() (*fasthttp.HostClient, error) {
 
 // newHTTPClient create fasthttp.HostClient with default settings
 func newHTTPClient() *fasthttp.HostClient {
+	return newHostClient(BaseHost, BaseHostPort)
+}
+
+// newHostClient create fasthttp.HostClient with default settings connected to the address of the host
+func newHostClient(host, addr string) *fasthttp.HostClient {
 	return &fasthttp.HostClient{
 		NoDefaultUserAgentHeader:      true, // Don't send: User-Agent: fasthttp
 		DisableHeaderNamesNormalizing: false,
 		DisablePathNormalizing:        false,
 		IsTLS:                         true,
 		Name:                          DefaultUserAgent,
-		Addr:                          BaseHostPort,
-		TLSConfig:                     &tls.Config{ServerName: BaseHost},
+		Addr:                          addr,
+		TLSConfig:                     &tls.Config{ServerName: host},
 	}
 }
 
@@ -181,7 +196,7 @@ func (c *restClient) Do(method, endpoint string, data interface{}, sign, stream
 		req.SetBody(pb)
 	}
 	req.SetRequestURI(b.String())
-	req.Header.SetHost(BaseHost)
+	req.Header.SetHost(c.host)
 	req.URI().SetScheme(DefaultSchema)
 	req.Header.SetMethod(method)
 
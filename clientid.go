@@ -0,0 +1,94 @@
+package binance
+
+import (
+	"crypto/rand"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// MaxClientOrderIDLength is the max length of the client order id accepted by the API
+	MaxClientOrderIDLength = 36
+	// MaxClientOrderIDPrefixLength leaves room for the generated part of the id
+	MaxClientOrderIDPrefixLength = MaxClientOrderIDLength - clientOrderIDBodyLength
+
+	clientOrderIDInstanceLength = 5
+	clientOrderIDTimeLength     = 9
+	clientOrderIDSeqLength      = 4
+	clientOrderIDBodyLength     = clientOrderIDInstanceLength + clientOrderIDTimeLength + clientOrderIDSeqLength
+
+	clientOrderIDAlphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
+)
+
+// ValidClientOrderID checks the id against the API format ^[a-zA-Z0-9-_]{1,36}$
+func ValidClientOrderID(id string) bool {
+	if id == "" || len(id) > MaxClientOrderIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// ClientOrderIDGenerator generates unique client order ids.
+// Each id consists of the prefix, random generator instance id, millisecond timestamp and a sequence number,
+// so ids don't collide between processes sharing the prefix
+type ClientOrderIDGenerator struct {
+	prefix   string
+	instance string
+	seq      uint32
+	now      func() time.Time
+}
+
+// NewClientOrderIDGenerator creates a generator with the given prefix, the prefix may be empty
+func NewClientOrderIDGenerator(prefix string) (*ClientOrderIDGenerator, error) {
+	if len(prefix) > MaxClientOrderIDPrefixLength || (prefix != "" && !ValidClientOrderID(prefix)) {
+		return nil, ErrInvalidClientOrderID
+	}
+	buf := make([]byte, clientOrderIDInstanceLength)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	for i := range buf {
+		buf[i] = clientOrderIDAlphabet[int(buf[i])%len(clientOrderIDAlphabet)]
+	}
+
+	return &ClientOrderIDGenerator{
+		prefix:   prefix,
+		instance: string(buf),
+		now:      time.Now,
+	}, nil
+}
+
+// Next returns a new client order id
+func (g *ClientOrderIDGenerator) Next() string {
+	seq := atomic.AddUint32(&g.seq, 1)
+
+	b := make([]byte, 0, len(g.prefix)+clientOrderIDBodyLength)
+	b = append(b, g.prefix...)
+	b = append(b, g.instance...)
+	b = appendPadded(b, strconv.FormatInt(g.now().UnixMilli(), len(clientOrderIDAlphabet)), clientOrderIDTimeLength)
+	b = appendPadded(b, strconv.FormatUint(uint64(seq), len(clientOrderIDAlphabet)), clientOrderIDSeqLength)
+
+	return b2s(b)
+}
+
+// appendPadded appends the value left padded with zeros and truncated to the given width
+func appendPadded(b []byte, value string, width int) []byte {
+	if len(value) > width {
+		return append(b, value[len(value)-width:]...)
+	}
+	for i := len(value); i < width; i++ {
+		b = append(b, '0')
+	}
+
+	return append(b, value...)
+}
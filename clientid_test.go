@@ -0,0 +1,53 @@
+package binance_test
+
+import (
+	"strings"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/xenking/binance-api"
+)
+
+type clientOrderIDTestSuite struct {
+	suite.Suite
+}
+
+func (s *clientOrderIDTestSuite) TestNext() {
+	gen, err := binance.NewClientOrderIDGenerator("bot-1_")
+	s.Require().NoError(err)
+
+	seen := make(map[string]struct{})
+	for i := 0; i < 10000; i++ {
+		id := gen.Next()
+		s.Require().True(binance.ValidClientOrderID(id), id)
+		s.Require().True(strings.HasPrefix(id, "bot-1_"))
+		s.Require().LessOrEqual(len(id), binance.MaxClientOrderIDLength)
+		_, ok := seen[id]
+		s.Require().False(ok, id)
+		seen[id] = struct{}{}
+	}
+
+	other, err := binance.NewClientOrderIDGenerator("bot-1_")
+	s.Require().NoError(err)
+	_, ok := seen[other.Next()]
+	s.Require().False(ok)
+}
+
+func (s *clientOrderIDTestSuite) TestInvalidPrefix() {
+	_, err := binance.NewClientOrderIDGenerator("bad prefix")
+	s.Require().ErrorIs(err, binance.ErrInvalidClientOrderID)
+
+	_, err = binance.NewClientOrderIDGenerator(strings.Repeat("a", binance.MaxClientOrderIDPrefixLength+1))
+	s.Require().ErrorIs(err, binance.ErrInvalidClientOrderID)
+
+	gen, err := binance.NewClientOrderIDGenerator(strings.Repeat("a", binance.MaxClientOrderIDPrefixLength))
+	s.Require().NoError(err)
+	s.Require().Len(gen.Next(), binance.MaxClientOrderIDLength)
+}
+
+func (s *clientOrderIDTestSuite) TestValidClientOrderID() {
+	s.Require().True(binance.ValidClientOrderID("web_123-ABC"))
+	s.Require().False(binance.ValidClientOrderID(""))
+	s.Require().False(binance.ValidClientOrderID("id.with.dots"))
+	s.Require().False(binance.ValidClientOrderID(strings.Repeat("a", 37)))
+}
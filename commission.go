@@ -0,0 +1,161 @@
+package binance
+
+import (
+	"github.com/xenking/decimal"
+)
+
+// FeeEstimateReq describes the order to estimate the commission for
+type FeeEstimateReq struct {
+	Order *OrderReq
+	// Maker selects maker rates for resting orders.
+	// LIMIT_MAKER orders are always makers, MARKET and STOP_LOSS/TAKE_PROFIT orders are always takers
+	Maker bool
+	// Price is the expected execution price, required when the order has no price, e.g. MARKET orders
+	Price string
+	// DiscountPrice is the price of the discount asset (BNB) in the symbol quote asset.
+	// When set and the discount is enabled the commission is paid in the discount asset
+	DiscountPrice string
+}
+
+// FeeEstimate represents the estimated commission of the order
+type FeeEstimate struct {
+	Rate         decimal.Decimal // Rate is the effective commission rate without discount
+	Base         decimal.Decimal // Base is the commission in the base asset
+	Quote        decimal.Decimal // Quote is the commission in the quote asset
+	DiscountRate decimal.Decimal // DiscountRate is the effective commission rate when paid in the discount asset
+	Discount     decimal.Decimal // Discount is the commission in the discount asset, zero when the discount price is unknown
+	Asset        string          // Asset is the asset the commission will be charged in
+	Amount       decimal.Decimal // Amount is the commission charged in Asset
+}
+
+// Rates returns the sum of maker or taker rate and buyer or seller rate
+func (r CommissionRates) Rates(side OrderSide, maker bool) (decimal.Decimal, error) {
+	rate := r.Taker
+	if maker {
+		rate = r.Maker
+	}
+	sideRate := r.Buyer
+	if side == OrderSideSell {
+		sideRate = r.Seller
+	}
+	total := decimal.Zero
+	for _, s := range [...]string{rate, sideRate} {
+		if s == "" {
+			continue
+		}
+		d, err := decimal.NewFromString(s)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		total = total.Add(d)
+	}
+
+	return total, nil
+}
+
+// DiscountEnabled reports whether the commission discount applies to the symbol
+func (c *AccountCommission) DiscountEnabled() bool {
+	return c.Discount.EnabledForAccount && c.Discount.EnabledForSymbol && c.Discount.DiscountAsset != ""
+}
+
+// EstimateFee estimates the commission of the order in base, quote and discount assets.
+// The discount applies only to the standard commission, special and tax commissions are charged in full.
+// Without the discount buyers pay commission in the base asset and sellers in the quote asset
+func (c *AccountCommission) EstimateFee(symbol *SymbolInfo, req *FeeEstimateReq) (*FeeEstimate, error) {
+	if symbol == nil || req == nil || req.Order == nil {
+		return nil, ErrNilRequest
+	}
+	if req.Order.Side == "" {
+		return nil, ErrEmptySide
+	}
+
+	price := req.Order.Price
+	if isZeroDecimal(price) {
+		price = req.Price
+	}
+	p, err := decimal.NewFromString(price)
+	if err != nil || p.Sign() <= 0 {
+		return nil, ErrInvalidPrice
+	}
+
+	var qty, notional decimal.Decimal
+	switch {
+	case req.Order.Quantity != "":
+		qty, err = decimal.NewFromString(req.Order.Quantity)
+		if err != nil || qty.Sign() <= 0 {
+			return nil, ErrInvalidQuantity
+		}
+		notional = qty.Mul(p)
+	case req.Order.QuoteQuantity != "":
+		notional, err = decimal.NewFromString(req.Order.QuoteQuantity)
+		if err != nil || notional.Sign() <= 0 {
+			return nil, ErrInvalidQuantity
+		}
+		qty = notional.Div(p)
+	default:
+		return nil, ErrEmptyQuantity
+	}
+
+	maker := req.Maker
+	switch req.Order.Type {
+	case OrderTypeLimitMaker:
+		maker = true
+	case OrderTypeMarket, OrderTypeStopLoss, OrderTypeTakeProfit:
+		maker = false
+	}
+
+	standard, err := c.StandardCommission.Rates(req.Order.Side, maker)
+	if err != nil {
+		return nil, err
+	}
+	special, err := c.SpecialCommission.Rates(req.Order.Side, maker)
+	if err != nil {
+		return nil, err
+	}
+	tax, err := c.TaxCommission.Rates(req.Order.Side, maker)
+	if err != nil {
+		return nil, err
+	}
+
+	rate := standard.Add(special).Add(tax)
+	resp := &FeeEstimate{
+		Rate:         rate,
+		Base:         qty.Mul(rate),
+		Quote:        notional.Mul(rate),
+		DiscountRate: rate,
+	}
+	if req.Order.Side == OrderSideBuy {
+		resp.Asset, resp.Amount = symbol.BaseAsset, resp.Base
+	} else {
+		resp.Asset, resp.Amount = symbol.QuoteAsset, resp.Quote
+	}
+	if !c.DiscountEnabled() {
+		return resp, nil
+	}
+
+	discount, err := decimal.NewFromString(c.Discount.Discount)
+	if err != nil {
+		return nil, err
+	}
+	resp.DiscountRate = standard.Mul(discount).Add(special).Add(tax)
+
+	var discountPrice decimal.Decimal
+	switch c.Discount.DiscountAsset {
+	case symbol.QuoteAsset:
+		discountPrice = decimal.NewFromInt(1)
+	case symbol.BaseAsset:
+		discountPrice = p
+	default:
+		if req.DiscountPrice == "" {
+			return resp, nil
+		}
+		discountPrice, err = decimal.NewFromString(req.DiscountPrice)
+		if err != nil || discountPrice.Sign() <= 0 {
+			return nil, ErrInvalidDiscountPrice
+		}
+	}
+	resp.Discount = notional.Mul(resp.DiscountRate).Div(discountPrice)
+	resp.Asset, resp.Amount = c.Discount.DiscountAsset, resp.Discount
+
+	return resp, nil
+}
@@ -0,0 +1,127 @@
+package binance_test
+
+import (
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+)
+
+type mockedCommissionTestSuite struct {
+	mockedTestSuite
+}
+
+func (s *mockedCommissionTestSuite) commission() *binance.AccountCommission {
+	return &binance.AccountCommission{
+		Symbol: "LTCBTC",
+		StandardCommission: binance.CommissionRates{
+			Maker:  "0.001",
+			Taker:  "0.002",
+			Buyer:  "0",
+			Seller: "0",
+		},
+		SpecialCommission: binance.CommissionRates{Maker: "0", Taker: "0", Buyer: "0", Seller: "0"},
+		TaxCommission:     binance.CommissionRates{Maker: "0.0001", Taker: "0.0001", Buyer: "0", Seller: "0"},
+		Discount: binance.CommissionDiscount{
+			EnabledForAccount: true,
+			EnabledForSymbol:  true,
+			DiscountAsset:     "BNB",
+			Discount:          "0.75",
+		},
+	}
+}
+
+func (s *mockedCommissionTestSuite) TestAccountCommission() {
+	expected := s.commission()
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(fasthttp.MethodGet, method)
+		s.Require().Equal(binance.EndpointAccountCommission, endpoint)
+		s.Require().True(sign)
+		s.Require().IsType(&binance.AccountCommissionReq{}, data)
+		s.Require().Equal("LTCBTC", data.(*binance.AccountCommissionReq).Symbol)
+		return json.Marshal(expected)
+	}
+
+	actual, err := s.client.AccountCommission(&binance.AccountCommissionReq{Symbol: "LTCBTC"})
+	s.Require().NoError(err)
+	s.Require().EqualValues(expected, actual)
+
+	_, err = s.client.AccountCommission(&binance.AccountCommissionReq{})
+	s.Require().ErrorIs(err, binance.ErrEmptySymbol)
+}
+
+func (s *mockedCommissionTestSuite) TestEstimateFee() {
+	symbol := &binance.SymbolInfo{Symbol: "LTCBTC", BaseAsset: "LTC", QuoteAsset: "BTC"}
+	commission := s.commission()
+	commission.Discount.EnabledForSymbol = false
+
+	fee, err := commission.EstimateFee(symbol, &binance.FeeEstimateReq{
+		Order: &binance.OrderReq{
+			Symbol:   "LTCBTC",
+			Side:     binance.OrderSideBuy,
+			Type:     binance.OrderTypeLimit,
+			Quantity: "10",
+			Price:    "0.01",
+		},
+		Maker: true,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal("0.0011", fee.Rate.String())
+	s.Require().Equal("0.011", fee.Base.String())
+	s.Require().Equal("0.00011", fee.Quote.String())
+	s.Require().Equal("LTC", fee.Asset)
+	s.Require().Equal(fee.Base, fee.Amount)
+
+	fee, err = commission.EstimateFee(symbol, &binance.FeeEstimateReq{
+		Order: &binance.OrderReq{
+			Symbol:        "LTCBTC",
+			Side:          binance.OrderSideSell,
+			Type:          binance.OrderTypeMarket,
+			QuoteQuantity: "0.1",
+		},
+		Maker: true,
+		Price: "0.01",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal("0.0021", fee.Rate.String())
+	s.Require().Equal("0.021", fee.Base.String())
+	s.Require().Equal("BTC", fee.Asset)
+	s.Require().Equal("0.00021", fee.Amount.String())
+
+	_, err = commission.EstimateFee(symbol, &binance.FeeEstimateReq{
+		Order: &binance.OrderReq{Side: binance.OrderSideSell, Type: binance.OrderTypeMarket, Quantity: "1"},
+	})
+	s.Require().ErrorIs(err, binance.ErrInvalidPrice)
+}
+
+func (s *mockedCommissionTestSuite) TestEstimateFeeDiscount() {
+	symbol := &binance.SymbolInfo{Symbol: "LTCBTC", BaseAsset: "LTC", QuoteAsset: "BTC"}
+	commission := s.commission()
+	req := &binance.FeeEstimateReq{
+		Order: &binance.OrderReq{
+			Symbol:   "LTCBTC",
+			Side:     binance.OrderSideSell,
+			Type:     binance.OrderTypeLimitMaker,
+			Quantity: "10",
+			Price:    "0.01",
+		},
+	}
+
+	// without the BNB price the commission is charged in the quote asset
+	fee, err := commission.EstimateFee(symbol, req)
+	s.Require().NoError(err)
+	s.Require().Equal("0.00085", fee.DiscountRate.String())
+	s.Require().True(fee.Discount.IsZero())
+	s.Require().Equal("BTC", fee.Asset)
+
+	req.DiscountPrice = "0.005"
+	fee, err = commission.EstimateFee(symbol, req)
+	s.Require().NoError(err)
+	s.Require().Equal("0.017", fee.Discount.String())
+	s.Require().Equal("BNB", fee.Asset)
+	s.Require().Equal(fee.Discount, fee.Amount)
+
+	req.DiscountPrice = "-1"
+	_, err = commission.EstimateFee(symbol, req)
+	s.Require().ErrorIs(err, binance.ErrInvalidDiscountPrice)
+}
@@ -0,0 +1,189 @@
+package binance
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+)
+
+// ConvertExchangeInfo get the convertible pairs with the amount limits, all pairs when the assets are not set
+func (c *Client) ConvertExchangeInfo(req *ConvertExchangeInfoReq) ([]*ConvertPair, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointConvertExchangeInfo, req, false, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*ConvertPair
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// ConvertQuote requests the quote of the conversion, the quote must be accepted before it expires
+func (c *Client) ConvertQuote(req *ConvertQuoteReq) (*ConvertQuote, error) {
+	switch {
+	case req == nil:
+		return nil, ErrNilRequest
+	case req.FromAsset == "", req.ToAsset == "":
+		return nil, ErrEmptyAsset
+	case req.FromAmount == "" && req.ToAmount == "":
+		return nil, ErrEmptyAmount
+	}
+	res, err := c.Do(fasthttp.MethodPost, EndpointConvertQuote, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ConvertQuote{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// ConvertAccept accepts the quote, the conversion is processed asynchronously
+func (c *Client) ConvertAccept(req *ConvertAcceptReq) (*ConvertAccept, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.QuoteID == "" {
+		return nil, ErrEmptyQuoteID
+	}
+	res, err := c.Do(fasthttp.MethodPost, EndpointConvertAccept, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ConvertAccept{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// ConvertOrderStatus get the status of the conversion by the order or the quote id
+func (c *Client) ConvertOrderStatus(req *ConvertOrderStatusReq) (*ConvertOrder, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.OrderID == "" && req.QuoteID == "" {
+		return nil, ErrEmptyOrderID
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointConvertOrderStatus, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ConvertOrder{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// ConvertTradeFlow get the conversions history of the time span
+func (c *Client) ConvertTradeFlow(req *ConvertTradeFlowReq) (*ConvertTradeFlow, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.Limit < 0 || req.Limit > MaxConvertTradeFlowLimit {
+		req.Limit = DefaultConvertTradeFlowLimit
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointConvertTradeFlow, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ConvertTradeFlow{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+const DefaultConvertPollInterval = 500 * time.Millisecond
+
+// ConvertConfig configures the quote-then-accept conversion
+type ConvertConfig struct {
+	PollInterval time.Duration // PollInterval is the pause between the order status requests. Default 500ms
+}
+
+func (c ConvertConfig) defaults() ConvertConfig {
+	if c.PollInterval <= 0 {
+		c.PollInterval = DefaultConvertPollInterval
+	}
+
+	return c
+}
+
+// Convert requests the quote, accepts it and waits for the conversion to succeed or fail.
+// The quote isn't accepted once it or the context is expired. When the context is done after the quote is accepted,
+// the accepted order is returned with the context error, its status can be checked later with ConvertOrderStatus
+func (c *Client) Convert(ctx context.Context, req *ConvertQuoteReq, config ConvertConfig) (*ConvertOrder, error) {
+	config = config.defaults()
+	quote, err := c.ConvertQuote(req)
+	if err != nil {
+		return nil, err
+	}
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+	if quote.Expired(time.Now()) {
+		return nil, ErrConvertQuoteExpired
+	}
+	accept, err := c.ConvertAccept(&ConvertAcceptReq{QuoteID: quote.QuoteID})
+	if err != nil {
+		return nil, err
+	}
+	order := &ConvertOrder{
+		QuoteID:      quote.QuoteID,
+		OrderStatus:  accept.OrderStatus,
+		FromAsset:    req.FromAsset,
+		FromAmount:   quote.FromAmount,
+		ToAsset:      req.ToAsset,
+		ToAmount:     quote.ToAmount,
+		Ratio:        quote.Ratio,
+		InverseRatio: quote.InverseRatio,
+		CreateTime:   accept.CreateTime,
+	}
+	order.OrderID, _ = strconv.ParseInt(accept.OrderID, 10, 64)
+
+	ticker := time.NewTicker(config.PollInterval)
+	defer ticker.Stop()
+	for !order.OrderStatus.Final() {
+		select {
+		case <-ctx.Done():
+			return order, errors.Wrap(ctx.Err(), "wait accepted convert order")
+		case <-ticker.C:
+		}
+		status, err := c.ConvertOrderStatus(&ConvertOrderStatusReq{OrderID: accept.OrderID})
+		if err != nil {
+			return order, err
+		}
+		order = status
+	}
+
+	return order, nil
+}
+
+// BNBBurn get whether the spot fees and the margin interest are paid with BNB
+func (c *Client) BNBBurn() (*BNBBurn, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointBNBBurn, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &BNBBurn{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// ToggleBNBBurn enables or disables paying the spot fees and the margin interest with BNB
+func (c *Client) ToggleBNBBurn(req *BNBBurnReq) (*BNBBurn, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	res, err := c.Do(fasthttp.MethodPost, EndpointBNBBurn, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &BNBBurn{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
@@ -0,0 +1,111 @@
+package binance_test
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/go-querystring/query"
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+)
+
+type mockedConvertTestSuite struct {
+	mockedTestSuite
+}
+
+func (s *mockedConvertTestSuite) quote(validTimestamp int64) []byte {
+	return []byte(`{"quoteId":"12415572564","ratio":"38163.7","inverseRatio":"0.0000262","validTimestamp":` +
+		strconv.FormatInt(validTimestamp, 10) + `,"toAmount":"3816.37","fromAmount":"0.1"}`)
+}
+
+func (s *mockedConvertTestSuite) TestConvert() {
+	var polls int
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().True(sign)
+		switch endpoint {
+		case binance.EndpointConvertQuote:
+			s.Require().Equal(fasthttp.MethodPost, method)
+			return s.quote(time.Now().Add(time.Minute).UnixMilli()), nil
+		case binance.EndpointConvertAccept:
+			s.Require().Equal("12415572564", data.(*binance.ConvertAcceptReq).QuoteID)
+			return []byte(`{"orderId":"933256278426274426","createTime":1623381330472,"orderStatus":"PROCESS"}`), nil
+		case binance.EndpointConvertOrderStatus:
+			s.Require().Equal("933256278426274426", data.(*binance.ConvertOrderStatusReq).OrderID)
+			polls++
+			if polls < 2 {
+				return []byte(`{"orderId":933256278426274426,"orderStatus":"PROCESS"}`), nil
+			}
+			return []byte(`{"quoteId":"12415572564","orderId":933256278426274426,"orderStatus":"SUCCESS","fromAsset":"BTC","fromAmount":"0.1","toAsset":"USDT","toAmount":"3816.37"}`), nil
+		}
+		s.FailNow("unexpected endpoint " + endpoint)
+		return nil, nil
+	}
+	order, err := s.client.Convert(context.Background(), &binance.ConvertQuoteReq{
+		FromAsset:  "BTC",
+		ToAsset:    "USDT",
+		FromAmount: "0.1",
+	}, binance.ConvertConfig{PollInterval: time.Millisecond})
+	s.Require().NoError(err)
+	s.Require().Equal(2, polls)
+	s.Require().Equal(binance.ConvertOrderStatusSuccess, order.OrderStatus)
+	s.Require().Equal(int64(933256278426274426), order.OrderID)
+	s.Require().Equal("3816.37", order.ToAmount)
+}
+
+func (s *mockedConvertTestSuite) TestConvertExpiredQuote() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(binance.EndpointConvertQuote, endpoint)
+		return s.quote(time.Now().Add(-time.Second).UnixMilli()), nil
+	}
+	_, err := s.client.Convert(context.Background(), &binance.ConvertQuoteReq{
+		FromAsset:  "BTC",
+		ToAsset:    "USDT",
+		FromAmount: "0.1",
+	}, binance.ConvertConfig{})
+	s.Require().ErrorIs(err, binance.ErrConvertQuoteExpired)
+}
+
+func (s *mockedConvertTestSuite) TestConvertContextDone() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		switch endpoint {
+		case binance.EndpointConvertQuote:
+			return s.quote(time.Now().Add(time.Minute).UnixMilli()), nil
+		case binance.EndpointConvertAccept:
+			cancel()
+			return []byte(`{"orderId":"933256278426274426","createTime":1623381330472,"orderStatus":"PROCESS"}`), nil
+		}
+		s.FailNow("unexpected endpoint " + endpoint)
+		return nil, nil
+	}
+	order, err := s.client.Convert(ctx, &binance.ConvertQuoteReq{
+		FromAsset: "BTC",
+		ToAsset:   "USDT",
+		ToAmount:  "100",
+	}, binance.ConvertConfig{})
+	s.Require().ErrorIs(err, context.Canceled)
+	s.Require().Equal(int64(933256278426274426), order.OrderID)
+	s.Require().Equal(binance.ConvertOrderStatusProcess, order.OrderStatus)
+
+	_, err = s.client.Convert(ctx, &binance.ConvertQuoteReq{FromAsset: "BTC", ToAsset: "USDT"}, binance.ConvertConfig{})
+	s.Require().ErrorIs(err, binance.ErrEmptyAmount)
+}
+
+func (s *mockedConvertTestSuite) TestToggleBNBBurn() {
+	enabled := false
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(binance.EndpointBNBBurn, endpoint)
+		s.Require().Equal(fasthttp.MethodPost, method)
+		values, err := query.Values(data)
+		s.Require().NoError(err)
+		s.Require().Equal("false", values.Get("spotBNBBurn"))
+		s.Require().False(values.Has("interestBNBBurn"))
+		return []byte(`{"spotBNBBurn":false,"interestBNBBurn":true}`), nil
+	}
+	burn, err := s.client.ToggleBNBBurn(&binance.BNBBurnReq{SpotBNBBurn: &enabled})
+	s.Require().NoError(err)
+	s.Require().False(burn.SpotBNBBurn)
+	s.Require().True(burn.InterestBNBBurn)
+}
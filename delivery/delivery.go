@@ -0,0 +1,243 @@
+package delivery
+
+import (
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/futures"
+)
+
+// BaseHost for COIN-M delivery futures addresses
+const BaseHost = "dapi.binance.com"
+
+var ErrEmptySymbolOrPair = binance.ValidationError{Msg: "symbol or pair is not set"}
+
+// Client is the COIN-M delivery futures API client. The contracts are margined and settled in the base asset
+// and their quantities are in contracts of the fixed quote value, see SymbolInfo.Contracts
+type Client struct {
+	binance.RestClient
+}
+
+// NewRestClient creates the rest client of the delivery host with key and secret
+func NewRestClient(apikey, secret string) binance.RestClient {
+	return binance.NewCustomRestClient(binance.RestClientConfig{APIKey: apikey, APISecret: secret, Host: BaseHost})
+}
+
+// NewClient creates a new delivery client with key and secret
+func NewClient(apikey, secret string) *Client {
+	return &Client{
+		RestClient: NewRestClient(apikey, secret),
+	}
+}
+
+func NewCustomClient(restClient binance.RestClient) *Client {
+	return &Client{
+		RestClient: restClient,
+	}
+}
+
+func (c *Client) ReqWindow(window int) *Client {
+	c.RestClient.SetWindow(window)
+
+	return c
+}
+
+// Time tests connectivity to the Rest API and get the current server time
+func (c *Client) Time() (*binance.ServerTime, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointTime, nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+	serverTime := &binance.ServerTime{}
+	err = json.Unmarshal(res, serverTime)
+
+	return serverTime, err
+}
+
+// Ping tests connectivity to the Rest API
+func (c *Client) Ping() error {
+	_, err := c.Do(fasthttp.MethodGet, EndpointPing, nil, false, false)
+
+	return err
+}
+
+// ExchangeInfo get current exchange trading rules and contracts information
+func (c *Client) ExchangeInfo() (*ExchangeInfo, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointExchangeInfo, nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ExchangeInfo{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// Depth retrieves the order book for the given symbol, the quantities are in contracts
+func (c *Client) Depth(req *binance.DepthReq) (*binance.Depth, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	if req.Limit < 0 || req.Limit > futures.MaxDepthLimit {
+		req.Limit = futures.DefaultDepthLimit
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointDepth, req, false, false)
+	if err != nil {
+		return nil, err
+	}
+	depth := &binance.Depth{}
+	err = json.Unmarshal(res, depth)
+
+	return depth, err
+}
+
+// Klines returns kline/candlestick bars for a symbol. The volume is in contracts
+// and the quote asset volume holds the base asset volume
+func (c *Client) Klines(req *binance.KlinesReq) ([]*binance.Kline, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	if req.Interval == "" {
+		req.Interval = binance.KlineInterval5min
+	}
+	if req.Limit < 0 || req.Limit > futures.MaxKlinesLimit {
+		req.Limit = futures.DefaultKlinesLimit
+	}
+
+	return c.klines(EndpointKlines, req)
+}
+
+// ContinuousKlines returns kline/candlestick bars of the contract type of the pair spanning the quarterly rollovers
+func (c *Client) ContinuousKlines(req *futures.ContinuousKlinesReq) ([]*binance.Kline, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Pair == "" {
+		return nil, futures.ErrEmptyPair
+	}
+	if req.ContractType == "" {
+		return nil, futures.ErrEmptyContractType
+	}
+	if req.Interval == "" {
+		req.Interval = binance.KlineInterval5min
+	}
+	if req.Limit < 0 || req.Limit > futures.MaxKlinesLimit {
+		req.Limit = futures.DefaultKlinesLimit
+	}
+
+	return c.klines(EndpointContinuousKlines, req)
+}
+
+func (c *Client) klines(endpoint string, req interface{}) ([]*binance.Kline, error) {
+	res, err := c.Do(fasthttp.MethodGet, endpoint, req, false, false)
+	if err != nil {
+		return nil, err
+	}
+	var klines []*binance.Kline
+	err = json.Unmarshal(res, &klines)
+
+	return klines, err
+}
+
+// MarkPrices returns the mark and index prices of the symbol, the contracts of the pair or all contracts
+func (c *Client) MarkPrices(req *MarkPriceReq) ([]*MarkPrice, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointPremiumIndex, req, false, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*MarkPrice
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// FundingRates returns the funding rate history of the perpetual symbol in ascending order
+func (c *Client) FundingRates(req *futures.FundingRateReq) ([]*futures.FundingRate, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	if req.Limit < 0 || req.Limit > futures.MaxFundingRateLimit {
+		req.Limit = futures.DefaultFundingRateLimit
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointFundingRate, req, false, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*futures.FundingRate
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// OpenInterest returns the present open interest of the symbol in contracts
+func (c *Client) OpenInterest(req *futures.OpenInterestReq) (*OpenInterest, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointOpenInterest, req, false, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &OpenInterest{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// Basis returns the basis history of the contract type of the pair
+func (c *Client) Basis(req *BasisReq) ([]*Basis, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Pair == "" {
+		return nil, futures.ErrEmptyPair
+	}
+	if req.ContractType == "" {
+		return nil, futures.ErrEmptyContractType
+	}
+	if req.Period == "" {
+		req.Period = BasisPeriod5min
+	}
+	if req.Limit < 0 || req.Limit > MaxBasisLimit {
+		req.Limit = DefaultBasisLimit
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointBasis, req, false, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*Basis
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// DeliveryPrices returns the settlement prices of the delivered quarterly contracts of the pair
+func (c *Client) DeliveryPrices(req *DeliveryPriceReq) ([]*DeliveryPrice, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Pair == "" {
+		return nil, futures.ErrEmptyPair
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointDeliveryPrice, req, false, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*DeliveryPrice
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
@@ -0,0 +1,161 @@
+package delivery_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-querystring/query"
+	"github.com/stretchr/testify/suite"
+	"github.com/valyala/fasthttp"
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/binancetest"
+	"github.com/xenking/binance-api/delivery"
+	"github.com/xenking/binance-api/futures"
+)
+
+func TestDelivery(t *testing.T) {
+	suite.Run(t, new(deliveryTestSuite))
+}
+
+type deliveryTestSuite struct {
+	suite.Suite
+	client *delivery.Client
+	mock   *binancetest.MockClient
+}
+
+func (s *deliveryTestSuite) SetupTest() {
+	s.mock = &binancetest.MockClient{}
+	s.client = delivery.NewCustomClient(s.mock)
+}
+
+func (s *deliveryTestSuite) TestNewOrder() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(fasthttp.MethodPost, method)
+		s.Require().Equal(delivery.EndpointOrder, endpoint)
+		s.Require().True(sign)
+		values, err := query.Values(data)
+		s.Require().NoError(err)
+		s.Require().Equal("BTCUSD_230929", values.Get("symbol"))
+		s.Require().Equal("3", values.Get("quantity"))
+		s.Require().Equal("RESULT", values.Get("newOrderRespType"))
+		return []byte(`{"symbol":"BTCUSD_230929","pair":"BTCUSD","orderId":22542179,"status":"FILLED","type":"MARKET","side":"BUY","origQty":"3","executedQty":"3","cumQty":"3","cumBase":"0.01020408","avgPrice":"29400.0","updateTime":1566818724722}`), nil
+	}
+	order, err := s.client.NewOrder(&futures.OrderReq{
+		Symbol: "BTCUSD_230929", Side: binance.OrderSideBuy, Type: futures.OrderTypeMarket, Quantity: "3",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal("BTCUSD", order.Pair)
+	s.Require().Equal("0.01020408", order.CumBase)
+
+	_, err = s.client.NewOrder(&futures.OrderReq{Symbol: "BTCUSD_230929", Side: binance.OrderSideBuy, Type: futures.OrderTypeMarket})
+	s.Require().ErrorIs(err, binance.ErrEmptyQuantity)
+	_, err = s.client.AccountTrades(&delivery.AccountTradesReq{})
+	s.Require().ErrorIs(err, delivery.ErrEmptySymbolOrPair)
+}
+
+func (s *deliveryTestSuite) TestMarketData() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().False(sign)
+		switch endpoint {
+		case delivery.EndpointExchangeInfo:
+			return []byte(`{"symbols":[{"symbol":"BTCUSD_230929","pair":"BTCUSD","contractType":"CURRENT_QUARTER","deliveryDate":1695974400000,"contractStatus":"TRADING","contractSize":100,"marginAsset":"BTC","filters":[{"filterType":"LOT_SIZE","stepSize":"1","minQty":"1"}]}]}`), nil
+		case delivery.EndpointPremiumIndex:
+			s.Require().Equal("BTCUSD", data.(*delivery.MarkPriceReq).Pair)
+			return []byte(`[{"symbol":"BTCUSD_PERP","pair":"BTCUSD","markPrice":"29401.5","indexPrice":"29400.1","lastFundingRate":"0.0001","time":1597370495002},{"symbol":"BTCUSD_230929","pair":"BTCUSD","markPrice":"29710.2","indexPrice":"29400.1","lastFundingRate":"","time":1597370495002}]`), nil
+		case delivery.EndpointBasis:
+			req := data.(*delivery.BasisReq)
+			s.Require().Equal(delivery.BasisPeriod5min, req.Period)
+			s.Require().Equal(delivery.DefaultBasisLimit, req.Limit)
+			return []byte(`[{"indexPrice":"29400.1","contractType":"CURRENT_QUARTER","basisRate":"0.0105","futuresPrice":"29710.2","annualizedBasisRate":"","basis":"310.1","pair":"BTCUSD","timestamp":1653381600000}]`), nil
+		case delivery.EndpointDeliveryPrice:
+			return []byte(`[{"deliveryTime":1695974400000,"deliveryPrice":26962.1}]`), nil
+		}
+		s.FailNow("unexpected endpoint " + endpoint)
+		return nil, nil
+	}
+	info, err := s.client.ExchangeInfo()
+	s.Require().NoError(err)
+	symbol := info.Symbols[0]
+	s.Require().Equal(delivery.ContractStatusTrading, symbol.ContractStatus)
+	s.Require().Equal(futures.ContractTypeCurrentQuarter, symbol.ContractType)
+	s.Require().EqualValues(100, symbol.ContractSize)
+	s.Require().Equal("1", symbol.Filters[0].StepSize)
+
+	price := decimal.RequireFromString("25000")
+	s.Require().Equal("300", symbol.Notional(decimal.NewFromInt(3)).String())
+	s.Require().Equal("0.012", symbol.BaseQuantity(decimal.NewFromInt(3), price).String())
+	s.Require().Equal("2", symbol.Contracts(decimal.RequireFromString("299.99")).String())
+	s.Require().Equal("3", symbol.ContractsForBase(decimal.RequireFromString("0.012"), price).String())
+
+	marks, err := s.client.MarkPrices(&delivery.MarkPriceReq{Pair: "BTCUSD"})
+	s.Require().NoError(err)
+	s.Require().Len(marks, 2)
+	s.Require().Equal("29710.2", marks[1].MarkPrice)
+
+	basis, err := s.client.Basis(&delivery.BasisReq{Pair: "BTCUSD", ContractType: futures.ContractTypeCurrentQuarter, Limit: 1000})
+	s.Require().NoError(err)
+	s.Require().Equal("310.1", basis[0].Basis)
+	_, err = s.client.Basis(&delivery.BasisReq{Pair: "BTCUSD"})
+	s.Require().ErrorIs(err, futures.ErrEmptyContractType)
+
+	prices, err := s.client.DeliveryPrices(&delivery.DeliveryPriceReq{Pair: "BTCUSD"})
+	s.Require().NoError(err)
+	s.Require().Equal("26962.1", prices[0].DeliveryPrice.String())
+	_, err = s.client.DeliveryPrices(&delivery.DeliveryPriceReq{})
+	s.Require().ErrorIs(err, futures.ErrEmptyPair)
+}
+
+func (s *deliveryTestSuite) TestAccount() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().True(sign)
+		switch endpoint {
+		case delivery.EndpointPositionRisk:
+			s.Require().Equal("BTCUSD", data.(*delivery.PositionRiskReq).Pair)
+			return []byte(`[{"symbol":"BTCUSD_PERP","positionAmt":"3","entryPrice":"29400.0","markPrice":"29401.5","unRealizedProfit":"0.00000005","liquidationPrice":"0","leverage":"20","maxQty":"50","marginType":"cross","isolatedMargin":"0","isAutoAddMargin":"false","positionSide":"BOTH","notionalValue":"0.01020356","isolatedWallet":"0","updateTime":1625474304765}]`), nil
+		case delivery.EndpointLeverage:
+			return []byte(`{"leverage":20,"maxQty":"50","symbol":"BTCUSD_PERP"}`), nil
+		}
+		s.FailNow("unexpected endpoint " + endpoint)
+		return nil, nil
+	}
+	positions, err := s.client.PositionRisk(&delivery.PositionRiskReq{Pair: "BTCUSD"})
+	s.Require().NoError(err)
+	s.Require().Equal("0.01020356", positions[0].NotionalValue)
+	s.Require().Equal(futures.PositionSideBoth, positions[0].PositionSide)
+
+	leverage, err := s.client.ChangeLeverage(&futures.LeverageReq{Symbol: "BTCUSD_PERP", Leverage: 20})
+	s.Require().NoError(err)
+	s.Require().Equal("50", leverage.MaxQty)
+	_, err = s.client.ChangeLeverage(&futures.LeverageReq{Symbol: "BTCUSD_PERP"})
+	s.Require().ErrorIs(err, futures.ErrInvalidLeverage)
+}
+
+func (s *deliveryTestSuite) TestStreams() {
+	server := binancetest.NewServer(binancetest.Config{})
+	defer server.Close()
+	client := delivery.NewCustomStreamClient(server.StreamURL(), nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	s.Require().NoError(server.Publish("btcusd@indexPrice@1s",
+		[]byte(`{"e":"indexPriceUpdate","E":1591261236000,"i":"BTCUSD","p":"9636.57860000"}`)))
+	index, err := client.IndexPrice(ctx, "BTCUSD", futures.MarkPriceFrequency1s)
+	s.Require().NoError(err)
+	defer index.Close()
+	u := <-index.Stream()
+	s.Require().Equal(delivery.UpdateTypeIndexPrice, u.EventType)
+	s.Require().Equal("BTCUSD", u.Pair)
+	s.Require().Equal("9636.57860000", u.Price)
+
+	s.Require().NoError(server.Publish("btcusd_230929@markPrice",
+		[]byte(`{"e":"markPriceUpdate","E":1596095725000,"s":"BTCUSD_230929","p":"10934.62615417","P":"10962.17178236","r":"","T":0}`)))
+	mark, err := client.MarkPrice(ctx, "BTCUSD_230929", "")
+	s.Require().NoError(err)
+	defer mark.Close()
+	m, err := mark.Read()
+	s.Require().NoError(err)
+	s.Require().Equal("10934.62615417", m.MarkPrice)
+}
@@ -0,0 +1,38 @@
+package delivery
+
+// Endpoints with NONE security
+const (
+	EndpointPing             = "/dapi/v1/ping"
+	EndpointTime             = "/dapi/v1/time"
+	EndpointExchangeInfo     = "/dapi/v1/exchangeInfo"
+	EndpointDepth            = "/dapi/v1/depth"
+	EndpointKlines           = "/dapi/v1/klines"
+	EndpointContinuousKlines = "/dapi/v1/continuousKlines"
+	EndpointPremiumIndex     = "/dapi/v1/premiumIndex"
+	EndpointFundingRate      = "/dapi/v1/fundingRate"
+	EndpointOpenInterest     = "/dapi/v1/openInterest"
+	EndpointBasis            = "/futures/data/basis"
+	EndpointDeliveryPrice    = "/futures/data/delivery-price"
+)
+
+// Endpoints with SIGNED security
+const (
+	EndpointOrder         = "/dapi/v1/order"
+	EndpointBatchOrders   = "/dapi/v1/batchOrders"
+	EndpointOpenOrders    = "/dapi/v1/openOrders"
+	EndpointAllOpenOrders = "/dapi/v1/allOpenOrders"
+	EndpointAccountTrades = "/dapi/v1/userTrades"
+	EndpointAccount       = "/dapi/v1/account"
+	EndpointBalance       = "/dapi/v1/balance"
+	EndpointPositionRisk  = "/dapi/v1/positionRisk"
+	EndpointLeverage      = "/dapi/v1/leverage"
+	EndpointMarginType    = "/dapi/v1/marginType"
+	EndpointPositionMode  = "/dapi/v1/positionSide/dual"
+	EndpointIncome        = "/dapi/v1/income"
+	EndpointDataStream    = "/dapi/v1/listenKey"
+)
+
+// Stream endpoints
+const (
+	EndpointIndexPriceStream = "@indexPrice"
+)
@@ -0,0 +1,139 @@
+package delivery
+
+import (
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/futures"
+)
+
+// NewOrder sends in a new order and returns the created order, the quantity is in contracts
+func (c *Client) NewOrder(req *futures.OrderReq) (*Order, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	req.OrderRespType = futures.OrderRespTypeResult
+	res, err := c.Do(fasthttp.MethodPost, EndpointOrder, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &Order{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// BatchOrders sends in up to 5 orders at once, the orders are placed independently
+// and the results keep the order of the requests
+func (c *Client) BatchOrders(reqs []*futures.OrderReq) ([]*BatchOrder, error) {
+	if len(reqs) == 0 || len(reqs) > futures.MaxBatchOrders {
+		return nil, futures.ErrBatchSize
+	}
+	for _, req := range reqs {
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+		req.OrderRespType = futures.OrderRespTypeResult
+	}
+	batch, err := json.Marshal(reqs)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.Do(fasthttp.MethodPost, EndpointBatchOrders, &batchOrdersReq{BatchOrders: string(batch)}, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*BatchOrder
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// QueryOrder checks an order's status
+func (c *Client) QueryOrder(req *futures.QueryOrderReq) (*Order, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	if req.OrderID == 0 && req.OrigClientOrderID == "" {
+		return nil, binance.ErrEmptyOrderID
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointOrder, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &Order{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// CancelOrder cancel an active order
+func (c *Client) CancelOrder(req *futures.CancelOrderReq) (*Order, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	if req.OrderID == 0 && req.OrigClientOrderID == "" {
+		return nil, binance.ErrEmptyOrderID
+	}
+	res, err := c.Do(fasthttp.MethodDelete, EndpointOrder, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &Order{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// CancelOpenOrders cancel all open orders on a symbol
+func (c *Client) CancelOpenOrders(req *futures.CancelOpenOrdersReq) error {
+	if req == nil {
+		return binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return binance.ErrEmptySymbol
+	}
+	_, err := c.Do(fasthttp.MethodDelete, EndpointAllOpenOrders, req, true, false)
+
+	return err
+}
+
+// OpenOrders get all open orders on a symbol, the contracts of the pair or all symbols when neither is set
+func (c *Client) OpenOrders(req *OpenOrdersReq) ([]*Order, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointOpenOrders, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*Order
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// AccountTrades get trades for a specific account and symbol or pair
+func (c *Client) AccountTrades(req *AccountTradesReq) ([]*AccountTrade, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" && req.Pair == "" {
+		return nil, ErrEmptySymbolOrPair
+	}
+	if req.Limit < 0 || req.Limit > MaxAccountTradesLimit {
+		req.Limit = DefaultAccountTradesLimit
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointAccountTrades, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*AccountTrade
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
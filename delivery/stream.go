@@ -0,0 +1,74 @@
+package delivery
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/segmentio/encoding/json"
+
+	"github.com/xenking/binance-api/futures"
+	"github.com/xenking/binance-api/ws"
+)
+
+const DefaultStreamPath = "wss://dstream.binance.com/ws/"
+
+// StreamClient opens the coin margined futures streams. The mark price, liquidation, continuous kline
+// and user data streams are served in the USD-M format and opened with the embedded futures client methods
+type StreamClient struct {
+	*futures.StreamClient
+}
+
+func NewStreamClient() *StreamClient {
+	return &StreamClient{futures.NewCustomStreamClient(DefaultStreamPath, nil)}
+}
+
+func NewCustomStreamClient(prefix string, conn net.Conn) *StreamClient {
+	return &StreamClient{futures.NewCustomStreamClient(prefix, conn)}
+}
+
+// IndexPrice opens websocket with the index price updates for the given pair
+func (c *StreamClient) IndexPrice(ctx context.Context, pair string, frequency futures.MarkPriceFrequency) (*IndexPriceStream, error) {
+	conn, err := c.Dial(ctx, strings.ToLower(pair)+EndpointIndexPriceStream+string(frequency))
+	if err != nil {
+		return nil, err
+	}
+
+	return &IndexPriceStream{conn}, nil
+}
+
+// IndexPriceStream is a wrapper for index price websocket
+type IndexPriceStream struct {
+	ws.Conn
+}
+
+// Read reads a index price update message from index price websocket
+func (s *IndexPriceStream) Read() (*IndexPriceUpdate, error) {
+	r := &IndexPriceUpdate{}
+	err := s.Conn.ReadValue(r)
+
+	return r, err
+}
+
+// Stream NewStream a index price update message from index price websocket to channel
+func (s *IndexPriceStream) Stream() <-chan *IndexPriceUpdate {
+	updates := make(chan *IndexPriceUpdate)
+	go s.NewStream(func(dec *json.Decoder, err error) error {
+		if err != nil {
+			close(updates)
+			return err
+		}
+
+		u := &IndexPriceUpdate{}
+		err = dec.Decode(u)
+		if err != nil {
+			close(updates)
+			return err
+		}
+		updates <- u
+
+		return nil
+	})
+
+	return updates
+}
@@ -0,0 +1,15 @@
+package delivery
+
+import (
+	"github.com/xenking/binance-api/futures"
+)
+
+const UpdateTypeIndexPrice futures.UpdateType = "indexPriceUpdate"
+
+// IndexPriceUpdate represents the index price of the pair
+type IndexPriceUpdate struct {
+	EventType futures.UpdateType `json:"e"` // EventType represents the update type
+	Time      int64              `json:"E"` // Time represents the event time
+	Pair      string             `json:"i"` // Pair represents the pair related to the update
+	Price     string             `json:"p"` // Price is the index price
+}
@@ -0,0 +1,339 @@
+package delivery
+
+import (
+	"github.com/segmentio/encoding/json"
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/futures"
+)
+
+// ContractStatus is the trading status of the delivery contract
+type ContractStatus string
+
+const (
+	ContractStatusPendingTrading ContractStatus = "PENDING_TRADING"
+	ContractStatusTrading        ContractStatus = "TRADING"
+	ContractStatusPreDelivering  ContractStatus = "PRE_DELIVERING"
+	ContractStatusDelivering     ContractStatus = "DELIVERING"
+	ContractStatusDelivered      ContractStatus = "DELIVERED"
+	ContractStatusPreSettle      ContractStatus = "PRE_SETTLE"
+	ContractStatusSettling       ContractStatus = "SETTLING"
+	ContractStatusClose          ContractStatus = "CLOSE"
+)
+
+// BasisPeriod is the interval of the basis history
+type BasisPeriod string
+
+const (
+	BasisPeriod5min   BasisPeriod = "5m"
+	BasisPeriod15min  BasisPeriod = "15m"
+	BasisPeriod30min  BasisPeriod = "30m"
+	BasisPeriod1hour  BasisPeriod = "1h"
+	BasisPeriod2hour  BasisPeriod = "2h"
+	BasisPeriod4hour  BasisPeriod = "4h"
+	BasisPeriod6hour  BasisPeriod = "6h"
+	BasisPeriod12hour BasisPeriod = "12h"
+	BasisPeriod1day   BasisPeriod = "1d"
+)
+
+type ExchangeInfo struct {
+	Timezone   string               `json:"timezone"`
+	ServerTime int64                `json:"serverTime"`
+	RateLimits []*binance.RateLimit `json:"rateLimits"`
+	Symbols    []*SymbolInfo        `json:"symbols"`
+}
+
+// SymbolInfo describes the contract, the quantities of the contract are in contracts
+// worth ContractSize of the quote asset each, e.g. 100 USD for BTCUSD_PERP
+type SymbolInfo struct {
+	Symbol                string                     `json:"symbol"`
+	Pair                  string                     `json:"pair"`
+	ContractType          futures.ContractType       `json:"contractType"`
+	DeliveryDate          int64                      `json:"deliveryDate"`
+	OnboardDate           int64                      `json:"onboardDate"`
+	ContractStatus        ContractStatus             `json:"contractStatus"`
+	ContractSize          int64                      `json:"contractSize"`
+	MaintMarginPercent    string                     `json:"maintMarginPercent"`
+	RequiredMarginPercent string                     `json:"requiredMarginPercent"`
+	BaseAsset             string                     `json:"baseAsset"`
+	QuoteAsset            string                     `json:"quoteAsset"`
+	MarginAsset           string                     `json:"marginAsset"`
+	PricePrecision        int                        `json:"pricePrecision"`
+	QuantityPrecision     int                        `json:"quantityPrecision"`
+	BaseAssetPrecision    int                        `json:"baseAssetPrecision"`
+	QuotePrecision        int                        `json:"quotePrecision"`
+	EqualQtyPrecision     int                        `json:"equalQtyPrecision"`
+	UnderlyingType        string                     `json:"underlyingType"`
+	UnderlyingSubType     []string                   `json:"underlyingSubType"`
+	TriggerProtect        string                     `json:"triggerProtect"`
+	LiquidationFee        string                     `json:"liquidationFee"`
+	MarketTakeBound       string                     `json:"marketTakeBound"`
+	OrderTypes            []futures.OrderType        `json:"orderTypes"`
+	TimeInForce           []binance.TimeInForce      `json:"timeInForce"`
+	Filters               []futures.SymbolInfoFilter `json:"filters"`
+}
+
+// Notional returns the quote value of the contracts
+func (s *SymbolInfo) Notional(contracts decimal.Decimal) decimal.Decimal {
+	return contracts.Mul(decimal.NewFromInt(s.ContractSize))
+}
+
+// BaseQuantity returns the base asset quantity of the contracts at the price
+func (s *SymbolInfo) BaseQuantity(contracts, price decimal.Decimal) decimal.Decimal {
+	if price.Sign() <= 0 {
+		return decimal.Zero
+	}
+
+	return s.Notional(contracts).Div(price)
+}
+
+// Contracts returns the whole number of contracts worth at most the quote notional
+func (s *SymbolInfo) Contracts(notional decimal.Decimal) decimal.Decimal {
+	if s.ContractSize <= 0 {
+		return decimal.Zero
+	}
+
+	return notional.Div(decimal.NewFromInt(s.ContractSize)).Floor()
+}
+
+// ContractsForBase returns the whole number of contracts worth at most the base asset quantity at the price
+func (s *SymbolInfo) ContractsForBase(quantity, price decimal.Decimal) decimal.Decimal {
+	return s.Contracts(quantity.Mul(price))
+}
+
+type MarkPriceReq struct {
+	Symbol string `url:"symbol,omitempty"`
+	Pair   string `url:"pair,omitempty"`
+}
+
+// MarkPrice is the mark and index price of the contract, the funding rate is set for the perpetual contracts
+type MarkPrice struct {
+	Symbol               string `json:"symbol"`
+	Pair                 string `json:"pair"`
+	MarkPrice            string `json:"markPrice"`
+	IndexPrice           string `json:"indexPrice"`
+	EstimatedSettlePrice string `json:"estimatedSettlePrice"`
+	LastFundingRate      string `json:"lastFundingRate"`
+	InterestRate         string `json:"interestRate"`
+	NextFundingTime      int64  `json:"nextFundingTime"`
+	Time                 int64  `json:"time"`
+}
+
+type OpenInterest struct {
+	Symbol       string               `json:"symbol"`
+	Pair         string               `json:"pair"`
+	OpenInterest string               `json:"openInterest"` // OpenInterest is in contracts
+	ContractType futures.ContractType `json:"contractType"`
+	Time         int64                `json:"time"`
+}
+
+const (
+	DefaultBasisLimit = 30
+	MaxBasisLimit     = 500
+)
+
+type BasisReq struct {
+	Pair         string               `url:"pair"`
+	ContractType futures.ContractType `url:"contractType"`
+	Period       BasisPeriod          `url:"period"`
+	Limit        int                  `url:"limit,omitempty"` // Limit is the maximal number of elements to receive. Default 30; Max 500
+	StartTime    int64                `url:"startTime,omitempty"`
+	EndTime      int64                `url:"endTime,omitempty"`
+}
+
+// Basis is the difference of the contract price and the index price
+type Basis struct {
+	Pair                string               `json:"pair"`
+	ContractType        futures.ContractType `json:"contractType"`
+	IndexPrice          string               `json:"indexPrice"`
+	FuturesPrice        string               `json:"futuresPrice"`
+	Basis               string               `json:"basis"`
+	BasisRate           string               `json:"basisRate"`
+	AnnualizedBasisRate string               `json:"annualizedBasisRate"`
+	Timestamp           int64                `json:"timestamp"`
+}
+
+type DeliveryPriceReq struct {
+	Pair string `url:"pair"`
+}
+
+type DeliveryPrice struct {
+	DeliveryTime  int64           `json:"deliveryTime"`
+	DeliveryPrice decimal.Decimal `json:"deliveryPrice"`
+}
+
+// Order is the delivery order, the quantities are in contracts and the cumulative value is in the base asset
+type Order struct {
+	Symbol        string               `json:"symbol"`
+	Pair          string               `json:"pair"`
+	OrderID       int64                `json:"orderId"`
+	ClientOrderID string               `json:"clientOrderId"`
+	Price         string               `json:"price"`
+	AvgPrice      string               `json:"avgPrice"`
+	OrigQty       string               `json:"origQty"`
+	ExecutedQty   string               `json:"executedQty"`
+	CumQty        string               `json:"cumQty"`
+	CumBase       string               `json:"cumBase"`
+	Status        binance.OrderStatus  `json:"status"`
+	TimeInForce   binance.TimeInForce  `json:"timeInForce"`
+	Type          futures.OrderType    `json:"type"`
+	OrigType      futures.OrderType    `json:"origType"`
+	Side          binance.OrderSide    `json:"side"`
+	PositionSide  futures.PositionSide `json:"positionSide"`
+	StopPrice     string               `json:"stopPrice"`
+	ClosePosition bool                 `json:"closePosition"`
+	ReduceOnly    bool                 `json:"reduceOnly"`
+	ActivatePrice string               `json:"activatePrice"`
+	PriceRate     string               `json:"priceRate"`
+	WorkingType   futures.WorkingType  `json:"workingType"`
+	PriceProtect  bool                 `json:"priceProtect"`
+	Time          int64                `json:"time"`
+	UpdateTime    int64                `json:"updateTime"`
+}
+
+type batchOrdersReq struct {
+	BatchOrders string `url:"batchOrders"`
+}
+
+// BatchOrder is the result of the order of the batch, either the order or the error rejecting it
+type BatchOrder struct {
+	Order *Order
+	Err   *binance.APIError
+}
+
+func (b *BatchOrder) UnmarshalJSON(data []byte) error {
+	apiErr := &binance.APIError{}
+	if err := json.Unmarshal(data, apiErr); err != nil {
+		return err
+	}
+	if apiErr.Code != 0 {
+		b.Err = apiErr
+		return nil
+	}
+	b.Order = &Order{}
+
+	return json.Unmarshal(data, b.Order)
+}
+
+type OpenOrdersReq struct {
+	Symbol string `url:"symbol,omitempty"`
+	Pair   string `url:"pair,omitempty"`
+}
+
+type AccountTradesReq struct {
+	Symbol    string `url:"symbol,omitempty"`
+	Pair      string `url:"pair,omitempty"` // Pair can't be sent with the symbol or the order id
+	OrderID   int64  `url:"orderId,omitempty"`
+	StartTime int64  `url:"startTime,omitempty"`
+	EndTime   int64  `url:"endTime,omitempty"`
+	FromID    int64  `url:"fromId,omitempty"`
+	Limit     int    `url:"limit,omitempty"` // Limit is the maximal number of elements to receive. Default 50; Max 1000
+}
+
+const (
+	DefaultAccountTradesLimit = 50
+	MaxAccountTradesLimit     = 1000
+)
+
+type AccountTrade struct {
+	Symbol          string               `json:"symbol"`
+	Pair            string               `json:"pair"`
+	ID              int64                `json:"id"`
+	OrderID         int64                `json:"orderId"`
+	Side            binance.OrderSide    `json:"side"`
+	PositionSide    futures.PositionSide `json:"positionSide"`
+	Price           string               `json:"price"`
+	Qty             string               `json:"qty"`
+	BaseQty         string               `json:"baseQty"`
+	MarginAsset     string               `json:"marginAsset"`
+	RealizedPnl     string               `json:"realizedPnl"`
+	Commission      string               `json:"commission"`
+	CommissionAsset string               `json:"commissionAsset"`
+	Time            int64                `json:"time"`
+	Buyer           bool                 `json:"buyer"`
+	Maker           bool                 `json:"maker"`
+}
+
+// Account is the coin margined account, every margin asset is margined separately
+type Account struct {
+	FeeTier     int                `json:"feeTier"`
+	CanTrade    bool               `json:"canTrade"`
+	CanDeposit  bool               `json:"canDeposit"`
+	CanWithdraw bool               `json:"canWithdraw"`
+	UpdateTime  int64              `json:"updateTime"`
+	Assets      []*AccountAsset    `json:"assets"`
+	Positions   []*AccountPosition `json:"positions"`
+}
+
+type AccountAsset struct {
+	Asset                  string `json:"asset"`
+	WalletBalance          string `json:"walletBalance"`
+	UnrealizedProfit       string `json:"unrealizedProfit"`
+	MarginBalance          string `json:"marginBalance"`
+	MaintMargin            string `json:"maintMargin"`
+	InitialMargin          string `json:"initialMargin"`
+	PositionInitialMargin  string `json:"positionInitialMargin"`
+	OpenOrderInitialMargin string `json:"openOrderInitialMargin"`
+	MaxWithdrawAmount      string `json:"maxWithdrawAmount"`
+	CrossWalletBalance     string `json:"crossWalletBalance"`
+	CrossUnPnl             string `json:"crossUnPnl"`
+	AvailableBalance       string `json:"availableBalance"`
+}
+
+type AccountPosition struct {
+	Symbol                 string               `json:"symbol"`
+	PositionAmt            string               `json:"positionAmt"`
+	InitialMargin          string               `json:"initialMargin"`
+	MaintMargin            string               `json:"maintMargin"`
+	UnrealizedProfit       string               `json:"unrealizedProfit"`
+	PositionInitialMargin  string               `json:"positionInitialMargin"`
+	OpenOrderInitialMargin string               `json:"openOrderInitialMargin"`
+	Leverage               string               `json:"leverage"`
+	Isolated               bool                 `json:"isolated"`
+	PositionSide           futures.PositionSide `json:"positionSide"`
+	EntryPrice             string               `json:"entryPrice"`
+	MaxQty                 string               `json:"maxQty"`
+	UpdateTime             int64                `json:"updateTime"`
+}
+
+type Balance struct {
+	AccountAlias       string `json:"accountAlias"`
+	Asset              string `json:"asset"`
+	Balance            string `json:"balance"`
+	WithdrawAvailable  string `json:"withdrawAvailable"`
+	CrossWalletBalance string `json:"crossWalletBalance"`
+	CrossUnPnl         string `json:"crossUnPnl"`
+	AvailableBalance   string `json:"availableBalance"`
+	UpdateTime         int64  `json:"updateTime"`
+}
+
+type PositionRiskReq struct {
+	MarginAsset string `url:"marginAsset,omitempty"`
+	Pair        string `url:"pair,omitempty"`
+}
+
+type PositionRisk struct {
+	Symbol           string               `json:"symbol"`
+	PositionAmt      string               `json:"positionAmt"`
+	EntryPrice       string               `json:"entryPrice"`
+	BreakEvenPrice   string               `json:"breakEvenPrice"`
+	MarkPrice        string               `json:"markPrice"`
+	UnRealizedProfit string               `json:"unRealizedProfit"`
+	LiquidationPrice string               `json:"liquidationPrice"`
+	Leverage         string               `json:"leverage"`
+	MaxQty           string               `json:"maxQty"`
+	MarginType       string               `json:"marginType"` // MarginType is lowercase: isolated or cross
+	IsolatedMargin   string               `json:"isolatedMargin"`
+	IsAutoAddMargin  string               `json:"isAutoAddMargin"`
+	PositionSide     futures.PositionSide `json:"positionSide"`
+	NotionalValue    string               `json:"notionalValue"` // NotionalValue is in the margin asset
+	IsolatedWallet   string               `json:"isolatedWallet"`
+	UpdateTime       int64                `json:"updateTime"`
+}
+
+type Leverage struct {
+	Symbol   string `json:"symbol"`
+	Leverage int    `json:"leverage"`
+	MaxQty   string `json:"maxQty"`
+}
@@ -0,0 +1,284 @@
+package binance
+
+import (
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+	"github.com/xenking/decimal"
+)
+
+// FlexibleProducts get the page of the Simple Earn flexible products
+func (c *Client) FlexibleProducts(req *EarnProductsReq) (*FlexibleProducts, error) {
+	resp := &FlexibleProducts{}
+
+	if err := c.earnPage(EndpointFlexibleProducts, earnProductsReq(req), resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// LockedProducts get the page of the Simple Earn locked products
+func (c *Client) LockedProducts(req *EarnProductsReq) (*LockedProducts, error) {
+	resp := &LockedProducts{}
+
+	if err := c.earnPage(EndpointLockedProducts, earnProductsReq(req), resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func earnProductsReq(req *EarnProductsReq) *EarnProductsReq {
+	if req == nil {
+		req = &EarnProductsReq{}
+	}
+	req.Size = earnPageSize(req.Size)
+
+	return req
+}
+
+// SubscribeFlexible subscribes the amount to the flexible product
+func (c *Client) SubscribeFlexible(req *FlexibleSubscribeReq) (*EarnSubscription, error) {
+	switch {
+	case req == nil:
+		return nil, ErrNilRequest
+	case req.ProductID == "":
+		return nil, ErrEmptyProductID
+	case req.Amount == "":
+		return nil, ErrEmptyAmount
+	}
+
+	return c.earnSubscribe(EndpointFlexibleSubscribe, req)
+}
+
+// SubscribeLocked subscribes the amount to the locked product
+func (c *Client) SubscribeLocked(req *LockedSubscribeReq) (*EarnSubscription, error) {
+	switch {
+	case req == nil:
+		return nil, ErrNilRequest
+	case req.ProjectID == "":
+		return nil, ErrEmptyProductID
+	case req.Amount == "":
+		return nil, ErrEmptyAmount
+	}
+
+	return c.earnSubscribe(EndpointLockedSubscribe, req)
+}
+
+func (c *Client) earnSubscribe(endpoint string, req interface{}) (*EarnSubscription, error) {
+	res, err := c.Do(fasthttp.MethodPost, endpoint, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &EarnSubscription{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// RedeemFlexible redeems the amount or the entire flexible position
+func (c *Client) RedeemFlexible(req *FlexibleRedeemReq) (*EarnRedemption, error) {
+	switch {
+	case req == nil:
+		return nil, ErrNilRequest
+	case req.ProductID == "":
+		return nil, ErrEmptyProductID
+	case req.Amount == "" && !req.RedeemAll:
+		return nil, ErrEmptyAmount
+	}
+
+	return c.earnRedeem(EndpointFlexibleRedeem, req)
+}
+
+// RedeemLocked redeems the locked position early
+func (c *Client) RedeemLocked(req *LockedRedeemReq) (*EarnRedemption, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.PositionID == "" {
+		return nil, ErrEmptyProductID
+	}
+
+	return c.earnRedeem(EndpointLockedRedeem, req)
+}
+
+func (c *Client) earnRedeem(endpoint string, req interface{}) (*EarnRedemption, error) {
+	res, err := c.Do(fasthttp.MethodPost, endpoint, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &EarnRedemption{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// FlexiblePositions get the page of the flexible product positions
+func (c *Client) FlexiblePositions(req *FlexiblePositionsReq) (*FlexiblePositions, error) {
+	if req == nil {
+		req = &FlexiblePositionsReq{}
+	}
+	req.Size = earnPageSize(req.Size)
+	resp := &FlexiblePositions{}
+
+	if err := c.earnPage(EndpointFlexiblePositions, req, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// AllFlexiblePositions get all flexible product positions page by page
+func (c *Client) AllFlexiblePositions(req *FlexiblePositionsReq) ([]*FlexiblePosition, error) {
+	r := FlexiblePositionsReq{Size: MaxEarnPageSize}
+	if req != nil {
+		r = *req
+	}
+	if r.Current <= 0 {
+		r.Current = 1
+	}
+	var resp []*FlexiblePosition
+	for {
+		page, err := c.FlexiblePositions(&r)
+		if err != nil {
+			return resp, err
+		}
+		resp = append(resp, page.Rows...)
+		if len(page.Rows) < r.Size || r.Current*r.Size >= page.Total {
+			return resp, nil
+		}
+		r.Current++
+	}
+}
+
+// LockedPositions get the page of the locked product positions
+func (c *Client) LockedPositions(req *LockedPositionsReq) (*LockedPositions, error) {
+	if req == nil {
+		req = &LockedPositionsReq{}
+	}
+	req.Size = earnPageSize(req.Size)
+	resp := &LockedPositions{}
+
+	if err := c.earnPage(EndpointLockedPositions, req, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// FlexibleRewards get the page of the flexible product rewards of the type
+func (c *Client) FlexibleRewards(req *FlexibleRewardsReq) (*FlexibleRewards, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.Type == "" {
+		req.Type = EarnRewardTypeAll
+	}
+	req.Size = earnPageSize(req.Size)
+	resp := &FlexibleRewards{}
+
+	if err := c.earnPage(EndpointFlexibleRewards, req, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// LockedRewards get the page of the locked product rewards
+func (c *Client) LockedRewards(req *LockedRewardsReq) (*LockedRewards, error) {
+	if req == nil {
+		req = &LockedRewardsReq{}
+	}
+	req.Size = earnPageSize(req.Size)
+	resp := &LockedRewards{}
+
+	if err := c.earnPage(EndpointLockedRewards, req, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// FlexibleRateHistory get the page of the annual percentage rates of the flexible product
+func (c *Client) FlexibleRateHistory(req *FlexibleRateHistoryReq) (*FlexibleRateHistory, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.ProductID == "" {
+		return nil, ErrEmptyProductID
+	}
+	req.Size = earnPageSize(req.Size)
+	resp := &FlexibleRateHistory{}
+
+	if err := c.earnPage(EndpointFlexibleRateHistory, req, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Client) earnPage(endpoint string, req, resp interface{}) error {
+	res, err := c.Do(fasthttp.MethodGet, endpoint, req, true, false)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(res, resp)
+}
+
+func earnPageSize(size int) int {
+	if size <= 0 || size > MaxEarnPageSize {
+		return DefaultEarnPageSize
+	}
+
+	return size
+}
+
+// AvailableBalances returns the free balances of the assets increased by the flexible positions
+// which can be redeemed instantly, so the amount can be traded after the redemption
+func AvailableBalances(balances []*Balance, positions []*FlexiblePosition) (map[string]decimal.Decimal, error) {
+	resp := make(map[string]decimal.Decimal, len(balances))
+	for _, b := range balances {
+		free, err := decimal.NewFromString(b.Free)
+		if err != nil {
+			return nil, err
+		}
+		resp[b.Asset] = resp[b.Asset].Add(free)
+	}
+	for _, p := range positions {
+		if !p.CanRedeem {
+			continue
+		}
+		amount, err := decimal.NewFromString(p.TotalAmount)
+		if err != nil {
+			return nil, err
+		}
+		// The collateral of the loans can't be redeemed
+		if p.CollateralAmount != "" {
+			collateral, err := decimal.NewFromString(p.CollateralAmount)
+			if err != nil {
+				return nil, err
+			}
+			amount = amount.Sub(collateral)
+		}
+		if amount.Sign() > 0 {
+			resp[p.Asset] = resp[p.Asset].Add(amount)
+		}
+	}
+
+	return resp, nil
+}
+
+// AvailableToTrade get the account balances and the flexible positions and returns the available balances of the assets
+func (c *Client) AvailableToTrade() (map[string]decimal.Decimal, error) {
+	account, err := c.Account()
+	if err != nil {
+		return nil, err
+	}
+	positions, err := c.AllFlexiblePositions(&FlexiblePositionsReq{Size: MaxEarnPageSize})
+	if err != nil {
+		return nil, err
+	}
+
+	return AvailableBalances(account.Balances, positions)
+}
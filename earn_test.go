@@ -0,0 +1,115 @@
+package binance_test
+
+import (
+	"strings"
+
+	"github.com/google/go-querystring/query"
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+)
+
+type mockedEarnTestSuite struct {
+	mockedTestSuite
+}
+
+func (s *mockedEarnTestSuite) TestAvailableBalances() {
+	balances := []*binance.Balance{
+		{Asset: "BTC", Free: "0.5", Locked: "0.1"},
+		{Asset: "USDT", Free: "100", Locked: "0"},
+	}
+	positions := []*binance.FlexiblePosition{
+		{Asset: "USDT", TotalAmount: "250", CanRedeem: true, CollateralAmount: "50"},
+		{Asset: "BTC", TotalAmount: "1", CanRedeem: false},
+		{Asset: "BNB", TotalAmount: "2", CanRedeem: true},
+	}
+	available, err := binance.AvailableBalances(balances, positions)
+	s.Require().NoError(err)
+	s.Require().Equal("0.5", available["BTC"].String())
+	s.Require().Equal("300", available["USDT"].String())
+	s.Require().Equal("2", available["BNB"].String())
+
+	_, err = binance.AvailableBalances([]*binance.Balance{{Asset: "BTC", Free: "x"}}, nil)
+	s.Require().Error(err)
+}
+
+func (s *mockedEarnTestSuite) TestAvailableToTrade() {
+	var pages []int
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().True(sign)
+		switch endpoint {
+		case binance.EndpointAccount:
+			return []byte(`{"balances":[{"asset":"USDT","free":"10.5","locked":"1"}]}`), nil
+		case binance.EndpointFlexiblePositions:
+			req := data.(*binance.FlexiblePositionsReq)
+			s.Require().Equal(binance.MaxEarnPageSize, req.Size)
+			pages = append(pages, req.Current)
+			if req.Current == 1 {
+				row := `{"asset":"USDT","productId":"USDT001","totalAmount":"1","canRedeem":true,"collateralAmount":"0"}`
+				rows := strings.Repeat(row+",", req.Size-1) + row
+				return []byte(`{"rows":[` + rows + `],"total":101}`), nil
+			}
+			return []byte(`{"rows":[{"asset":"BTC","productId":"BTC001","totalAmount":"0.01","canRedeem":true}],"total":101}`), nil
+		}
+		s.FailNow("unexpected endpoint " + endpoint)
+		return nil, nil
+	}
+	available, err := s.client.AvailableToTrade()
+	s.Require().NoError(err)
+	s.Require().Equal([]int{1, 2}, pages)
+	s.Require().Equal("110.5", available["USDT"].String())
+	s.Require().Equal("0.01", available["BTC"].String())
+}
+
+func (s *mockedEarnTestSuite) TestAllFlexiblePositions() {
+	var pages []int
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(binance.EndpointFlexiblePositions, endpoint)
+		req := data.(*binance.FlexiblePositionsReq)
+		s.Require().Equal(1, req.Size)
+		pages = append(pages, req.Current)
+		return []byte(`{"rows":[{"asset":"USDT","productId":"USDT001","totalAmount":"1","canRedeem":true}],"total":3}`), nil
+	}
+	req := &binance.FlexiblePositionsReq{Asset: "USDT", Size: 1}
+	positions, err := s.client.AllFlexiblePositions(req)
+	s.Require().NoError(err)
+	s.Require().Len(positions, 3)
+	s.Require().Equal([]int{1, 2, 3}, pages)
+	// the pages are requested with the copy of the request
+	s.Require().Zero(req.Current)
+}
+
+func (s *mockedEarnTestSuite) TestSubscribeRedeem() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(fasthttp.MethodPost, method)
+		values, err := query.Values(data)
+		s.Require().NoError(err)
+		switch endpoint {
+		case binance.EndpointFlexibleSubscribe:
+			s.Require().Equal("false", values.Get("autoSubscribe"))
+			s.Require().Equal("FUND", values.Get("sourceAccount"))
+			return []byte(`{"purchaseId":40607,"success":true}`), nil
+		case binance.EndpointFlexibleRedeem:
+			s.Require().Equal("true", values.Get("redeemAll"))
+			s.Require().False(values.Has("amount"))
+			return []byte(`{"redeemId":40607,"success":true}`), nil
+		}
+		s.FailNow("unexpected endpoint " + endpoint)
+		return nil, nil
+	}
+	autoSubscribe := false
+	subscription, err := s.client.SubscribeFlexible(&binance.FlexibleSubscribeReq{
+		ProductID: "USDT001", Amount: "100", AutoSubscribe: &autoSubscribe, SourceAccount: binance.EarnAccountFund,
+	})
+	s.Require().NoError(err)
+	s.Require().True(subscription.Success)
+
+	redemption, err := s.client.RedeemFlexible(&binance.FlexibleRedeemReq{ProductID: "USDT001", RedeemAll: true})
+	s.Require().NoError(err)
+	s.Require().EqualValues(40607, redemption.RedeemID)
+
+	_, err = s.client.RedeemFlexible(&binance.FlexibleRedeemReq{ProductID: "USDT001"})
+	s.Require().ErrorIs(err, binance.ErrEmptyAmount)
+	_, err = s.client.SubscribeLocked(&binance.LockedSubscribeReq{Amount: "1"})
+	s.Require().ErrorIs(err, binance.ErrEmptyProductID)
+}
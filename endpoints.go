@@ -26,15 +26,75 @@ const (
 	EndpointOrder              = "/api/v3/order"
 	EndpointOrderTest          = "/api/v3/order/test"
 	EndpointCancelReplaceOrder = "/api/v3/order/cancelReplace"
+	EndpointOrderAmend         = "/api/v3/order/amend/keepPriority"
+	EndpointOrderAmendments    = "/api/v3/order/amendments"
 	EndpointOrdersAll          = "/api/v3/allOrders"
 	EndpointOpenOrders         = "/api/v3/openOrders"
-	EndpointOCOOrder           = "/api/v3/order/oco"
+	EndpointOCOOrder           = "/api/v3/order/oco" // Deprecated: use EndpointOrderListOCO
+	EndpointOrderListOCO       = "/api/v3/orderList/oco"
+	EndpointOrderListOTO       = "/api/v3/orderList/oto"
+	EndpointOrderListOTOCO     = "/api/v3/orderList/otoco"
 	EndpointOCOOrders          = "/api/v3/orderList"
 	EndpointOCOOrdersAll       = "/api/v3/allOrderList"
 	EndpointOpenOCOOrders      = "/api/v3/openOrderList"
+	EndpointSOROrder           = "/api/v3/sor/order"
+	EndpointSOROrderTest       = "/api/v3/sor/order/test"
+	EndpointMyAllocations      = "/api/v3/myAllocations"
 )
 
 const (
-	EndpointAccount    = "/api/v3/account"
-	EndpointDataStream = "/api/v3/userDataStream"
+	EndpointAccount           = "/api/v3/account"
+	EndpointAccountCommission = "/api/v3/account/commission"
+	EndpointDataStream        = "/api/v3/userDataStream"
+)
+
+// Wallet endpoints with SIGNED security
+const (
+	EndpointCoins             = "/sapi/v1/capital/config/getall"
+	EndpointDepositAddress    = "/sapi/v1/capital/deposit/address"
+	EndpointDepositHistory    = "/sapi/v1/capital/deposit/hisrec"
+	EndpointWithdraw          = "/sapi/v1/capital/withdraw/apply"
+	EndpointWithdrawHistory   = "/sapi/v1/capital/withdraw/history"
+	EndpointUniversalTransfer = "/sapi/v1/asset/transfer"
+	EndpointAssetDividend     = "/sapi/v1/asset/assetDividend"
+	EndpointDustAssets        = "/sapi/v1/asset/dust-btc"
+	EndpointDust              = "/sapi/v1/asset/dust"
+	EndpointAssetDetail       = "/sapi/v1/asset/assetDetail"
+)
+
+// Sub-account endpoints with SIGNED security
+const (
+	EndpointSubAccounts                = "/sapi/v1/sub-account/list"
+	EndpointSubAccountCreate           = "/sapi/v1/sub-account/virtualSubAccount"
+	EndpointSubAccountAssets           = "/sapi/v3/sub-account/assets"
+	EndpointSubAccountSpotSummary      = "/sapi/v1/sub-account/spotSummary"
+	EndpointSubAccountTransfer         = "/sapi/v1/sub-account/universalTransfer"
+	EndpointSubAccountIPRestriction    = "/sapi/v1/sub-account/subAccountApi/ipRestriction"
+	EndpointSubAccountSetIPRestriction = "/sapi/v2/sub-account/subAccountApi/ipRestriction"
+	EndpointSubAccountIPList           = "/sapi/v1/sub-account/subAccountApi/ipRestriction/ipList"
+)
+
+// Simple Earn endpoints with SIGNED security
+const (
+	EndpointFlexibleProducts    = "/sapi/v1/simple-earn/flexible/list"
+	EndpointLockedProducts      = "/sapi/v1/simple-earn/locked/list"
+	EndpointFlexibleSubscribe   = "/sapi/v1/simple-earn/flexible/subscribe"
+	EndpointLockedSubscribe     = "/sapi/v1/simple-earn/locked/subscribe"
+	EndpointFlexibleRedeem      = "/sapi/v1/simple-earn/flexible/redeem"
+	EndpointLockedRedeem        = "/sapi/v1/simple-earn/locked/redeem"
+	EndpointFlexiblePositions   = "/sapi/v1/simple-earn/flexible/position"
+	EndpointLockedPositions     = "/sapi/v1/simple-earn/locked/position"
+	EndpointFlexibleRewards     = "/sapi/v1/simple-earn/flexible/history/rewardsRecord"
+	EndpointLockedRewards       = "/sapi/v1/simple-earn/locked/history/rewardsRecord"
+	EndpointFlexibleRateHistory = "/sapi/v1/simple-earn/flexible/history/rateHistory"
+)
+
+// Convert endpoints with SIGNED security
+const (
+	EndpointConvertExchangeInfo = "/sapi/v1/convert/exchangeInfo"
+	EndpointConvertQuote        = "/sapi/v1/convert/getQuote"
+	EndpointConvertAccept       = "/sapi/v1/convert/acceptQuote"
+	EndpointConvertOrderStatus  = "/sapi/v1/convert/orderStatus"
+	EndpointConvertTradeFlow    = "/sapi/v1/convert/tradeFlow"
+	EndpointBNBBurn             = "/sapi/v1/bnbBurn"
 )
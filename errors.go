@@ -4,12 +4,13 @@ import (
 	"github.com/segmentio/encoding/json"
 )
 
+// ValidationError represents error of the request rejected by the client before it's sent
 type ValidationError struct {
-	msg string
+	Msg string
 }
 
 func (e ValidationError) Error() string {
-	return e.msg
+	return e.Msg
 }
 
 var (
@@ -25,10 +26,50 @@ var (
 	ErrInvalidJSON         = ValidationError{"invalid json"}
 	ErrInvalidTickerWindow = ValidationError{"invalid ticker window"}
 	ErrInvalidOrderType    = ValidationError{"invalid order type"}
+	ErrEmptyClientOrderID  = ValidationError{"client order id is not set"}
+	ErrInvalidQuantity     = ValidationError{"invalid quantity"}
+	ErrInvalidPrice        = ValidationError{"invalid price"}
+	// ErrInvalidDiscountPrice represents error when the discount asset price is not a positive number
+	ErrInvalidDiscountPrice = ValidationError{"invalid discount asset price"}
+	// ErrAmendQtyNotDecreased represents error when the amended quantity isn't lower than the current one
+	ErrAmendQtyNotDecreased = ValidationError{"amended quantity must be lower than current quantity"}
+	// ErrInvalidClientOrderID represents error when client order id doesn't match ^[a-zA-Z0-9-_]{1,36}$
+	ErrInvalidClientOrderID = ValidationError{"invalid client order id"}
+	ErrEmptyAsset           = ValidationError{"asset is not set"}
+	ErrEmptyCoin            = ValidationError{"coin is not set"}
+	ErrEmptyAmount          = ValidationError{"amount is not set"}
+	ErrEmptyAddress         = ValidationError{"address is not set"}
+	ErrEmptyTransferType    = ValidationError{"transfer type is not set"}
+	ErrEmptyEmail           = ValidationError{"email is not set"}
+	ErrEmptyAPIKey          = ValidationError{"api key is not set"}
+	ErrEmptyProductID       = ValidationError{"product id is not set"}
+	ErrEmptyQuoteID         = ValidationError{"quote id is not set"}
+	// ErrConvertQuoteExpired represents error when the convert quote is no longer valid to be accepted
+	ErrConvertQuoteExpired = ValidationError{"convert quote expired"}
+	// ErrWithdrawDisabled represents error when the withdrawal is sent by the client without EnableWithdraw
+	ErrWithdrawDisabled = ValidationError{"withdraw is disabled"}
 	// ErrIncorrectAccountEventType represents error when event type can't before determined
 	ErrIncorrectAccountEventType = ValidationError{"incorrect account event type"}
 )
 
+// API error codes
+const (
+	ErrorCodeUnknown          = -1000
+	ErrorCodeTooManyRequests  = -1003
+	ErrorCodeTimeout          = -1007 // Timeout waiting for response from backend server, execution status unknown
+	ErrorCodeFilterFailure    = -1013
+	ErrorCodeInvalidTimestamp = -1021
+	ErrorCodeInvalidSignature = -1022
+	ErrorCodeMandatoryParam   = -1102
+	ErrorCodeBadInterval      = -1120
+	ErrorCodeBadSymbol        = -1121
+	ErrorCodeInvalidListenKey = -1125
+	ErrorCodeNewOrderRejected = -2010
+	ErrorCodeCancelRejected   = -2011
+	ErrorCodeNoSuchOrder      = -2013
+	ErrorCodeRejectedMBXKey   = -2015
+)
+
 type APIError struct {
 	Code int    `json:"code"`
 	Msg  string `json:"msg"`
@@ -0,0 +1,447 @@
+package binance
+
+import (
+	"strings"
+
+	"github.com/xenking/decimal"
+)
+
+// RoundingMode represents the direction in which price or quantity is fitted into the filter grid
+type RoundingMode int
+
+const (
+	RoundingModeDown    RoundingMode = iota // RoundingModeDown rounds towards zero
+	RoundingModeUp                          // RoundingModeUp rounds away from zero
+	RoundingModeNearest                     // RoundingModeNearest rounds half away from zero
+)
+
+// FilterViolationReason describes why a value doesn't pass the symbol filter
+type FilterViolationReason string
+
+const (
+	FilterViolationInvalid    FilterViolationReason = "invalid value"
+	FilterViolationBelowMin   FilterViolationReason = "below minimum"
+	FilterViolationAboveMax   FilterViolationReason = "above maximum"
+	FilterViolationStep       FilterViolationReason = "not a multiple of step"
+	FilterViolationNotAllowed FilterViolationReason = "not allowed"
+)
+
+// FilterViolation represents a single failed check of the order request against the symbol filters
+type FilterViolation struct {
+	Filter FilterType            // Filter is the symbol filter type, empty for symbol flags checks
+	Field  string                // Field is the request field name as sent to the API
+	Value  string                // Value is the checked value
+	Limit  string                // Limit is the filter bound the value was compared with
+	Reason FilterViolationReason // Reason describes the violation
+}
+
+func (v FilterViolation) Error() string {
+	var b strings.Builder
+	if v.Filter != "" {
+		b.WriteString(string(v.Filter))
+		b.WriteString(": ")
+	}
+	b.WriteString(v.Field)
+	b.WriteByte(' ')
+	b.WriteString(v.Value)
+	b.WriteByte(' ')
+	b.WriteString(string(v.Reason))
+	if v.Limit != "" {
+		b.WriteByte(' ')
+		b.WriteString(v.Limit)
+	}
+
+	return b.String()
+}
+
+// FilterViolations is returned by the symbol validators when at least one filter check failed
+type FilterViolations []FilterViolation
+
+func (v FilterViolations) Error() string {
+	msgs := make([]string, len(v))
+	for i := range v {
+		msgs[i] = v[i].Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// Symbol returns the symbol info by its name or nil if the symbol isn't listed
+func (i *ExchangeInfo) Symbol(symbol string) *SymbolInfo {
+	for _, s := range i.Symbols {
+		if s.Symbol == symbol {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// Filter returns the symbol filter of the given type or nil if the symbol has no such filter
+func (s *SymbolInfo) Filter(t FilterType) *SymbolInfoFilter {
+	for i := range s.Filters {
+		if s.Filters[i].Type == t {
+			return &s.Filters[i]
+		}
+	}
+
+	return nil
+}
+
+// OrderTypeAllowed checks whether the order type is listed in the symbol order types
+func (s *SymbolInfo) OrderTypeAllowed(t OrderType) bool {
+	for _, ot := range s.OrderTypes {
+		if ot == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NormalizePrice rounds the price to the symbol PRICE_FILTER tick size
+func (s *SymbolInfo) NormalizePrice(price string, mode RoundingMode) (string, error) {
+	f := s.Filter(FilterTypePrice)
+	if f == nil || price == "" {
+		return price, nil
+	}
+
+	return roundToStep(price, f.TickSize, mode)
+}
+
+// NormalizeQuantity rounds the quantity to the symbol LOT_SIZE step size.
+// MARKET_LOT_SIZE is used instead when market is set and the filter defines a step
+func (s *SymbolInfo) NormalizeQuantity(qty string, mode RoundingMode, market bool) (string, error) {
+	f := s.lotSizeFilter(market)
+	if f == nil || qty == "" {
+		return qty, nil
+	}
+
+	return roundToStep(qty, f.StepSize, mode)
+}
+
+// NormalizeOrder rounds all prices of the order request to the tick size and
+// all quantities to the step size in place
+func (s *SymbolInfo) NormalizeOrder(req *OrderReq, priceMode, qtyMode RoundingMode) error {
+	if req == nil {
+		return ErrNilRequest
+	}
+	var err error
+	if req.Price, err = s.NormalizePrice(req.Price, priceMode); err != nil {
+		return err
+	}
+	if req.StopPrice, err = s.NormalizePrice(req.StopPrice, priceMode); err != nil {
+		return err
+	}
+	if req.Quantity, err = s.NormalizeQuantity(req.Quantity, qtyMode, req.Type == OrderTypeMarket); err != nil {
+		return err
+	}
+	req.IcebergQty, err = s.NormalizeQuantity(req.IcebergQty, qtyMode, false)
+
+	return err
+}
+
+// NormalizeOCO rounds all prices of the OCO request to the tick size and
+// all quantities to the step size in place
+func (s *SymbolInfo) NormalizeOCO(req *OCOReq, priceMode, qtyMode RoundingMode) error {
+	if req == nil {
+		return ErrNilRequest
+	}
+	var err error
+	for _, p := range []*string{&req.Price, &req.StopPrice, &req.StopLimitPrice} {
+		if *p, err = s.NormalizePrice(*p, priceMode); err != nil {
+			return err
+		}
+	}
+	for _, q := range []*string{&req.Quantity, &req.LimitIcebergQty, &req.StopIcebergQty} {
+		if *q, err = s.NormalizeQuantity(*q, qtyMode, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateOrder checks the order request against the symbol flags and filters.
+// Remark: PERCENT_PRICE filters are not checked because they depend on the current average price
+func (s *SymbolInfo) ValidateOrder(req *OrderReq) error {
+	if req == nil {
+		return ErrNilRequest
+	}
+	v := &filterValidator{symbol: s}
+	v.checkSymbol(req.Symbol)
+	if !s.OrderTypeAllowed(req.Type) {
+		v.add(FilterViolation{Field: "type", Value: string(req.Type), Reason: FilterViolationNotAllowed})
+	}
+	if req.QuoteQuantity != "" && req.Type == OrderTypeMarket && !s.QuoteOrderQtyMarketAllowed {
+		v.add(FilterViolation{Field: "quoteOrderQty", Value: req.QuoteQuantity, Reason: FilterViolationNotAllowed})
+	}
+
+	market := req.Type == OrderTypeMarket
+	v.checkPrice("price", req.Price)
+	v.checkPrice("stopPrice", req.StopPrice)
+	v.checkQuantity("quantity", req.Quantity, market)
+	v.checkIceberg(req.Quantity, req.IcebergQty)
+	v.checkTrailingDelta(req.Side, req.Type, req.TrailingDelta)
+
+	switch {
+	case market && req.QuoteQuantity != "":
+		v.checkNotional(req.QuoteQuantity, "", true)
+	case !market:
+		v.checkNotional(req.Quantity, req.Price, false)
+	}
+
+	return v.err()
+}
+
+// ValidateOCO checks the OCO request against the symbol flags and filters
+func (s *SymbolInfo) ValidateOCO(req *OCOReq) error {
+	if req == nil {
+		return ErrNilRequest
+	}
+	v := &filterValidator{symbol: s}
+	v.checkSymbol(req.Symbol)
+	if !s.OCOAllowed {
+		v.add(FilterViolation{Field: "symbol", Value: req.Symbol, Reason: FilterViolationNotAllowed})
+	}
+
+	v.checkPrice("price", req.Price)
+	v.checkPrice("stopPrice", req.StopPrice)
+	v.checkPrice("stopLimitPrice", req.StopLimitPrice)
+	v.checkQuantity("quantity", req.Quantity, false)
+	v.checkIceberg(req.Quantity, req.LimitIcebergQty)
+	v.checkIceberg(req.Quantity, req.StopIcebergQty)
+	v.checkNotional(req.Quantity, req.Price, false)
+	if req.StopLimitPrice != "" {
+		v.checkNotional(req.Quantity, req.StopLimitPrice, false)
+	}
+	stopType := OrderTypeStopLoss
+	if req.StopLimitPrice != "" {
+		stopType = OrderTypeStopLossLimit
+	}
+	v.checkTrailingDelta(req.Side, stopType, req.TrailingDelta)
+
+	return v.err()
+}
+
+func (s *SymbolInfo) lotSizeFilter(market bool) *SymbolInfoFilter {
+	if market {
+		if f := s.Filter(FilterTypeMarketLotSize); f != nil && !isZeroDecimal(f.StepSize) {
+			return f
+		}
+	}
+
+	return s.Filter(FilterTypeLotSize)
+}
+
+type filterValidator struct {
+	symbol     *SymbolInfo
+	violations FilterViolations
+}
+
+func (v *filterValidator) add(violation FilterViolation) {
+	v.violations = append(v.violations, violation)
+}
+
+func (v *filterValidator) err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+
+	return v.violations
+}
+
+func (v *filterValidator) checkSymbol(symbol string) {
+	if symbol != v.symbol.Symbol {
+		v.add(FilterViolation{Field: "symbol", Value: symbol, Limit: v.symbol.Symbol, Reason: FilterViolationInvalid})
+	}
+	if v.symbol.Status != "" && v.symbol.Status != SymbolStatusTrading {
+		v.add(FilterViolation{Field: "symbol", Value: string(v.symbol.Status), Reason: FilterViolationNotAllowed})
+	}
+}
+
+func (v *filterValidator) checkPrice(field, price string) {
+	f := v.symbol.Filter(FilterTypePrice)
+	if f == nil || price == "" {
+		return
+	}
+	v.checkRange(f.Type, field, price, f.MinPrice, f.MaxPrice, f.TickSize)
+}
+
+func (v *filterValidator) checkQuantity(field, qty string, market bool) {
+	if qty == "" {
+		return
+	}
+	if f := v.symbol.Filter(FilterTypeLotSize); f != nil {
+		v.checkRange(f.Type, field, qty, f.MinQty, f.MaxQty, f.StepSize)
+	}
+	if !market {
+		return
+	}
+	if f := v.symbol.Filter(FilterTypeMarketLotSize); f != nil {
+		v.checkRange(f.Type, field, qty, f.MinQty, f.MaxQty, f.StepSize)
+	}
+}
+
+func (v *filterValidator) checkIceberg(qty, icebergQty string) {
+	if icebergQty == "" {
+		return
+	}
+	if !v.symbol.IcebergAllowed {
+		v.add(FilterViolation{Field: "icebergQty", Value: icebergQty, Reason: FilterViolationNotAllowed})
+
+		return
+	}
+	v.checkQuantity("icebergQty", icebergQty, false)
+
+	f := v.symbol.Filter(FilterTypeIcebergParts)
+	if f == nil || f.IcebergLimit == 0 || qty == "" {
+		return
+	}
+	q, err1 := decimal.NewFromString(qty)
+	iq, err2 := decimal.NewFromString(icebergQty)
+	if err1 != nil || err2 != nil || !iq.IsPositive() {
+		return
+	}
+	limit := decimal.NewFromInt(int64(f.IcebergLimit))
+	if parts := q.Div(iq).Ceil(); parts.GreaterThan(limit) {
+		v.add(FilterViolation{
+			Filter: f.Type,
+			Field:  "icebergQty",
+			Value:  parts.String(),
+			Limit:  limit.String(),
+			Reason: FilterViolationAboveMax,
+		})
+	}
+}
+
+func (v *filterValidator) checkNotional(qty, price string, market bool) {
+	if qty == "" || (!market && price == "") {
+		return
+	}
+	notional, err := decimal.NewFromString(qty)
+	if err != nil {
+		return
+	}
+	if !market {
+		p, err := decimal.NewFromString(price)
+		if err != nil {
+			return
+		}
+		notional = notional.Mul(p)
+	}
+
+	if f := v.symbol.Filter(FilterTypeMinNotional); f != nil && (!market || f.ApplyToMarket) {
+		v.checkBound(f.Type, "notional", notional, f.MinNotional, FilterViolationBelowMin)
+	}
+	if f := v.symbol.Filter(FilterTypeNotional); f != nil {
+		if !market || f.ApplyMinToMarket {
+			v.checkBound(f.Type, "notional", notional, f.MinNotional, FilterViolationBelowMin)
+		}
+		if !market || f.ApplyMaxToMarket {
+			v.checkBound(f.Type, "notional", notional, f.MaxNotional, FilterViolationAboveMax)
+		}
+	}
+}
+
+func (v *filterValidator) checkTrailingDelta(side OrderSide, t OrderType, delta int64) {
+	if delta == 0 {
+		return
+	}
+	if !v.symbol.AllowTrailingStop {
+		v.add(FilterViolation{Field: "trailingDelta", Value: decimal.NewFromInt(delta).String(), Reason: FilterViolationNotAllowed})
+
+		return
+	}
+	f := v.symbol.Filter(FilterTypeTrailingDelta)
+	if f == nil {
+		return
+	}
+	// Orders triggered when the price goes up use the "above" bounds
+	minDelta, maxDelta := f.MinTrailingBelowDelta, f.MaxTrailingBelowDelta
+	switch t {
+	case OrderTypeStopLoss, OrderTypeStopLossLimit:
+		if side == OrderSideBuy {
+			minDelta, maxDelta = f.MinTrailingAboveDelta, f.MaxTrailingAboveDelta
+		}
+	case OrderTypeTakeProfit, OrderTypeTakeProfitLimit:
+		if side == OrderSideSell {
+			minDelta, maxDelta = f.MinTrailingAboveDelta, f.MaxTrailingAboveDelta
+		}
+	}
+	d := decimal.NewFromInt(delta)
+	v.checkBound(f.Type, "trailingDelta", d, decimal.NewFromInt(int64(minDelta)).String(), FilterViolationBelowMin)
+	v.checkBound(f.Type, "trailingDelta", d, decimal.NewFromInt(int64(maxDelta)).String(), FilterViolationAboveMax)
+}
+
+func (v *filterValidator) checkRange(filter FilterType, field, value, minValue, maxValue, step string) {
+	d, err := decimal.NewFromString(value)
+	if err != nil || !d.IsPositive() {
+		v.add(FilterViolation{Filter: filter, Field: field, Value: value, Reason: FilterViolationInvalid})
+
+		return
+	}
+	v.checkBound(filter, field, d, minValue, FilterViolationBelowMin)
+	v.checkBound(filter, field, d, maxValue, FilterViolationAboveMax)
+
+	st, err := decimal.NewFromString(step)
+	if err != nil || st.IsZero() {
+		return
+	}
+	minD, err := decimal.NewFromString(minValue)
+	if err != nil {
+		minD = decimal.Zero
+	}
+	if !d.Sub(minD).Mod(st).IsZero() {
+		v.add(FilterViolation{Filter: filter, Field: field, Value: value, Limit: step, Reason: FilterViolationStep})
+	}
+}
+
+// checkBound compares value with the filter bound, zero or empty bounds are disabled
+func (v *filterValidator) checkBound(filter FilterType, field string, value decimal.Decimal, bound string, reason FilterViolationReason) {
+	b, err := decimal.NewFromString(bound)
+	if err != nil || b.IsZero() {
+		return
+	}
+	switch reason {
+	case FilterViolationBelowMin:
+		if !value.LessThan(b) {
+			return
+		}
+	case FilterViolationAboveMax:
+		if !value.GreaterThan(b) {
+			return
+		}
+	default:
+		return
+	}
+	v.add(FilterViolation{Filter: filter, Field: field, Value: value.String(), Limit: bound, Reason: reason})
+}
+
+func roundToStep(value, step string, mode RoundingMode) (string, error) {
+	st, err := decimal.NewFromString(step)
+	if err != nil || st.IsZero() {
+		return value, nil //nolint:nilerr // zero or missing step disables rounding
+	}
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		return "", err
+	}
+	n := d.DivRound(st, -st.Exponent()+8)
+	switch mode {
+	case RoundingModeUp:
+		n = n.RoundUp(0)
+	case RoundingModeNearest:
+		n = n.Round(0)
+	default:
+		n = n.RoundDown(0)
+	}
+
+	return n.Mul(st).String(), nil
+}
+
+func isZeroDecimal(s string) bool {
+	d, err := decimal.NewFromString(s)
+
+	return err != nil || d.IsZero()
+}
@@ -0,0 +1,138 @@
+package binance_test
+
+import (
+	"github.com/stretchr/testify/suite"
+
+	"github.com/xenking/binance-api"
+)
+
+type filtersTestSuite struct {
+	suite.Suite
+	symbol *binance.SymbolInfo
+}
+
+func (s *filtersTestSuite) SetupTest() {
+	s.symbol = &binance.SymbolInfo{
+		Symbol:            "LTCBTC",
+		Status:            binance.SymbolStatusTrading,
+		OrderTypes:        []binance.OrderType{binance.OrderTypeLimit, binance.OrderTypeMarket, binance.OrderTypeStopLossLimit},
+		IcebergAllowed:    true,
+		OCOAllowed:        true,
+		AllowTrailingStop: true,
+		Filters: []binance.SymbolInfoFilter{
+			{Type: binance.FilterTypePrice, MinPrice: "0.00000100", MaxPrice: "100.00000000", TickSize: "0.00000100"},
+			{Type: binance.FilterTypeLotSize, MinQty: "0.00100000", MaxQty: "100000.00000000", StepSize: "0.00100000"},
+			{Type: binance.FilterTypeMinNotional, MinNotional: "0.00010000", ApplyToMarket: true},
+			{Type: binance.FilterTypeIcebergParts, IcebergLimit: 10},
+			{
+				Type:                  binance.FilterTypeTrailingDelta,
+				MinTrailingAboveDelta: 10,
+				MaxTrailingAboveDelta: 2000,
+				MinTrailingBelowDelta: 10,
+				MaxTrailingBelowDelta: 2000,
+			},
+		},
+	}
+}
+
+func (s *filtersTestSuite) TestValidateOrder() {
+	err := s.symbol.ValidateOrder(&binance.OrderReq{
+		Symbol:   "LTCBTC",
+		Side:     binance.OrderSideBuy,
+		Type:     binance.OrderTypeLimit,
+		Quantity: "1.5",
+		Price:    "0.003",
+	})
+	s.Require().NoError(err)
+}
+
+func (s *filtersTestSuite) TestValidateOrderViolations() {
+	err := s.symbol.ValidateOrder(&binance.OrderReq{
+		Symbol:     "LTCBTC",
+		Side:       binance.OrderSideBuy,
+		Type:       binance.OrderTypeLimitMaker,
+		Quantity:   "0.0015",
+		Price:      "0.0000001",
+		IcebergQty: "0.0001",
+	})
+	s.Require().Error(err)
+
+	var violations binance.FilterViolations
+	s.Require().ErrorAs(err, &violations)
+
+	reasons := make(map[string][]binance.FilterViolationReason)
+	for _, v := range violations {
+		reasons[v.Field] = append(reasons[v.Field], v.Reason)
+	}
+	s.Require().Contains(reasons["type"], binance.FilterViolationNotAllowed)
+	s.Require().Contains(reasons["price"], binance.FilterViolationBelowMin)
+	s.Require().Contains(reasons["price"], binance.FilterViolationStep)
+	s.Require().Contains(reasons["quantity"], binance.FilterViolationStep)
+	s.Require().Contains(reasons["icebergQty"], binance.FilterViolationBelowMin)
+	s.Require().Contains(reasons["icebergQty"], binance.FilterViolationAboveMax)
+	s.Require().Contains(reasons["notional"], binance.FilterViolationBelowMin)
+}
+
+func (s *filtersTestSuite) TestValidateOrderTrailingDelta() {
+	err := s.symbol.ValidateOrder(&binance.OrderReq{
+		Symbol:        "LTCBTC",
+		Side:          binance.OrderSideSell,
+		Type:          binance.OrderTypeStopLossLimit,
+		Quantity:      "1",
+		Price:         "0.003",
+		TrailingDelta: 5000,
+	})
+	var violations binance.FilterViolations
+	s.Require().ErrorAs(err, &violations)
+	s.Require().Len(violations, 1)
+	s.Require().Equal(binance.FilterTypeTrailingDelta, violations[0].Filter)
+	s.Require().Equal(binance.FilterViolationAboveMax, violations[0].Reason)
+}
+
+func (s *filtersTestSuite) TestValidateOCO() {
+	req := &binance.OCOReq{
+		Symbol:         "LTCBTC",
+		Side:           binance.OrderSideSell,
+		Quantity:       "1",
+		Price:          "0.004",
+		StopPrice:      "0.002",
+		StopLimitPrice: "0.0019",
+	}
+	s.Require().NoError(s.symbol.ValidateOCO(req))
+
+	s.symbol.OCOAllowed = false
+	var violations binance.FilterViolations
+	s.Require().ErrorAs(s.symbol.ValidateOCO(req), &violations)
+	s.Require().Equal(binance.FilterViolationNotAllowed, violations[0].Reason)
+}
+
+func (s *filtersTestSuite) TestNormalizeOrder() {
+	req := &binance.OrderReq{
+		Symbol:    "LTCBTC",
+		Side:      binance.OrderSideBuy,
+		Type:      binance.OrderTypeStopLossLimit,
+		Quantity:  "1.23456",
+		Price:     "0.0031239",
+		StopPrice: "0.0031231",
+	}
+	s.Require().NoError(s.symbol.NormalizeOrder(req, binance.RoundingModeNearest, binance.RoundingModeDown))
+	s.Require().Equal("1.234", req.Quantity)
+	s.Require().Equal("0.003124", req.Price)
+	s.Require().Equal("0.003123", req.StopPrice)
+	s.Require().NoError(s.symbol.ValidateOrder(req))
+
+	price, err := s.symbol.NormalizePrice("0.0031231", binance.RoundingModeUp)
+	s.Require().NoError(err)
+	s.Require().Equal("0.003124", price)
+
+	_, err = s.symbol.NormalizeQuantity("abc", binance.RoundingModeDown, false)
+	s.Require().Error(err)
+}
+
+func (s *filtersTestSuite) TestExchangeInfoSymbol() {
+	info := &binance.ExchangeInfo{Symbols: []*binance.SymbolInfo{s.symbol}}
+	s.Require().Equal(s.symbol, info.Symbol("LTCBTC"))
+	s.Require().Nil(info.Symbol("ETHBTC"))
+	s.Require().NotNil(s.symbol.Filter(binance.FilterTypeLotSize))
+	s.Require().Nil(s.symbol.Filter(binance.FilterTypeMaxPosition))
+}
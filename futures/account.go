@@ -0,0 +1,147 @@
+package futures
+
+import (
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+)
+
+// Account get current account information with the assets and positions
+func (c *Client) Account() (*Account, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointAccount, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &Account{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// Balance get the balances of the margin assets
+func (c *Client) Balance() ([]*Balance, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointBalance, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*Balance
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// PositionRisk get the positions with their liquidation prices, all symbols when the symbol is not set
+func (c *Client) PositionRisk(req *PositionRiskReq) ([]*PositionRisk, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointPositionRisk, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*PositionRisk
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// ChangeLeverage changes the initial leverage of the symbol
+func (c *Client) ChangeLeverage(req *LeverageReq) (*Leverage, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	if req.Leverage < 1 || req.Leverage > MaxLeverage {
+		return nil, ErrInvalidLeverage
+	}
+	res, err := c.Do(fasthttp.MethodPost, EndpointLeverage, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &Leverage{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// ChangeMarginType changes the margin type of the symbol
+func (c *Client) ChangeMarginType(req *MarginTypeReq) error {
+	if req == nil {
+		return binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return binance.ErrEmptySymbol
+	}
+	if req.MarginType == "" {
+		return ErrEmptyMarginType
+	}
+	_, err := c.Do(fasthttp.MethodPost, EndpointMarginType, req, true, false)
+
+	return err
+}
+
+// PositionMode get the position mode of all symbols, the dual side position is the hedge mode
+func (c *Client) PositionMode() (*PositionMode, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointPositionMode, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &PositionMode{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// ChangePositionMode switches all symbols between the one-way and the hedge mode
+func (c *Client) ChangePositionMode(req *PositionModeReq) error {
+	if req == nil {
+		return binance.ErrNilRequest
+	}
+	_, err := c.Do(fasthttp.MethodPost, EndpointPositionMode, req, true, false)
+
+	return err
+}
+
+// Income get the income history: realized pnl, funding fees, commissions, transfers
+func (c *Client) Income(req *IncomeReq) ([]*Income, error) {
+	if req == nil {
+		req = &IncomeReq{}
+	}
+	if req.Limit < 0 || req.Limit > MaxIncomeLimit {
+		req.Limit = DefaultIncomeLimit
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointIncome, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*Income
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// DataStream starts a new user data stream
+func (c *Client) DataStream() (string, error) {
+	res, err := c.Do(fasthttp.MethodPost, EndpointDataStream, nil, false, true)
+	if err != nil {
+		return "", err
+	}
+	resp := &binance.DataStream{}
+	err = json.Unmarshal(res, resp)
+
+	return resp.ListenKey, err
+}
+
+// DataStreamKeepAlive pings the data stream key to prevent timeout
+func (c *Client) DataStreamKeepAlive(listenKey string) error {
+	_, err := c.Do(fasthttp.MethodPut, EndpointDataStream, binance.DataStream{ListenKey: listenKey}, false, true)
+
+	return err
+}
+
+// DataStreamClose closes the data stream key
+func (c *Client) DataStreamClose(listenKey string) error {
+	_, err := c.Do(fasthttp.MethodDelete, EndpointDataStream, binance.DataStream{ListenKey: listenKey}, false, true)
+
+	return err
+}
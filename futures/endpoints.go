@@ -0,0 +1,42 @@
+package futures
+
+// Endpoints with NONE security
+const (
+	EndpointPing             = "/fapi/v1/ping"
+	EndpointTime             = "/fapi/v1/time"
+	EndpointExchangeInfo     = "/fapi/v1/exchangeInfo"
+	EndpointDepth            = "/fapi/v1/depth"
+	EndpointKlines           = "/fapi/v1/klines"
+	EndpointContinuousKlines = "/fapi/v1/continuousKlines"
+	EndpointPremiumIndex     = "/fapi/v1/premiumIndex"
+	EndpointFundingRate      = "/fapi/v1/fundingRate"
+	EndpointOpenInterest     = "/fapi/v1/openInterest"
+)
+
+// Endpoints with SIGNED security
+const (
+	EndpointOrder         = "/fapi/v1/order"
+	EndpointOrderTest     = "/fapi/v1/order/test"
+	EndpointBatchOrders   = "/fapi/v1/batchOrders"
+	EndpointOpenOrders    = "/fapi/v1/openOrders"
+	EndpointAllOpenOrders = "/fapi/v1/allOpenOrders"
+	EndpointAccountTrades = "/fapi/v1/userTrades"
+	EndpointAccount       = "/fapi/v2/account"
+	EndpointBalance       = "/fapi/v2/balance"
+	EndpointPositionRisk  = "/fapi/v2/positionRisk"
+	EndpointLeverage      = "/fapi/v1/leverage"
+	EndpointMarginType    = "/fapi/v1/marginType"
+	EndpointPositionMode  = "/fapi/v1/positionSide/dual"
+	EndpointIncome        = "/fapi/v1/income"
+	EndpointDataStream    = "/fapi/v1/listenKey"
+)
+
+// Stream endpoints
+const (
+	EndpointMarkPriceStream            = "@markPrice"
+	EndpointAllMarketMarkPriceStream   = "!markPrice@arr"
+	EndpointLiquidationStream          = "@forceOrder"
+	EndpointAllMarketLiquidationStream = "!forceOrder@arr"
+	EndpointContinuousKlineStream      = "@continuousKline_"
+	EndpointCompositeIndexStream       = "@compositeIndex"
+)
@@ -0,0 +1,219 @@
+package futures
+
+import (
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+)
+
+// BaseHost for USD-M futures addresses
+const BaseHost = "fapi.binance.com"
+
+var (
+	ErrEmptyPair            = binance.ValidationError{Msg: "pair is not set"}
+	ErrEmptyContractType    = binance.ValidationError{Msg: "contract type is not set"}
+	ErrEmptyCallbackRate    = binance.ValidationError{Msg: "callback rate is not set"}
+	ErrEmptyMarginType      = binance.ValidationError{Msg: "margin type is not set"}
+	ErrInvalidLeverage      = binance.ValidationError{Msg: "leverage must be from 1 to 125"}
+	ErrInvalidClosePosition = binance.ValidationError{Msg: "close position is used with stop and take profit market orders without quantity and reduce only"}
+	ErrBatchSize            = binance.ValidationError{Msg: "batch must contain from 1 to 5 orders"}
+)
+
+// Client is the USD-M futures API client, the rest client signs the requests
+// and tracks the rate limits the same way as the spot one
+type Client struct {
+	binance.RestClient
+}
+
+// NewRestClient creates the rest client of the futures host with key and secret
+func NewRestClient(apikey, secret string) binance.RestClient {
+	return binance.NewCustomRestClient(binance.RestClientConfig{APIKey: apikey, APISecret: secret, Host: BaseHost})
+}
+
+// NewClient creates a new futures client with key and secret
+func NewClient(apikey, secret string) *Client {
+	return &Client{
+		RestClient: NewRestClient(apikey, secret),
+	}
+}
+
+func NewCustomClient(restClient binance.RestClient) *Client {
+	return &Client{
+		RestClient: restClient,
+	}
+}
+
+func (c *Client) ReqWindow(window int) *Client {
+	c.RestClient.SetWindow(window)
+
+	return c
+}
+
+// Time tests connectivity to the Rest API and get the current server time
+func (c *Client) Time() (*binance.ServerTime, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointTime, nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+	serverTime := &binance.ServerTime{}
+	err = json.Unmarshal(res, serverTime)
+
+	return serverTime, err
+}
+
+// Ping tests connectivity to the Rest API
+func (c *Client) Ping() error {
+	_, err := c.Do(fasthttp.MethodGet, EndpointPing, nil, false, false)
+
+	return err
+}
+
+// ExchangeInfo get current exchange trading rules and symbols information
+func (c *Client) ExchangeInfo() (*ExchangeInfo, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointExchangeInfo, nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ExchangeInfo{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// Depth retrieves the order book for the given symbol
+func (c *Client) Depth(req *binance.DepthReq) (*binance.Depth, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	if req.Limit < 0 || req.Limit > MaxDepthLimit {
+		req.Limit = DefaultDepthLimit
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointDepth, req, false, false)
+	if err != nil {
+		return nil, err
+	}
+	depth := &binance.Depth{}
+	err = json.Unmarshal(res, depth)
+
+	return depth, err
+}
+
+// Klines returns kline/candlestick bars for a symbol. Kline are uniquely identified by their open time
+func (c *Client) Klines(req *binance.KlinesReq) ([]*binance.Kline, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	if req.Interval == "" {
+		req.Interval = binance.KlineInterval5min
+	}
+	if req.Limit < 0 || req.Limit > MaxKlinesLimit {
+		req.Limit = DefaultKlinesLimit
+	}
+
+	return c.klines(EndpointKlines, req)
+}
+
+// ContinuousKlines returns kline/candlestick bars of the contract type of the pair spanning the contract rollovers
+func (c *Client) ContinuousKlines(req *ContinuousKlinesReq) ([]*binance.Kline, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Pair == "" {
+		return nil, ErrEmptyPair
+	}
+	if req.ContractType == "" {
+		return nil, ErrEmptyContractType
+	}
+	if req.Interval == "" {
+		req.Interval = binance.KlineInterval5min
+	}
+	if req.Limit < 0 || req.Limit > MaxKlinesLimit {
+		req.Limit = DefaultKlinesLimit
+	}
+
+	return c.klines(EndpointContinuousKlines, req)
+}
+
+func (c *Client) klines(endpoint string, req interface{}) ([]*binance.Kline, error) {
+	res, err := c.Do(fasthttp.MethodGet, endpoint, req, false, false)
+	if err != nil {
+		return nil, err
+	}
+	var klines []*binance.Kline
+	err = json.Unmarshal(res, &klines)
+
+	return klines, err
+}
+
+// MarkPrice returns the mark price and the funding rate of the symbol
+func (c *Client) MarkPrice(req *MarkPriceReq) (*MarkPrice, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointPremiumIndex, req, false, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &MarkPrice{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// MarkPrices returns the mark prices and the funding rates of all symbols
+func (c *Client) MarkPrices() ([]*MarkPrice, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointPremiumIndex, nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*MarkPrice
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// FundingRates returns the funding rate history in ascending order, all symbols when the symbol is not set
+func (c *Client) FundingRates(req *FundingRateReq) ([]*FundingRate, error) {
+	if req == nil {
+		req = &FundingRateReq{}
+	}
+	if req.Limit < 0 || req.Limit > MaxFundingRateLimit {
+		req.Limit = DefaultFundingRateLimit
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointFundingRate, req, false, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*FundingRate
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// OpenInterest returns the present open interest of the symbol
+func (c *Client) OpenInterest(req *OpenInterestReq) (*OpenInterest, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointOpenInterest, req, false, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &OpenInterest{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
@@ -0,0 +1,246 @@
+package futures_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-querystring/query"
+	"github.com/segmentio/encoding/json"
+	"github.com/stretchr/testify/suite"
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/binancetest"
+	"github.com/xenking/binance-api/futures"
+)
+
+func TestFutures(t *testing.T) {
+	suite.Run(t, new(futuresTestSuite))
+}
+
+type futuresTestSuite struct {
+	suite.Suite
+	client *futures.Client
+	mock   *binancetest.MockClient
+}
+
+func (s *futuresTestSuite) SetupTest() {
+	s.mock = &binancetest.MockClient{}
+	s.client = futures.NewCustomClient(s.mock)
+}
+
+func (s *futuresTestSuite) TestNewOrder() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(fasthttp.MethodPost, method)
+		s.Require().Equal(futures.EndpointOrder, endpoint)
+		s.Require().True(sign)
+		values, err := query.Values(data)
+		s.Require().NoError(err)
+		s.Require().Equal("true", values.Get("reduceOnly"))
+		s.Require().Equal("SHORT", values.Get("positionSide"))
+		s.Require().Equal("GTC", values.Get("timeInForce"))
+		s.Require().Equal("RESULT", values.Get("newOrderRespType"))
+		s.Require().False(values.Has("closePosition"))
+		return []byte(`{"symbol":"BTCUSDT","orderId":22542179,"status":"NEW","type":"LIMIT","side":"BUY","positionSide":"SHORT","reduceOnly":true,"origQty":"0.01","price":"30000","updateTime":1566818724722}`), nil
+	}
+	order, err := s.client.NewOrder(&futures.OrderReq{
+		Symbol: "BTCUSDT", Side: binance.OrderSideBuy, PositionSide: futures.PositionSideShort, Type: futures.OrderTypeLimit,
+		Quantity: "0.01", Price: "30000", ReduceOnly: true,
+	})
+	s.Require().NoError(err)
+	s.Require().EqualValues(22542179, order.OrderID)
+	s.Require().Equal(futures.PositionSideShort, order.PositionSide)
+	s.Require().True(order.ReduceOnly)
+
+	_, err = s.client.NewOrder(&futures.OrderReq{
+		Symbol: "BTCUSDT", Side: binance.OrderSideSell, Type: futures.OrderTypeStopMarket, StopPrice: "29000", ClosePosition: true, Quantity: "1",
+	})
+	s.Require().ErrorIs(err, futures.ErrInvalidClosePosition)
+	_, err = s.client.NewOrder(&futures.OrderReq{
+		Symbol: "BTCUSDT", Side: binance.OrderSideSell, Type: futures.OrderTypeTrailingStopMarket, Quantity: "1",
+	})
+	s.Require().ErrorIs(err, futures.ErrEmptyCallbackRate)
+}
+
+func (s *futuresTestSuite) TestBatchOrders() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(futures.EndpointBatchOrders, endpoint)
+		values, err := query.Values(data)
+		s.Require().NoError(err)
+		var orders []map[string]string
+		s.Require().NoError(json.Unmarshal([]byte(values.Get("batchOrders")), &orders))
+		s.Require().Len(orders, 2)
+		s.Require().Equal(map[string]string{
+			"symbol": "BTCUSDT", "side": "SELL", "type": "STOP_MARKET", "stopPrice": "29000",
+			"closePosition": "true", "newOrderRespType": "RESULT",
+		}, orders[1])
+		return []byte(`[{"symbol":"BTCUSDT","orderId":1,"status":"NEW"},{"code":-2021,"msg":"Order would immediately trigger."}]`), nil
+	}
+	resp, err := s.client.BatchOrders([]*futures.OrderReq{
+		{Symbol: "BTCUSDT", Side: binance.OrderSideBuy, Type: futures.OrderTypeMarket, Quantity: "0.01"},
+		{Symbol: "BTCUSDT", Side: binance.OrderSideSell, Type: futures.OrderTypeStopMarket, StopPrice: "29000", ClosePosition: true},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(resp, 2)
+	s.Require().EqualValues(1, resp[0].Order.OrderID)
+	s.Require().Nil(resp[0].Err)
+	s.Require().Nil(resp[1].Order)
+	s.Require().Equal(-2021, resp[1].Err.Code)
+
+	_, err = s.client.BatchOrders(make([]*futures.OrderReq, futures.MaxBatchOrders+1))
+	s.Require().ErrorIs(err, futures.ErrBatchSize)
+}
+
+func (s *futuresTestSuite) TestMarketData() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().False(sign)
+		switch endpoint {
+		case futures.EndpointContinuousKlines:
+			req := data.(*futures.ContinuousKlinesReq)
+			s.Require().Equal(futures.DefaultKlinesLimit, req.Limit)
+			return []byte(`[[1607444700000,"18879.99","18900.00","18878.98","18896.13","492.363",1607444759999,"9302145.66080",1874,"385.983","7292402.33267","0"]]`), nil
+		case futures.EndpointPremiumIndex:
+			return []byte(`{"symbol":"BTCUSDT","markPrice":"11793.63104562","indexPrice":"11781.80495970","lastFundingRate":"0.00038246","nextFundingTime":1597392000000,"time":1597370495002}`), nil
+		case futures.EndpointExchangeInfo:
+			return []byte(`{"symbols":[{"symbol":"BTCUSDT","contractType":"PERPETUAL","marginAsset":"USDT","filters":[{"filterType":"MIN_NOTIONAL","notional":"5"},{"filterType":"LOT_SIZE","stepSize":"0.001","minQty":"0.001"}]}]}`), nil
+		}
+		s.FailNow("unexpected endpoint " + endpoint)
+		return nil, nil
+	}
+	klines, err := s.client.ContinuousKlines(&futures.ContinuousKlinesReq{
+		Pair: "BTCUSDT", ContractType: futures.ContractTypePerpetual, Interval: binance.KlineInterval1min, Limit: 5000,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(klines, 1)
+	s.Require().Equal("18896.13", klines[0].ClosePrice.String())
+
+	mark, err := s.client.MarkPrice(&futures.MarkPriceReq{Symbol: "BTCUSDT"})
+	s.Require().NoError(err)
+	s.Require().Equal("0.00038246", mark.LastFundingRate)
+
+	info, err := s.client.ExchangeInfo()
+	s.Require().NoError(err)
+	filters := info.Symbols[0].Filters
+	s.Require().Equal("5", filters[0].Notional)
+	s.Require().Equal(binance.FilterTypeLotSize, filters[1].Type)
+	s.Require().Equal("0.001", filters[1].StepSize)
+
+	_, err = s.client.ContinuousKlines(&futures.ContinuousKlinesReq{Pair: "BTCUSDT"})
+	s.Require().ErrorIs(err, futures.ErrEmptyContractType)
+}
+
+func (s *futuresTestSuite) TestAccount() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().True(sign)
+		switch endpoint {
+		case futures.EndpointLeverage:
+			return []byte(`{"leverage":21,"maxNotionalValue":"1000000","symbol":"BTCUSDT"}`), nil
+		case futures.EndpointPositionMode:
+			values, err := query.Values(data)
+			s.Require().NoError(err)
+			s.Require().Equal("false", values.Get("dualSidePosition"))
+			return []byte(`{"code":200,"msg":"success"}`), nil
+		case futures.EndpointIncome:
+			s.Require().Equal(futures.IncomeTypeFundingFee, data.(*futures.IncomeReq).IncomeType)
+			return []byte(`[{"symbol":"BTCUSDT","incomeType":"FUNDING_FEE","income":"-0.37500000","asset":"USDT","time":1570636800000,"tranId":9689322392,"tradeId":""}]`), nil
+		}
+		s.FailNow("unexpected endpoint " + endpoint)
+		return nil, nil
+	}
+	leverage, err := s.client.ChangeLeverage(&futures.LeverageReq{Symbol: "BTCUSDT", Leverage: 21})
+	s.Require().NoError(err)
+	s.Require().Equal(21, leverage.Leverage)
+	_, err = s.client.ChangeLeverage(&futures.LeverageReq{Symbol: "BTCUSDT", Leverage: 200})
+	s.Require().ErrorIs(err, futures.ErrInvalidLeverage)
+
+	s.Require().NoError(s.client.ChangePositionMode(&futures.PositionModeReq{}))
+	s.Require().ErrorIs(s.client.ChangeMarginType(&futures.MarginTypeReq{Symbol: "BTCUSDT"}), futures.ErrEmptyMarginType)
+
+	income, err := s.client.Income(&futures.IncomeReq{IncomeType: futures.IncomeTypeFundingFee})
+	s.Require().NoError(err)
+	s.Require().Len(income, 1)
+	s.Require().Equal("-0.37500000", income[0].Income)
+}
+
+func (s *futuresTestSuite) TestStreams() {
+	server := binancetest.NewServer(binancetest.Config{})
+	defer server.Close()
+	client := futures.NewCustomStreamClient(server.StreamURL(), nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	s.Require().NoError(server.Publish("btcusdt@markPrice@1s",
+		[]byte(`{"e":"markPriceUpdate","E":1562305380000,"s":"BTCUSDT","p":"11794.15000000","i":"11784.62659091","P":"11784.25641265","r":"0.00038167","T":1562306400000}`)))
+	mark, err := client.MarkPrice(ctx, "BTCUSDT", futures.MarkPriceFrequency1s)
+	s.Require().NoError(err)
+	defer mark.Close()
+	u, err := mark.Read()
+	s.Require().NoError(err)
+	s.Require().Equal(futures.UpdateTypeMarkPrice, u.EventType)
+	s.Require().Equal("0.00038167", u.FundingRate)
+	s.Require().EqualValues(1562306400000, u.NextFundingTime)
+
+	s.Require().NoError(server.Publish("btcusdt_perpetual@continuousKline_1m",
+		[]byte(`{"e":"continuous_kline","E":1607443058651,"ps":"BTCUSDT","ct":"PERPETUAL","k":{"t":1607443020000,"T":1607443079999,"i":"1m","o":"18787.00","c":"18804.04","h":"18804.04","l":"18786.54","v":"197.664","n":543,"x":false}}`)))
+	klines, err := client.ContinuousKlines(ctx, "BTCUSDT", futures.ContractTypePerpetual, binance.KlineInterval1min)
+	s.Require().NoError(err)
+	defer klines.Close()
+	k := <-klines.Stream()
+	s.Require().Equal(futures.ContractTypePerpetual, k.ContractType)
+	s.Require().Equal("18804.04", k.Kline.ClosePrice)
+	s.Require().False(k.Kline.Final)
+
+	s.Require().NoError(server.Publish("!forceOrder@arr",
+		[]byte(`{"e":"forceOrder","E":1568014460893,"o":{"s":"BTCUSDT","S":"SELL","o":"LIMIT","f":"IOC","q":"0.014","p":"9910","ap":"9910","X":"FILLED","l":"0.014","z":"0.014","T":1568014460893}}`)))
+	liquidations, err := client.AllMarketLiquidations(ctx)
+	s.Require().NoError(err)
+	defer liquidations.Close()
+	l, err := liquidations.Read()
+	s.Require().NoError(err)
+	s.Require().Equal(binance.OrderSideSell, l.Order.Side)
+	s.Require().Equal("9910", l.Order.AvgPrice)
+
+	for _, event := range []string{
+		`{"e":"ACCOUNT_UPDATE","E":1564745798939,"T":1564745798938,"a":{"m":"ORDER","B":[{"a":"USDT","wb":"122624.12345678","cw":"100.12345678","bc":"50.12345678"}],"P":[{"s":"BTCUSDT","pa":"0","ep":"0.00000","cr":"200","up":"0","mt":"isolated","iw":"0.00000000","ps":"BOTH"}]}}`,
+		`{"e":"ORDER_TRADE_UPDATE","E":1568879465651,"T":1568879465650,"o":{"s":"BTCUSDT","c":"TEST","S":"SELL","o":"TRAILING_STOP_MARKET","f":"GTC","q":"0.001","p":"0","ap":"0","sp":"7103.04","x":"NEW","X":"NEW","i":8886774,"l":"0","z":"0","L":"0","T":1568879465650,"t":0,"m":false,"R":false,"wt":"CONTRACT_PRICE","ot":"TRAILING_STOP_MARKET","ps":"LONG","cp":false,"AP":"7476.89","cr":"5.0","rp":"0"}}`,
+		`{"e":"MARGIN_CALL","E":1587727187525,"cw":"3.16812045","p":[{"s":"ETHUSDT","ps":"LONG","pa":"1.327","mt":"CROSSED","iw":"0","mp":"187.17127","up":"-1.166074","mm":"1.614445"}]}`,
+		`{"e":"ACCOUNT_CONFIG_UPDATE","E":1611646737479,"T":1611646737476,"ac":{"s":"BTCUSDT","l":25}}`,
+		`{"e":"listenKeyExpired","E":1576653824250}`,
+	} {
+		s.Require().NoError(server.Publish("listenkey", []byte(event)))
+	}
+	userData, err := client.UserData(ctx, "listenkey")
+	s.Require().NoError(err)
+	defer userData.Close()
+
+	et, event, err := userData.Read()
+	s.Require().NoError(err)
+	s.Require().Equal(futures.UpdateTypeAccount, et)
+	account := event.(*futures.AccountUpdateEvent)
+	s.Require().Equal(futures.AccountUpdateReasonOrder, account.Update.Reason)
+	s.Require().Equal("50.12345678", account.Update.Balances[0].BalanceChange)
+	s.Require().Equal("200", account.Update.Positions[0].AccumulatedPnL)
+
+	_, event, err = userData.Read()
+	s.Require().NoError(err)
+	order := event.(*futures.OrderTradeUpdateEvent)
+	s.Require().Equal(futures.OrderTypeTrailingStopMarket, order.Order.Type)
+	s.Require().Equal(futures.PositionSideLong, order.Order.PositionSide)
+	s.Require().Equal("5.0", order.Order.CallbackRate)
+
+	_, event, err = userData.Read()
+	s.Require().NoError(err)
+	s.Require().Equal("1.614445", event.(*futures.MarginCallEvent).Positions[0].MaintenanceMargin)
+
+	_, event, err = userData.Read()
+	s.Require().NoError(err)
+	config := event.(*futures.AccountConfigUpdateEvent)
+	s.Require().Equal(25, config.Leverage.Leverage)
+	s.Require().Nil(config.MultiAssets)
+
+	et, event, err = userData.Read()
+	s.Require().NoError(err)
+	s.Require().Equal(futures.UpdateTypeListenKeyExpired, et)
+	s.Require().IsType([]byte{}, event)
+}
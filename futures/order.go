@@ -0,0 +1,208 @@
+package futures
+
+import (
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+)
+
+// NewOrder sends in a new order and returns the created order
+func (c *Client) NewOrder(req *OrderReq) (*Order, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	req.OrderRespType = OrderRespTypeResult
+	res, err := c.Do(fasthttp.MethodPost, EndpointOrder, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &Order{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// NewOrderTest tests new order creation and signature/recvWindow long without sending it into the matching engine
+func (c *Client) NewOrderTest(req *OrderReq) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+	_, err := c.Do(fasthttp.MethodPost, EndpointOrderTest, req, true, false)
+
+	return err
+}
+
+// BatchOrders sends in up to 5 orders at once, the orders are placed independently
+// and the results keep the order of the requests
+func (c *Client) BatchOrders(reqs []*OrderReq) ([]*BatchOrder, error) {
+	if len(reqs) == 0 || len(reqs) > MaxBatchOrders {
+		return nil, ErrBatchSize
+	}
+	for _, req := range reqs {
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+		req.OrderRespType = OrderRespTypeResult
+	}
+	batch, err := json.Marshal(reqs)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.Do(fasthttp.MethodPost, EndpointBatchOrders, &batchOrdersReq{BatchOrders: string(batch)}, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*BatchOrder
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// QueryOrder checks an order's status
+func (c *Client) QueryOrder(req *QueryOrderReq) (*Order, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	if req.OrderID == 0 && req.OrigClientOrderID == "" {
+		return nil, binance.ErrEmptyOrderID
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointOrder, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &Order{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// CancelOrder cancel an active order
+func (c *Client) CancelOrder(req *CancelOrderReq) (*Order, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	if req.OrderID == 0 && req.OrigClientOrderID == "" {
+		return nil, binance.ErrEmptyOrderID
+	}
+	res, err := c.Do(fasthttp.MethodDelete, EndpointOrder, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &Order{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// CancelOpenOrders cancel all open orders on a symbol
+func (c *Client) CancelOpenOrders(req *CancelOpenOrdersReq) error {
+	if req == nil {
+		return binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return binance.ErrEmptySymbol
+	}
+	_, err := c.Do(fasthttp.MethodDelete, EndpointAllOpenOrders, req, true, false)
+
+	return err
+}
+
+// OpenOrders get all open orders on a symbol or all symbols when the symbol is not set
+func (c *Client) OpenOrders(req *OpenOrdersReq) ([]*Order, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointOpenOrders, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*Order
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// AccountTrades get trades for a specific account and symbol
+func (c *Client) AccountTrades(req *AccountTradesReq) ([]*AccountTrade, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	if req.Limit < 0 || req.Limit > MaxAccountTradesLimit {
+		req.Limit = DefaultAccountTradesLimit
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointAccountTrades, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*AccountTrade
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// Validate checks the required parameters of the order type and sets the default time in force of limit orders
+func (req *OrderReq) Validate() error {
+	switch {
+	case req == nil:
+		return binance.ErrNilRequest
+	case req.Symbol == "":
+		return binance.ErrEmptySymbol
+	case req.Side == "":
+		return binance.ErrEmptySide
+	}
+	if req.ClosePosition {
+		if req.Type != OrderTypeStopMarket && req.Type != OrderTypeTakeProfitMarket || req.Quantity != "" || req.ReduceOnly {
+			return ErrInvalidClosePosition
+		}
+	}
+
+	switch req.Type {
+	case OrderTypeLimit:
+		switch {
+		case req.Price == "":
+			return binance.ErrEmptyPrice
+		case req.Quantity == "":
+			return binance.ErrEmptyQuantity
+		case req.TimeInForce == "":
+			req.TimeInForce = binance.TimeInForceGTC
+		}
+	case OrderTypeMarket:
+		if req.Quantity == "" {
+			return binance.ErrEmptyQuantity
+		}
+	case OrderTypeStop, OrderTypeTakeProfit:
+		switch {
+		case req.Quantity == "":
+			return binance.ErrEmptyQuantity
+		case req.Price == "":
+			return binance.ErrEmptyPrice
+		case req.StopPrice == "":
+			return binance.ErrEmptyStopPrice
+		}
+	case OrderTypeStopMarket, OrderTypeTakeProfitMarket:
+		switch {
+		case req.Quantity == "" && !req.ClosePosition:
+			return binance.ErrEmptyQuantity
+		case req.StopPrice == "":
+			return binance.ErrEmptyStopPrice
+		}
+	case OrderTypeTrailingStopMarket:
+		switch {
+		case req.Quantity == "":
+			return binance.ErrEmptyQuantity
+		case req.CallbackRate == "":
+			return ErrEmptyCallbackRate
+		}
+	default:
+		return binance.ErrInvalidOrderType
+	}
+
+	return nil
+}
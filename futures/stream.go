@@ -0,0 +1,323 @@
+package futures
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/segmentio/encoding/json"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/ws"
+)
+
+const DefaultStreamPath = "wss://fstream.binance.com/ws/"
+
+// StreamClient opens the futures streams. The depth, ticker, kline and trade streams
+// are served in the spot format and opened with the embedded spot client methods
+type StreamClient struct {
+	*ws.Client
+}
+
+func NewStreamClient() *StreamClient {
+	return &StreamClient{ws.NewCustomClient(DefaultStreamPath, nil)}
+}
+
+func NewCustomStreamClient(prefix string, conn net.Conn) *StreamClient {
+	return &StreamClient{ws.NewCustomClient(prefix, conn)}
+}
+
+// MarkPrice opens websocket with the mark price and funding rate updates for the given symbol
+func (c *StreamClient) MarkPrice(ctx context.Context, symbol string, frequency MarkPriceFrequency) (*MarkPriceStream, error) {
+	conn, err := c.Dial(ctx, strings.ToLower(symbol)+EndpointMarkPriceStream+string(frequency))
+	if err != nil {
+		return nil, err
+	}
+
+	return &MarkPriceStream{conn}, nil
+}
+
+// AllMarketMarkPrices opens websocket with the mark price and funding rate updates for all symbols
+func (c *StreamClient) AllMarketMarkPrices(ctx context.Context, frequency MarkPriceFrequency) (*AllMarketMarkPriceStream, error) {
+	conn, err := c.Dial(ctx, EndpointAllMarketMarkPriceStream+string(frequency))
+	if err != nil {
+		return nil, err
+	}
+
+	return &AllMarketMarkPriceStream{conn}, nil
+}
+
+// Liquidations opens websocket with the liquidation orders for the given symbol
+func (c *StreamClient) Liquidations(ctx context.Context, symbol string) (*LiquidationStream, error) {
+	conn, err := c.Dial(ctx, strings.ToLower(symbol)+EndpointLiquidationStream)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LiquidationStream{conn}, nil
+}
+
+// AllMarketLiquidations opens websocket with the liquidation orders for all symbols
+func (c *StreamClient) AllMarketLiquidations(ctx context.Context) (*LiquidationStream, error) {
+	conn, err := c.Dial(ctx, EndpointAllMarketLiquidationStream)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LiquidationStream{conn}, nil
+}
+
+// ContinuousKlines opens websocket with klines updates for the contract type of the pair with the given interval
+func (c *StreamClient) ContinuousKlines(ctx context.Context, pair string, contractType ContractType, interval binance.KlineInterval) (*ContinuousKlineStream, error) {
+	path := strings.ToLower(pair + "_" + string(contractType))
+	conn, err := c.Dial(ctx, path+EndpointContinuousKlineStream+string(interval))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ContinuousKlineStream{conn}, nil
+}
+
+// CompositeIndex opens websocket with the composite index updates for the given index symbol
+func (c *StreamClient) CompositeIndex(ctx context.Context, symbol string) (*CompositeIndexStream, error) {
+	conn, err := c.Dial(ctx, strings.ToLower(symbol)+EndpointCompositeIndexStream)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CompositeIndexStream{conn}, nil
+}
+
+// UserData opens websocket with the futures account, order and margin call updates
+func (c *StreamClient) UserData(ctx context.Context, listenKey string) (*UserDataStream, error) {
+	conn, err := c.Dial(ctx, listenKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserDataStream{conn}, nil
+}
+
+// MarkPriceStream is a wrapper for mark price websocket
+type MarkPriceStream struct {
+	ws.Conn
+}
+
+// Read reads a mark price update message from mark price websocket
+func (s *MarkPriceStream) Read() (*MarkPriceUpdate, error) {
+	r := &MarkPriceUpdate{}
+	err := s.Conn.ReadValue(r)
+
+	return r, err
+}
+
+// Stream NewStream a mark price update message from mark price websocket to channel
+func (s *MarkPriceStream) Stream() <-chan *MarkPriceUpdate {
+	updates := make(chan *MarkPriceUpdate)
+	go s.NewStream(func(dec *json.Decoder, err error) error {
+		if err != nil {
+			close(updates)
+			return err
+		}
+
+		u := &MarkPriceUpdate{}
+		err = dec.Decode(u)
+		if err != nil {
+			close(updates)
+			return err
+		}
+		updates <- u
+
+		return nil
+	})
+
+	return updates
+}
+
+// AllMarketMarkPriceStream is a wrapper for all markets mark price websocket
+type AllMarketMarkPriceStream struct {
+	ws.Conn
+}
+
+// Read reads a mark prices update message from all markets mark price websocket
+func (s *AllMarketMarkPriceStream) Read() (AllMarketMarkPriceUpdate, error) {
+	var r AllMarketMarkPriceUpdate
+	err := s.Conn.ReadValue(&r)
+
+	return r, err
+}
+
+// Stream NewStream a mark prices update message from all markets mark price websocket to channel
+func (s *AllMarketMarkPriceStream) Stream() <-chan AllMarketMarkPriceUpdate {
+	updates := make(chan AllMarketMarkPriceUpdate)
+	go s.NewStream(func(dec *json.Decoder, err error) error {
+		if err != nil {
+			close(updates)
+			return err
+		}
+
+		var u AllMarketMarkPriceUpdate
+		err = dec.Decode(&u)
+		if err != nil {
+			close(updates)
+			return err
+		}
+		updates <- u
+
+		return nil
+	})
+
+	return updates
+}
+
+// LiquidationStream is a wrapper for liquidation orders websocket
+type LiquidationStream struct {
+	ws.Conn
+}
+
+// Read reads a liquidation order message from liquidation orders websocket
+func (s *LiquidationStream) Read() (*LiquidationUpdate, error) {
+	r := &LiquidationUpdate{}
+	err := s.Conn.ReadValue(r)
+
+	return r, err
+}
+
+// Stream NewStream a liquidation order message from liquidation orders websocket to channel
+func (s *LiquidationStream) Stream() <-chan *LiquidationUpdate {
+	updates := make(chan *LiquidationUpdate)
+	go s.NewStream(func(dec *json.Decoder, err error) error {
+		if err != nil {
+			close(updates)
+			return err
+		}
+
+		u := &LiquidationUpdate{}
+		err = dec.Decode(u)
+		if err != nil {
+			close(updates)
+			return err
+		}
+		updates <- u
+
+		return nil
+	})
+
+	return updates
+}
+
+// ContinuousKlineStream is a wrapper for continuous contract klines websocket
+type ContinuousKlineStream struct {
+	ws.Conn
+}
+
+// Read reads a kline update message from continuous contract klines websocket
+func (s *ContinuousKlineStream) Read() (*ContinuousKlineUpdate, error) {
+	r := &ContinuousKlineUpdate{}
+	err := s.Conn.ReadValue(r)
+
+	return r, err
+}
+
+// Stream NewStream a kline update message from continuous contract klines websocket to channel
+func (s *ContinuousKlineStream) Stream() <-chan *ContinuousKlineUpdate {
+	updates := make(chan *ContinuousKlineUpdate)
+	go s.NewStream(func(dec *json.Decoder, err error) error {
+		if err != nil {
+			close(updates)
+			return err
+		}
+
+		u := &ContinuousKlineUpdate{}
+		err = dec.Decode(u)
+		if err != nil {
+			close(updates)
+			return err
+		}
+		updates <- u
+
+		return nil
+	})
+
+	return updates
+}
+
+// CompositeIndexStream is a wrapper for composite index websocket
+type CompositeIndexStream struct {
+	ws.Conn
+}
+
+// Read reads a composite index update message from composite index websocket
+func (s *CompositeIndexStream) Read() (*CompositeIndexUpdate, error) {
+	r := &CompositeIndexUpdate{}
+	err := s.Conn.ReadValue(r)
+
+	return r, err
+}
+
+// Stream NewStream a composite index update message from composite index websocket to channel
+func (s *CompositeIndexStream) Stream() <-chan *CompositeIndexUpdate {
+	updates := make(chan *CompositeIndexUpdate)
+	go s.NewStream(func(dec *json.Decoder, err error) error {
+		if err != nil {
+			close(updates)
+			return err
+		}
+
+		u := &CompositeIndexUpdate{}
+		err = dec.Decode(u)
+		if err != nil {
+			close(updates)
+			return err
+		}
+		updates <- u
+
+		return nil
+	})
+
+	return updates
+}
+
+// UserDataStream is a wrapper for futures user data websocket
+type UserDataStream struct {
+	ws.Conn
+}
+
+// Read reads a user data message from user data websocket.
+// The event is one of AccountUpdateEvent, OrderTradeUpdateEvent, MarginCallEvent and AccountConfigUpdateEvent,
+// the messages of other types, e.g. listenKeyExpired, are returned as raw bytes
+func (s *UserDataStream) Read() (UpdateType, interface{}, error) {
+	payload, err := s.Conn.ReadRaw()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return decodeUserDataEvent(payload)
+}
+
+// Stream NewStream a user data message from user data websocket to channel,
+// the values are the same as returned by Read
+func (s *UserDataStream) Stream() <-chan interface{} {
+	updates := make(chan interface{})
+	go s.NewStreamRaw(func(payload []byte, err error) error {
+		if err != nil {
+			close(updates)
+			return err
+		}
+		// control frames are handled by the reader
+		if len(payload) == 0 {
+			return nil
+		}
+
+		_, u, err := decodeUserDataEvent(payload)
+		if err != nil {
+			close(updates)
+			return err
+		}
+		updates <- u
+
+		return nil
+	})
+
+	return updates
+}
@@ -0,0 +1,264 @@
+package futures
+
+import (
+	"github.com/segmentio/encoding/json"
+
+	"github.com/xenking/binance-api"
+)
+
+// UpdateType represents type of the futures stream event
+type UpdateType string
+
+const (
+	UpdateTypeMarkPrice        UpdateType = "markPriceUpdate"
+	UpdateTypeLiquidation      UpdateType = "forceOrder"
+	UpdateTypeContinuousKline  UpdateType = "continuous_kline"
+	UpdateTypeCompositeIndex   UpdateType = "compositeIndex"
+	UpdateTypeAccount          UpdateType = "ACCOUNT_UPDATE"
+	UpdateTypeOrderTrade       UpdateType = "ORDER_TRADE_UPDATE"
+	UpdateTypeMarginCall       UpdateType = "MARGIN_CALL"
+	UpdateTypeAccountConfig    UpdateType = "ACCOUNT_CONFIG_UPDATE"
+	UpdateTypeListenKeyExpired UpdateType = "listenKeyExpired"
+)
+
+// MarkPriceFrequency is the interval of the mark price updates
+type MarkPriceFrequency string
+
+const (
+	MarkPriceFrequency3s MarkPriceFrequency = ""    // MarkPriceFrequency3s is default frequency
+	MarkPriceFrequency1s MarkPriceFrequency = "@1s" // MarkPriceFrequency1s for fastest updates
+)
+
+// MarkPriceUpdate represents the mark price with the funding rate of the symbol
+type MarkPriceUpdate struct {
+	EventType            UpdateType `json:"e"` // EventType represents the update type
+	Time                 int64      `json:"E"` // Time represents the event time
+	Symbol               string     `json:"s"` // Symbol represents the symbol related to the update
+	MarkPrice            string     `json:"p"` // MarkPrice is the mark price
+	IndexPrice           string     `json:"i"` // IndexPrice is the index price
+	EstimatedSettlePrice string     `json:"P"` // EstimatedSettlePrice is only useful in the last hour before the settlement starts
+	FundingRate          string     `json:"r"` // FundingRate is the current funding rate
+	NextFundingTime      int64      `json:"T"` // NextFundingTime is the time of the next funding
+}
+
+// AllMarketMarkPriceUpdate represents the mark prices of all symbols
+type AllMarketMarkPriceUpdate []*MarkPriceUpdate
+
+// LiquidationUpdate represents the liquidation order of the symbol
+type LiquidationUpdate struct {
+	EventType UpdateType `json:"e"` // EventType represents the update type
+	Time      int64      `json:"E"` // Time represents the event time
+	Order     struct {
+		Symbol      string              `json:"s"`  // Symbol represents the symbol related to the order
+		Side        binance.OrderSide   `json:"S"`  // Side is the side of the liquidation order
+		Type        OrderType           `json:"o"`  // Type is the order type
+		TimeInForce binance.TimeInForce `json:"f"`  // TimeInForce is the order time in force
+		OrigQty     string              `json:"q"`  // OrigQty is the original quantity
+		Price       string              `json:"p"`  // Price is the order price
+		AvgPrice    string              `json:"ap"` // AvgPrice is the average filled price
+		Status      binance.OrderStatus `json:"X"`  // Status is the order status
+		LastQty     string              `json:"l"`  // LastQty is the last filled quantity
+		FilledQty   string              `json:"z"`  // FilledQty is the accumulated filled quantity
+		TradeTime   int64               `json:"T"`  // TradeTime is the order trade time
+	} `json:"o"` // Order is the liquidation order
+}
+
+// ContinuousKlineUpdate represents the kline of the contract type of the pair
+type ContinuousKlineUpdate struct {
+	EventType    UpdateType   `json:"e"`  // EventType represents the update type
+	Time         int64        `json:"E"`  // Time represents the event time
+	Pair         string       `json:"ps"` // Pair represents the pair related to the update
+	ContractType ContractType `json:"ct"` // ContractType is the contract type of the kline
+	Kline        StreamKline  `json:"k"`  // Kline is the kline update
+}
+
+// StreamKline is the kline of the futures streams
+type StreamKline struct {
+	StartTime    int64                 `json:"t"` // StartTime is the start time of this bar
+	EndTime      int64                 `json:"T"` // EndTime is the end time of this bar
+	Interval     binance.KlineInterval `json:"i"` // Interval is the kline interval
+	FirstTradeID int64                 `json:"f"` // FirstTradeID is the first update ID
+	LastTradeID  int64                 `json:"L"` // LastTradeID is the last update ID
+
+	OpenPrice            string `json:"o"` // OpenPrice represents the open price for this bar
+	ClosePrice           string `json:"c"` // ClosePrice represents the close price for this bar
+	High                 string `json:"h"` // High represents the highest price for this bar
+	Low                  string `json:"l"` // Low represents the lowest price for this bar
+	Volume               string `json:"v"` // Volume is the trades volume for this bar
+	Trades               int    `json:"n"` // Trades is the number of conducted trades
+	Final                bool   `json:"x"` // Final indicates whether this bar is final or yet may receive updates
+	VolumeQuote          string `json:"q"` // VolumeQuote indicates the quote volume for the symbol
+	VolumeActiveBuy      string `json:"V"` // VolumeActiveBuy represents the volume of active buy
+	VolumeQuoteActiveBuy string `json:"Q"` // VolumeQuoteActiveBuy represents the quote volume of active buy
+}
+
+// CompositeIndexUpdate represents the price of the composite index and its components
+type CompositeIndexUpdate struct {
+	EventType  UpdateType `json:"e"` // EventType represents the update type
+	Time       int64      `json:"E"` // Time represents the event time
+	Symbol     string     `json:"s"` // Symbol represents the index symbol
+	Price      string     `json:"p"` // Price is the index price
+	BaseAsset  string     `json:"C"` // BaseAsset is the base asset of the index
+	Components []struct {
+		BaseAsset      string `json:"b"` // BaseAsset is the base asset of the component
+		QuoteAsset     string `json:"q"` // QuoteAsset is the quote asset of the component
+		WeightQuantity string `json:"w"` // WeightQuantity is the weight in quantity
+		WeightPercent  string `json:"W"` // WeightPercent is the weight in percentage
+		IndexPrice     string `json:"i"` // IndexPrice is the index price of the component
+	} `json:"c"` // Components are the index components
+}
+
+// UpdateEventType is the event type of the user data stream message
+type UpdateEventType struct {
+	EventType UpdateType `json:"e"` // EventType represents the update type
+	Time      int64      `json:"E"` // Time keeps the event time from matching the case-insensitive type field
+}
+
+// AccountUpdateReason is the reason of the balance and position change
+type AccountUpdateReason string
+
+const (
+	AccountUpdateReasonDeposit             AccountUpdateReason = "DEPOSIT"
+	AccountUpdateReasonWithdraw            AccountUpdateReason = "WITHDRAW"
+	AccountUpdateReasonOrder               AccountUpdateReason = "ORDER"
+	AccountUpdateReasonFundingFee          AccountUpdateReason = "FUNDING_FEE"
+	AccountUpdateReasonWithdrawReject      AccountUpdateReason = "WITHDRAW_REJECT"
+	AccountUpdateReasonAdjustment          AccountUpdateReason = "ADJUSTMENT"
+	AccountUpdateReasonInsuranceClear      AccountUpdateReason = "INSURANCE_CLEAR"
+	AccountUpdateReasonAdminDeposit        AccountUpdateReason = "ADMIN_DEPOSIT"
+	AccountUpdateReasonAdminWithdraw       AccountUpdateReason = "ADMIN_WITHDRAW"
+	AccountUpdateReasonMarginTransfer      AccountUpdateReason = "MARGIN_TRANSFER"
+	AccountUpdateReasonMarginTypeChange    AccountUpdateReason = "MARGIN_TYPE_CHANGE"
+	AccountUpdateReasonAssetTransfer       AccountUpdateReason = "ASSET_TRANSFER"
+	AccountUpdateReasonOptionsPremiumFee   AccountUpdateReason = "OPTIONS_PREMIUM_FEE"
+	AccountUpdateReasonOptionsSettleProfit AccountUpdateReason = "OPTIONS_SETTLE_PROFIT"
+	AccountUpdateReasonAutoExchange        AccountUpdateReason = "AUTO_EXCHANGE"
+)
+
+// AccountUpdateEvent represents the balances and positions changed by the event
+type AccountUpdateEvent struct {
+	EventType       UpdateType `json:"e"` // EventType represents the update type
+	Time            int64      `json:"E"` // Time represents the event time
+	TransactionTime int64      `json:"T"` // TransactionTime is the time of the change
+	Update          struct {
+		Reason   AccountUpdateReason `json:"m"` // Reason is the reason of the change
+		Balances []struct {
+			Asset              string `json:"a"`  // Asset is the balance asset
+			WalletBalance      string `json:"wb"` // WalletBalance is the wallet balance
+			CrossWalletBalance string `json:"cw"` // CrossWalletBalance is the wallet balance excluding the isolated margin
+			BalanceChange      string `json:"bc"` // BalanceChange is the change except pnl and commission
+		} `json:"B"` // Balances are the changed balances
+		Positions []struct {
+			Symbol         string       `json:"s"`   // Symbol is the position symbol
+			Amount         string       `json:"pa"`  // Amount is the position amount
+			EntryPrice     string       `json:"ep"`  // EntryPrice is the entry price
+			BreakEvenPrice string       `json:"bep"` // BreakEvenPrice is the breakeven price
+			AccumulatedPnL string       `json:"cr"`  // AccumulatedPnL is the pre-fee accumulated realized
+			UnrealizedPnL  string       `json:"up"`  // UnrealizedPnL is the unrealized pnl
+			MarginType     string       `json:"mt"`  // MarginType is lowercase: isolated or cross
+			IsolatedWallet string       `json:"iw"`  // IsolatedWallet is the isolated wallet if isolated position
+			PositionSide   PositionSide `json:"ps"`  // PositionSide is the position side
+		} `json:"P"` // Positions are the changed positions
+	} `json:"a"` // Update is the account update
+}
+
+// OrderTradeUpdateEvent represents the order change or the trade
+type OrderTradeUpdateEvent struct {
+	EventType       UpdateType `json:"e"` // EventType represents the update type
+	Time            int64      `json:"E"` // Time represents the event time
+	TransactionTime int64      `json:"T"` // TransactionTime is the time of the change
+	Order           struct {
+		Symbol                  string                          `json:"s"`   // Symbol represents the symbol related to the order
+		ClientOrderID           string                          `json:"c"`   // ClientOrderID is the client order id
+		Side                    binance.OrderSide               `json:"S"`   // Side is the order side
+		Type                    OrderType                       `json:"o"`   // Type is the order type
+		TimeInForce             binance.TimeInForce             `json:"f"`   // TimeInForce is the order time in force
+		OrigQty                 string                          `json:"q"`   // OrigQty is the original quantity
+		Price                   string                          `json:"p"`   // Price is the order price
+		AvgPrice                string                          `json:"ap"`  // AvgPrice is the average filled price
+		StopPrice               string                          `json:"sp"`  // StopPrice is the stop price
+		ExecutionType           binance.OrderStatus             `json:"x"`   // ExecutionType is the execution type
+		Status                  binance.OrderStatus             `json:"X"`   // Status is the order status
+		OrderID                 int64                           `json:"i"`   // OrderID is the order id
+		LastQty                 string                          `json:"l"`   // LastQty is the last filled quantity
+		FilledQty               string                          `json:"z"`   // FilledQty is the accumulated filled quantity
+		LastPrice               string                          `json:"L"`   // LastPrice is the last filled price
+		CommissionAsset         string                          `json:"N"`   // CommissionAsset is the commission asset, will not push if no commission
+		Commission              string                          `json:"n"`   // Commission is the commission, will not push if no commission
+		TradeTime               int64                           `json:"T"`   // TradeTime is the order trade time
+		TradeID                 int64                           `json:"t"`   // TradeID is the trade id
+		BidsNotional            string                          `json:"b"`   // BidsNotional is the bids notional
+		AsksNotional            string                          `json:"a"`   // AsksNotional is the asks notional
+		Maker                   bool                            `json:"m"`   // Maker is whether the trade is the maker side
+		ReduceOnly              bool                            `json:"R"`   // ReduceOnly is whether the order is reduce only
+		WorkingType             WorkingType                     `json:"wt"`  // WorkingType is the stop price working type
+		OrigType                OrderType                       `json:"ot"`  // OrigType is the original order type
+		PositionSide            PositionSide                    `json:"ps"`  // PositionSide is the position side
+		ClosePosition           bool                            `json:"cp"`  // ClosePosition is whether the order closes all, conditional order only
+		ActivationPrice         string                          `json:"AP"`  // ActivationPrice is the trailing stop activation price
+		CallbackRate            string                          `json:"cr"`  // CallbackRate is the trailing stop callback rate
+		PriceProtect            bool                            `json:"pP"`  // PriceProtect is whether the price protection is on
+		RealizedProfit          string                          `json:"rp"`  // RealizedProfit is the realized profit of the trade
+		SelfTradePreventionMode binance.SelfTradePreventionMode `json:"V"`   // SelfTradePreventionMode is the STP mode
+		GoodTillDate            int64                           `json:"gtd"` // GoodTillDate is the auto cancel time of GTD orders
+	} `json:"o"` // Order is the updated order
+}
+
+// MarginCallEvent represents the positions at risk of liquidation
+type MarginCallEvent struct {
+	EventType          UpdateType `json:"e"`  // EventType represents the update type
+	Time               int64      `json:"E"`  // Time represents the event time
+	CrossWalletBalance string     `json:"cw"` // CrossWalletBalance is only pushed with the crossed position margin call
+	Positions          []struct {
+		Symbol            string       `json:"s"`  // Symbol is the position symbol
+		PositionSide      PositionSide `json:"ps"` // PositionSide is the position side
+		Amount            string       `json:"pa"` // Amount is the position amount
+		MarginType        string       `json:"mt"` // MarginType is lowercase: isolated or cross
+		IsolatedWallet    string       `json:"iw"` // IsolatedWallet is the isolated wallet if isolated position
+		MarkPrice         string       `json:"mp"` // MarkPrice is the mark price
+		UnrealizedPnL     string       `json:"up"` // UnrealizedPnL is the unrealized pnl
+		MaintenanceMargin string       `json:"mm"` // MaintenanceMargin is the maintenance margin required
+	} `json:"p"` // Positions are the positions of the margin call
+}
+
+// AccountConfigUpdateEvent represents the leverage change of the symbol or the multi-assets mode change
+type AccountConfigUpdateEvent struct {
+	EventType       UpdateType `json:"e"` // EventType represents the update type
+	Time            int64      `json:"E"` // Time represents the event time
+	TransactionTime int64      `json:"T"` // TransactionTime is the time of the change
+	Leverage        *struct {
+		Symbol   string `json:"s"` // Symbol is the symbol of the leverage change
+		Leverage int    `json:"l"` // Leverage is the new leverage
+	} `json:"ac,omitempty"` // Leverage is set when the leverage of the symbol is changed
+	MultiAssets *struct {
+		Enabled bool `json:"j"` // Enabled is the multi-assets mode
+	} `json:"ai,omitempty"` // MultiAssets is set when the multi-assets mode is changed
+}
+
+// decodeUserDataEvent decodes the user data message to the struct of its type,
+// the messages of unknown types are returned as is
+func decodeUserDataEvent(payload []byte) (UpdateType, interface{}, error) {
+	et := UpdateEventType{}
+	if err := json.Unmarshal(payload, &et); err != nil {
+		return "", nil, err
+	}
+
+	var resp interface{}
+	switch et.EventType {
+	case UpdateTypeAccount:
+		resp = &AccountUpdateEvent{}
+	case UpdateTypeOrderTrade:
+		resp = &OrderTradeUpdateEvent{}
+	case UpdateTypeMarginCall:
+		resp = &MarginCallEvent{}
+	case UpdateTypeAccountConfig:
+		resp = &AccountConfigUpdateEvent{}
+	default:
+		buf := make([]byte, len(payload))
+		copy(buf, payload)
+		return et.EventType, buf, nil
+	}
+	err := json.Unmarshal(payload, resp)
+
+	return et.EventType, resp, err
+}
@@ -0,0 +1,466 @@
+package futures
+
+import (
+	"github.com/segmentio/encoding/json"
+
+	"github.com/xenking/binance-api"
+)
+
+// OrderType represents the futures order type
+type OrderType string
+
+const (
+	OrderTypeLimit              OrderType = "LIMIT"
+	OrderTypeMarket             OrderType = "MARKET"
+	OrderTypeStop               OrderType = "STOP"
+	OrderTypeStopMarket         OrderType = "STOP_MARKET"
+	OrderTypeTakeProfit         OrderType = "TAKE_PROFIT"
+	OrderTypeTakeProfitMarket   OrderType = "TAKE_PROFIT_MARKET"
+	OrderTypeTrailingStopMarket OrderType = "TRAILING_STOP_MARKET"
+)
+
+const (
+	TimeInForceGTX binance.TimeInForce = "GTX" // Good Till Crossing, the post only order
+	TimeInForceGTD binance.TimeInForce = "GTD" // Good Till Date, the order expires at GoodTillDate
+)
+
+// PositionSide is the side of the position in the hedge mode, the one-way mode uses BOTH
+type PositionSide string
+
+const (
+	PositionSideBoth  PositionSide = "BOTH"
+	PositionSideLong  PositionSide = "LONG"
+	PositionSideShort PositionSide = "SHORT"
+)
+
+type MarginType string
+
+const (
+	MarginTypeIsolated MarginType = "ISOLATED"
+	MarginTypeCrossed  MarginType = "CROSSED"
+)
+
+// WorkingType is the price triggering the stop orders
+type WorkingType string
+
+const (
+	WorkingTypeMarkPrice     WorkingType = "MARK_PRICE"
+	WorkingTypeContractPrice WorkingType = "CONTRACT_PRICE"
+)
+
+type ContractType string
+
+const (
+	ContractTypePerpetual      ContractType = "PERPETUAL"
+	ContractTypeCurrentMonth   ContractType = "CURRENT_MONTH"
+	ContractTypeNextMonth      ContractType = "NEXT_MONTH"
+	ContractTypeCurrentQuarter ContractType = "CURRENT_QUARTER"
+	ContractTypeNextQuarter    ContractType = "NEXT_QUARTER"
+)
+
+type OrderRespType string
+
+const (
+	OrderRespTypeAck    OrderRespType = "ACK"
+	OrderRespTypeResult OrderRespType = "RESULT"
+)
+
+type IncomeType string
+
+const (
+	IncomeTypeTransfer         IncomeType = "TRANSFER"
+	IncomeTypeWelcomeBonus     IncomeType = "WELCOME_BONUS"
+	IncomeTypeRealizedPnL      IncomeType = "REALIZED_PNL"
+	IncomeTypeFundingFee       IncomeType = "FUNDING_FEE"
+	IncomeTypeCommission       IncomeType = "COMMISSION"
+	IncomeTypeInsuranceClear   IncomeType = "INSURANCE_CLEAR"
+	IncomeTypeReferralKickback IncomeType = "REFERRAL_KICKBACK"
+	IncomeTypeCommissionRebate IncomeType = "COMMISSION_REBATE"
+	IncomeTypeAPIRebate        IncomeType = "API_REBATE"
+	IncomeTypeContestReward    IncomeType = "CONTEST_REWARD"
+	IncomeTypeInternalTransfer IncomeType = "INTERNAL_TRANSFER"
+)
+
+type ExchangeInfo struct {
+	Timezone   string               `json:"timezone"`
+	ServerTime int64                `json:"serverTime"`
+	RateLimits []*binance.RateLimit `json:"rateLimits"`
+	Assets     []*AssetInfo         `json:"assets"`
+	Symbols    []*SymbolInfo        `json:"symbols"`
+}
+
+// AssetInfo describes the margin asset
+type AssetInfo struct {
+	Asset             string `json:"asset"`
+	MarginAvailable   bool   `json:"marginAvailable"`
+	AutoAssetExchange string `json:"autoAssetExchange"`
+}
+
+type SymbolInfo struct {
+	Symbol                string                `json:"symbol"`
+	Pair                  string                `json:"pair"`
+	ContractType          ContractType          `json:"contractType"`
+	DeliveryDate          int64                 `json:"deliveryDate"`
+	OnboardDate           int64                 `json:"onboardDate"`
+	Status                binance.SymbolStatus  `json:"status"`
+	MaintMarginPercent    string                `json:"maintMarginPercent"`
+	RequiredMarginPercent string                `json:"requiredMarginPercent"`
+	BaseAsset             string                `json:"baseAsset"`
+	QuoteAsset            string                `json:"quoteAsset"`
+	MarginAsset           string                `json:"marginAsset"`
+	PricePrecision        int                   `json:"pricePrecision"`
+	QuantityPrecision     int                   `json:"quantityPrecision"`
+	BaseAssetPrecision    int                   `json:"baseAssetPrecision"`
+	QuotePrecision        int                   `json:"quotePrecision"`
+	UnderlyingType        string                `json:"underlyingType"`
+	UnderlyingSubType     []string              `json:"underlyingSubType"`
+	TriggerProtect        string                `json:"triggerProtect"`
+	LiquidationFee        string                `json:"liquidationFee"`
+	MarketTakeBound       string                `json:"marketTakeBound"`
+	OrderTypes            []OrderType           `json:"orderTypes"`
+	TimeInForce           []binance.TimeInForce `json:"timeInForce"`
+	Filters               []SymbolInfoFilter    `json:"filters"`
+}
+
+// SymbolInfoFilter is the spot filter with the futures parameters
+type SymbolInfoFilter struct {
+	binance.SymbolInfoFilter
+
+	// MIN_NOTIONAL parameter
+	Notional string `json:"notional"`
+	// PERCENT_PRICE parameter
+	MultiplierDecimal string `json:"multiplierDecimal"`
+}
+
+// ContinuousKlinesReq requests the klines of the contract type of the pair
+type ContinuousKlinesReq struct {
+	Pair         string                `url:"pair"`
+	ContractType ContractType          `url:"contractType"`
+	Interval     binance.KlineInterval `url:"interval"`
+	Limit        int                   `url:"limit,omitempty"` // Limit is the maximal number of elements to receive. Default 500; Max 1500
+	StartTime    int64                 `url:"startTime,omitempty"`
+	EndTime      int64                 `url:"endTime,omitempty"`
+}
+
+const (
+	DefaultDepthLimit  = 500
+	MaxDepthLimit      = 1000
+	DefaultKlinesLimit = 500
+	MaxKlinesLimit     = 1500
+)
+
+type MarkPriceReq struct {
+	Symbol string `url:"symbol"`
+}
+
+// MarkPrice is the mark and index price with the funding rate of the symbol
+type MarkPrice struct {
+	Symbol               string `json:"symbol"`
+	MarkPrice            string `json:"markPrice"`
+	IndexPrice           string `json:"indexPrice"`
+	EstimatedSettlePrice string `json:"estimatedSettlePrice"`
+	LastFundingRate      string `json:"lastFundingRate"`
+	InterestRate         string `json:"interestRate"`
+	NextFundingTime      int64  `json:"nextFundingTime"`
+	Time                 int64  `json:"time"`
+}
+
+const (
+	DefaultFundingRateLimit = 100
+	MaxFundingRateLimit     = 1000
+)
+
+type FundingRateReq struct {
+	Symbol    string `url:"symbol,omitempty"`
+	StartTime int64  `url:"startTime,omitempty"`
+	EndTime   int64  `url:"endTime,omitempty"`
+	Limit     int    `url:"limit,omitempty"` // Limit is the maximal number of elements to receive. Default 100; Max 1000
+}
+
+type FundingRate struct {
+	Symbol      string `json:"symbol"`
+	FundingRate string `json:"fundingRate"`
+	FundingTime int64  `json:"fundingTime"`
+	MarkPrice   string `json:"markPrice"`
+}
+
+type OpenInterestReq struct {
+	Symbol string `url:"symbol"`
+}
+
+type OpenInterest struct {
+	Symbol       string `json:"symbol"`
+	OpenInterest string `json:"openInterest"`
+	Time         int64  `json:"time"`
+}
+
+// OrderReq is the futures order, the json tags encode the order of the batch
+type OrderReq struct {
+	Symbol           string              `url:"symbol" json:"symbol"`
+	Side             binance.OrderSide   `url:"side" json:"side"`
+	PositionSide     PositionSide        `url:"positionSide,omitempty" json:"positionSide,omitempty"` // PositionSide must be sent in the hedge mode
+	Type             OrderType           `url:"type" json:"type"`
+	TimeInForce      binance.TimeInForce `url:"timeInForce,omitempty" json:"timeInForce,omitempty"`
+	Quantity         string              `url:"quantity,omitempty" json:"quantity,omitempty"`
+	ReduceOnly       bool                `url:"reduceOnly,omitempty" json:"reduceOnly,omitempty,string"` // ReduceOnly can't be sent in the hedge mode
+	Price            string              `url:"price,omitempty" json:"price,omitempty"`
+	NewClientOrderID string              `url:"newClientOrderId,omitempty" json:"newClientOrderId,omitempty"`
+	StopPrice        string              `url:"stopPrice,omitempty" json:"stopPrice,omitempty"`
+	// ClosePosition closes the whole position by the STOP_MARKET or TAKE_PROFIT_MARKET order, the quantity is not sent
+	ClosePosition           bool                            `url:"closePosition,omitempty" json:"closePosition,omitempty,string"`
+	ActivationPrice         string                          `url:"activationPrice,omitempty" json:"activationPrice,omitempty"` // ActivationPrice is used with TRAILING_STOP_MARKET orders
+	CallbackRate            string                          `url:"callbackRate,omitempty" json:"callbackRate,omitempty"`       // CallbackRate is the TRAILING_STOP_MARKET percent from 0.1 to 10
+	WorkingType             WorkingType                     `url:"workingType,omitempty" json:"workingType,omitempty"`
+	PriceProtect            bool                            `url:"priceProtect,omitempty" json:"priceProtect,omitempty,string"`
+	OrderRespType           OrderRespType                   `url:"newOrderRespType,omitempty" json:"newOrderRespType,omitempty"`
+	SelfTradePreventionMode binance.SelfTradePreventionMode `url:"selfTradePreventionMode,omitempty" json:"selfTradePreventionMode,omitempty"`
+	GoodTillDate            int64                           `url:"goodTillDate,omitempty" json:"goodTillDate,omitempty,string"`
+}
+
+type Order struct {
+	Symbol                  string                          `json:"symbol"`
+	OrderID                 int64                           `json:"orderId"`
+	ClientOrderID           string                          `json:"clientOrderId"`
+	Price                   string                          `json:"price"`
+	AvgPrice                string                          `json:"avgPrice"`
+	OrigQty                 string                          `json:"origQty"`
+	ExecutedQty             string                          `json:"executedQty"`
+	CumQuote                string                          `json:"cumQuote"`
+	Status                  binance.OrderStatus             `json:"status"`
+	TimeInForce             binance.TimeInForce             `json:"timeInForce"`
+	Type                    OrderType                       `json:"type"`
+	OrigType                OrderType                       `json:"origType"`
+	Side                    binance.OrderSide               `json:"side"`
+	PositionSide            PositionSide                    `json:"positionSide"`
+	StopPrice               string                          `json:"stopPrice"`
+	ClosePosition           bool                            `json:"closePosition"`
+	ReduceOnly              bool                            `json:"reduceOnly"`
+	ActivatePrice           string                          `json:"activatePrice"`
+	PriceRate               string                          `json:"priceRate"`
+	WorkingType             WorkingType                     `json:"workingType"`
+	PriceProtect            bool                            `json:"priceProtect"`
+	SelfTradePreventionMode binance.SelfTradePreventionMode `json:"selfTradePreventionMode"`
+	GoodTillDate            int64                           `json:"goodTillDate"`
+	Time                    int64                           `json:"time"`
+	UpdateTime              int64                           `json:"updateTime"`
+}
+
+// MaxBatchOrders is the max number of orders in the batch
+const MaxBatchOrders = 5
+
+type batchOrdersReq struct {
+	BatchOrders string `url:"batchOrders"`
+}
+
+// BatchOrder is the result of the order of the batch, either the order or the error rejecting it
+type BatchOrder struct {
+	Order *Order
+	Err   *binance.APIError
+}
+
+func (b *BatchOrder) UnmarshalJSON(data []byte) error {
+	apiErr := &binance.APIError{}
+	if err := json.Unmarshal(data, apiErr); err != nil {
+		return err
+	}
+	if apiErr.Code != 0 {
+		b.Err = apiErr
+		return nil
+	}
+	b.Order = &Order{}
+
+	return json.Unmarshal(data, b.Order)
+}
+
+type QueryOrderReq struct {
+	Symbol            string `url:"symbol"`
+	OrderID           int64  `url:"orderId,omitempty"`
+	OrigClientOrderID string `url:"origClientOrderId,omitempty"`
+}
+
+type CancelOrderReq struct {
+	Symbol            string `url:"symbol"`
+	OrderID           int64  `url:"orderId,omitempty"`
+	OrigClientOrderID string `url:"origClientOrderId,omitempty"`
+}
+
+type CancelOpenOrdersReq struct {
+	Symbol string `url:"symbol"`
+}
+
+type OpenOrdersReq struct {
+	Symbol string `url:"symbol,omitempty"`
+}
+
+const (
+	DefaultAccountTradesLimit = 500
+	MaxAccountTradesLimit     = 1000
+)
+
+type AccountTradesReq struct {
+	Symbol    string `url:"symbol"`
+	OrderID   int64  `url:"orderId,omitempty"`
+	StartTime int64  `url:"startTime,omitempty"`
+	EndTime   int64  `url:"endTime,omitempty"`
+	FromID    int64  `url:"fromId,omitempty"`
+	Limit     int    `url:"limit,omitempty"` // Limit is the maximal number of elements to receive. Default 500; Max 1000
+}
+
+type AccountTrade struct {
+	Symbol          string            `json:"symbol"`
+	ID              int64             `json:"id"`
+	OrderID         int64             `json:"orderId"`
+	Side            binance.OrderSide `json:"side"`
+	PositionSide    PositionSide      `json:"positionSide"`
+	Price           string            `json:"price"`
+	Qty             string            `json:"qty"`
+	QuoteQty        string            `json:"quoteQty"`
+	RealizedPnl     string            `json:"realizedPnl"`
+	Commission      string            `json:"commission"`
+	CommissionAsset string            `json:"commissionAsset"`
+	Time            int64             `json:"time"`
+	Buyer           bool              `json:"buyer"`
+	Maker           bool              `json:"maker"`
+}
+
+type Account struct {
+	FeeTier                     int                `json:"feeTier"`
+	CanTrade                    bool               `json:"canTrade"`
+	CanDeposit                  bool               `json:"canDeposit"`
+	CanWithdraw                 bool               `json:"canWithdraw"`
+	UpdateTime                  int64              `json:"updateTime"`
+	MultiAssetsMargin           bool               `json:"multiAssetsMargin"`
+	TotalInitialMargin          string             `json:"totalInitialMargin"`
+	TotalMaintMargin            string             `json:"totalMaintMargin"`
+	TotalWalletBalance          string             `json:"totalWalletBalance"`
+	TotalUnrealizedProfit       string             `json:"totalUnrealizedProfit"`
+	TotalMarginBalance          string             `json:"totalMarginBalance"`
+	TotalPositionInitialMargin  string             `json:"totalPositionInitialMargin"`
+	TotalOpenOrderInitialMargin string             `json:"totalOpenOrderInitialMargin"`
+	TotalCrossWalletBalance     string             `json:"totalCrossWalletBalance"`
+	TotalCrossUnPnl             string             `json:"totalCrossUnPnl"`
+	AvailableBalance            string             `json:"availableBalance"`
+	MaxWithdrawAmount           string             `json:"maxWithdrawAmount"`
+	Assets                      []*AccountAsset    `json:"assets"`
+	Positions                   []*AccountPosition `json:"positions"`
+}
+
+type AccountAsset struct {
+	Asset                  string `json:"asset"`
+	WalletBalance          string `json:"walletBalance"`
+	UnrealizedProfit       string `json:"unrealizedProfit"`
+	MarginBalance          string `json:"marginBalance"`
+	MaintMargin            string `json:"maintMargin"`
+	InitialMargin          string `json:"initialMargin"`
+	PositionInitialMargin  string `json:"positionInitialMargin"`
+	OpenOrderInitialMargin string `json:"openOrderInitialMargin"`
+	CrossWalletBalance     string `json:"crossWalletBalance"`
+	CrossUnPnl             string `json:"crossUnPnl"`
+	AvailableBalance       string `json:"availableBalance"`
+	MaxWithdrawAmount      string `json:"maxWithdrawAmount"`
+	MarginAvailable        bool   `json:"marginAvailable"`
+	UpdateTime             int64  `json:"updateTime"`
+}
+
+type AccountPosition struct {
+	Symbol                 string       `json:"symbol"`
+	InitialMargin          string       `json:"initialMargin"`
+	MaintMargin            string       `json:"maintMargin"`
+	UnrealizedProfit       string       `json:"unrealizedProfit"`
+	PositionInitialMargin  string       `json:"positionInitialMargin"`
+	OpenOrderInitialMargin string       `json:"openOrderInitialMargin"`
+	Leverage               string       `json:"leverage"`
+	Isolated               bool         `json:"isolated"`
+	EntryPrice             string       `json:"entryPrice"`
+	MaxNotional            string       `json:"maxNotional"`
+	PositionSide           PositionSide `json:"positionSide"`
+	PositionAmt            string       `json:"positionAmt"`
+	UpdateTime             int64        `json:"updateTime"`
+}
+
+type Balance struct {
+	AccountAlias       string `json:"accountAlias"`
+	Asset              string `json:"asset"`
+	Balance            string `json:"balance"`
+	CrossWalletBalance string `json:"crossWalletBalance"`
+	CrossUnPnl         string `json:"crossUnPnl"`
+	AvailableBalance   string `json:"availableBalance"`
+	MaxWithdrawAmount  string `json:"maxWithdrawAmount"`
+	MarginAvailable    bool   `json:"marginAvailable"`
+	UpdateTime         int64  `json:"updateTime"`
+}
+
+type PositionRiskReq struct {
+	Symbol string `url:"symbol,omitempty"`
+}
+
+type PositionRisk struct {
+	Symbol           string       `json:"symbol"`
+	PositionAmt      string       `json:"positionAmt"`
+	EntryPrice       string       `json:"entryPrice"`
+	BreakEvenPrice   string       `json:"breakEvenPrice"`
+	MarkPrice        string       `json:"markPrice"`
+	UnRealizedProfit string       `json:"unRealizedProfit"`
+	LiquidationPrice string       `json:"liquidationPrice"`
+	Leverage         string       `json:"leverage"`
+	MaxNotionalValue string       `json:"maxNotionalValue"`
+	MarginType       string       `json:"marginType"` // MarginType is lowercase: isolated or cross
+	IsolatedMargin   string       `json:"isolatedMargin"`
+	IsAutoAddMargin  string       `json:"isAutoAddMargin"`
+	PositionSide     PositionSide `json:"positionSide"`
+	Notional         string       `json:"notional"`
+	IsolatedWallet   string       `json:"isolatedWallet"`
+	UpdateTime       int64        `json:"updateTime"`
+}
+
+// MaxLeverage is the highest leverage the API accepts, the symbol brackets may limit it further
+const MaxLeverage = 125
+
+type LeverageReq struct {
+	Symbol   string `url:"symbol"`
+	Leverage int    `url:"leverage"` // Leverage is from 1 to 125
+}
+
+type Leverage struct {
+	Symbol           string `json:"symbol"`
+	Leverage         int    `json:"leverage"`
+	MaxNotionalValue string `json:"maxNotionalValue"`
+}
+
+type MarginTypeReq struct {
+	Symbol     string     `url:"symbol"`
+	MarginType MarginType `url:"marginType"`
+}
+
+type PositionModeReq struct {
+	DualSidePosition bool `url:"dualSidePosition"` // DualSidePosition enables the hedge mode
+}
+
+type PositionMode struct {
+	DualSidePosition bool `json:"dualSidePosition"`
+}
+
+const (
+	DefaultIncomeLimit = 100
+	MaxIncomeLimit     = 1000
+)
+
+type IncomeReq struct {
+	Symbol     string     `url:"symbol,omitempty"`
+	IncomeType IncomeType `url:"incomeType,omitempty"`
+	StartTime  int64      `url:"startTime,omitempty"`
+	EndTime    int64      `url:"endTime,omitempty"`
+	Page       int        `url:"page,omitempty"`
+	Limit      int        `url:"limit,omitempty"` // Limit is the maximal number of elements to receive. Default 100; Max 1000
+}
+
+type Income struct {
+	Symbol     string     `json:"symbol"`
+	IncomeType IncomeType `json:"incomeType"`
+	Income     string     `json:"income"`
+	Asset      string     `json:"asset"`
+	Info       string     `json:"info"`
+	Time       int64      `json:"time"`
+	TranID     int64      `json:"tranId"`
+	TradeID    string     `json:"tradeId"`
+}
@@ -0,0 +1,105 @@
+package indicators
+
+import (
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/binance-api"
+)
+
+// SMA is the simple moving average of the close prices
+type SMA struct {
+	n      decimal.Decimal
+	window *window
+	value  decimal.Decimal
+	ready  bool
+}
+
+// NewSMA creates the simple moving average of the period
+func NewSMA(period int) (*SMA, error) {
+	if err := checkPeriods(period); err != nil {
+		return nil, err
+	}
+
+	return &SMA{n: decimal.NewFromInt(int64(period)), window: newWindow(period), value: decimal.Zero}, nil
+}
+
+func (i *SMA) Update(k *binance.Kline, final bool) {
+	sum, full := i.window.peekSum(k.ClosePrice)
+	if final {
+		i.window.push(k.ClosePrice)
+	}
+	if full {
+		i.value, i.ready = sum.Div(i.n), true
+	}
+}
+
+// Value returns the average once the period is filled
+func (i *SMA) Value() (decimal.Decimal, bool) {
+	return i.value, i.ready
+}
+
+// EMA is the exponential moving average of the close prices seeded with the simple average of the first period
+type EMA struct {
+	s     *smoother
+	value decimal.Decimal
+	ready bool
+}
+
+// NewEMA creates the exponential moving average of the period
+func NewEMA(period int) (*EMA, error) {
+	if err := checkPeriods(period); err != nil {
+		return nil, err
+	}
+
+	return &EMA{s: newEMA(period), value: decimal.Zero}, nil
+}
+
+func (i *EMA) Update(k *binance.Kline, final bool) {
+	i.value, i.ready = update(i.s, k.ClosePrice, final)
+}
+
+// Value returns the average once the period is filled
+func (i *EMA) Value() (decimal.Decimal, bool) {
+	return i.value, i.ready
+}
+
+// WMA is the linearly weighted moving average of the close prices, the newest price has the largest weight
+type WMA struct {
+	window *window
+	weight decimal.Decimal
+	value  decimal.Decimal
+	ready  bool
+}
+
+// NewWMA creates the weighted moving average of the period
+func NewWMA(period int) (*WMA, error) {
+	if err := checkPeriods(period); err != nil {
+		return nil, err
+	}
+
+	return &WMA{
+		window: newWindow(period),
+		weight: decimal.NewFromInt(int64(period * (period + 1) / 2)),
+		value:  decimal.Zero,
+	}, nil
+}
+
+func (i *WMA) Update(k *binance.Kline, final bool) {
+	values := i.window.with(k.ClosePrice)
+	if final {
+		i.window.push(k.ClosePrice)
+	}
+	if len(values) < i.window.size {
+		return
+	}
+	sum := decimal.Zero
+	for n, v := range values {
+		sum = sum.Add(v.Mul(decimal.NewFromInt(int64(n + 1))))
+	}
+	i.value, i.ready = sum.Div(i.weight), true
+}
+
+// Value returns the average once the period is filled
+func (i *WMA) Value() (decimal.Decimal, bool) {
+	return i.value, i.ready
+}
@@ -0,0 +1,194 @@
+package indicators
+
+import (
+	"github.com/go-faster/errors"
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/candles"
+	"github.com/xenking/binance-api/ws"
+)
+
+// Precision is the number of decimal places the smoothed values are rounded to
+const Precision = 16
+
+var (
+	ErrPeriod     = errors.New("period must be positive")
+	ErrMultiplier = errors.New("multiplier must be positive")
+)
+
+var hundred = decimal.NewFromInt(100)
+
+// Indicator is updated with the bars of the kline series.
+// Final bars are committed to the indicator state, while the non-final bar only changes the current value,
+// so the open bar may be revised any number of times before it is committed
+type Indicator interface {
+	Update(k *binance.Kline, final bool)
+}
+
+// Series feeds the indicators with the klines of a single symbol and interval, e.g. the REST history
+// followed by the stream updates. Bars older than the last committed one are skipped,
+// the open bar which was never received as final is committed by the first bar after it
+type Series struct {
+	indicators []Indicator
+	open       *binance.Kline
+	last       int64
+	committed  bool
+}
+
+// NewSeries creates the series of the indicators
+func NewSeries(indicators ...Indicator) *Series {
+	return &Series{indicators: indicators}
+}
+
+// Update updates the indicators with the bar
+func (s *Series) Update(k *binance.Kline, final bool) {
+	if s.committed && k.OpenTime <= s.last {
+		return
+	}
+	if s.open != nil && s.open.OpenTime != k.OpenTime {
+		s.commit(s.open)
+	}
+	if final {
+		s.commit(k)
+		return
+	}
+	s.open = k
+	for _, i := range s.indicators {
+		i.Update(k, false)
+	}
+}
+
+// UpdateStream updates the indicators with the kline of the stream update
+func (s *Series) UpdateStream(u *ws.KlinesUpdate) error {
+	k, err := candles.KlineFromUpdate(u)
+	if err != nil {
+		return err
+	}
+	s.Update(k, u.Kline.Final)
+
+	return nil
+}
+
+func (s *Series) commit(k *binance.Kline) {
+	s.open = nil
+	s.last, s.committed = k.OpenTime, true
+	for _, i := range s.indicators {
+		i.Update(k, true)
+	}
+}
+
+// window is the ring of the last values with their sum
+type window struct {
+	size   int
+	values []decimal.Decimal
+	pos    int
+	sum    decimal.Decimal
+}
+
+func newWindow(size int) *window {
+	return &window{size: size, values: make([]decimal.Decimal, 0, size), sum: decimal.Zero}
+}
+
+func (w *window) full() bool {
+	return len(w.values) == w.size
+}
+
+func (w *window) push(v decimal.Decimal) {
+	if !w.full() {
+		w.values = append(w.values, v)
+		w.sum = w.sum.Add(v)
+		return
+	}
+	w.sum = w.sum.Sub(w.values[w.pos]).Add(v)
+	w.values[w.pos] = v
+	w.pos = (w.pos + 1) % w.size
+}
+
+// peekSum returns the sum after pushing the value and whether the window would be full
+func (w *window) peekSum(v decimal.Decimal) (decimal.Decimal, bool) {
+	if !w.full() {
+		return w.sum.Add(v), len(w.values)+1 == w.size
+	}
+
+	return w.sum.Sub(w.values[w.pos]).Add(v), true
+}
+
+// with returns the values after pushing the value from the oldest to the newest
+func (w *window) with(v decimal.Decimal) []decimal.Decimal {
+	resp := make([]decimal.Decimal, 0, w.size)
+	if w.full() {
+		resp = append(resp, w.values[w.pos+1:]...)
+		resp = append(resp, w.values[:w.pos]...)
+	} else {
+		resp = append(resp, w.values...)
+	}
+
+	return append(resp, v)
+}
+
+// smoother is the exponential moving average seeded with the simple average of the first period values
+type smoother struct {
+	alpha decimal.Decimal
+	n     decimal.Decimal
+	seed  *window
+	value decimal.Decimal
+	ready bool
+}
+
+// newEMA creates the smoother with the 2 / (period + 1) weight
+func newEMA(period int) *smoother {
+	return newSmoother(period, decimal.NewFromInt(2).Div(decimal.NewFromInt(int64(period+1))))
+}
+
+// newRMA creates the Wilder's smoother with the 1 / period weight
+func newRMA(period int) *smoother {
+	return newSmoother(period, decimal.NewFromInt(1).Div(decimal.NewFromInt(int64(period))))
+}
+
+func newSmoother(period int, alpha decimal.Decimal) *smoother {
+	return &smoother{alpha: alpha, n: decimal.NewFromInt(int64(period)), seed: newWindow(period), value: decimal.Zero}
+}
+
+// next returns the smoothed value after the value without changing the state
+func (s *smoother) next(v decimal.Decimal) (decimal.Decimal, bool) {
+	if s.ready {
+		return v.Sub(s.value).Mul(s.alpha).Add(s.value).Round(Precision), true
+	}
+	sum, full := s.seed.peekSum(v)
+	if !full {
+		return decimal.Zero, false
+	}
+
+	return sum.Div(s.n), true
+}
+
+func (s *smoother) push(v decimal.Decimal) (decimal.Decimal, bool) {
+	value, ok := s.next(v)
+	if !s.ready {
+		s.seed.push(v)
+	}
+	if ok {
+		s.value, s.ready = value, true
+	}
+
+	return value, ok
+}
+
+func update(s *smoother, v decimal.Decimal, final bool) (decimal.Decimal, bool) {
+	if final {
+		return s.push(v)
+	}
+
+	return s.next(v)
+}
+
+func checkPeriods(periods ...int) error {
+	for _, p := range periods {
+		if p < 1 {
+			return ErrPeriod
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,213 @@
+package indicators_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/indicators"
+	"github.com/xenking/binance-api/ws"
+)
+
+func TestIndicators(t *testing.T) {
+	suite.Run(t, new(indicatorsTestSuite))
+}
+
+type indicatorsTestSuite struct {
+	suite.Suite
+}
+
+func bar(i int64, high, low, closePrice, volume string) *binance.Kline {
+	return &binance.Kline{
+		OpenTime:   i * 60000,
+		CloseTime:  i*60000 + 59999,
+		OpenPrice:  decimal.RequireFromString(closePrice),
+		HighPrice:  decimal.RequireFromString(high),
+		LowPrice:   decimal.RequireFromString(low),
+		ClosePrice: decimal.RequireFromString(closePrice),
+		Volume:     decimal.RequireFromString(volume),
+	}
+}
+
+func closes(prices ...string) []*binance.Kline {
+	resp := make([]*binance.Kline, len(prices))
+	for i, p := range prices {
+		resp[i] = bar(int64(i), p, p, p, "1")
+	}
+
+	return resp
+}
+
+type valuer interface {
+	indicators.Indicator
+	Value() (decimal.Decimal, bool)
+}
+
+// values commits the bars and returns the values after each of them
+func (s *indicatorsTestSuite) values(i valuer, bars []*binance.Kline) []string {
+	resp := make([]string, len(bars))
+	for n, k := range bars {
+		i.Update(k, true)
+		if v, ok := i.Value(); ok {
+			resp[n] = v.String()
+		}
+	}
+
+	return resp
+}
+
+func (s *indicatorsTestSuite) TestAverages() {
+	sma, err := indicators.NewSMA(3)
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"", "", "2", "3", "4"}, s.values(sma, closes("1", "2", "3", "4", "5")))
+
+	// the open bar is revised without changing the committed prices
+	sma.Update(bar(5, "21", "21", "21", "1"), false)
+	v, _ := sma.Value()
+	s.Require().Equal("10", v.String())
+	sma.Update(bar(5, "6", "6", "6", "1"), false)
+	v, _ = sma.Value()
+	s.Require().Equal("5", v.String())
+	sma.Update(bar(5, "9", "9", "9", "1"), true)
+	v, _ = sma.Value()
+	s.Require().Equal("6", v.String())
+	sma.Update(bar(6, "10", "10", "10", "1"), false)
+	v, _ = sma.Value()
+	s.Require().Equal("8", v.String())
+
+	ema, err := indicators.NewEMA(3)
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"", "", "2", "3", "4"}, s.values(ema, closes("1", "2", "3", "4", "5")))
+	ema.Update(bar(5, "13", "13", "13", "1"), false)
+	v, _ = ema.Value()
+	s.Require().Equal("8.5", v.String())
+	ema.Update(bar(5, "6", "6", "6", "1"), true)
+	v, _ = ema.Value()
+	s.Require().Equal("5", v.String())
+
+	wma, err := indicators.NewWMA(3)
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"", "", "2.3333333333333333", "3.3333333333333333"}, s.values(wma, closes("1", "2", "3", "4")))
+
+	_, err = indicators.NewSMA(0)
+	s.Require().ErrorIs(err, indicators.ErrPeriod)
+}
+
+func (s *indicatorsTestSuite) TestOscillators() {
+	rsi, err := indicators.NewRSI(2)
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"", "", "100", "50"}, s.values(rsi, closes("1", "2", "3", "2")))
+	rsi.Update(bar(4, "1", "1", "1", "1"), false)
+	v, _ := rsi.Value()
+	s.Require().Equal("25", v.Round(8).String())
+	rsi.Update(bar(4, "2", "2", "2", "1"), true)
+	v, _ = rsi.Value()
+	s.Require().Equal("50", v.String())
+
+	macd, err := indicators.NewMACD(1, 2, 2)
+	s.Require().NoError(err)
+	for _, k := range closes("1", "2", "3") {
+		macd.Update(k, true)
+	}
+	m, ok := macd.Value()
+	s.Require().True(ok)
+	s.Require().Equal("0.5", m.MACD.Round(8).String())
+	s.Require().Equal("0.5", m.Signal.Round(8).String())
+	s.Require().True(m.Histogram.Round(8).IsZero())
+	macd.Update(bar(3, "6", "6", "6", "1"), false)
+	m, _ = macd.Value()
+	s.Require().Equal("1.16666667", m.MACD.Round(8).String())
+	s.Require().Equal("0.94444444", m.Signal.Round(8).String())
+
+	stoch, err := indicators.NewStochastic(3, 1, 2)
+	s.Require().NoError(err)
+	stoch.Update(bar(0, "3", "1", "2", "1"), true)
+	stoch.Update(bar(1, "4", "2", "3", "1"), true)
+	stoch.Update(bar(2, "5", "3", "5", "1"), true)
+	_, ok = stoch.Value()
+	s.Require().False(ok)
+	stoch.Update(bar(3, "6", "4", "4", "1"), true)
+	st, ok := stoch.Value()
+	s.Require().True(ok)
+	s.Require().Equal("50", st.K.String())
+	s.Require().Equal("75", st.D.String())
+}
+
+func (s *indicatorsTestSuite) TestVolatility() {
+	bb, err := indicators.NewBollinger(3, decimal.NewFromInt(2))
+	s.Require().NoError(err)
+	for _, k := range closes("1", "2", "3") {
+		bb.Update(k, true)
+	}
+	b, ok := bb.Value()
+	s.Require().True(ok)
+	s.Require().Equal("2", b.Middle.String())
+	s.Require().InDelta(3.632993161855452, b.Upper.InexactFloat64(), 1e-9)
+	s.Require().InDelta(0.367006838144548, b.Lower.InexactFloat64(), 1e-9)
+	_, err = indicators.NewBollinger(3, decimal.Zero)
+	s.Require().ErrorIs(err, indicators.ErrMultiplier)
+
+	atr, err := indicators.NewATR(2)
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"", "2", "3.5"}, s.values(atr, []*binance.Kline{
+		bar(0, "10", "8", "9", "1"),
+		bar(1, "11", "9", "10", "1"),
+		bar(2, "12", "7", "8", "1"),
+	}))
+}
+
+func (s *indicatorsTestSuite) TestVolume() {
+	vwap := indicators.NewVWAP(2 * time.Minute)
+	s.Require().Equal([]string{"10", "13", "30"}, s.values(vwap, []*binance.Kline{
+		bar(0, "12", "8", "10", "1"),
+		bar(1, "15", "12", "15", "3"),
+		bar(2, "30", "30", "30", "1"),
+	}))
+	// the open bar of the new session replaces the committed session
+	vwap.Update(bar(4, "40", "40", "40", "2"), false)
+	v, _ := vwap.Value()
+	s.Require().Equal("40", v.String())
+
+	obv := indicators.NewOBV()
+	s.Require().Equal([]string{"0", "20", "20", "-20"}, s.values(obv, []*binance.Kline{
+		bar(0, "1", "1", "1", "10"),
+		bar(1, "2", "2", "2", "20"),
+		bar(2, "2", "2", "2", "30"),
+		bar(3, "1", "1", "1", "40"),
+	}))
+}
+
+func (s *indicatorsTestSuite) TestSeries() {
+	sma, err := indicators.NewSMA(2)
+	s.Require().NoError(err)
+	series := indicators.NewSeries(sma)
+	for _, k := range closes("1", "2", "3") {
+		series.Update(k, true)
+	}
+
+	update := func(i int64, price string, final bool) *ws.KlinesUpdate {
+		u := &ws.KlinesUpdate{EventType: ws.UpdateTypeKline}
+		k := &u.Kline
+		k.StartTime, k.EndTime, k.Interval, k.Final = i*60000, i*60000+59999, binance.KlineInterval1min, final
+		k.OpenPrice, k.ClosePrice, k.High, k.Low, k.Volume = price, price, price, price, "1"
+		return u
+	}
+	// the stream overlapping the history is skipped
+	s.Require().NoError(series.UpdateStream(update(2, "100", true)))
+	v, _ := sma.Value()
+	s.Require().Equal("2.5", v.String())
+
+	s.Require().NoError(series.UpdateStream(update(3, "5", false)))
+	v, _ = sma.Value()
+	s.Require().Equal("4", v.String())
+	// the open bar without the final update is committed by the next bar
+	s.Require().NoError(series.UpdateStream(update(4, "7", false)))
+	v, _ = sma.Value()
+	s.Require().Equal("6", v.String())
+
+	u := update(5, "x", false)
+	s.Require().Error(series.UpdateStream(u))
+}
@@ -0,0 +1,165 @@
+package indicators
+
+import (
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/binance-api"
+)
+
+// RSI is the relative strength index of the close prices with the Wilder's smoothing of gains and losses
+type RSI struct {
+	gain, loss *smoother
+	prev       decimal.Decimal
+	started    bool
+	value      decimal.Decimal
+	ready      bool
+}
+
+// NewRSI creates the relative strength index of the period
+func NewRSI(period int) (*RSI, error) {
+	if err := checkPeriods(period); err != nil {
+		return nil, err
+	}
+
+	return &RSI{gain: newRMA(period), loss: newRMA(period), prev: decimal.Zero, value: decimal.Zero}, nil
+}
+
+func (i *RSI) Update(k *binance.Kline, final bool) {
+	if !i.started {
+		if final {
+			i.prev, i.started = k.ClosePrice, true
+		}
+		return
+	}
+	change := k.ClosePrice.Sub(i.prev)
+	gain, loss := decimal.Max(change, decimal.Zero), decimal.Max(change.Neg(), decimal.Zero)
+	if final {
+		i.prev = k.ClosePrice
+	}
+	avgGain, ok := update(i.gain, gain, final)
+	avgLoss, _ := update(i.loss, loss, final)
+	if !ok {
+		return
+	}
+	i.value, i.ready = rsi(avgGain, avgLoss), true
+}
+
+// Value returns the index in the range from 0 to 100 once the period is filled
+func (i *RSI) Value() (decimal.Decimal, bool) {
+	return i.value, i.ready
+}
+
+func rsi(gain, loss decimal.Decimal) decimal.Decimal {
+	switch {
+	case loss.IsZero() && gain.IsZero():
+		return decimal.NewFromInt(50)
+	case loss.IsZero():
+		return hundred
+	}
+
+	return hundred.Sub(hundred.Div(gain.Div(loss).Add(decimal.NewFromInt(1))))
+}
+
+// MACDValue is the value of the moving average convergence divergence
+type MACDValue struct {
+	MACD      decimal.Decimal // MACD is the difference of the fast and slow averages
+	Signal    decimal.Decimal // Signal is the average of the MACD
+	Histogram decimal.Decimal // Histogram is the difference of the MACD and the signal
+}
+
+// MACD is the moving average convergence divergence of the close prices
+type MACD struct {
+	fast, slow, signal *smoother
+	value              MACDValue
+	ready              bool
+}
+
+// NewMACD creates the moving average convergence divergence, the usual periods are 12, 26 and 9
+func NewMACD(fast, slow, signal int) (*MACD, error) {
+	if err := checkPeriods(fast, slow, signal); err != nil {
+		return nil, err
+	}
+
+	return &MACD{fast: newEMA(fast), slow: newEMA(slow), signal: newEMA(signal)}, nil
+}
+
+func (i *MACD) Update(k *binance.Kline, final bool) {
+	fast, okFast := update(i.fast, k.ClosePrice, final)
+	slow, okSlow := update(i.slow, k.ClosePrice, final)
+	if !okFast || !okSlow {
+		return
+	}
+	macd := fast.Sub(slow)
+	signal, ok := update(i.signal, macd, final)
+	if !ok {
+		return
+	}
+	i.value, i.ready = MACDValue{MACD: macd, Signal: signal, Histogram: macd.Sub(signal)}, true
+}
+
+// Value returns the MACD once the signal period is filled
+func (i *MACD) Value() (MACDValue, bool) {
+	return i.value, i.ready
+}
+
+// StochasticValue is the value of the stochastic oscillator
+type StochasticValue struct {
+	K decimal.Decimal // K is the position of the close in the high-low range of the period from 0 to 100
+	D decimal.Decimal // D is the simple average of K
+}
+
+// Stochastic is the stochastic oscillator
+type Stochastic struct {
+	highs, lows *window
+	smooth, d   *window
+	value       StochasticValue
+	ready       bool
+}
+
+// NewStochastic creates the stochastic oscillator with the K period, the K smoothing period and the D period,
+// the smoothing period 1 gives the fast oscillator, the usual periods are 14, 3 and 3
+func NewStochastic(period, smooth, d int) (*Stochastic, error) {
+	if err := checkPeriods(period, smooth, d); err != nil {
+		return nil, err
+	}
+
+	return &Stochastic{highs: newWindow(period), lows: newWindow(period), smooth: newWindow(smooth), d: newWindow(d)}, nil
+}
+
+func (i *Stochastic) Update(k *binance.Kline, final bool) {
+	highs, lows := i.highs.with(k.HighPrice), i.lows.with(k.LowPrice)
+	if final {
+		i.highs.push(k.HighPrice)
+		i.lows.push(k.LowPrice)
+	}
+	if len(highs) < i.highs.size {
+		return
+	}
+	high, low := decimal.Max(highs[0], highs[1:]...), decimal.Min(lows[0], lows[1:]...)
+	raw := decimal.NewFromInt(50)
+	if !high.Equal(low) {
+		raw = k.ClosePrice.Sub(low).Div(high.Sub(low)).Mul(hundred)
+	}
+
+	sum, ok := i.smooth.peekSum(raw)
+	if final {
+		i.smooth.push(raw)
+	}
+	if !ok {
+		return
+	}
+	value := sum.Div(decimal.NewFromInt(int64(i.smooth.size)))
+	sum, ok = i.d.peekSum(value)
+	if final {
+		i.d.push(value)
+	}
+	if !ok {
+		return
+	}
+	i.value, i.ready = StochasticValue{K: value, D: sum.Div(decimal.NewFromInt(int64(i.d.size)))}, true
+}
+
+// Value returns the oscillator once the D period is filled
+func (i *Stochastic) Value() (StochasticValue, bool) {
+	return i.value, i.ready
+}
@@ -0,0 +1,102 @@
+package indicators
+
+import (
+	"math"
+
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/binance-api"
+)
+
+// BollingerValue is the value of the Bollinger bands
+type BollingerValue struct {
+	Upper  decimal.Decimal
+	Middle decimal.Decimal // Middle is the simple average of the close prices
+	Lower  decimal.Decimal
+}
+
+// Bollinger is the Bollinger bands of the close prices, the bands are the multiplier of the population
+// standard deviation away from the middle. The deviation is rounded to the float64 precision
+type Bollinger struct {
+	window     *window
+	n          decimal.Decimal
+	multiplier decimal.Decimal
+	value      BollingerValue
+	ready      bool
+}
+
+// NewBollinger creates the Bollinger bands of the period, the usual period is 20 with the multiplier 2
+func NewBollinger(period int, multiplier decimal.Decimal) (*Bollinger, error) {
+	if err := checkPeriods(period); err != nil {
+		return nil, err
+	}
+	if multiplier.Sign() <= 0 {
+		return nil, ErrMultiplier
+	}
+
+	return &Bollinger{window: newWindow(period), n: decimal.NewFromInt(int64(period)), multiplier: multiplier}, nil
+}
+
+func (i *Bollinger) Update(k *binance.Kline, final bool) {
+	values := i.window.with(k.ClosePrice)
+	if final {
+		i.window.push(k.ClosePrice)
+	}
+	if len(values) < i.window.size {
+		return
+	}
+	sum := decimal.Zero
+	for _, v := range values {
+		sum = sum.Add(v)
+	}
+	mean := sum.Div(i.n)
+	variance := decimal.Zero
+	for _, v := range values {
+		d := v.Sub(mean)
+		variance = variance.Add(d.Mul(d))
+	}
+	deviation := decimal.NewFromFloat(math.Sqrt(variance.Div(i.n).InexactFloat64()))
+	band := deviation.Mul(i.multiplier)
+	i.value, i.ready = BollingerValue{Upper: mean.Add(band), Middle: mean, Lower: mean.Sub(band)}, true
+}
+
+// Value returns the bands once the period is filled
+func (i *Bollinger) Value() (BollingerValue, bool) {
+	return i.value, i.ready
+}
+
+// ATR is the average true range with the Wilder's smoothing, the true range of the first bar is its high-low range
+type ATR struct {
+	s       *smoother
+	prev    decimal.Decimal
+	started bool
+	value   decimal.Decimal
+	ready   bool
+}
+
+// NewATR creates the average true range of the period
+func NewATR(period int) (*ATR, error) {
+	if err := checkPeriods(period); err != nil {
+		return nil, err
+	}
+
+	return &ATR{s: newRMA(period), prev: decimal.Zero, value: decimal.Zero}, nil
+}
+
+func (i *ATR) Update(k *binance.Kline, final bool) {
+	tr := k.HighPrice.Sub(k.LowPrice)
+	if i.started {
+		tr = decimal.Max(tr, k.HighPrice.Sub(i.prev).Abs(), k.LowPrice.Sub(i.prev).Abs())
+	}
+	if final {
+		i.prev, i.started = k.ClosePrice, true
+	}
+	if value, ok := update(i.s, tr, final); ok {
+		i.value, i.ready = value, true
+	}
+}
+
+// Value returns the average once the period is filled
+func (i *ATR) Value() (decimal.Decimal, bool) {
+	return i.value, i.ready
+}
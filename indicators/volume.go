@@ -0,0 +1,87 @@
+package indicators
+
+import (
+	"time"
+
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/binance-api"
+)
+
+var three = decimal.NewFromInt(3)
+
+// VWAP is the volume weighted average of the typical prices (high + low + close) / 3
+type VWAP struct {
+	session int64
+	start   int64
+	pv, vol decimal.Decimal
+	value   decimal.Decimal
+	ready   bool
+}
+
+// NewVWAP creates the volume weighted average price reset at the start of every session aligned to the epoch,
+// e.g. 24 hours reset it at 00:00 UTC. The zero session never resets it
+func NewVWAP(session time.Duration) *VWAP {
+	return &VWAP{session: session.Milliseconds(), pv: decimal.Zero, vol: decimal.Zero, value: decimal.Zero}
+}
+
+func (i *VWAP) Update(k *binance.Kline, final bool) {
+	pv, vol := i.pv, i.vol
+	var start int64
+	if i.session > 0 {
+		start = k.OpenTime - k.OpenTime%i.session
+		if start != i.start {
+			pv, vol = decimal.Zero, decimal.Zero
+		}
+	}
+	typical := k.HighPrice.Add(k.LowPrice).Add(k.ClosePrice).Div(three)
+	pv, vol = pv.Add(typical.Mul(k.Volume)), vol.Add(k.Volume)
+	if final {
+		i.start, i.pv, i.vol = start, pv, vol
+	}
+	if vol.IsZero() {
+		i.ready = false
+		return
+	}
+	i.value, i.ready = pv.Div(vol), true
+}
+
+// Value returns the average once the session has any volume
+func (i *VWAP) Value() (decimal.Decimal, bool) {
+	return i.value, i.ready
+}
+
+// OBV is the on-balance volume, the volume of the bar is added when the close rises and subtracted when it falls
+type OBV struct {
+	prev    decimal.Decimal
+	obv     decimal.Decimal
+	started bool
+	value   decimal.Decimal
+	ready   bool
+}
+
+// NewOBV creates the on-balance volume starting from zero at the first bar
+func NewOBV() *OBV {
+	return &OBV{prev: decimal.Zero, obv: decimal.Zero, value: decimal.Zero}
+}
+
+func (i *OBV) Update(k *binance.Kline, final bool) {
+	obv := i.obv
+	if i.started {
+		switch k.ClosePrice.Cmp(i.prev) {
+		case 1:
+			obv = obv.Add(k.Volume)
+		case -1:
+			obv = obv.Sub(k.Volume)
+		}
+	}
+	if final {
+		i.prev, i.obv, i.started = k.ClosePrice, obv, true
+	}
+	i.value, i.ready = obv, true
+}
+
+// Value returns the volume, it is ready from the first bar
+func (i *OBV) Value() (decimal.Decimal, bool) {
+	return i.value, i.ready
+}
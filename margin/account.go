@@ -0,0 +1,94 @@
+package margin
+
+import (
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+)
+
+// Account get the cross margin account details
+func (c *Client) Account() (*Account, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointAccount, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &Account{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// IsolatedAccount get the isolated margin accounts details of the symbols or all symbols when they are not set
+func (c *Client) IsolatedAccount(req *IsolatedAccountReq) (*IsolatedAccount, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointIsolatedAccount, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &IsolatedAccount{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// DataStream starts a new cross margin user data stream.
+// The stream events are the spot ones and are read with the ws.Client AccountInfo
+func (c *Client) DataStream() (string, error) {
+	res, err := c.Do(fasthttp.MethodPost, EndpointDataStream, nil, false, true)
+	if err != nil {
+		return "", err
+	}
+	resp := &binance.DataStream{}
+	err = json.Unmarshal(res, resp)
+
+	return resp.ListenKey, err
+}
+
+// DataStreamKeepAlive pings the cross margin data stream key to prevent timeout
+func (c *Client) DataStreamKeepAlive(listenKey string) error {
+	_, err := c.Do(fasthttp.MethodPut, EndpointDataStream, binance.DataStream{ListenKey: listenKey}, false, true)
+
+	return err
+}
+
+// DataStreamClose closes the cross margin data stream key
+func (c *Client) DataStreamClose(listenKey string) error {
+	_, err := c.Do(fasthttp.MethodDelete, EndpointDataStream, binance.DataStream{ListenKey: listenKey}, false, true)
+
+	return err
+}
+
+// IsolatedDataStream starts a new user data stream of the isolated margin account of the symbol
+func (c *Client) IsolatedDataStream(symbol string) (string, error) {
+	if symbol == "" {
+		return "", binance.ErrEmptySymbol
+	}
+	res, err := c.Do(fasthttp.MethodPost, EndpointIsolatedDataStream, &isolatedDataStreamReq{Symbol: symbol}, false, true)
+	if err != nil {
+		return "", err
+	}
+	resp := &binance.DataStream{}
+	err = json.Unmarshal(res, resp)
+
+	return resp.ListenKey, err
+}
+
+// IsolatedDataStreamKeepAlive pings the isolated margin data stream key to prevent timeout
+func (c *Client) IsolatedDataStreamKeepAlive(symbol, listenKey string) error {
+	if symbol == "" {
+		return binance.ErrEmptySymbol
+	}
+	_, err := c.Do(fasthttp.MethodPut, EndpointIsolatedDataStream, &isolatedDataStreamReq{Symbol: symbol, ListenKey: listenKey}, false, true)
+
+	return err
+}
+
+// IsolatedDataStreamClose closes the isolated margin data stream key
+func (c *Client) IsolatedDataStreamClose(symbol, listenKey string) error {
+	if symbol == "" {
+		return binance.ErrEmptySymbol
+	}
+	_, err := c.Do(fasthttp.MethodDelete, EndpointIsolatedDataStream, &isolatedDataStreamReq{Symbol: symbol, ListenKey: listenKey}, false, true)
+
+	return err
+}
@@ -0,0 +1,25 @@
+package margin
+
+// Endpoints with SIGNED security
+const (
+	EndpointBorrowRepay     = "/sapi/v1/margin/borrow-repay"
+	EndpointAccount         = "/sapi/v1/margin/account"
+	EndpointIsolatedAccount = "/sapi/v1/margin/isolated/account"
+	EndpointOrder           = "/sapi/v1/margin/order"
+	EndpointOpenOrders      = "/sapi/v1/margin/openOrders"
+	EndpointOrdersAll       = "/sapi/v1/margin/allOrders"
+	EndpointAccountTrades   = "/sapi/v1/margin/myTrades"
+	EndpointOCOOrder        = "/sapi/v1/margin/order/oco"
+	EndpointOCOOrders       = "/sapi/v1/margin/orderList"
+	EndpointOCOOrdersAll    = "/sapi/v1/margin/allOrderList"
+	EndpointOpenOCOOrders   = "/sapi/v1/margin/openOrderList"
+	EndpointInterestHistory = "/sapi/v1/margin/interestHistory"
+	EndpointMaxBorrowable   = "/sapi/v1/margin/maxBorrowable"
+	EndpointMaxTransferable = "/sapi/v1/margin/maxTransferable"
+)
+
+// Endpoints with USER_STREAM security
+const (
+	EndpointDataStream         = "/sapi/v1/userDataStream"
+	EndpointIsolatedDataStream = "/sapi/v1/userDataStream/isolated"
+)
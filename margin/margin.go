@@ -0,0 +1,152 @@
+package margin
+
+import (
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+)
+
+var (
+	ErrEmptyBorrowRepayType = binance.ValidationError{Msg: "borrow or repay type is not set"}
+	ErrEmptyIsolatedSymbol  = binance.ValidationError{Msg: "symbol of the isolated margin is not set"}
+)
+
+// Client is the cross and isolated margin API client, the margin endpoints are served
+// by the spot host and share its rate limits
+type Client struct {
+	binance.RestClient
+}
+
+// NewClient creates a new margin client with key and secret
+func NewClient(apikey, secret string) *Client {
+	return &Client{
+		RestClient: binance.NewRestClient(apikey, secret),
+	}
+}
+
+func NewCustomClient(restClient binance.RestClient) *Client {
+	return &Client{
+		RestClient: restClient,
+	}
+}
+
+func (c *Client) ReqWindow(window int) *Client {
+	c.RestClient.SetWindow(window)
+
+	return c
+}
+
+// Borrow borrows the asset to the cross margin account or the isolated margin account of the symbol
+func (c *Client) Borrow(req *BorrowRepayReq) (*BorrowRepay, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	req.Type = BorrowRepayTypeBorrow
+
+	return c.borrowRepay(req)
+}
+
+// Repay repays the debt of the asset of the cross margin account or the isolated margin account of the symbol
+func (c *Client) Repay(req *BorrowRepayReq) (*BorrowRepay, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	req.Type = BorrowRepayTypeRepay
+
+	return c.borrowRepay(req)
+}
+
+func (c *Client) borrowRepay(req *BorrowRepayReq) (*BorrowRepay, error) {
+	switch {
+	case req.Asset == "":
+		return nil, binance.ErrEmptyAsset
+	case req.Amount == "":
+		return nil, binance.ErrEmptyAmount
+	case req.IsIsolated == IsolatedTrue && req.Symbol == "":
+		return nil, ErrEmptyIsolatedSymbol
+	}
+	res, err := c.Do(fasthttp.MethodPost, EndpointBorrowRepay, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &BorrowRepay{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// BorrowRepayRecords get the borrow or repay history for the last 6 months by default
+func (c *Client) BorrowRepayRecords(req *BorrowRepayRecordsReq) (*BorrowRepayRecords, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Type == "" {
+		return nil, ErrEmptyBorrowRepayType
+	}
+	if req.Size < 0 || req.Size > MaxHistorySize {
+		req.Size = DefaultHistorySize
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointBorrowRepay, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &BorrowRepayRecords{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// InterestHistory get the interest charged for the borrowed assets
+func (c *Client) InterestHistory(req *InterestHistoryReq) (*InterestHistory, error) {
+	if req == nil {
+		req = &InterestHistoryReq{}
+	}
+	if req.Size < 0 || req.Size > MaxHistorySize {
+		req.Size = DefaultHistorySize
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointInterestHistory, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &InterestHistory{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// MaxBorrowable get the amount of the asset which can be borrowed
+func (c *Client) MaxBorrowable(req *MaxAmountReq) (*MaxBorrowable, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Asset == "" {
+		return nil, binance.ErrEmptyAsset
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointMaxBorrowable, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &MaxBorrowable{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// MaxTransferable get the amount of the asset which can be transferred out of the margin account
+func (c *Client) MaxTransferable(req *MaxAmountReq) (*MaxTransferable, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Asset == "" {
+		return nil, binance.ErrEmptyAsset
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointMaxTransferable, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &MaxTransferable{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
@@ -0,0 +1,146 @@
+package margin_test
+
+import (
+	"testing"
+
+	"github.com/google/go-querystring/query"
+	"github.com/stretchr/testify/suite"
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/binancetest"
+	"github.com/xenking/binance-api/margin"
+)
+
+func TestMargin(t *testing.T) {
+	suite.Run(t, new(marginTestSuite))
+}
+
+type marginTestSuite struct {
+	suite.Suite
+	client *margin.Client
+	mock   *binancetest.MockClient
+}
+
+func (s *marginTestSuite) SetupTest() {
+	s.mock = &binancetest.MockClient{}
+	s.client = margin.NewCustomClient(s.mock)
+}
+
+func (s *marginTestSuite) TestBorrowRepay() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(fasthttp.MethodPost, method)
+		s.Require().Equal(margin.EndpointBorrowRepay, endpoint)
+		s.Require().True(sign)
+		values, err := query.Values(data)
+		s.Require().NoError(err)
+		s.Require().Equal("REPAY", values.Get("type"))
+		s.Require().Equal("TRUE", values.Get("isIsolated"))
+		s.Require().Equal("BTCUSDT", values.Get("symbol"))
+		return []byte(`{"tranId":100000001}`), nil
+	}
+	resp, err := s.client.Repay(&margin.BorrowRepayReq{Asset: "USDT", Amount: "10", IsIsolated: margin.IsolatedTrue, Symbol: "BTCUSDT"})
+	s.Require().NoError(err)
+	s.Require().EqualValues(100000001, resp.TranID)
+
+	_, err = s.client.Borrow(&margin.BorrowRepayReq{Asset: "USDT", Amount: "10", IsIsolated: margin.IsolatedTrue})
+	s.Require().ErrorIs(err, margin.ErrEmptyIsolatedSymbol)
+	_, err = s.client.Borrow(&margin.BorrowRepayReq{Asset: "USDT"})
+	s.Require().ErrorIs(err, binance.ErrEmptyAmount)
+	_, err = s.client.BorrowRepayRecords(&margin.BorrowRepayRecordsReq{})
+	s.Require().ErrorIs(err, margin.ErrEmptyBorrowRepayType)
+}
+
+func (s *marginTestSuite) TestNewOrder() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(margin.EndpointOrder, endpoint)
+		values, err := query.Values(data)
+		s.Require().NoError(err)
+		s.Require().Equal("BTCUSDT", values.Get("symbol"))
+		s.Require().Equal("MARGIN_BUY", values.Get("sideEffectType"))
+		s.Require().Equal("GTC", values.Get("timeInForce"))
+		s.Require().Equal("FULL", values.Get("newOrderRespType"))
+		s.Require().False(values.Has("isIsolated"))
+		return []byte(`{"symbol":"BTCUSDT","orderId":28,"clientOrderId":"6gCrw2kRUAF9CvJDGP16IP","transactTime":1507725176595,"price":"1.00000000","origQty":"10.00000000","executedQty":"10.00000000","status":"FILLED","type":"LIMIT","side":"BUY","marginBuyBorrowAmount":"5","marginBuyBorrowAsset":"USDT","isIsolated":false,"fills":[{"price":"1.00000000","qty":"10.00000000","commission":"0.01","commissionAsset":"BTC"}]}`), nil
+	}
+	order, err := s.client.NewOrder(&margin.OrderReq{
+		OrderReq: binance.OrderReq{
+			Symbol: "BTCUSDT", Side: binance.OrderSideBuy, Type: binance.OrderTypeLimit, Price: "1", Quantity: "10",
+		},
+		SideEffectType: margin.SideEffectTypeMarginBuy,
+	})
+	s.Require().NoError(err)
+	s.Require().EqualValues(28, order.OrderID)
+	s.Require().Equal(binance.OrderStatusFilled, order.Status)
+	s.Require().Equal("5", order.MarginBuyBorrowAmount)
+	s.Require().Len(order.Fills, 1)
+
+	_, err = s.client.NewOrder(&margin.OrderReq{OrderReq: binance.OrderReq{Symbol: "BTCUSDT", Side: binance.OrderSideBuy, Type: binance.OrderTypeMarket}})
+	s.Require().ErrorIs(err, binance.ErrEmptyQuantity)
+	_, err = s.client.NewOrder(nil)
+	s.Require().ErrorIs(err, binance.ErrNilRequest)
+}
+
+func (s *marginTestSuite) TestOCO() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(margin.EndpointOCOOrder, endpoint)
+		values, err := query.Values(data)
+		s.Require().NoError(err)
+		s.Require().Equal("AUTO_REPAY", values.Get("sideEffectType"))
+		s.Require().Equal("GTC", values.Get("stopLimitTimeInForce"))
+		return []byte(`{"orderListId":0,"contingencyType":"OCO","listStatusType":"EXEC_STARTED","listOrderStatus":"EXECUTING","listClientOrderId":"JYVpp3F0f5CAG15DhtrqLp","transactionTime":1563417480525,"symbol":"LTCBTC","marginBuyBorrowAmount":"0","marginBuyBorrowAsset":"BTC","isIsolated":false,"orders":[{"symbol":"LTCBTC","orderId":2,"clientOrderId":"Kk7sqHb9J6mJWTMDVW7Vos"},{"symbol":"LTCBTC","orderId":3,"clientOrderId":"xTXKaGYd4bluPVp78IVRvl"}]}`), nil
+	}
+	oco, err := s.client.NewOCO(&margin.OCOReq{
+		Symbol: "LTCBTC", Side: binance.OrderSideSell, Quantity: "1", Price: "0.1", StopPrice: "0.05", StopLimitPrice: "0.049",
+		SideEffectType: margin.SideEffectTypeAutoRepay,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(oco.Orders, 2)
+	s.Require().Equal("BTC", oco.MarginBuyBorrowAsset)
+
+	_, err = s.client.QueryOCO(&margin.QueryOCOReq{OrderListID: 1, IsIsolated: margin.IsolatedTrue})
+	s.Require().ErrorIs(err, margin.ErrEmptyIsolatedSymbol)
+}
+
+func (s *marginTestSuite) TestAccount() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		switch endpoint {
+		case margin.EndpointAccount:
+			return []byte(`{"borrowEnabled":true,"marginLevel":"11.64405625","totalAssetOfBtc":"6.82728457","totalLiabilityOfBtc":"0.58633215","totalNetAssetOfBtc":"6.24095242","tradeEnabled":true,"transferInEnabled":true,"transferOutEnabled":true,"accountType":"MARGIN_1","userAssets":[{"asset":"BTC","borrowed":"0.00000000","free":"0.00499500","interest":"0.00000000","locked":"0.00000000","netAsset":"0.00499500"}]}`), nil
+		case margin.EndpointIsolatedAccount:
+			s.Require().Equal("BTCUSDT", data.(*margin.IsolatedAccountReq).Symbols)
+			return []byte(`{"assets":[{"baseAsset":{"asset":"BTC","borrowEnabled":true,"borrowed":"0.00000000","free":"0.00000000","interest":"0.00000000","locked":"0.00000000","netAsset":"0.00000000","netAssetOfBtc":"0.00000000","repayEnabled":true,"totalAsset":"0.00000000"},"quoteAsset":{"asset":"USDT","borrowEnabled":true,"borrowed":"0.00000000","free":"0.00000000","interest":"0.00000000","locked":"0.00000000","netAsset":"0.00000000","netAssetOfBtc":"0.00000000","repayEnabled":true,"totalAsset":"0.00000000"},"symbol":"BTCUSDT","isolatedCreated":true,"enabled":true,"marginLevel":"0.00000000","marginLevelStatus":"EXCESSIVE","marginRatio":"0.00000000","indexPrice":"10000.00000000","liquidatePrice":"1000.00000000","liquidateRate":"1.00000000","tradeEnabled":true}],"totalAssetOfBtc":"0.00000000","totalLiabilityOfBtc":"0.00000000","totalNetAssetOfBtc":"0.00000000"}`), nil
+		case margin.EndpointMaxBorrowable:
+			s.Require().Equal("BTCUSDT", data.(*margin.MaxAmountReq).IsolatedSymbol)
+			return []byte(`{"amount":"1.69248805","borrowLimit":"60"}`), nil
+		case margin.EndpointIsolatedDataStream:
+			s.Require().True(stream)
+			s.Require().False(sign)
+			values, err := query.Values(data)
+			s.Require().NoError(err)
+			s.Require().Equal("BTCUSDT", values.Get("symbol"))
+			return []byte(`{"listenKey":"T3ee22BIYuWqmvne0HNq2A2WsFlEtLhvWCtItw6ffhhdmjifQ2tRbuKkTHhr"}`), nil
+		}
+		s.FailNow("unexpected endpoint " + endpoint)
+		return nil, nil
+	}
+	account, err := s.client.Account()
+	s.Require().NoError(err)
+	s.Require().Equal("11.64405625", account.MarginLevel)
+	s.Require().Equal("0.00499500", account.UserAssets[0].Free)
+
+	isolated, err := s.client.IsolatedAccount(&margin.IsolatedAccountReq{Symbols: "BTCUSDT"})
+	s.Require().NoError(err)
+	s.Require().Equal("USDT", isolated.Assets[0].QuoteAsset.Asset)
+	s.Require().Equal("1000.00000000", isolated.Assets[0].LiquidatePrice)
+
+	borrowable, err := s.client.MaxBorrowable(&margin.MaxAmountReq{Asset: "USDT", IsolatedSymbol: "BTCUSDT"})
+	s.Require().NoError(err)
+	s.Require().Equal("60", borrowable.BorrowLimit)
+
+	key, err := s.client.IsolatedDataStream("BTCUSDT")
+	s.Require().NoError(err)
+	s.Require().NotEmpty(key)
+	_, err = s.client.IsolatedDataStream("")
+	s.Require().ErrorIs(err, binance.ErrEmptySymbol)
+}
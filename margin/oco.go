@@ -0,0 +1,115 @@
+package margin
+
+import (
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+)
+
+// NewOCO sends in a new margin OCO order
+func (c *Client) NewOCO(req *OCOReq) (*OCOOrder, error) {
+	switch {
+	case req == nil:
+		return nil, binance.ErrNilRequest
+	case req.Symbol == "":
+		return nil, binance.ErrEmptySymbol
+	case req.Side == "":
+		return nil, binance.ErrEmptySide
+	case req.Quantity == "":
+		return nil, binance.ErrEmptyQuantity
+	case req.Price == "":
+		return nil, binance.ErrEmptyPrice
+	case req.StopPrice == "":
+		return nil, binance.ErrEmptyStopPrice
+	}
+	if req.StopLimitPrice != "" && req.StopLimitTimeInForce == "" {
+		req.StopLimitTimeInForce = binance.TimeInForceGTC
+	}
+	res, err := c.Do(fasthttp.MethodPost, EndpointOCOOrder, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &OCOOrder{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// CancelOCO cancel an entire margin order list
+func (c *Client) CancelOCO(req *CancelOCOReq) (*OCOOrder, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	if req.OrderListID == 0 && req.ListClientOrderID == "" {
+		return nil, binance.ErrEmptyOrderID
+	}
+	res, err := c.Do(fasthttp.MethodDelete, EndpointOCOOrders, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &OCOOrder{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// QueryOCO retrieves a specific margin order list based on provided optional parameters
+func (c *Client) QueryOCO(req *QueryOCOReq) (*OCOOrder, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.OrderListID == 0 && req.OrigClientOrderID == "" {
+		return nil, binance.ErrEmptyOrderID
+	}
+	if req.IsIsolated == IsolatedTrue && req.Symbol == "" {
+		return nil, ErrEmptyIsolatedSymbol
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointOCOOrders, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &OCOOrder{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// AllOCO retrieves all margin order lists based on provided optional parameters
+func (c *Client) AllOCO(req *AllOCOReq) ([]*OCOOrder, error) {
+	if req == nil {
+		req = &AllOCOReq{}
+	}
+	if req.IsIsolated == IsolatedTrue && req.Symbol == "" {
+		return nil, ErrEmptyIsolatedSymbol
+	}
+	if req.Limit < 0 || req.Limit > MaxOCOLimit {
+		req.Limit = DefaultOCOLimit
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointOCOOrdersAll, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*OCOOrder
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// OpenOCO retrieves all open margin order lists
+func (c *Client) OpenOCO(req *OpenOCOReq) ([]*OCOOrder, error) {
+	if req != nil && req.IsIsolated == IsolatedTrue && req.Symbol == "" {
+		return nil, ErrEmptyIsolatedSymbol
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointOpenOCOOrders, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*OCOOrder
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
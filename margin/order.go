@@ -0,0 +1,145 @@
+package margin
+
+import (
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+)
+
+// NewOrder sends in a new margin order and returns the created full order info.
+// The side effect type borrows the missing amount or repays the debt with the filled amount
+func (c *Client) NewOrder(req *OrderReq) (*Order, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	req.OrderRespType = binance.OrderRespTypeFull
+	res, err := c.Do(fasthttp.MethodPost, EndpointOrder, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &Order{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// QueryOrder checks a margin order's status
+func (c *Client) QueryOrder(req *QueryOrderReq) (*QueryOrder, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	if req.OrderID == 0 && req.OrigClientOrderID == "" {
+		return nil, binance.ErrEmptyOrderID
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointOrder, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &QueryOrder{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// CancelOrder cancel an active margin order
+func (c *Client) CancelOrder(req *CancelOrderReq) (*CancelOrder, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	if req.OrderID == 0 && req.OrigClientOrderID == "" {
+		return nil, binance.ErrEmptyOrderID
+	}
+	res, err := c.Do(fasthttp.MethodDelete, EndpointOrder, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &CancelOrder{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// CancelOpenOrders cancel all open margin orders on a symbol
+func (c *Client) CancelOpenOrders(req *CancelOpenOrdersReq) ([]*CancelOrder, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	res, err := c.Do(fasthttp.MethodDelete, EndpointOpenOrders, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*CancelOrder
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// OpenOrders get all open margin orders on a symbol or all symbols of the cross margin when the symbol is not set
+func (c *Client) OpenOrders(req *OpenOrdersReq) ([]*QueryOrder, error) {
+	if req != nil && req.IsIsolated == IsolatedTrue && req.Symbol == "" {
+		return nil, ErrEmptyIsolatedSymbol
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointOpenOrders, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*QueryOrder
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// AllOrders get all margin orders; active, canceled, or filled
+func (c *Client) AllOrders(req *AllOrdersReq) ([]*QueryOrder, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	if req.Limit < 0 || req.Limit > MaxOrderLimit {
+		req.Limit = DefaultOrderLimit
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointOrdersAll, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*QueryOrder
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// AccountTrades get margin trades for a specific symbol
+func (c *Client) AccountTrades(req *AccountTradesReq) ([]*AccountTrade, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	if req.Limit < 0 || req.Limit > MaxAccountTradesLimit {
+		req.Limit = DefaultAccountTradesLimit
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointAccountTrades, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*AccountTrade
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
@@ -0,0 +1,344 @@
+package margin
+
+import (
+	"github.com/xenking/binance-api"
+)
+
+// Isolated selects the isolated margin account of the symbol, the cross margin account is used by default
+type Isolated string
+
+const (
+	IsolatedFalse Isolated = "FALSE"
+	IsolatedTrue  Isolated = "TRUE"
+)
+
+// SideEffectType is the borrowing or repayment made along with the margin order
+type SideEffectType string
+
+const (
+	SideEffectTypeNoSideEffect    SideEffectType = "NO_SIDE_EFFECT"
+	SideEffectTypeMarginBuy       SideEffectType = "MARGIN_BUY"        // MarginBuy borrows the missing amount for the order
+	SideEffectTypeAutoRepay       SideEffectType = "AUTO_REPAY"        // AutoRepay repays the debt with the filled amount
+	SideEffectTypeAutoBorrowRepay SideEffectType = "AUTO_BORROW_REPAY" // AutoBorrowRepay combines the margin buy and the auto repay
+)
+
+type BorrowRepayType string
+
+const (
+	BorrowRepayTypeBorrow BorrowRepayType = "BORROW"
+	BorrowRepayTypeRepay  BorrowRepayType = "REPAY"
+)
+
+type BorrowRepayStatus string
+
+const (
+	BorrowRepayStatusPending   BorrowRepayStatus = "PENDING"
+	BorrowRepayStatusConfirmed BorrowRepayStatus = "CONFIRMED"
+	BorrowRepayStatusFailed    BorrowRepayStatus = "FAILED"
+)
+
+// BorrowRepayReq borrows or repays the asset of the cross margin account or the isolated margin account of the symbol
+type BorrowRepayReq struct {
+	Asset      string          `url:"asset"`
+	IsIsolated Isolated        `url:"isIsolated,omitempty"`
+	Symbol     string          `url:"symbol,omitempty"` // Symbol is required for the isolated margin
+	Amount     string          `url:"amount"`
+	Type       BorrowRepayType `url:"type"`
+}
+
+type BorrowRepay struct {
+	TranID int64 `json:"tranId"`
+}
+
+const (
+	DefaultHistorySize = 10
+	MaxHistorySize     = 100
+)
+
+type BorrowRepayRecordsReq struct {
+	Type           BorrowRepayType `url:"type"`
+	Asset          string          `url:"asset,omitempty"`
+	IsolatedSymbol string          `url:"isolatedSymbol,omitempty"`
+	TxID           int64           `url:"txId,omitempty"`
+	StartTime      int64           `url:"startTime,omitempty"`
+	EndTime        int64           `url:"endTime,omitempty"`
+	Current        int             `url:"current,omitempty"` // Current is the page number starting from 1
+	Size           int             `url:"size,omitempty"`    // Size is the page size. Default 10; Max 100
+}
+
+type BorrowRepayRecords struct {
+	Rows  []*BorrowRepayRecord `json:"rows"`
+	Total int                  `json:"total"`
+}
+
+type BorrowRepayRecord struct {
+	IsolatedSymbol string            `json:"isolatedSymbol"`
+	Amount         string            `json:"amount"`
+	Asset          string            `json:"asset"`
+	Interest       string            `json:"interest"`
+	Principal      string            `json:"principal"`
+	Status         BorrowRepayStatus `json:"status"`
+	Timestamp      int64             `json:"timestamp"`
+	TxID           int64             `json:"txId"`
+}
+
+// Account is the cross margin account
+type Account struct {
+	Created               bool            `json:"created"`
+	BorrowEnabled         bool            `json:"borrowEnabled"`
+	MarginLevel           string          `json:"marginLevel"`
+	CollateralMarginLevel string          `json:"collateralMarginLevel"`
+	TotalAssetOfBtc       string          `json:"totalAssetOfBtc"`
+	TotalLiabilityOfBtc   string          `json:"totalLiabilityOfBtc"`
+	TotalNetAssetOfBtc    string          `json:"totalNetAssetOfBtc"`
+	TradeEnabled          bool            `json:"tradeEnabled"`
+	TransferInEnabled     bool            `json:"transferInEnabled"`
+	TransferOutEnabled    bool            `json:"transferOutEnabled"`
+	AccountType           string          `json:"accountType"`
+	UserAssets            []*AccountAsset `json:"userAssets"`
+}
+
+type AccountAsset struct {
+	Asset    string `json:"asset"`
+	Borrowed string `json:"borrowed"`
+	Free     string `json:"free"`
+	Interest string `json:"interest"`
+	Locked   string `json:"locked"`
+	NetAsset string `json:"netAsset"`
+}
+
+type IsolatedAccountReq struct {
+	Symbols string `url:"symbols,omitempty"` // Symbols is the comma separated list of up to 5 symbols, all symbols when not set
+}
+
+// IsolatedAccount is the isolated margin accounts of the symbols
+type IsolatedAccount struct {
+	Assets              []*IsolatedSymbol `json:"assets"`
+	TotalAssetOfBtc     string            `json:"totalAssetOfBtc"`
+	TotalLiabilityOfBtc string            `json:"totalLiabilityOfBtc"`
+	TotalNetAssetOfBtc  string            `json:"totalNetAssetOfBtc"`
+}
+
+type IsolatedSymbol struct {
+	Symbol            string         `json:"symbol"`
+	BaseAsset         *IsolatedAsset `json:"baseAsset"`
+	QuoteAsset        *IsolatedAsset `json:"quoteAsset"`
+	IsolatedCreated   bool           `json:"isolatedCreated"`
+	Enabled           bool           `json:"enabled"`
+	MarginLevel       string         `json:"marginLevel"`
+	MarginLevelStatus string         `json:"marginLevelStatus"` // MarginLevelStatus is EXCESSIVE, NORMAL, MARGIN_CALL, PRE_LIQUIDATION or FORCE_LIQUIDATION
+	MarginRatio       string         `json:"marginRatio"`
+	IndexPrice        string         `json:"indexPrice"`
+	LiquidatePrice    string         `json:"liquidatePrice"`
+	LiquidateRate     string         `json:"liquidateRate"`
+	TradeEnabled      bool           `json:"tradeEnabled"`
+}
+
+type IsolatedAsset struct {
+	Asset         string `json:"asset"`
+	BorrowEnabled bool   `json:"borrowEnabled"`
+	Borrowed      string `json:"borrowed"`
+	Free          string `json:"free"`
+	Interest      string `json:"interest"`
+	Locked        string `json:"locked"`
+	NetAsset      string `json:"netAsset"`
+	NetAssetOfBtc string `json:"netAssetOfBtc"`
+	RepayEnabled  bool   `json:"repayEnabled"`
+	TotalAsset    string `json:"totalAsset"`
+}
+
+// OrderReq is the spot order placed with the margin account
+type OrderReq struct {
+	binance.OrderReq
+	IsIsolated        Isolated       `url:"isIsolated,omitempty"`
+	SideEffectType    SideEffectType `url:"sideEffectType,omitempty"`
+	AutoRepayAtCancel bool           `url:"autoRepayAtCancel,omitempty"` // AutoRepayAtCancel repays the margin buy debt of the canceled order
+}
+
+// Order is the created margin order with the borrowed amount of the margin buy
+type Order struct {
+	binance.OrderRespFull
+	MarginBuyBorrowAmount string `json:"marginBuyBorrowAmount"`
+	MarginBuyBorrowAsset  string `json:"marginBuyBorrowAsset"`
+	IsIsolated            bool   `json:"isIsolated"`
+}
+
+// Remark: Either OrderID or OrigClientOrderID must be set
+type QueryOrderReq struct {
+	Symbol            string   `url:"symbol"`
+	IsIsolated        Isolated `url:"isIsolated,omitempty"`
+	OrderID           int64    `url:"orderId,omitempty"`
+	OrigClientOrderID string   `url:"origClientOrderId,omitempty"`
+}
+
+type QueryOrder struct {
+	binance.QueryOrder
+	IsIsolated bool `json:"isIsolated"`
+}
+
+// Remark: Either OrderID or OrigClientOrderID must be set
+type CancelOrderReq struct {
+	Symbol            string   `url:"symbol"`
+	IsIsolated        Isolated `url:"isIsolated,omitempty"`
+	OrderID           int64    `url:"orderId,omitempty"`
+	OrigClientOrderID string   `url:"origClientOrderId,omitempty"`
+	NewClientOrderID  string   `url:"newClientOrderId,omitempty"`
+}
+
+type CancelOrder struct {
+	binance.CancelOrder
+	IsIsolated bool `json:"isIsolated"`
+}
+
+type OpenOrdersReq struct {
+	Symbol     string   `url:"symbol,omitempty"`
+	IsIsolated Isolated `url:"isIsolated,omitempty"` // IsIsolated requires the symbol
+}
+
+type CancelOpenOrdersReq struct {
+	Symbol     string   `url:"symbol"`
+	IsIsolated Isolated `url:"isIsolated,omitempty"`
+}
+
+type AllOrdersReq struct {
+	Symbol     string   `url:"symbol"`
+	IsIsolated Isolated `url:"isIsolated,omitempty"`
+	OrderID    int64    `url:"orderId,omitempty"`
+	StartTime  int64    `url:"startTime,omitempty"`
+	EndTime    int64    `url:"endTime,omitempty"`
+	Limit      int      `url:"limit,omitempty"` // Limit is the maximal number of elements to receive. Default 500; Max 500
+}
+
+const (
+	DefaultOrderLimit = 500
+	MaxOrderLimit     = 500
+)
+
+type AccountTradesReq struct {
+	Symbol     string   `url:"symbol"`
+	IsIsolated Isolated `url:"isIsolated,omitempty"`
+	OrderID    int64    `url:"orderId,omitempty"`
+	StartTime  int64    `url:"startTime,omitempty"`
+	EndTime    int64    `url:"endTime,omitempty"`
+	FromID     int64    `url:"fromId,omitempty"`
+	Limit      int      `url:"limit,omitempty"` // Limit is the maximal number of elements to receive. Default 500; Max 1000
+}
+
+const (
+	DefaultAccountTradesLimit = 500
+	MaxAccountTradesLimit     = 1000
+)
+
+type AccountTrade struct {
+	binance.AccountTrade
+	IsIsolated bool `json:"isIsolated"`
+}
+
+// OCOReq is the one-cancels-the-other pair of the limit and the stop loss limit orders placed with the margin account
+type OCOReq struct {
+	Symbol                  string                          `url:"symbol"`
+	IsIsolated              Isolated                        `url:"isIsolated,omitempty"`
+	Side                    binance.OrderSide               `url:"side"`
+	Quantity                string                          `url:"quantity"`
+	Price                   string                          `url:"price"`
+	StopPrice               string                          `url:"stopPrice"`
+	ListClientOrderID       string                          `url:"listClientOrderId,omitempty"`
+	LimitClientOrderID      string                          `url:"limitClientOrderId,omitempty"`
+	LimitIcebergQty         string                          `url:"limitIcebergQty,omitempty"`
+	StopClientOrderID       string                          `url:"stopClientOrderId,omitempty"`
+	StopLimitPrice          string                          `url:"stopLimitPrice,omitempty"`
+	StopIcebergQty          string                          `url:"stopIcebergQty,omitempty"`
+	StopLimitTimeInForce    binance.TimeInForce             `url:"stopLimitTimeInForce,omitempty"`
+	OrderRespType           binance.OrderRespType           `url:"newOrderRespType,omitempty"`
+	SideEffectType          SideEffectType                  `url:"sideEffectType,omitempty"`
+	SelfTradePreventionMode binance.SelfTradePreventionMode `url:"selfTradePreventionMode,omitempty"`
+	AutoRepayAtCancel       bool                            `url:"autoRepayAtCancel,omitempty"`
+}
+
+type OCOOrder struct {
+	binance.OrderList
+	MarginBuyBorrowAmount string `json:"marginBuyBorrowAmount"`
+	MarginBuyBorrowAsset  string `json:"marginBuyBorrowAsset"`
+	IsIsolated            bool   `json:"isIsolated"`
+}
+
+// Remark: Either OrderListID or ListClientOrderID must be set
+type CancelOCOReq struct {
+	Symbol            string   `url:"symbol"`
+	IsIsolated        Isolated `url:"isIsolated,omitempty"`
+	OrderListID       int64    `url:"orderListId,omitempty"`
+	ListClientOrderID string   `url:"listClientOrderId,omitempty"`
+	NewClientOrderID  string   `url:"newClientOrderId,omitempty"`
+}
+
+// Remark: Either OrderListID or OrigClientOrderID must be set
+type QueryOCOReq struct {
+	Symbol            string   `url:"symbol,omitempty"` // Symbol is required for the isolated margin
+	IsIsolated        Isolated `url:"isIsolated,omitempty"`
+	OrderListID       int64    `url:"orderListId,omitempty"`
+	OrigClientOrderID string   `url:"origClientOrderId,omitempty"`
+}
+
+type AllOCOReq struct {
+	Symbol     string   `url:"symbol,omitempty"` // Symbol is required for the isolated margin
+	IsIsolated Isolated `url:"isIsolated,omitempty"`
+	FromID     int64    `url:"fromId,omitempty"` // If supplied, neither startTime or endTime can be provided
+	StartTime  int64    `url:"startTime,omitempty"`
+	EndTime    int64    `url:"endTime,omitempty"`
+	Limit      int      `url:"limit,omitempty"` // Limit is the maximal number of elements to receive. Default 500; Max 1000
+}
+
+type OpenOCOReq struct {
+	Symbol     string   `url:"symbol,omitempty"` // Symbol is required for the isolated margin
+	IsIsolated Isolated `url:"isIsolated,omitempty"`
+}
+
+const (
+	DefaultOCOLimit = 500
+	MaxOCOLimit     = 1000
+)
+
+type InterestHistoryReq struct {
+	Asset          string `url:"asset,omitempty"`
+	IsolatedSymbol string `url:"isolatedSymbol,omitempty"`
+	StartTime      int64  `url:"startTime,omitempty"`
+	EndTime        int64  `url:"endTime,omitempty"`
+	Current        int    `url:"current,omitempty"` // Current is the page number starting from 1
+	Size           int    `url:"size,omitempty"`    // Size is the page size. Default 10; Max 100
+}
+
+type InterestHistory struct {
+	Rows  []*Interest `json:"rows"`
+	Total int         `json:"total"`
+}
+
+type Interest struct {
+	TxID                int64  `json:"txId"`
+	InterestAccuredTime int64  `json:"interestAccuredTime"`
+	Asset               string `json:"asset"`
+	RawAsset            string `json:"rawAsset"` // RawAsset is set when the interest is paid in BNB
+	Principal           string `json:"principal"`
+	Interest            string `json:"interest"`
+	InterestRate        string `json:"interestRate"`
+	Type                string `json:"type"` // Type is PERIODIC, ON_BORROW, PERIODIC_CONVERTED or ON_BORROW_CONVERTED
+	IsolatedSymbol      string `json:"isolatedSymbol"`
+}
+
+type MaxAmountReq struct {
+	Asset          string `url:"asset"`
+	IsolatedSymbol string `url:"isolatedSymbol,omitempty"`
+}
+
+type MaxBorrowable struct {
+	Amount      string `json:"amount"`
+	BorrowLimit string `json:"borrowLimit"`
+}
+
+type MaxTransferable struct {
+	Amount string `json:"amount"`
+}
+
+type isolatedDataStreamReq struct {
+	Symbol    string `url:"symbol"`
+	ListenKey string `url:"listenKey,omitempty"`
+}
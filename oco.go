@@ -5,7 +5,8 @@ import (
 	"github.com/valyala/fasthttp"
 )
 
-// NewOCO get all account orders; active, canceled, or filled
+// NewOCO sends in a new OCO order
+// Deprecated: use NewOrderListOCO
 func (c *Client) NewOCO(req *OCOReq) (*OCOOrder, error) {
 	switch {
 	case req == nil:
@@ -20,7 +21,7 @@ func (c *Client) NewOCO(req *OCOReq) (*OCOOrder, error) {
 		return nil, ErrEmptyStopPrice
 	}
 
-	res, err := c.Do(fasthttp.MethodGet, EndpointOCOOrder, req, true, false)
+	res, err := c.Do(fasthttp.MethodPost, EndpointOCOOrder, req, true, false)
 	if err != nil {
 		return nil, err
 	}
@@ -30,6 +31,211 @@ func (c *Client) NewOCO(req *OCOReq) (*OCOOrder, error) {
 	return resp, err
 }
 
+// NewOrderListOCO sends in a new OCO order list with above and below legs
+func (c *Client) NewOrderListOCO(req *OrderListOCOReq) (*OrderList, error) {
+	switch {
+	case req == nil:
+		return nil, ErrNilRequest
+	case req.Symbol == "":
+		return nil, ErrEmptySymbol
+	case req.Side == "":
+		return nil, ErrEmptySide
+	case req.Quantity == "":
+		return nil, ErrEmptyQuantity
+	}
+	above := orderListLeg{
+		Type:          req.AboveType,
+		Price:         req.AbovePrice,
+		StopPrice:     req.AboveStopPrice,
+		TrailingDelta: req.AboveTrailingDelta,
+		StrategyType:  req.AboveStrategyType,
+		TimeInForce:   &req.AboveTimeInForce,
+	}
+	if err := above.validate(ocoAboveTypes); err != nil {
+		return nil, err
+	}
+	below := orderListLeg{
+		Type:          req.BelowType,
+		Price:         req.BelowPrice,
+		StopPrice:     req.BelowStopPrice,
+		TrailingDelta: req.BelowTrailingDelta,
+		StrategyType:  req.BelowStrategyType,
+		TimeInForce:   &req.BelowTimeInForce,
+	}
+	if err := below.validate(ocoBelowTypes); err != nil {
+		return nil, err
+	}
+
+	return c.newOrderList(EndpointOrderListOCO, req)
+}
+
+// NewOrderListOTO sends in a new OTO order list, the pending order is placed once the working order is filled
+func (c *Client) NewOrderListOTO(req *OrderListOTOReq) (*OrderList, error) {
+	switch {
+	case req == nil:
+		return nil, ErrNilRequest
+	case req.Symbol == "":
+		return nil, ErrEmptySymbol
+	case req.WorkingSide == "", req.PendingSide == "":
+		return nil, ErrEmptySide
+	case req.WorkingQuantity == "", req.PendingQuantity == "":
+		return nil, ErrEmptyQuantity
+	}
+	working := orderListLeg{
+		Type:         req.WorkingType,
+		Price:        req.WorkingPrice,
+		StrategyType: req.WorkingStrategyType,
+		TimeInForce:  &req.WorkingTimeInForce,
+	}
+	if err := working.validate(workingTypes); err != nil {
+		return nil, err
+	}
+	pending := orderListLeg{
+		Type:          req.PendingType,
+		Price:         req.PendingPrice,
+		StopPrice:     req.PendingStopPrice,
+		TrailingDelta: req.PendingTrailingDelta,
+		StrategyType:  req.PendingStrategyType,
+		TimeInForce:   &req.PendingTimeInForce,
+	}
+	if err := pending.validate(nil); err != nil {
+		return nil, err
+	}
+
+	return c.newOrderList(EndpointOrderListOTO, req)
+}
+
+// NewOrderListOTOCO sends in a new OTOCO order list, the pending OCO pair is placed once the working order is filled
+func (c *Client) NewOrderListOTOCO(req *OrderListOTOCOReq) (*OrderList, error) {
+	switch {
+	case req == nil:
+		return nil, ErrNilRequest
+	case req.Symbol == "":
+		return nil, ErrEmptySymbol
+	case req.WorkingSide == "", req.PendingSide == "":
+		return nil, ErrEmptySide
+	case req.WorkingQuantity == "", req.PendingQuantity == "":
+		return nil, ErrEmptyQuantity
+	}
+	working := orderListLeg{
+		Type:         req.WorkingType,
+		Price:        req.WorkingPrice,
+		StrategyType: req.WorkingStrategyType,
+		TimeInForce:  &req.WorkingTimeInForce,
+	}
+	if err := working.validate(workingTypes); err != nil {
+		return nil, err
+	}
+	above := orderListLeg{
+		Type:          req.PendingAboveType,
+		Price:         req.PendingAbovePrice,
+		StopPrice:     req.PendingAboveStopPrice,
+		TrailingDelta: req.PendingAboveTrailingDelta,
+		StrategyType:  req.PendingAboveStrategyType,
+		TimeInForce:   &req.PendingAboveTimeInForce,
+	}
+	if err := above.validate(ocoAboveTypes); err != nil {
+		return nil, err
+	}
+	// The below leg is optional for OTOCO lists
+	if req.PendingBelowType != "" {
+		below := orderListLeg{
+			Type:          req.PendingBelowType,
+			Price:         req.PendingBelowPrice,
+			StopPrice:     req.PendingBelowStopPrice,
+			TrailingDelta: req.PendingBelowTrailingDelta,
+			StrategyType:  req.PendingBelowStrategyType,
+			TimeInForce:   &req.PendingBelowTimeInForce,
+		}
+		if err := below.validate(ocoBelowTypes); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.newOrderList(EndpointOrderListOTOCO, req)
+}
+
+func (c *Client) newOrderList(endpoint string, req interface{}) (*OrderList, error) {
+	res, err := c.Do(fasthttp.MethodPost, endpoint, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &OrderList{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+var (
+	ocoAboveTypes = []OrderType{
+		OrderTypeStopLossLimit, OrderTypeStopLoss, OrderTypeLimitMaker, OrderTypeTakeProfit, OrderTypeTakeProfitLimit,
+	}
+	ocoBelowTypes = []OrderType{
+		OrderTypeStopLoss, OrderTypeStopLossLimit, OrderTypeTakeProfit, OrderTypeTakeProfitLimit,
+	}
+	workingTypes = []OrderType{OrderTypeLimit, OrderTypeLimitMaker}
+)
+
+// orderListLeg holds the order parameters of a single order list leg for validation
+type orderListLeg struct {
+	Type          OrderType
+	Price         string
+	StopPrice     string
+	TrailingDelta int64
+	StrategyType  int
+	TimeInForce   *TimeInForce
+}
+
+// validate checks the prices required by the leg order type and sets the default time in force of limit legs,
+// allowed limits the leg order types if not empty. Quantity and side are shared or checked by the caller
+func (l orderListLeg) validate(allowed []OrderType) error {
+	if len(allowed) > 0 {
+		found := false
+		for _, t := range allowed {
+			found = found || t == l.Type
+		}
+		if !found {
+			return ErrInvalidOrderType
+		}
+	}
+	if l.StrategyType > 0 && l.StrategyType < MinStrategyType {
+		return ErrMinStrategyType
+	}
+
+	switch l.Type {
+	case OrderTypeMarket:
+	case OrderTypeLimitMaker:
+		// LIMIT_MAKER orders don't accept time in force
+		if l.Price == "" {
+			return ErrEmptyPrice
+		}
+	case OrderTypeLimit:
+		if l.Price == "" {
+			return ErrEmptyPrice
+		}
+		if *l.TimeInForce == "" {
+			*l.TimeInForce = TimeInForceGTC
+		}
+	case OrderTypeStopLoss, OrderTypeTakeProfit:
+		if l.StopPrice == "" && l.TrailingDelta == 0 {
+			return ErrEmptyStopPrice
+		}
+	case OrderTypeStopLossLimit, OrderTypeTakeProfitLimit:
+		switch {
+		case l.Price == "":
+			return ErrEmptyPrice
+		case l.StopPrice == "" && l.TrailingDelta == 0:
+			return ErrEmptyStopPrice
+		case *l.TimeInForce == "":
+			*l.TimeInForce = TimeInForceGTC
+		}
+	default:
+		return ErrInvalidOrderType
+	}
+
+	return nil
+}
+
 // CancelOCO cancel an active OCO order
 func (c *Client) CancelOCO(req *CancelOCOReq) (*OCOOrder, error) {
 	if req == nil {
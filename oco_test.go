@@ -1,5 +1,170 @@
 package binance_test
 
+import (
+	"math/rand"
+
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+)
+
 type mockedOCOTestSuite struct {
 	mockedTestSuite
 }
+
+func (s *mockedOCOTestSuite) orderListResponse(endpoint string, contingency binance.ContingencyType, expected **binance.OrderList) {
+	s.mock.Response = func(method, actualEndpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(fasthttp.MethodPost, method)
+		s.Require().Equal(endpoint, actualEndpoint)
+		s.Require().True(sign)
+		*expected = &binance.OrderList{
+			Symbol:            "LTCBTC",
+			OrderListID:       int64(rand.Uint32()),
+			ContingencyType:   contingency,
+			ListStatusType:    binance.OCOStatusExecStarted,
+			ListOrderStatus:   binance.OCOOrderStatusExecuting,
+			ListClientOrderID: "list-id",
+			TransactionTime:   int64(rand.Uint32()),
+			Orders: []binance.OCOOrderStatusResp{
+				{Symbol: "LTCBTC", OrderID: int(rand.Int31()), ClientOrderID: "leg-1"},
+				{Symbol: "LTCBTC", OrderID: int(rand.Int31()), ClientOrderID: "leg-2"},
+			},
+		}
+		return json.Marshal(*expected)
+	}
+}
+
+func (s *mockedOCOTestSuite) TestNewOCO() {
+	var expected *binance.OrderList
+	s.orderListResponse(binance.EndpointOCOOrder, binance.ContingencyTypeOCO, &expected)
+
+	actual, e := s.client.NewOCO(&binance.OCOReq{
+		Symbol:         "LTCBTC",
+		Side:           binance.OrderSideSell,
+		Quantity:       "1",
+		Price:          "0.1",
+		StopPrice:      "0.05",
+		StopLimitPrice: "0.049",
+	})
+	s.Require().NoError(e)
+	s.Require().EqualValues(expected, actual)
+}
+
+func (s *mockedOCOTestSuite) TestNewOrderListOCO() {
+	var expected *binance.OrderList
+	s.orderListResponse(binance.EndpointOrderListOCO, binance.ContingencyTypeOCO, &expected)
+
+	req := &binance.OrderListOCOReq{
+		Symbol:         "LTCBTC",
+		Side:           binance.OrderSideSell,
+		Quantity:       "1",
+		AboveType:      binance.OrderTypeLimitMaker,
+		AbovePrice:     "0.1",
+		BelowType:      binance.OrderTypeStopLossLimit,
+		BelowPrice:     "0.049",
+		BelowStopPrice: "0.05",
+	}
+	actual, e := s.client.NewOrderListOCO(req)
+	s.Require().NoError(e)
+	s.Require().EqualValues(expected, actual)
+	s.Require().Empty(req.AboveTimeInForce)
+	s.Require().Equal(binance.TimeInForceGTC, req.BelowTimeInForce)
+}
+
+func (s *mockedOCOTestSuite) TestNewOrderListOCOValidation() {
+	_, e := s.client.NewOrderListOCO(&binance.OrderListOCOReq{
+		Symbol:     "LTCBTC",
+		Side:       binance.OrderSideSell,
+		Quantity:   "1",
+		AboveType:  binance.OrderTypeLimitMaker,
+		AbovePrice: "0.1",
+		BelowType:  binance.OrderTypeLimitMaker,
+		BelowPrice: "0.05",
+	})
+	s.Require().ErrorIs(e, binance.ErrInvalidOrderType)
+
+	_, e = s.client.NewOrderListOCO(&binance.OrderListOCOReq{
+		Symbol:    "LTCBTC",
+		Side:      binance.OrderSideSell,
+		Quantity:  "1",
+		AboveType: binance.OrderTypeLimitMaker,
+		BelowType: binance.OrderTypeStopLoss,
+	})
+	s.Require().ErrorIs(e, binance.ErrEmptyPrice)
+
+	_, e = s.client.NewOrderListOCO(&binance.OrderListOCOReq{
+		Symbol:     "LTCBTC",
+		Side:       binance.OrderSideSell,
+		Quantity:   "1",
+		AboveType:  binance.OrderTypeLimitMaker,
+		AbovePrice: "0.1",
+		BelowType:  binance.OrderTypeStopLoss,
+	})
+	s.Require().ErrorIs(e, binance.ErrEmptyStopPrice)
+}
+
+func (s *mockedOCOTestSuite) TestNewOrderListOTO() {
+	var expected *binance.OrderList
+	s.orderListResponse(binance.EndpointOrderListOTO, binance.ContingencyTypeOTO, &expected)
+
+	req := &binance.OrderListOTOReq{
+		Symbol:          "LTCBTC",
+		WorkingType:     binance.OrderTypeLimit,
+		WorkingSide:     binance.OrderSideBuy,
+		WorkingPrice:    "0.1",
+		WorkingQuantity: "1",
+		PendingType:     binance.OrderTypeMarket,
+		PendingSide:     binance.OrderSideSell,
+		PendingQuantity: "1",
+	}
+	actual, e := s.client.NewOrderListOTO(req)
+	s.Require().NoError(e)
+	s.Require().EqualValues(expected, actual)
+	s.Require().Equal(binance.ContingencyTypeOTO, actual.ContingencyType)
+	s.Require().Equal(binance.TimeInForceGTC, req.WorkingTimeInForce)
+
+	req.WorkingType = binance.OrderTypeMarket
+	_, e = s.client.NewOrderListOTO(req)
+	s.Require().ErrorIs(e, binance.ErrInvalidOrderType)
+
+	req.WorkingType = binance.OrderTypeLimit
+	req.PendingType = binance.OrderTypeLimit
+	_, e = s.client.NewOrderListOTO(req)
+	s.Require().ErrorIs(e, binance.ErrEmptyPrice)
+	req.PendingPrice = "0.12"
+	_, e = s.client.NewOrderListOTO(req)
+	s.Require().NoError(e)
+	s.Require().Equal(binance.TimeInForceGTC, req.PendingTimeInForce)
+}
+
+func (s *mockedOCOTestSuite) TestNewOrderListOTOCO() {
+	var expected *binance.OrderList
+	s.orderListResponse(binance.EndpointOrderListOTOCO, binance.ContingencyTypeOTO, &expected)
+
+	actual, e := s.client.NewOrderListOTOCO(&binance.OrderListOTOCOReq{
+		Symbol:                "LTCBTC",
+		WorkingType:           binance.OrderTypeLimit,
+		WorkingSide:           binance.OrderSideBuy,
+		WorkingPrice:          "0.1",
+		WorkingQuantity:       "1",
+		PendingSide:           binance.OrderSideSell,
+		PendingQuantity:       "1",
+		PendingAboveType:      binance.OrderTypeLimitMaker,
+		PendingAbovePrice:     "0.12",
+		PendingBelowType:      binance.OrderTypeStopLoss,
+		PendingBelowStopPrice: "0.09",
+	})
+	s.Require().NoError(e)
+	s.Require().EqualValues(expected, actual)
+
+	_, e = s.client.NewOrderListOTOCO(&binance.OrderListOTOCOReq{
+		Symbol:          "LTCBTC",
+		WorkingType:     binance.OrderTypeLimit,
+		WorkingSide:     binance.OrderSideBuy,
+		WorkingPrice:    "0.1",
+		WorkingQuantity: "1",
+		PendingQuantity: "1",
+	})
+	s.Require().ErrorIs(e, binance.ErrEmptySide)
+}
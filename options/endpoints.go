@@ -0,0 +1,31 @@
+package options
+
+// Endpoints with NONE security
+const (
+	EndpointPing         = "/eapi/v1/ping"
+	EndpointTime         = "/eapi/v1/time"
+	EndpointExchangeInfo = "/eapi/v1/exchangeInfo"
+	EndpointDepth        = "/eapi/v1/depth"
+	EndpointKlines       = "/eapi/v1/klines"
+	EndpointMark         = "/eapi/v1/mark"
+	EndpointOpenInterest = "/eapi/v1/openInterest"
+)
+
+// Endpoints with SIGNED security
+const (
+	EndpointOrder         = "/eapi/v1/order"
+	EndpointOpenOrders    = "/eapi/v1/openOrders"
+	EndpointAllOpenOrders = "/eapi/v1/allOpenOrders"
+	EndpointPosition      = "/eapi/v1/position"
+)
+
+// Stream endpoints
+const (
+	EndpointTradeStream        = "@trade"
+	EndpointIndexStream        = "@index"
+	EndpointMarkPriceStream    = "@markPrice"
+	EndpointKlineStream        = "@kline_"
+	EndpointTickerStream       = "@ticker"
+	EndpointDepthStream        = "@depth"
+	EndpointOpenInterestStream = "@openInterest@"
+)
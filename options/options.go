@@ -0,0 +1,157 @@
+package options
+
+import (
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+)
+
+// BaseHost for European options addresses
+const BaseHost = "eapi.binance.com"
+
+var (
+	ErrInvalidSymbol    = binance.ValidationError{Msg: "symbol must be UNDERLYING-YYMMDD-STRIKE-C|P"}
+	ErrEmptyUnderlying  = binance.ValidationError{Msg: "underlying asset is not set"}
+	ErrEmptyExpiration  = binance.ValidationError{Msg: "expiration is not set"}
+	ErrInvalidOrderType = binance.ValidationError{Msg: "only limit orders are supported"}
+)
+
+// Client is the European options API client. The options are quoted in USDT
+// and named by the UNDERLYING-YYMMDD-STRIKE-C|P scheme, see ParseContract
+type Client struct {
+	binance.RestClient
+}
+
+// NewRestClient creates the rest client of the options host with key and secret
+func NewRestClient(apikey, secret string) binance.RestClient {
+	return binance.NewCustomRestClient(binance.RestClientConfig{APIKey: apikey, APISecret: secret, Host: BaseHost})
+}
+
+// NewClient creates a new options client with key and secret
+func NewClient(apikey, secret string) *Client {
+	return &Client{
+		RestClient: NewRestClient(apikey, secret),
+	}
+}
+
+func NewCustomClient(restClient binance.RestClient) *Client {
+	return &Client{
+		RestClient: restClient,
+	}
+}
+
+func (c *Client) ReqWindow(window int) *Client {
+	c.RestClient.SetWindow(window)
+
+	return c
+}
+
+// Time tests connectivity to the Rest API and get the current server time
+func (c *Client) Time() (*binance.ServerTime, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointTime, nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+	serverTime := &binance.ServerTime{}
+	err = json.Unmarshal(res, serverTime)
+
+	return serverTime, err
+}
+
+// Ping tests connectivity to the Rest API
+func (c *Client) Ping() error {
+	_, err := c.Do(fasthttp.MethodGet, EndpointPing, nil, false, false)
+
+	return err
+}
+
+// ExchangeInfo get current exchange trading rules with the underlyings and the option symbols
+func (c *Client) ExchangeInfo() (*ExchangeInfo, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointExchangeInfo, nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ExchangeInfo{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// Depth retrieves the order book for the given option symbol, the limit is one of 10, 20, 50, 100, 500, 1000
+func (c *Client) Depth(req *binance.DepthReq) (*Depth, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	if req.Limit < 0 || req.Limit > MaxDepthLimit {
+		req.Limit = DefaultDepthLimit
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointDepth, req, false, false)
+	if err != nil {
+		return nil, err
+	}
+	depth := &Depth{}
+	err = json.Unmarshal(res, depth)
+
+	return depth, err
+}
+
+// Klines returns kline/candlestick bars for the option symbol
+func (c *Client) Klines(req *KlinesReq) ([]*Kline, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	if req.Interval == "" {
+		req.Interval = binance.KlineInterval5min
+	}
+	if req.Limit < 0 || req.Limit > MaxKlinesLimit {
+		req.Limit = DefaultKlinesLimit
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointKlines, req, false, false)
+	if err != nil {
+		return nil, err
+	}
+	var klines []*Kline
+	err = json.Unmarshal(res, &klines)
+
+	return klines, err
+}
+
+// Mark returns the mark prices with the greeks, all symbols when the symbol is not set
+func (c *Client) Mark(req *MarkReq) ([]*Mark, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointMark, req, false, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*Mark
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// OpenInterest returns the open interest of the options of the underlying asset expiring on the date
+func (c *Client) OpenInterest(req *OpenInterestReq) ([]*OpenInterest, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.UnderlyingAsset == "" {
+		return nil, ErrEmptyUnderlying
+	}
+	if req.Expiration == "" {
+		return nil, ErrEmptyExpiration
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointOpenInterest, req, false, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*OpenInterest
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
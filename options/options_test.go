@@ -0,0 +1,216 @@
+package options_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-querystring/query"
+	"github.com/stretchr/testify/suite"
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/binancetest"
+	"github.com/xenking/binance-api/options"
+	"github.com/xenking/binance-api/ws"
+)
+
+func TestOptions(t *testing.T) {
+	suite.Run(t, new(optionsTestSuite))
+}
+
+type optionsTestSuite struct {
+	suite.Suite
+	client *options.Client
+	mock   *binancetest.MockClient
+}
+
+func (s *optionsTestSuite) SetupTest() {
+	s.mock = &binancetest.MockClient{}
+	s.client = options.NewCustomClient(s.mock)
+}
+
+func (s *optionsTestSuite) TestParseContract() {
+	contract, err := options.ParseContract("BTC-240628-60000-C")
+	s.Require().NoError(err)
+	s.Require().Equal("BTC", contract.Underlying)
+	s.Require().Equal(time.Date(2024, time.June, 28, 0, 0, 0, 0, time.UTC), contract.Expiry)
+	s.Require().Equal("60000", contract.Strike)
+	s.Require().Equal(options.OptionSideCall, contract.Side)
+	s.Require().Equal("BTC-240628-60000-C", contract.String())
+
+	contract, err = options.ParseContract("ETH-231229-1500-P")
+	s.Require().NoError(err)
+	s.Require().Equal(options.OptionSidePut, contract.Side)
+
+	for _, symbol := range []string{"BTCUSDT", "BTC-240631-60000-C", "BTC-240628-60000-X", "BTC--60000-C"} {
+		_, err = options.ParseContract(symbol)
+		s.Require().ErrorIs(err, options.ErrInvalidSymbol, symbol)
+	}
+}
+
+func (s *optionsTestSuite) TestMarketData() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().False(sign)
+		switch endpoint {
+		case options.EndpointExchangeInfo:
+			return []byte(`{"timezone":"UTC","serverTime":1592387337630,"optionContracts":[{"id":1,"baseAsset":"BTC","quoteAsset":"USDT","underlying":"BTCUSDT","settleAsset":"USDT"}],"optionAssets":[{"id":1,"name":"USDT"}],"optionSymbols":[{"contractId":2,"expiryDate":1719561600000,"filters":[{"filterType":"PRICE_FILTER","minPrice":"5","maxPrice":"100000","tickSize":"5"},{"filterType":"LOT_SIZE","minQty":"0.01","maxQty":"100","stepSize":"0.01"}],"id":17,"symbol":"BTC-240628-60000-C","side":"CALL","strikePrice":"60000","underlying":"BTCUSDT","unit":1,"makerFeeRate":"0.0002","takerFeeRate":"0.0002","minQty":"0.01","maxQty":"100","initialMargin":"0.15","maintenanceMargin":"0.075","minInitialMargin":"0.1","minMaintenanceMargin":"0.05","priceScale":0,"quantityScale":2,"quoteAsset":"USDT"}]}`), nil
+		case options.EndpointDepth:
+			s.Require().Equal(options.DefaultDepthLimit, data.(*binance.DepthReq).Limit)
+			return []byte(`{"T":1589436922972,"u":37461,"bids":[["1000","0.9"]],"asks":[["1100","0.1"]]}`), nil
+		case options.EndpointKlines:
+			req := data.(*options.KlinesReq)
+			s.Require().Equal(binance.KlineInterval5min, req.Interval)
+			return []byte(`[{"open":"950","high":"1100","low":"950","close":"1100","volume":"0","amount":"0","interval":"5m","tradeCount":0,"takerVolume":"0","takerAmount":"0","openTime":1499040000000,"closeTime":1499644799999}]`), nil
+		case options.EndpointMark:
+			s.Require().Nil(data.(*options.MarkReq))
+			return []byte(`[{"symbol":"BTC-240628-60000-C","markPrice":"1482.2","bidIV":"0.51","askIV":"0.55","markIV":"0.53","delta":"0.5468","theta":"-54.21","gamma":"0.00003","vega":"95.68","highPriceLimit":"2030","lowPriceLimit":"935","riskFreeInterest":"0.1"}]`), nil
+		case options.EndpointOpenInterest:
+			values, err := query.Values(data)
+			s.Require().NoError(err)
+			s.Require().Equal("BTC", values.Get("underlyingAsset"))
+			s.Require().Equal("240628", values.Get("expiration"))
+			return []byte(`[{"symbol":"BTC-240628-60000-C","sumOpenInterest":"21.37","sumOpenInterestUsd":"1402537.87","timestamp":"1672376496986"}]`), nil
+		}
+		s.FailNow("unexpected endpoint " + endpoint)
+		return nil, nil
+	}
+	info, err := s.client.ExchangeInfo()
+	s.Require().NoError(err)
+	symbol := info.OptionSymbols[0]
+	s.Require().Equal(options.OptionSideCall, symbol.Side)
+	s.Require().Equal("60000", symbol.StrikePrice)
+	s.Require().Equal(binance.FilterTypeLotSize, symbol.Filters[1].Type)
+	s.Require().Equal("0.01", symbol.Filters[1].StepSize)
+	s.Require().Equal("BTCUSDT", info.OptionContracts[0].Underlying)
+
+	depth, err := s.client.Depth(&binance.DepthReq{Symbol: "BTC-240628-60000-C", Limit: 5000})
+	s.Require().NoError(err)
+	s.Require().EqualValues(37461, depth.UpdateID)
+	s.Require().Equal("1000", depth.Bids[0].Price.String())
+	s.Require().Equal("0.1", depth.Asks[0].Quantity.String())
+
+	klines, err := s.client.Klines(&options.KlinesReq{Symbol: "BTC-240628-60000-C"})
+	s.Require().NoError(err)
+	s.Require().Equal("1100", klines[0].Close)
+	s.Require().EqualValues(1499644799999, klines[0].CloseTime)
+
+	marks, err := s.client.Mark(nil)
+	s.Require().NoError(err)
+	s.Require().Equal("0.5468", marks[0].Delta)
+	s.Require().Equal("0.53", marks[0].MarkIV)
+
+	interest, err := s.client.OpenInterest(&options.OpenInterestReq{UnderlyingAsset: "BTC", Expiration: "240628"})
+	s.Require().NoError(err)
+	s.Require().Equal("21.37", interest[0].SumOpenInterest)
+
+	_, err = s.client.OpenInterest(&options.OpenInterestReq{UnderlyingAsset: "BTC"})
+	s.Require().ErrorIs(err, options.ErrEmptyExpiration)
+}
+
+func (s *optionsTestSuite) TestOrders() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().True(sign)
+		switch endpoint {
+		case options.EndpointOrder:
+			values, err := query.Values(data)
+			s.Require().NoError(err)
+			if method == fasthttp.MethodDelete {
+				s.Require().Equal("4611875134427365377", values.Get("orderId"))
+				return []byte(`{"orderId":4611875134427365377,"symbol":"BTC-240628-60000-C","status":"CANCELLED"}`), nil
+			}
+			s.Require().Equal(fasthttp.MethodPost, method)
+			s.Require().Equal("LIMIT", values.Get("type"))
+			s.Require().Equal("GTC", values.Get("timeInForce"))
+			s.Require().Equal("true", values.Get("postOnly"))
+			s.Require().Equal("RESULT", values.Get("newOrderRespType"))
+			s.Require().False(values.Has("reduceOnly"))
+			return []byte(`{"orderId":4611875134427365377,"symbol":"BTC-240628-60000-C","price":"100","quantity":"1","executedQty":"0","fee":"0","side":"BUY","type":"LIMIT","timeInForce":"GTC","reduceOnly":false,"postOnly":true,"createTime":1592465880683,"updateTime":1566818724722,"status":"ACCEPTED","avgPrice":"0","clientOrderId":"","priceScale":2,"quantityScale":2,"optionSide":"CALL","quoteAsset":"USDT","mmp":false}`), nil
+		case options.EndpointPosition:
+			return []byte(`[{"entryPrice":"1000","symbol":"BTC-240628-60000-C","side":"SHORT","quantity":"-0.1","reducibleQty":"0","markValue":"105.00138","ror":"-0.05","unrealizedPNL":"-5.00138","markPrice":"1050.0138","strikePrice":"60000","positionCost":"100","expiryDate":1719561600000,"priceScale":2,"quantityScale":2,"optionSide":"CALL","quoteAsset":"USDT"}]`), nil
+		}
+		s.FailNow("unexpected endpoint " + endpoint)
+		return nil, nil
+	}
+	order, err := s.client.NewOrder(&options.OrderReq{
+		Symbol: "BTC-240628-60000-C", Side: binance.OrderSideBuy, Quantity: "1", Price: "100", PostOnly: true,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(options.OrderStatusAccepted, order.Status)
+	s.Require().Equal(options.OptionSideCall, order.OptionSide)
+
+	order, err = s.client.CancelOrder(&options.CancelOrderReq{Symbol: "BTC-240628-60000-C", OrderID: order.OrderID})
+	s.Require().NoError(err)
+	s.Require().Equal(options.OrderStatusCancelled, order.Status)
+
+	positions, err := s.client.Positions(nil)
+	s.Require().NoError(err)
+	s.Require().Equal(options.PositionSideShort, positions[0].Side)
+	s.Require().Equal("-5.00138", positions[0].UnrealizedPnL)
+
+	_, err = s.client.NewOrder(&options.OrderReq{
+		Symbol: "BTC-240628-60000-C", Side: binance.OrderSideBuy, Type: "MARKET", Quantity: "1",
+	})
+	s.Require().ErrorIs(err, options.ErrInvalidOrderType)
+	_, err = s.client.NewOrder(&options.OrderReq{Symbol: "BTC-240628-60000-C", Side: binance.OrderSideBuy, Quantity: "1"})
+	s.Require().ErrorIs(err, binance.ErrEmptyPrice)
+	_, err = s.client.CancelOrder(&options.CancelOrderReq{Symbol: "BTC-240628-60000-C"})
+	s.Require().ErrorIs(err, binance.ErrEmptyOrderID)
+}
+
+func (s *optionsTestSuite) TestStreams() {
+	server := binancetest.NewServer(binancetest.Config{})
+	defer server.Close()
+	client := options.NewCustomStreamClient(server.StreamURL(), nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	s.Require().NoError(server.Publish("BTC-240628-60000-C@trade",
+		[]byte(`{"e":"trade","E":1591677941092,"s":"BTC-240628-60000-C","t":"315","p":"4.0","q":"-2.0","b":4611781675939004417,"a":4611690230147551233,"T":1591677567872,"S":"-1"}`)))
+	trades, err := client.Trades(ctx, "BTC-240628-60000-C")
+	s.Require().NoError(err)
+	defer trades.Close()
+	t, err := trades.Read()
+	s.Require().NoError(err)
+	s.Require().Equal(options.UpdateTypeTrade, t.EventType)
+	s.Require().Equal("315", t.TradeID)
+	s.Require().Equal("-1", t.Direction)
+
+	s.Require().NoError(server.Publish("BTC@markPrice",
+		[]byte(`[{"e":"markPrice","E":1663684594227,"s":"BTC-240628-60000-C","mp":"1482.2"},{"e":"markPrice","E":1663684594228,"s":"BTC-240628-60000-P","mp":"2.1"}]`)))
+	marks, err := client.MarkPrices(ctx, "BTC")
+	s.Require().NoError(err)
+	defer marks.Close()
+	m := <-marks.Stream()
+	s.Require().Len(m, 2)
+	s.Require().Equal("2.1", m[1].MarkPrice)
+
+	s.Require().NoError(server.Publish("BTC-240628-60000-C@ticker",
+		[]byte(`{"e":"24hrTicker","E":1657706425200,"T":1657706425220,"s":"BTC-240628-60000-C","o":"1000","h":"1000","l":"1000","c":"1000","V":"2","A":"2000","P":"0","p":"0","Q":"2","F":"1","L":"1","n":1,"bo":"990","ao":"1010","bq":"1","aq":"1","b":"0.51","a":"0.55","d":"0.5468","t":"-54.21","g":"0.00003","v":"95.68","vo":"0.53","mp":"1482.2","hl":"2030","ll":"935","eep":"0"}`)))
+	ticker, err := client.Ticker(ctx, "BTC-240628-60000-C")
+	s.Require().NoError(err)
+	defer ticker.Close()
+	tu, err := ticker.Read()
+	s.Require().NoError(err)
+	s.Require().Equal("0.5468", tu.Delta)
+	s.Require().Equal("0.53", tu.MarkIV)
+
+	s.Require().NoError(server.Publish("BTC-240628-60000-C@depth10@100ms",
+		[]byte(`{"e":"depth","E":1591695934010,"T":1591695934000,"s":"BTC-240628-60000-C","u":162,"pu":162,"b":[["990","1"]],"a":[["1010","0.5"]]}`)))
+	depth, err := client.Depth(ctx, "BTC-240628-60000-C", options.DepthLevel10, ws.Frequency100ms)
+	s.Require().NoError(err)
+	defer depth.Close()
+	d, err := depth.Read()
+	s.Require().NoError(err)
+	s.Require().Equal("990", d.Bids[0].Price.String())
+	s.Require().Equal("0.5", d.Asks[0].Quantity.String())
+
+	s.Require().NoError(server.Publish("BTC@openInterest@240628",
+		[]byte(`[{"e":"openInterest","E":1668759300045,"s":"BTC-240628-60000-C","o":"21.37","h":"1402537.87"}]`)))
+	interest, err := client.OpenInterest(ctx, "BTC", "240628")
+	s.Require().NoError(err)
+	defer interest.Close()
+	oi, err := interest.Read()
+	s.Require().NoError(err)
+	s.Require().Equal("1402537.87", oi[0].OpenInterestUSD)
+}
@@ -0,0 +1,129 @@
+package options
+
+import (
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+)
+
+// NewOrder sends in a new limit order and returns the created order
+func (c *Client) NewOrder(req *OrderReq) (*Order, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	req.OrderRespType = OrderRespTypeResult
+	res, err := c.Do(fasthttp.MethodPost, EndpointOrder, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &Order{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// QueryOrder checks an order's status
+func (c *Client) QueryOrder(req *QueryOrderReq) (*Order, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	if req.OrderID == 0 && req.ClientOrderID == "" {
+		return nil, binance.ErrEmptyOrderID
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointOrder, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &Order{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// CancelOrder cancel an active order
+func (c *Client) CancelOrder(req *CancelOrderReq) (*Order, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	if req.OrderID == 0 && req.ClientOrderID == "" {
+		return nil, binance.ErrEmptyOrderID
+	}
+	res, err := c.Do(fasthttp.MethodDelete, EndpointOrder, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &Order{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// CancelOpenOrders cancel all open orders on a symbol
+func (c *Client) CancelOpenOrders(req *CancelOpenOrdersReq) error {
+	if req == nil {
+		return binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return binance.ErrEmptySymbol
+	}
+	_, err := c.Do(fasthttp.MethodDelete, EndpointAllOpenOrders, req, true, false)
+
+	return err
+}
+
+// OpenOrders get all open orders on a symbol or all symbols when the symbol is not set
+func (c *Client) OpenOrders(req *OpenOrdersReq) ([]*Order, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointOpenOrders, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*Order
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// Positions get the option positions, all symbols when the symbol is not set
+func (c *Client) Positions(req *PositionReq) ([]*Position, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointPosition, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*Position
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// Validate checks the required parameters of the limit order and sets the default time in force
+func (req *OrderReq) Validate() error {
+	switch {
+	case req == nil:
+		return binance.ErrNilRequest
+	case req.Symbol == "":
+		return binance.ErrEmptySymbol
+	case req.Side == "":
+		return binance.ErrEmptySide
+	case req.Type == "":
+		req.Type = OrderTypeLimit
+	case req.Type != OrderTypeLimit:
+		return ErrInvalidOrderType
+	}
+	switch {
+	case req.Price == "":
+		return binance.ErrEmptyPrice
+	case req.Quantity == "":
+		return binance.ErrEmptyQuantity
+	case req.TimeInForce == "":
+		req.TimeInForce = binance.TimeInForceGTC
+	}
+
+	return nil
+}
@@ -0,0 +1,350 @@
+package options
+
+import (
+	"context"
+	"net"
+
+	"github.com/segmentio/encoding/json"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/ws"
+)
+
+const DefaultStreamPath = "wss://nbstream.binance.com/eoptions/ws/"
+
+// StreamClient opens the options streams. The streams are named by the option symbols
+// and the underlyings in the upper case, unlike the spot ones
+type StreamClient struct {
+	client *ws.Client
+}
+
+func NewStreamClient() *StreamClient {
+	return &StreamClient{ws.NewCustomClient(DefaultStreamPath, nil)}
+}
+
+func NewCustomStreamClient(prefix string, conn net.Conn) *StreamClient {
+	return &StreamClient{ws.NewCustomClient(prefix, conn)}
+}
+
+// Trades opens websocket with the trades of the given option symbol or of all options of the underlying asset, e.g. BTC
+func (c *StreamClient) Trades(ctx context.Context, symbol string) (*TradeStream, error) {
+	conn, err := c.client.Dial(ctx, symbol+EndpointTradeStream)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TradeStream{conn}, nil
+}
+
+// Index opens websocket with the index price updates of the given underlying, e.g. BTCUSDT
+func (c *StreamClient) Index(ctx context.Context, underlying string) (*IndexStream, error) {
+	conn, err := c.client.Dial(ctx, underlying+EndpointIndexStream)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IndexStream{conn}, nil
+}
+
+// MarkPrices opens websocket with the mark prices of all options of the given underlying asset, e.g. BTC
+func (c *StreamClient) MarkPrices(ctx context.Context, underlyingAsset string) (*MarkPriceStream, error) {
+	conn, err := c.client.Dial(ctx, underlyingAsset+EndpointMarkPriceStream)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MarkPriceStream{conn}, nil
+}
+
+// Klines opens websocket with klines updates for the given option symbol with the given interval
+func (c *StreamClient) Klines(ctx context.Context, symbol string, interval binance.KlineInterval) (*KlineStream, error) {
+	conn, err := c.client.Dial(ctx, symbol+EndpointKlineStream+string(interval))
+	if err != nil {
+		return nil, err
+	}
+
+	return &KlineStream{conn}, nil
+}
+
+// Ticker opens websocket with the 24hr ticker with the greeks of the given option symbol
+func (c *StreamClient) Ticker(ctx context.Context, symbol string) (*TickerStream, error) {
+	conn, err := c.client.Dial(ctx, symbol+EndpointTickerStream)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TickerStream{conn}, nil
+}
+
+// Depth opens websocket with the order book levels of the given option symbol, the empty frequency is 500ms
+func (c *StreamClient) Depth(ctx context.Context, symbol string, level DepthLevel, frequency ws.FrequencyType) (*DepthStream, error) {
+	conn, err := c.client.Dial(ctx, symbol+EndpointDepthStream+string(level)+string(frequency))
+	if err != nil {
+		return nil, err
+	}
+
+	return &DepthStream{conn}, nil
+}
+
+// OpenInterest opens websocket with the open interest of the options of the underlying asset expiring on the date,
+// the expiration is in the ExpiryDateLayout
+func (c *StreamClient) OpenInterest(ctx context.Context, underlyingAsset, expiration string) (*OpenInterestStream, error) {
+	conn, err := c.client.Dial(ctx, underlyingAsset+EndpointOpenInterestStream+expiration)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OpenInterestStream{conn}, nil
+}
+
+// TradeStream is a wrapper for trades websocket
+type TradeStream struct {
+	ws.Conn
+}
+
+// Read reads a trade update message from trades websocket
+func (s *TradeStream) Read() (*TradeUpdate, error) {
+	r := &TradeUpdate{}
+	err := s.Conn.ReadValue(r)
+
+	return r, err
+}
+
+// Stream NewStream a trade update message from trades websocket to channel
+func (s *TradeStream) Stream() <-chan *TradeUpdate {
+	updates := make(chan *TradeUpdate)
+	go s.NewStream(func(dec *json.Decoder, err error) error {
+		if err != nil {
+			close(updates)
+			return err
+		}
+
+		u := &TradeUpdate{}
+		err = dec.Decode(u)
+		if err != nil {
+			close(updates)
+			return err
+		}
+		updates <- u
+
+		return nil
+	})
+
+	return updates
+}
+
+// IndexStream is a wrapper for index price websocket
+type IndexStream struct {
+	ws.Conn
+}
+
+// Read reads an index price update message from index price websocket
+func (s *IndexStream) Read() (*IndexUpdate, error) {
+	r := &IndexUpdate{}
+	err := s.Conn.ReadValue(r)
+
+	return r, err
+}
+
+// Stream NewStream an index price update message from index price websocket to channel
+func (s *IndexStream) Stream() <-chan *IndexUpdate {
+	updates := make(chan *IndexUpdate)
+	go s.NewStream(func(dec *json.Decoder, err error) error {
+		if err != nil {
+			close(updates)
+			return err
+		}
+
+		u := &IndexUpdate{}
+		err = dec.Decode(u)
+		if err != nil {
+			close(updates)
+			return err
+		}
+		updates <- u
+
+		return nil
+	})
+
+	return updates
+}
+
+// MarkPriceStream is a wrapper for mark price websocket
+type MarkPriceStream struct {
+	ws.Conn
+}
+
+// Read reads a mark prices update message from mark price websocket
+func (s *MarkPriceStream) Read() (AllMarkPriceUpdate, error) {
+	var r AllMarkPriceUpdate
+	err := s.Conn.ReadValue(&r)
+
+	return r, err
+}
+
+// Stream NewStream a mark prices update message from mark price websocket to channel
+func (s *MarkPriceStream) Stream() <-chan AllMarkPriceUpdate {
+	updates := make(chan AllMarkPriceUpdate)
+	go s.NewStream(func(dec *json.Decoder, err error) error {
+		if err != nil {
+			close(updates)
+			return err
+		}
+
+		var u AllMarkPriceUpdate
+		err = dec.Decode(&u)
+		if err != nil {
+			close(updates)
+			return err
+		}
+		updates <- u
+
+		return nil
+	})
+
+	return updates
+}
+
+// KlineStream is a wrapper for klines websocket
+type KlineStream struct {
+	ws.Conn
+}
+
+// Read reads a kline update message from klines websocket
+func (s *KlineStream) Read() (*KlineUpdate, error) {
+	r := &KlineUpdate{}
+	err := s.Conn.ReadValue(r)
+
+	return r, err
+}
+
+// Stream NewStream a kline update message from klines websocket to channel
+func (s *KlineStream) Stream() <-chan *KlineUpdate {
+	updates := make(chan *KlineUpdate)
+	go s.NewStream(func(dec *json.Decoder, err error) error {
+		if err != nil {
+			close(updates)
+			return err
+		}
+
+		u := &KlineUpdate{}
+		err = dec.Decode(u)
+		if err != nil {
+			close(updates)
+			return err
+		}
+		updates <- u
+
+		return nil
+	})
+
+	return updates
+}
+
+// TickerStream is a wrapper for ticker websocket
+type TickerStream struct {
+	ws.Conn
+}
+
+// Read reads a ticker update message from ticker websocket
+func (s *TickerStream) Read() (*TickerUpdate, error) {
+	r := &TickerUpdate{}
+	err := s.Conn.ReadValue(r)
+
+	return r, err
+}
+
+// Stream NewStream a ticker update message from ticker websocket to channel
+func (s *TickerStream) Stream() <-chan *TickerUpdate {
+	updates := make(chan *TickerUpdate)
+	go s.NewStream(func(dec *json.Decoder, err error) error {
+		if err != nil {
+			close(updates)
+			return err
+		}
+
+		u := &TickerUpdate{}
+		err = dec.Decode(u)
+		if err != nil {
+			close(updates)
+			return err
+		}
+		updates <- u
+
+		return nil
+	})
+
+	return updates
+}
+
+// DepthStream is a wrapper for depth websocket
+type DepthStream struct {
+	ws.Conn
+}
+
+// Read reads a depth update message from depth websocket
+func (s *DepthStream) Read() (*DepthUpdate, error) {
+	r := &DepthUpdate{}
+	err := s.Conn.ReadValue(r)
+
+	return r, err
+}
+
+// Stream NewStream a depth update message from depth websocket to channel
+func (s *DepthStream) Stream() <-chan *DepthUpdate {
+	updates := make(chan *DepthUpdate)
+	go s.NewStream(func(dec *json.Decoder, err error) error {
+		if err != nil {
+			close(updates)
+			return err
+		}
+
+		u := &DepthUpdate{}
+		err = dec.Decode(u)
+		if err != nil {
+			close(updates)
+			return err
+		}
+		updates <- u
+
+		return nil
+	})
+
+	return updates
+}
+
+// OpenInterestStream is a wrapper for open interest websocket
+type OpenInterestStream struct {
+	ws.Conn
+}
+
+// Read reads an open interest update message from open interest websocket
+func (s *OpenInterestStream) Read() (AllOpenInterestUpdate, error) {
+	var r AllOpenInterestUpdate
+	err := s.Conn.ReadValue(&r)
+
+	return r, err
+}
+
+// Stream NewStream an open interest update message from open interest websocket to channel
+func (s *OpenInterestStream) Stream() <-chan AllOpenInterestUpdate {
+	updates := make(chan AllOpenInterestUpdate)
+	go s.NewStream(func(dec *json.Decoder, err error) error {
+		if err != nil {
+			close(updates)
+			return err
+		}
+
+		var u AllOpenInterestUpdate
+		err = dec.Decode(&u)
+		if err != nil {
+			close(updates)
+			return err
+		}
+		updates <- u
+
+		return nil
+	})
+
+	return updates
+}
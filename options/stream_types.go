@@ -0,0 +1,148 @@
+package options
+
+import (
+	"github.com/xenking/binance-api"
+)
+
+// UpdateType represents type of the options stream event
+type UpdateType string
+
+const (
+	UpdateTypeTrade        UpdateType = "trade"
+	UpdateTypeIndex        UpdateType = "index"
+	UpdateTypeMarkPrice    UpdateType = "markPrice"
+	UpdateTypeKline        UpdateType = "kline"
+	UpdateTypeTicker       UpdateType = "24hrTicker"
+	UpdateTypeDepth        UpdateType = "depth"
+	UpdateTypeOpenInterest UpdateType = "openInterest"
+)
+
+// DepthLevel is the number of the order book levels of the depth stream
+type DepthLevel string
+
+const (
+	DepthLevel10  DepthLevel = "10"
+	DepthLevel20  DepthLevel = "20"
+	DepthLevel50  DepthLevel = "50"
+	DepthLevel100 DepthLevel = "100"
+)
+
+// TradeUpdate represents the trade of the option
+type TradeUpdate struct {
+	EventType   UpdateType `json:"e"` // EventType represents the update type
+	Time        int64      `json:"E"` // Time represents the event time
+	Symbol      string     `json:"s"` // Symbol represents the symbol related to the update
+	TradeID     string     `json:"t"` // TradeID is the trade id
+	Price       string     `json:"p"` // Price is the trade price
+	Quantity    string     `json:"q"` // Quantity is the trade quantity
+	BuyOrderID  int64      `json:"b"` // BuyOrderID is the buyer order id
+	SellOrderID int64      `json:"a"` // SellOrderID is the seller order id
+	TradeTime   int64      `json:"T"` // TradeTime is the trade time
+	Direction   string     `json:"S"` // Direction is 1 when the taker is the buyer and -1 when the seller
+}
+
+// IndexUpdate represents the index price of the underlying
+type IndexUpdate struct {
+	EventType UpdateType `json:"e"` // EventType represents the update type
+	Time      int64      `json:"E"` // Time represents the event time
+	Symbol    string     `json:"s"` // Symbol represents the underlying, e.g. BTCUSDT
+	Price     string     `json:"p"` // Price is the index price
+}
+
+// MarkPriceUpdate represents the mark price of the option
+type MarkPriceUpdate struct {
+	EventType UpdateType `json:"e"`  // EventType represents the update type
+	Time      int64      `json:"E"`  // Time represents the event time
+	Symbol    string     `json:"s"`  // Symbol represents the symbol related to the update
+	MarkPrice string     `json:"mp"` // MarkPrice is the mark price
+}
+
+// AllMarkPriceUpdate represents the mark prices of all options of the underlying asset
+type AllMarkPriceUpdate []*MarkPriceUpdate
+
+// KlineUpdate represents the kline of the option
+type KlineUpdate struct {
+	EventType UpdateType  `json:"e"` // EventType represents the update type
+	Time      int64       `json:"E"` // Time represents the event time
+	Symbol    string      `json:"s"` // Symbol represents the symbol related to the update
+	Kline     StreamKline `json:"k"` // Kline is the kline update
+}
+
+// StreamKline is the kline of the options streams
+type StreamKline struct {
+	StartTime    int64                 `json:"t"` // StartTime is the start time of this bar
+	EndTime      int64                 `json:"T"` // EndTime is the end time of this bar
+	Interval     binance.KlineInterval `json:"i"` // Interval is the kline interval
+	FirstTradeID int64                 `json:"F"` // FirstTradeID is the first trade ID
+	LastTradeID  int64                 `json:"L"` // LastTradeID is the last trade ID
+
+	OpenPrice            string `json:"o"` // OpenPrice represents the open price for this bar
+	ClosePrice           string `json:"c"` // ClosePrice represents the close price for this bar
+	High                 string `json:"h"` // High represents the highest price for this bar
+	Low                  string `json:"l"` // Low represents the lowest price for this bar
+	Volume               string `json:"v"` // Volume is the trades volume in contracts for this bar
+	Trades               int    `json:"n"` // Trades is the number of conducted trades
+	Final                bool   `json:"x"` // Final indicates whether this bar is final or yet may receive updates
+	VolumeQuote          string `json:"q"` // VolumeQuote indicates the quote volume for the symbol
+	VolumeActiveBuy      string `json:"V"` // VolumeActiveBuy represents the volume of active buy
+	VolumeQuoteActiveBuy string `json:"Q"` // VolumeQuoteActiveBuy represents the quote volume of active buy
+}
+
+// TickerUpdate represents the 24hr ticker of the option with the implied volatilities and the greeks
+type TickerUpdate struct {
+	EventType          UpdateType `json:"e"`   // EventType represents the update type
+	Time               int64      `json:"E"`   // Time represents the event time
+	TransactTime       int64      `json:"T"`   // TransactTime is the transaction time
+	Symbol             string     `json:"s"`   // Symbol represents the symbol related to the update
+	OpenPrice          string     `json:"o"`   // OpenPrice is the 24hr open price
+	HighPrice          string     `json:"h"`   // HighPrice is the 24hr highest price
+	LowPrice           string     `json:"l"`   // LowPrice is the 24hr lowest price
+	LastPrice          string     `json:"c"`   // LastPrice is the latest price
+	Volume             string     `json:"V"`   // Volume is the trading volume in contracts
+	Amount             string     `json:"A"`   // Amount is the trading amount in the quote asset
+	PriceChangePercent string     `json:"P"`   // PriceChangePercent is the price change percent
+	PriceChange        string     `json:"p"`   // PriceChange is the price change
+	LastQty            string     `json:"Q"`   // LastQty is the volume of the last trade
+	FirstTradeID       string     `json:"F"`   // FirstTradeID is the first trade id
+	LastTradeID        string     `json:"L"`   // LastTradeID is the last trade id
+	TradeCount         int64      `json:"n"`   // TradeCount is the number of trades
+	BidPrice           string     `json:"bo"`  // BidPrice is the best bid price
+	AskPrice           string     `json:"ao"`  // AskPrice is the best ask price
+	BidQty             string     `json:"bq"`  // BidQty is the best bid quantity
+	AskQty             string     `json:"aq"`  // AskQty is the best ask quantity
+	BidIV              string     `json:"b"`   // BidIV is the implied volatility of the bid
+	AskIV              string     `json:"a"`   // AskIV is the implied volatility of the ask
+	Delta              string     `json:"d"`   // Delta is the delta
+	Theta              string     `json:"t"`   // Theta is the theta
+	Gamma              string     `json:"g"`   // Gamma is the gamma
+	Vega               string     `json:"v"`   // Vega is the vega
+	MarkIV             string     `json:"vo"`  // MarkIV is the implied volatility of the mark price
+	MarkPrice          string     `json:"mp"`  // MarkPrice is the mark price
+	HighPriceLimit     string     `json:"hl"`  // HighPriceLimit is the highest buy price
+	LowPriceLimit      string     `json:"ll"`  // LowPriceLimit is the lowest sell price
+	ExercisePrice      string     `json:"eep"` // ExercisePrice is the estimated strike price, only set in the last hour before the exercise
+}
+
+// DepthUpdate represents the order book levels of the option
+type DepthUpdate struct {
+	EventType    UpdateType          `json:"e"`  // EventType represents the update type
+	Time         int64               `json:"E"`  // Time represents the event time
+	TransactTime int64               `json:"T"`  // TransactTime is the transaction time
+	Symbol       string              `json:"s"`  // Symbol represents the symbol related to the update
+	UpdateID     int64               `json:"u"`  // UpdateID is the update id
+	PrevUpdateID int64               `json:"pu"` // PrevUpdateID is the update id of the previous event
+	Bids         []binance.DepthElem `json:"b"`  // Bids is the list of the bids
+	Asks         []binance.DepthElem `json:"a"`  // Asks is the list of the asks
+}
+
+// OpenInterestUpdate represents the open interest of the option
+type OpenInterestUpdate struct {
+	EventType       UpdateType `json:"e"` // EventType represents the update type
+	Time            int64      `json:"E"` // Time represents the event time
+	Symbol          string     `json:"s"` // Symbol represents the symbol related to the update
+	OpenInterest    string     `json:"o"` // OpenInterest is the open interest in contracts
+	OpenInterestUSD string     `json:"h"` // OpenInterestUSD is the open interest in USDT
+}
+
+// AllOpenInterestUpdate represents the open interest of all options of the underlying asset expiring on the date
+type AllOpenInterestUpdate []*OpenInterestUpdate
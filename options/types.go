@@ -0,0 +1,292 @@
+package options
+
+import (
+	"strings"
+	"time"
+
+	"github.com/xenking/binance-api"
+)
+
+// OptionSide is the kind of the option contract
+type OptionSide string
+
+const (
+	OptionSideCall OptionSide = "CALL"
+	OptionSidePut  OptionSide = "PUT"
+)
+
+// ExpiryDateLayout is the layout of the expiry date in the symbols and the open interest requests, e.g. 240628
+const ExpiryDateLayout = "060102"
+
+// Contract is the parsed option symbol, e.g. BTC-240628-60000-C is the BTC call expiring on 28 Jun 2024 with the 60000 strike
+type Contract struct {
+	Underlying string     // Underlying is the base asset of the underlying index, e.g. BTC
+	Expiry     time.Time  // Expiry is the UTC date of the expiry, the options are exercised at 08:00 UTC
+	Strike     string     // Strike is the strike price
+	Side       OptionSide // Side is either the call or the put
+}
+
+// ParseContract parses the option symbol of the UNDERLYING-YYMMDD-STRIKE-C|P scheme
+func ParseContract(symbol string) (*Contract, error) {
+	parts := strings.Split(symbol, "-")
+	if len(parts) != 4 || parts[0] == "" || parts[2] == "" {
+		return nil, ErrInvalidSymbol
+	}
+	expiry, err := time.Parse(ExpiryDateLayout, parts[1])
+	if err != nil {
+		return nil, ErrInvalidSymbol
+	}
+	c := &Contract{Underlying: parts[0], Expiry: expiry, Strike: parts[2]}
+	switch parts[3] {
+	case "C":
+		c.Side = OptionSideCall
+	case "P":
+		c.Side = OptionSidePut
+	default:
+		return nil, ErrInvalidSymbol
+	}
+
+	return c, nil
+}
+
+// String formats the contract as the option symbol
+func (c *Contract) String() string {
+	side := "C"
+	if c.Side == OptionSidePut {
+		side = "P"
+	}
+
+	return c.Underlying + "-" + c.Expiry.Format(ExpiryDateLayout) + "-" + c.Strike + "-" + side
+}
+
+// OrderType represents the options order type, only the limit orders are supported
+type OrderType string
+
+const OrderTypeLimit OrderType = "LIMIT"
+
+// OrderStatus represents the options order status, it differs from the spot one
+type OrderStatus string
+
+const (
+	OrderStatusAccepted        OrderStatus = "ACCEPTED"
+	OrderStatusRejected        OrderStatus = "REJECTED"
+	OrderStatusPartiallyFilled OrderStatus = "PARTIALLY_FILLED"
+	OrderStatusFilled          OrderStatus = "FILLED"
+	OrderStatusCancelled       OrderStatus = "CANCELLED"
+)
+
+type OrderRespType string
+
+const (
+	OrderRespTypeAck    OrderRespType = "ACK"
+	OrderRespTypeResult OrderRespType = "RESULT"
+)
+
+// PositionSide is the direction of the position
+type PositionSide string
+
+const (
+	PositionSideLong  PositionSide = "LONG"
+	PositionSideShort PositionSide = "SHORT"
+)
+
+type ExchangeInfo struct {
+	Timezone        string               `json:"timezone"`
+	ServerTime      int64                `json:"serverTime"`
+	RateLimits      []*binance.RateLimit `json:"rateLimits"`
+	OptionContracts []*ContractInfo      `json:"optionContracts"`
+	OptionAssets    []*AssetInfo         `json:"optionAssets"`
+	OptionSymbols   []*SymbolInfo        `json:"optionSymbols"`
+}
+
+// ContractInfo describes the underlying of the options
+type ContractInfo struct {
+	ID          int64  `json:"id"`
+	BaseAsset   string `json:"baseAsset"`
+	QuoteAsset  string `json:"quoteAsset"`
+	Underlying  string `json:"underlying"`
+	SettleAsset string `json:"settleAsset"`
+}
+
+type AssetInfo struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type SymbolInfo struct {
+	ID                   int64                      `json:"id"`
+	ContractID           int64                      `json:"contractId"`
+	Symbol               string                     `json:"symbol"`
+	Underlying           string                     `json:"underlying"`
+	Side                 OptionSide                 `json:"side"`
+	StrikePrice          string                     `json:"strikePrice"`
+	ExpiryDate           int64                      `json:"expiryDate"`
+	Unit                 int                        `json:"unit"` // Unit is the quantity of the underlying per contract
+	QuoteAsset           string                     `json:"quoteAsset"`
+	MakerFeeRate         string                     `json:"makerFeeRate"`
+	TakerFeeRate         string                     `json:"takerFeeRate"`
+	MinQty               string                     `json:"minQty"`
+	MaxQty               string                     `json:"maxQty"`
+	InitialMargin        string                     `json:"initialMargin"`
+	MaintenanceMargin    string                     `json:"maintenanceMargin"`
+	MinInitialMargin     string                     `json:"minInitialMargin"`
+	MinMaintenanceMargin string                     `json:"minMaintenanceMargin"`
+	PriceScale           int                        `json:"priceScale"`
+	QuantityScale        int                        `json:"quantityScale"`
+	Filters              []binance.SymbolInfoFilter `json:"filters"`
+}
+
+const (
+	DefaultDepthLimit  = 100
+	MaxDepthLimit      = 1000
+	DefaultKlinesLimit = 500
+	MaxKlinesLimit     = 1500
+)
+
+// Depth is the order book of the option, the levels are decoded the same way as the spot ones
+type Depth struct {
+	TransactTime int64               `json:"T"`
+	UpdateID     int64               `json:"u"`
+	Bids         []binance.DepthElem `json:"bids"`
+	Asks         []binance.DepthElem `json:"asks"`
+}
+
+type KlinesReq struct {
+	Symbol    string                `url:"symbol"`
+	Interval  binance.KlineInterval `url:"interval"`
+	StartTime int64                 `url:"startTime,omitempty"`
+	EndTime   int64                 `url:"endTime,omitempty"`
+	Limit     int                   `url:"limit,omitempty"` // Limit is the maximal number of elements to receive. Default 500; Max 1500
+}
+
+// Kline is the options kline, unlike the spot one it is sent as the object
+type Kline struct {
+	OpenTime    int64                 `json:"openTime"`
+	CloseTime   int64                 `json:"closeTime"`
+	Interval    binance.KlineInterval `json:"interval"`
+	Open        string                `json:"open"`
+	High        string                `json:"high"`
+	Low         string                `json:"low"`
+	Close       string                `json:"close"`
+	Volume      string                `json:"volume"`
+	Amount      string                `json:"amount"`
+	TakerVolume string                `json:"takerVolume"`
+	TakerAmount string                `json:"takerAmount"`
+	TradeCount  int64                 `json:"tradeCount"`
+}
+
+type MarkReq struct {
+	Symbol string `url:"symbol,omitempty"`
+}
+
+// Mark is the mark price with the implied volatilities and the greeks of the option
+type Mark struct {
+	Symbol           string `json:"symbol"`
+	MarkPrice        string `json:"markPrice"`
+	BidIV            string `json:"bidIV"`
+	AskIV            string `json:"askIV"`
+	MarkIV           string `json:"markIV"`
+	Delta            string `json:"delta"`
+	Theta            string `json:"theta"`
+	Gamma            string `json:"gamma"`
+	Vega             string `json:"vega"`
+	HighPriceLimit   string `json:"highPriceLimit"`
+	LowPriceLimit    string `json:"lowPriceLimit"`
+	RiskFreeInterest string `json:"riskFreeInterest"`
+}
+
+type OpenInterestReq struct {
+	UnderlyingAsset string `url:"underlyingAsset"` // UnderlyingAsset is the base asset, e.g. BTC
+	Expiration      string `url:"expiration"`      // Expiration is the expiry date in the ExpiryDateLayout
+}
+
+type OpenInterest struct {
+	Symbol             string `json:"symbol"`
+	SumOpenInterest    string `json:"sumOpenInterest"`
+	SumOpenInterestUSD string `json:"sumOpenInterestUsd"`
+	Timestamp          string `json:"timestamp"`
+}
+
+type OrderReq struct {
+	Symbol        string              `url:"symbol"`
+	Side          binance.OrderSide   `url:"side"`
+	Type          OrderType           `url:"type"`
+	Quantity      string              `url:"quantity"`
+	Price         string              `url:"price"`
+	TimeInForce   binance.TimeInForce `url:"timeInForce,omitempty"` // TimeInForce is GTC by default
+	ReduceOnly    bool                `url:"reduceOnly,omitempty"`
+	PostOnly      bool                `url:"postOnly,omitempty"`
+	OrderRespType OrderRespType       `url:"newOrderRespType,omitempty"`
+	ClientOrderID string              `url:"clientOrderId,omitempty"`
+	IsMMP         bool                `url:"isMmp,omitempty"` // IsMMP marks the order of the market maker protection
+}
+
+type Order struct {
+	OrderID       int64               `json:"orderId"`
+	Symbol        string              `json:"symbol"`
+	Price         string              `json:"price"`
+	Quantity      string              `json:"quantity"`
+	ExecutedQty   string              `json:"executedQty"`
+	Fee           string              `json:"fee"`
+	Side          binance.OrderSide   `json:"side"`
+	Type          OrderType           `json:"type"`
+	TimeInForce   binance.TimeInForce `json:"timeInForce"`
+	ReduceOnly    bool                `json:"reduceOnly"`
+	PostOnly      bool                `json:"postOnly"`
+	CreateTime    int64               `json:"createTime"`
+	UpdateTime    int64               `json:"updateTime"`
+	Status        OrderStatus         `json:"status"`
+	AvgPrice      string              `json:"avgPrice"`
+	ClientOrderID string              `json:"clientOrderId"`
+	PriceScale    int                 `json:"priceScale"`
+	QuantityScale int                 `json:"quantityScale"`
+	OptionSide    OptionSide          `json:"optionSide"`
+	QuoteAsset    string              `json:"quoteAsset"`
+	MMP           bool                `json:"mmp"`
+}
+
+type QueryOrderReq struct {
+	Symbol        string `url:"symbol"`
+	OrderID       int64  `url:"orderId,omitempty"`
+	ClientOrderID string `url:"clientOrderId,omitempty"`
+}
+
+type CancelOrderReq struct {
+	Symbol        string `url:"symbol"`
+	OrderID       int64  `url:"orderId,omitempty"`
+	ClientOrderID string `url:"clientOrderId,omitempty"`
+}
+
+type CancelOpenOrdersReq struct {
+	Symbol string `url:"symbol"`
+}
+
+type OpenOrdersReq struct {
+	Symbol    string `url:"symbol,omitempty"`
+	OrderID   int64  `url:"orderId,omitempty"` // OrderID returns the orders from the id, the latest otherwise
+	StartTime int64  `url:"startTime,omitempty"`
+	EndTime   int64  `url:"endTime,omitempty"`
+}
+
+type PositionReq struct {
+	Symbol string `url:"symbol,omitempty"`
+}
+
+type Position struct {
+	Symbol        string       `json:"symbol"`
+	Side          PositionSide `json:"side"`
+	Quantity      string       `json:"quantity"`
+	ReducibleQty  string       `json:"reducibleQty"`
+	EntryPrice    string       `json:"entryPrice"`
+	MarkPrice     string       `json:"markPrice"`
+	MarkValue     string       `json:"markValue"`
+	PositionCost  string       `json:"positionCost"`
+	UnrealizedPnL string       `json:"unrealizedPNL"`
+	ROR           string       `json:"ror"` // ROR is the rate of return
+	StrikePrice   string       `json:"strikePrice"`
+	ExpiryDate    int64        `json:"expiryDate"`
+	OptionSide    OptionSide   `json:"optionSide"`
+	QuoteAsset    string       `json:"quoteAsset"`
+	PriceScale    int          `json:"priceScale"`
+	QuantityScale int          `json:"quantityScale"`
+}
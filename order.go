@@ -1,13 +1,18 @@
 package binance
 
 import (
+	"net"
+	"strings"
+	"time"
+
+	"github.com/go-faster/errors"
 	"github.com/segmentio/encoding/json"
 	"github.com/valyala/fasthttp"
 )
 
 // NewOrder sends in a new order
 func (c *Client) NewOrder(req *OrderReq) (*OrderRespAck, error) {
-	if err := c.validateNewOrderReq(req); err != nil {
+	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 	req.OrderRespType = OrderRespTypeAsk
@@ -24,7 +29,7 @@ func (c *Client) NewOrder(req *OrderReq) (*OrderRespAck, error) {
 
 // NewOrderResult sends in a new order and return created order
 func (c *Client) NewOrderResult(req *OrderReq) (*OrderRespResult, error) {
-	if err := c.validateNewOrderReq(req); err != nil {
+	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 	req.OrderRespType = OrderRespTypeResult
@@ -41,7 +46,7 @@ func (c *Client) NewOrderResult(req *OrderReq) (*OrderRespResult, error) {
 
 // NewOrderFull sends in a new order and return created full order info
 func (c *Client) NewOrderFull(req *OrderReq) (*OrderRespFull, error) {
-	if err := c.validateNewOrderReq(req); err != nil {
+	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 	req.OrderRespType = OrderRespTypeFull
@@ -58,7 +63,7 @@ func (c *Client) NewOrderFull(req *OrderReq) (*OrderRespFull, error) {
 
 // NewOrderTest tests new order creation and signature/recvWindow long. Creates and validates a new order but does not send it into the matching engine
 func (c *Client) NewOrderTest(req *OrderReq) error {
-	if err := c.validateNewOrderReq(req); err != nil {
+	if err := req.Validate(); err != nil {
 		return err
 	}
 	_, err := c.Do(fasthttp.MethodPost, EndpointOrderTest, req, true, false)
@@ -66,6 +71,89 @@ func (c *Client) NewOrderTest(req *OrderReq) error {
 	return err
 }
 
+const DefaultSubmitAttempts = 3
+
+// SubmitOrderConfig configures the idempotent order submission
+type SubmitOrderConfig struct {
+	Attempts   int           // Attempts is the max number of order placements. Default 3
+	QueryDelay time.Duration // QueryDelay is the pause before querying the order with unknown execution status
+}
+
+func (c SubmitOrderConfig) defaults() SubmitOrderConfig {
+	if c.Attempts <= 0 {
+		c.Attempts = DefaultSubmitAttempts
+	}
+
+	return c
+}
+
+// SubmitOrder sends in a new order and makes sure it is placed at most once.
+// When the placement times out or fails with unknown execution status, the order is queried by its client order id
+// and sent again only if it doesn't exist.
+// Remark: NewClientOrderID must be set, use ClientOrderIDGenerator to get unique ids
+func (c *Client) SubmitOrder(req *OrderReq, config SubmitOrderConfig) (*OrderRespAck, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if req.NewClientOrderID == "" {
+		return nil, ErrEmptyClientOrderID
+	}
+	if !ValidClientOrderID(req.NewClientOrderID) {
+		return nil, ErrInvalidClientOrderID
+	}
+	config = config.defaults()
+
+	var err error
+	for attempt := 0; attempt < config.Attempts; attempt++ {
+		var resp *OrderRespAck
+		resp, err = c.NewOrder(req)
+		// Duplicate rejection on resend means the previous attempt was placed after all
+		if err == nil || !(isUnknownExecutionErr(err) || attempt > 0 && isDuplicateOrderErr(err)) {
+			return resp, err
+		}
+
+		if config.QueryDelay > 0 {
+			time.Sleep(config.QueryDelay)
+		}
+		order, queryErr := c.QueryOrder(&QueryOrderReq{Symbol: req.Symbol, OrigClientOrderID: req.NewClientOrderID})
+		if queryErr == nil {
+			return &OrderRespAck{
+				Symbol:        order.Symbol,
+				OrderID:       order.OrderID,
+				OrderListID:   order.OrderListID,
+				ClientOrderID: order.ClientOrderID,
+				TransactTime:  order.Time,
+			}, nil
+		}
+		var apiErr *APIError
+		if !errors.As(queryErr, &apiErr) || apiErr.Code != ErrorCodeNoSuchOrder {
+			return nil, errors.Wrap(queryErr, "query order with unknown execution status")
+		}
+	}
+
+	return nil, err
+}
+
+func isDuplicateOrderErr(err error) bool {
+	var apiErr *APIError
+
+	return errors.As(err, &apiErr) && apiErr.Code == ErrorCodeNewOrderRejected && strings.Contains(apiErr.Msg, "Duplicate")
+}
+
+// isUnknownExecutionErr checks whether the order could have been placed despite the error
+func isUnknownExecutionErr(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code == ErrorCodeTimeout
+	}
+	if errors.Is(err, fasthttp.ErrTimeout) {
+		return true
+	}
+	var netErr net.Error
+
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
 // QueryOrder checks an order's status
 func (c *Client) QueryOrder(req *QueryOrderReq) (*QueryOrder, error) {
 	if req == nil {
@@ -134,7 +222,7 @@ func (c *Client) CancelReplaceOrder(req *CancelReplaceOrderReq) (*CancelReplaceO
 	if req.CancelOrderID == 0 && req.CancelOrigClientOrderID == "" {
 		return nil, ErrEmptyOrderID
 	}
-	if err := c.validateNewOrderReq(&req.OrderReq); err != nil {
+	if err := req.OrderReq.Validate(); err != nil {
 		return nil, err
 	}
 	if req.CancelReplaceMode == "" {
@@ -187,7 +275,8 @@ func (c *Client) AllOrders(req *AllOrdersReq) ([]*QueryOrder, error) {
 	return resp, err
 }
 
-func (c *Client) validateNewOrderReq(req *OrderReq) error {
+// Validate checks the required parameters of the order type and sets the default time in force of limit orders
+func (req *OrderReq) Validate() error {
 	switch {
 	case req == nil:
 		return ErrNilRequest
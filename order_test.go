@@ -4,6 +4,8 @@ import (
 	"math/rand"
 
 	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+
 	"github.com/xenking/binance-api"
 )
 
@@ -394,3 +396,99 @@ func (s *mockedOrderTestSuite) TestCancelOpenOrders() {
 	s.Require().NoError(e)
 	s.Require().EqualValues(expected, actual)
 }
+
+func (s *mockedOrderTestSuite) TestSubmitOrderTimeoutPlaced() {
+	var placed, queried int
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		switch method {
+		case fasthttp.MethodPost:
+			placed++
+			return nil, &binance.APIError{Code: binance.ErrorCodeTimeout, Msg: "Timeout waiting for response from backend server."}
+		case fasthttp.MethodGet:
+			queried++
+			s.Require().IsType(&binance.QueryOrderReq{}, data)
+			req := data.(*binance.QueryOrderReq)
+			s.Require().Equal("client-id-1", req.OrigClientOrderID)
+			return json.Marshal(&binance.QueryOrder{
+				Symbol:        req.Symbol,
+				OrderID:       42,
+				ClientOrderID: req.OrigClientOrderID,
+				Status:        binance.OrderStatusNew,
+			})
+		}
+		s.FailNow("unexpected method", method)
+		return nil, nil
+	}
+
+	resp, e := s.client.SubmitOrder(&binance.OrderReq{
+		Symbol:           "LTCBTC",
+		Side:             binance.OrderSideSell,
+		Type:             binance.OrderTypeLimit,
+		Quantity:         "1",
+		Price:            "0.1",
+		NewClientOrderID: "client-id-1",
+	}, binance.SubmitOrderConfig{})
+	s.Require().NoError(e)
+	s.Require().EqualValues(42, resp.OrderID)
+	s.Require().Equal(1, placed)
+	s.Require().Equal(1, queried)
+}
+
+func (s *mockedOrderTestSuite) TestSubmitOrderTimeoutResend() {
+	var placed int
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		if method == fasthttp.MethodGet {
+			return nil, &binance.APIError{Code: binance.ErrorCodeNoSuchOrder, Msg: "Order does not exist."}
+		}
+		placed++
+		if placed == 1 {
+			return nil, fasthttp.ErrTimeout
+		}
+		req := data.(*binance.OrderReq)
+		return json.Marshal(&binance.OrderRespAck{
+			Symbol:        req.Symbol,
+			OrderID:       43,
+			ClientOrderID: req.NewClientOrderID,
+		})
+	}
+
+	resp, e := s.client.SubmitOrder(&binance.OrderReq{
+		Symbol:           "LTCBTC",
+		Side:             binance.OrderSideSell,
+		Type:             binance.OrderTypeLimit,
+		Quantity:         "1",
+		Price:            "0.1",
+		NewClientOrderID: "client-id-2",
+	}, binance.SubmitOrderConfig{Attempts: 2})
+	s.Require().NoError(e)
+	s.Require().EqualValues(43, resp.OrderID)
+	s.Require().Equal(2, placed)
+}
+
+func (s *mockedOrderTestSuite) TestSubmitOrderRejected() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(fasthttp.MethodPost, method)
+		return nil, &binance.APIError{Code: binance.ErrorCodeNewOrderRejected, Msg: "Account has insufficient balance for requested action."}
+	}
+
+	_, e := s.client.SubmitOrder(&binance.OrderReq{
+		Symbol:           "LTCBTC",
+		Side:             binance.OrderSideSell,
+		Type:             binance.OrderTypeLimit,
+		Quantity:         "1",
+		Price:            "0.1",
+		NewClientOrderID: "client-id-3",
+	}, binance.SubmitOrderConfig{})
+	var apiErr *binance.APIError
+	s.Require().ErrorAs(e, &apiErr)
+	s.Require().Equal(binance.ErrorCodeNewOrderRejected, apiErr.Code)
+
+	_, e = s.client.SubmitOrder(&binance.OrderReq{
+		Symbol:   "LTCBTC",
+		Side:     binance.OrderSideSell,
+		Type:     binance.OrderTypeLimit,
+		Quantity: "1",
+		Price:    "0.1",
+	}, binance.SubmitOrderConfig{})
+	s.Require().ErrorIs(e, binance.ErrEmptyClientOrderID)
+}
@@ -0,0 +1,342 @@
+package paper
+
+import (
+	"sort"
+
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/ws"
+)
+
+// book is an order book snapshot, fills consume its liquidity so orders matched
+// against the same snapshot don't share it
+type book struct {
+	bids []binance.DepthElem
+	asks []binance.DepthElem
+}
+
+func newBook(d *binance.Depth) *book {
+	b := &book{}
+	if d == nil {
+		return b
+	}
+	b.bids = append(b.bids, d.Bids...)
+	b.asks = append(b.asks, d.Asks...)
+
+	return b
+}
+
+// levels returns the opposite side of the book for the order side
+func (b *book) levels(side binance.OrderSide) []binance.DepthElem {
+	if side == binance.OrderSideBuy {
+		return b.asks
+	}
+
+	return b.bids
+}
+
+// best returns the best opposite price for the order side
+func (b *book) best(side binance.OrderSide) (decimal.Decimal, bool) {
+	for _, l := range b.levels(side) {
+		if l.Quantity.Sign() > 0 {
+			return l.Price, true
+		}
+	}
+
+	return decimal.Zero, false
+}
+
+// crosses checks whether the level price is executable for the limit price
+func crosses(side binance.OrderSide, price, limit decimal.Decimal) bool {
+	if side == binance.OrderSideBuy {
+		return price.LessThanOrEqual(limit)
+	}
+
+	return price.GreaterThanOrEqual(limit)
+}
+
+type fill struct {
+	level int
+	price decimal.Decimal
+	qty   decimal.Decimal
+}
+
+// match returns the fills of the order remaining quantity without consuming the liquidity.
+// Takers are filled at the book prices, makers at their limit price
+func (b *book) match(o *order, maker bool) []fill {
+	var (
+		fills     []fill
+		remaining = o.remaining()
+		quote     = o.quoteQty.Sub(o.cumQuote)
+		byQuote   = o.byQuote()
+		limited   = !o.market()
+	)
+	for i, l := range b.levels(o.side) {
+		if l.Quantity.Sign() <= 0 {
+			continue
+		}
+		if limited && !crosses(o.side, l.Price, o.price) {
+			break
+		}
+		price := l.Price
+		if maker {
+			price = o.price
+		}
+		var qty decimal.Decimal
+		if byQuote {
+			qty = o.stepDown(decimal.Min(l.Quantity, quote.Div(price)))
+			quote = quote.Sub(qty.Mul(price))
+		} else {
+			qty = decimal.Min(l.Quantity, remaining)
+			remaining = remaining.Sub(qty)
+		}
+		if qty.Sign() <= 0 {
+			break
+		}
+		fills = append(fills, fill{level: i, price: price, qty: qty})
+		if !byQuote && remaining.Sign() <= 0 {
+			break
+		}
+	}
+
+	return fills
+}
+
+func (b *book) consume(side binance.OrderSide, f fill) {
+	levels := b.levels(side)
+	levels[f.level].Quantity = levels[f.level].Quantity.Sub(f.qty)
+}
+
+type balance struct {
+	free   decimal.Decimal
+	locked decimal.Decimal
+}
+
+// lock holds the funds reserved by the order, legs of the order list share the same lock
+type lock struct {
+	asset  string
+	amount decimal.Decimal
+	refs   int
+}
+
+func (c *Client) balance(asset string) *balance {
+	b, ok := c.balances[asset]
+	if !ok {
+		b = &balance{}
+		c.balances[asset] = b
+	}
+
+	return b
+}
+
+func (c *Client) free(asset string) decimal.Decimal {
+	if b, ok := c.balances[asset]; ok {
+		return b.free
+	}
+
+	return decimal.Zero
+}
+
+func (c *Client) credit(asset string, amount decimal.Decimal) {
+	b := c.balance(asset)
+	b.free = b.free.Add(amount)
+	c.changed[asset] = struct{}{}
+}
+
+// reserve moves the amount from free to locked balance
+func (c *Client) reserve(asset string, amount decimal.Decimal, refs int) *lock {
+	b := c.balance(asset)
+	b.free = b.free.Sub(amount)
+	b.locked = b.locked.Add(amount)
+	c.changed[asset] = struct{}{}
+
+	return &lock{asset: asset, amount: amount, refs: refs}
+}
+
+// debit takes the amount from the order lock first and the rest from the free balance
+func (c *Client) debit(l *lock, asset string, amount decimal.Decimal) bool {
+	b := c.balance(asset)
+	locked := decimal.Zero
+	if l != nil && l.asset == asset {
+		locked = decimal.Min(l.amount, amount)
+	}
+	rest := amount.Sub(locked)
+	if b.free.LessThan(rest) {
+		return false
+	}
+	if l != nil {
+		l.amount = l.amount.Sub(locked)
+	}
+	b.locked = b.locked.Sub(locked)
+	b.free = b.free.Sub(rest)
+	c.changed[asset] = struct{}{}
+
+	return true
+}
+
+// release returns the funds left in the order lock once no order holds it
+func (c *Client) release(o *order) {
+	l := o.lock
+	if l == nil {
+		return
+	}
+	o.lock = nil
+	l.refs--
+	if l.refs > 0 || l.amount.Sign() <= 0 {
+		return
+	}
+	b := c.balance(l.asset)
+	b.locked = b.locked.Sub(l.amount)
+	b.free = b.free.Add(l.amount)
+	l.amount = decimal.Zero
+	c.changed[l.asset] = struct{}{}
+}
+
+func (c *Client) balanceList(assets map[string]struct{}) []*binance.Balance {
+	resp := make([]*binance.Balance, 0, len(c.balances))
+	for asset, b := range c.balances {
+		if _, ok := assets[asset]; assets != nil && !ok {
+			continue
+		}
+		resp = append(resp, &binance.Balance{Asset: asset, Free: b.free.String(), Locked: b.locked.String()})
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].Asset < resp[j].Asset
+	})
+
+	return resp
+}
+
+// match runs the open orders of the symbol against the book in the order of placement
+func (c *Client) match(symbol string, b *book) {
+	var orders []*order
+	for _, o := range c.orders {
+		if o.symbol.Symbol == symbol && o.open() {
+			orders = append(orders, o)
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].id < orders[j].id
+	})
+
+	for _, o := range orders {
+		// the order may be expired by the triggered order of the same list
+		if !o.open() {
+			continue
+		}
+		if !o.working {
+			if !o.triggered(b) {
+				continue
+			}
+			c.trigger(o)
+		}
+		c.execute(o, b)
+	}
+	c.flush()
+}
+
+// execute fills the order against the book and expires the rest of market, IOC and FOK orders
+func (c *Client) execute(o *order, b *book) {
+	maker := o.resting
+	fills := b.match(o, maker)
+	if o.byQuote() {
+		qty := decimal.Zero
+		for _, f := range fills {
+			qty = qty.Add(f.qty)
+		}
+		o.qty = qty
+	}
+	if o.tif == binance.TimeInForceFOK && !maker {
+		qty := decimal.Zero
+		for _, f := range fills {
+			qty = qty.Add(f.qty)
+		}
+		if qty.LessThan(o.remaining()) {
+			fills = nil
+		}
+	}
+
+	for _, f := range fills {
+		if !c.settle(o, f, maker) {
+			break
+		}
+		b.consume(o.side, f)
+	}
+
+	switch {
+	case !o.open():
+	case o.market(), o.tif == binance.TimeInForceIOC, o.tif == binance.TimeInForceFOK, o.qty.Sign() == 0:
+		c.finish(o, binance.OrderStatusExpired, "")
+	default:
+		o.resting = true
+	}
+}
+
+// settle applies the fill to the order and balances, the commission is taken from the received asset
+func (c *Client) settle(o *order, f fill, maker bool) bool {
+	base, quote := o.symbol.BaseAsset, o.symbol.QuoteAsset
+	quoteQty := f.qty.Mul(f.price)
+
+	spend, spendQty, receive, receiveQty := quote, quoteQty, base, f.qty
+	if o.side == binance.OrderSideSell {
+		spend, spendQty, receive, receiveQty = base, f.qty, quote, quoteQty
+	}
+	if !c.debit(o.lock, spend, spendQty) {
+		return false
+	}
+	rate := c.config.TakerCommission
+	if maker {
+		rate = c.config.MakerCommission
+	}
+	commission := receiveQty.Mul(rate)
+	c.credit(receive, receiveQty.Sub(commission))
+
+	now := c.now()
+	o.executed = o.executed.Add(f.qty)
+	o.cumQuote = o.cumQuote.Add(quoteQty)
+	o.updated = now
+	o.status = binance.OrderStatusPartial
+	if o.remaining().Sign() <= 0 {
+		o.status = binance.OrderStatusFilled
+	}
+
+	c.tradeID++
+	t := &binance.AccountTrade{
+		ID:              c.tradeID,
+		OrderID:         o.id,
+		OrderListID:     o.listID(),
+		Symbol:          o.symbol.Symbol,
+		Price:           f.price.String(),
+		Qty:             f.qty.String(),
+		QuoteQty:        quoteQty.String(),
+		Commission:      commission.String(),
+		CommissionAsset: receive,
+		Time:            now,
+		Buyer:           o.side == binance.OrderSideBuy,
+		Maker:           maker,
+		BestMatch:       true,
+	}
+	c.trades = append(c.trades, t)
+	respFill := binance.OrderRespFullFill{
+		Price:           t.Price,
+		Qty:             t.Qty,
+		Commission:      t.Commission,
+		CommissionAsset: t.CommissionAsset,
+		TradeID:         t.ID,
+	}
+	if o.sor {
+		respFill.MatchType, respFill.AllocID = matchTypeSOR, t.ID
+	}
+	o.fills = append(o.fills, respFill)
+	c.orderEvent(o, ws.ExecutionTypeTrade, t)
+
+	if o.status == binance.OrderStatusFilled {
+		c.release(o)
+	}
+	// a fill of the order list leg expires the other legs
+	c.expireSiblings(o)
+	c.listDone(o.list)
+
+	return true
+}
@@ -0,0 +1,166 @@
+package paper
+
+import (
+	"io"
+	"net"
+
+	"github.com/gobwas/ws/wsutil"
+	"github.com/segmentio/encoding/json"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/ws"
+)
+
+type subscriber struct {
+	events chan []byte
+	conn   net.Conn
+}
+
+// AccountInfo opens the simulated user data stream.
+// The stream is closed when the reader falls behind by more than EventsBuffer events
+func (c *Client) AccountInfo() *ws.AccountInfo {
+	server, client := net.Pipe()
+	s := &subscriber{
+		events: make(chan []byte, c.config.EventsBuffer),
+		conn:   server,
+	}
+	c.mu.Lock()
+	c.subscribers[s] = struct{}{}
+	c.mu.Unlock()
+
+	// the reader replies with pongs which aren't used
+	go func() {
+		_, _ = io.Copy(io.Discard, server)
+	}()
+	go c.stream(s)
+
+	return &ws.AccountInfo{Conn: ws.NewConn(client)}
+}
+
+func (c *Client) stream(s *subscriber) {
+	defer s.conn.Close()
+
+	for payload := range s.events {
+		if err := wsutil.WriteServerText(s.conn, payload); err != nil {
+			c.mu.Lock()
+			c.unsubscribe(s)
+			c.mu.Unlock()
+			return
+		}
+	}
+}
+
+func (c *Client) unsubscribe(s *subscriber) {
+	if _, ok := c.subscribers[s]; ok {
+		delete(c.subscribers, s)
+		close(s.events)
+	}
+}
+
+func (c *Client) publish(event interface{}) {
+	if len(c.subscribers) == 0 {
+		return
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	for s := range c.subscribers {
+		select {
+		case s.events <- payload:
+		default:
+			c.unsubscribe(s)
+		}
+	}
+}
+
+func (c *Client) orderEvent(o *order, execType binance.OrderStatus, t *binance.AccountTrade) {
+	e := &ws.OrderUpdateEvent{
+		EventType:           ws.AccountUpdateEventTypeOrderReport,
+		Symbol:              o.symbol.Symbol,
+		NewClientOrderID:    o.clientID,
+		Side:                o.side,
+		OrderType:           o.typ,
+		TimeInForce:         o.tif,
+		OrigQty:             o.qty.String(),
+		Price:               o.price.String(),
+		StopPrice:           o.stopPrice.String(),
+		IcebergQty:          "0",
+		ExecutionType:       execType,
+		Status:              o.status,
+		Error:               binance.OrderFailureNone,
+		FilledQty:           "0",
+		TotalFilledQty:      o.executed.String(),
+		FilledPrice:         "0",
+		Commission:          "0",
+		QuoteTotalFilledQty: o.cumQuote.String(),
+		QuoteFilledQty:      "0",
+		QuoteQty:            o.quoteQty.String(),
+		Time:                o.updated,
+		TradeTime:           o.updated,
+		OrderCreatedTime:    o.created,
+		OrderID:             o.id,
+		TradeID:             -1,
+		OrderListID:         o.listID(),
+		StrategyID:          o.strategyID,
+		StrategyType:        o.strategyType,
+		IsWorking:           o.working && o.open(),
+		WorkingTime:         o.workingTime,
+	}
+	if execType == ws.ExecutionTypeCanceled {
+		e.NewClientOrderID, e.OrigClientOrderID = o.cancelID, o.clientID
+	}
+	if t != nil {
+		e.FilledQty = t.Qty
+		e.FilledPrice = t.Price
+		e.QuoteFilledQty = t.QuoteQty
+		e.Commission = t.Commission
+		e.CommissionAsset = t.CommissionAsset
+		e.TradeID = t.ID
+		e.TradeTime = t.Time
+		e.Maker = t.Maker
+	}
+	c.publish(e)
+}
+
+func (c *Client) listEvent(l *orderList) {
+	e := &ws.OCOOrderUpdateEvent{
+		EventType:        ws.AccountUpdateEventTypeOCOReport,
+		Symbol:           l.symbol,
+		ContingencyType:  binance.ContingencyTypeOCO,
+		OCOStatus:        l.status,
+		OrderStatus:      binance.OrderStatus(l.orderStatus),
+		OCORejectReason:  binance.OrderFailureNone,
+		OCOClientOrderID: l.clientID,
+		TransactTime:     l.time,
+		OrderListID:      l.id,
+		Time:             l.time,
+	}
+	for _, o := range l.orders {
+		e.Orders = append(e.Orders, ws.OCOOrderUpdateEventOrder{
+			Symbol:        o.symbol.Symbol,
+			ClientOrderID: o.clientID,
+			OrderID:       o.id,
+		})
+	}
+	c.publish(e)
+}
+
+// flush emits the account position of the balances changed since the last flush
+func (c *Client) flush() {
+	if len(c.changed) == 0 {
+		return
+	}
+	now := c.now()
+	c.updateTime = now
+	e := &ws.AccountUpdateEvent{
+		EventType:  ws.AccountUpdateEventTypeOutboundAccountPosition,
+		Time:       now,
+		LastUpdate: now,
+	}
+	for _, b := range c.balanceList(c.changed) {
+		e.Balances = append(e.Balances, ws.AccountBalance{Asset: b.Asset, Free: b.Free, Locked: b.Locked})
+	}
+	c.changed = make(map[string]struct{})
+	c.publish(e)
+}
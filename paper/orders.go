@@ -0,0 +1,1003 @@
+package paper
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/valyala/fasthttp"
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/ws"
+)
+
+type order struct {
+	symbol       *binance.SymbolInfo
+	id           int64
+	clientID     string
+	cancelID     string
+	side         binance.OrderSide
+	typ          binance.OrderType
+	tif          binance.TimeInForce
+	price        decimal.Decimal
+	stopPrice    decimal.Decimal
+	qty          decimal.Decimal
+	quoteQty     decimal.Decimal
+	executed     decimal.Decimal
+	cumQuote     decimal.Decimal
+	status       binance.OrderStatus
+	working      bool // working is unset until the stop order is triggered
+	resting      bool // resting orders are on the book and filled as makers
+	created      int64
+	updated      int64
+	workingTime  int64
+	strategyID   int
+	strategyType int
+	sor          bool // sor orders are placed by smart order routing, their fills are reported as allocations
+	lock         *lock
+	list         *orderList
+	fills        []binance.OrderRespFullFill
+}
+
+func isStop(t binance.OrderType) bool {
+	switch t {
+	case binance.OrderTypeStopLoss, binance.OrderTypeStopLossLimit, binance.OrderTypeTakeProfit, binance.OrderTypeTakeProfitLimit:
+		return true
+	}
+
+	return false
+}
+
+func parseDecimal(s string) (decimal.Decimal, error) {
+	if s == "" {
+		return decimal.Zero, nil
+	}
+
+	return decimal.NewFromString(s)
+}
+
+func filterError(err error) error {
+	return &binance.APIError{Code: binance.ErrorCodeFilterFailure, Msg: "Filter failure: " + err.Error()}
+}
+
+func clientKey(symbol, clientID string) string {
+	return symbol + ":" + clientID
+}
+
+func parseOrder(s *binance.SymbolInfo, req *binance.OrderReq) (*order, error) {
+	if req.TrailingDelta != 0 {
+		return nil, ErrTrailingDelta
+	}
+	o := &order{
+		symbol:       s,
+		clientID:     req.NewClientOrderID,
+		side:         req.Side,
+		typ:          req.Type,
+		tif:          req.TimeInForce,
+		status:       binance.OrderStatusNew,
+		working:      !isStop(req.Type),
+		strategyID:   req.StrategyID,
+		strategyType: req.StrategyType,
+	}
+	var err error
+	if o.qty, err = parseDecimal(req.Quantity); err != nil {
+		return nil, binance.ErrInvalidQuantity
+	}
+	if req.Type == binance.OrderTypeMarket {
+		if o.quoteQty, err = parseDecimal(req.QuoteQuantity); err != nil {
+			return nil, binance.ErrInvalidQuantity
+		}
+	}
+	if o.price, err = parseDecimal(req.Price); err != nil {
+		return nil, binance.ErrInvalidPrice
+	}
+	if o.stopPrice, err = parseDecimal(req.StopPrice); err != nil {
+		return nil, binance.ErrInvalidPrice
+	}
+	switch {
+	case o.qty.Sign() <= 0 && o.quoteQty.Sign() <= 0:
+		return nil, binance.ErrEmptyQuantity
+	case !o.market() && o.price.Sign() <= 0:
+		return nil, binance.ErrEmptyPrice
+	case isStop(o.typ) && o.stopPrice.Sign() <= 0:
+		return nil, binance.ErrEmptyStopPrice
+	}
+	if o.tif == "" {
+		o.tif = binance.TimeInForceGTC
+	}
+
+	return o, nil
+}
+
+func (o *order) open() bool {
+	return o.status == binance.OrderStatusNew || o.status == binance.OrderStatusPartial
+}
+
+// market checks whether the order is filled at the book prices without the limit
+func (o *order) market() bool {
+	switch o.typ {
+	case binance.OrderTypeMarket, binance.OrderTypeStopLoss, binance.OrderTypeTakeProfit:
+		return true
+	}
+
+	return false
+}
+
+func (o *order) byQuote() bool {
+	return o.quoteQty.Sign() > 0
+}
+
+func (o *order) remaining() decimal.Decimal {
+	return o.qty.Sub(o.executed)
+}
+
+func (o *order) listID() int64 {
+	if o.list == nil {
+		return -1
+	}
+
+	return o.list.id
+}
+
+func (o *order) stepDown(qty decimal.Decimal) decimal.Decimal {
+	s, err := o.symbol.NormalizeQuantity(qty.String(), binance.RoundingModeDown, true)
+	if err != nil {
+		return qty
+	}
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return qty
+	}
+
+	return d
+}
+
+// triggered checks the stop price against the best price the order can be filled at
+func (o *order) triggered(b *book) bool {
+	p, ok := b.best(o.side)
+	if !ok {
+		return false
+	}
+	buy := o.side == binance.OrderSideBuy
+	switch o.typ {
+	case binance.OrderTypeStopLoss, binance.OrderTypeStopLossLimit:
+		if buy {
+			return p.GreaterThanOrEqual(o.stopPrice)
+		}
+		return p.LessThanOrEqual(o.stopPrice)
+	case binance.OrderTypeTakeProfit, binance.OrderTypeTakeProfitLimit:
+		if buy {
+			return p.LessThanOrEqual(o.stopPrice)
+		}
+		return p.GreaterThanOrEqual(o.stopPrice)
+	}
+
+	return false
+}
+
+// required returns the asset and the amount needed to place the order
+func (o *order) required(fills []fill) (string, decimal.Decimal) {
+	if o.side == binance.OrderSideSell {
+		if !o.byQuote() {
+			return o.symbol.BaseAsset, o.qty
+		}
+		qty := decimal.Zero
+		for _, f := range fills {
+			qty = qty.Add(f.qty)
+		}
+		return o.symbol.BaseAsset, qty
+	}
+
+	switch {
+	case o.byQuote():
+		return o.symbol.QuoteAsset, o.quoteQty
+	case o.typ == binance.OrderTypeMarket:
+		cost := decimal.Zero
+		for _, f := range fills {
+			cost = cost.Add(f.qty.Mul(f.price))
+		}
+		return o.symbol.QuoteAsset, cost
+	case o.market():
+		return o.symbol.QuoteAsset, o.qty.Mul(o.stopPrice)
+	}
+
+	return o.symbol.QuoteAsset, o.qty.Mul(o.price)
+}
+
+func (o *order) ack() *binance.OrderRespAck {
+	return &binance.OrderRespAck{
+		Symbol:        o.symbol.Symbol,
+		OrderID:       o.id,
+		OrderListID:   o.listID(),
+		ClientOrderID: o.clientID,
+		TransactTime:  o.created,
+	}
+}
+
+func (o *order) result() *binance.OrderRespResult {
+	return &binance.OrderRespResult{
+		Symbol:              o.symbol.Symbol,
+		OrderID:             o.id,
+		OrderListID:         int(o.listID()),
+		ClientOrderID:       o.clientID,
+		TransactTime:        o.created,
+		Price:               o.price.String(),
+		OrigQty:             o.qty.String(),
+		ExecutedQty:         o.executed.String(),
+		CummulativeQuoteQty: o.cumQuote.String(),
+		Status:              o.status,
+		TimeInForce:         string(o.tif),
+		Type:                o.typ,
+		Side:                o.side,
+		WorkingTime:         o.workingTime,
+		StopPrice:           o.stopPrice.String(),
+		StrategyID:          o.strategyID,
+		StrategyType:        o.strategyType,
+	}
+}
+
+func (o *order) full() *binance.OrderRespFull {
+	return &binance.OrderRespFull{
+		Symbol:              o.symbol.Symbol,
+		OrderID:             o.id,
+		OrderListID:         o.listID(),
+		ClientOrderID:       o.clientID,
+		TransactTime:        o.created,
+		Price:               o.price.String(),
+		OrigQty:             o.qty.String(),
+		ExecutedQty:         o.executed.String(),
+		CummulativeQuoteQty: o.cumQuote.String(),
+		Status:              o.status,
+		TimeInForce:         string(o.tif),
+		Type:                o.typ,
+		Side:                o.side,
+		WorkingTime:         o.workingTime,
+		StopPrice:           o.stopPrice.String(),
+		StrategyID:          o.strategyID,
+		StrategyType:        o.strategyType,
+		Fills:               append([]binance.OrderRespFullFill{}, o.fills...),
+	}
+}
+
+func (o *order) query() *binance.QueryOrder {
+	return &binance.QueryOrder{
+		Symbol:              o.symbol.Symbol,
+		OrderID:             o.id,
+		OrderListID:         o.listID(),
+		ClientOrderID:       o.clientID,
+		Price:               o.price.String(),
+		OrigQty:             o.qty.String(),
+		ExecutedQty:         o.executed.String(),
+		CummulativeQuoteQty: o.cumQuote.String(),
+		Status:              o.status,
+		TimeInForce:         o.tif,
+		Type:                o.typ,
+		Side:                o.side,
+		Time:                o.created,
+		UpdateTime:          o.updated,
+		IsWorking:           o.working && o.open(),
+		WorkingTime:         o.workingTime,
+		OrigQuoteOrderQty:   o.quoteQty.String(),
+		StopPrice:           o.stopPrice.String(),
+		StrategyID:          o.strategyID,
+		StrategyType:        o.strategyType,
+	}
+}
+
+func (o *order) canceled() *binance.CancelOrder {
+	return &binance.CancelOrder{
+		Symbol:              o.symbol.Symbol,
+		OrigClientOrderID:   o.clientID,
+		OrderID:             o.id,
+		OrderListID:         o.listID(),
+		ClientOrderID:       o.cancelID,
+		Price:               o.price.String(),
+		OrigQty:             o.qty.String(),
+		ExecutedQty:         o.executed.String(),
+		CummulativeQuoteQty: o.cumQuote.String(),
+		Status:              o.status,
+		TimeInForce:         o.tif,
+		Type:                o.typ,
+		Side:                o.side,
+		StopPrice:           o.stopPrice.String(),
+		StrategyID:          o.strategyID,
+		StrategyType:        o.strategyType,
+	}
+}
+
+// response returns the order response of the requested type,
+// MARKET and LIMIT orders default to FULL and other types to ACK like the API does
+func (o *order) response(respType binance.OrderRespType) interface{} {
+	switch respType {
+	case binance.OrderRespTypeFull:
+		return o.full()
+	case binance.OrderRespTypeResult:
+		return o.result()
+	case "":
+		if o.typ == binance.OrderTypeMarket || o.typ == binance.OrderTypeLimit {
+			return o.full()
+		}
+	}
+
+	return o.ack()
+}
+
+type orderList struct {
+	id          int64
+	clientID    string
+	symbol      string
+	orders      []*order
+	status      binance.OCOStatus
+	orderStatus binance.OCOOrderStatus
+	time        int64
+}
+
+func (l *orderList) open() bool {
+	return l.status != binance.OCOStatusAllDone
+}
+
+func (l *orderList) response(reports bool) *binance.OrderList {
+	resp := &binance.OrderList{
+		Symbol:            l.symbol,
+		OrderListID:       l.id,
+		ContingencyType:   binance.ContingencyTypeOCO,
+		ListStatusType:    l.status,
+		ListOrderStatus:   l.orderStatus,
+		ListClientOrderID: l.clientID,
+		TransactionTime:   l.time,
+		Orders:            make([]binance.OCOOrderStatusResp, 0, len(l.orders)),
+	}
+	for _, o := range l.orders {
+		resp.Orders = append(resp.Orders, binance.OCOOrderStatusResp{
+			Symbol:        o.symbol.Symbol,
+			OrderID:       int(o.id),
+			ClientOrderID: o.clientID,
+		})
+		if reports {
+			resp.OrderReports = append(resp.OrderReports, *o.full())
+		}
+	}
+
+	return resp
+}
+
+func (c *Client) doOrder(method string, data interface{}) (interface{}, error) {
+	switch req := data.(type) {
+	case *binance.OrderReq:
+		if method == fasthttp.MethodPost {
+			return c.newOrder(req)
+		}
+	case *binance.QueryOrderReq:
+		if method == fasthttp.MethodGet {
+			return c.queryOrder(req)
+		}
+	case *binance.CancelOrderReq:
+		if method == fasthttp.MethodDelete {
+			return c.cancelOrder(req)
+		}
+	}
+
+	return nil, ErrNotSimulated
+}
+
+func (c *Client) prepareOrder(req *binance.OrderReq) (*order, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	s, err := c.symbol(req.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.ValidateOrder(req); err != nil {
+		return nil, filterError(err)
+	}
+
+	return parseOrder(s, req)
+}
+
+func (c *Client) testOrder(method string, data interface{}) (interface{}, error) {
+	req, ok := data.(*binance.OrderReq)
+	if method != fasthttp.MethodPost || !ok {
+		return nil, ErrNotSimulated
+	}
+	if _, err := c.prepareOrder(req); err != nil {
+		return nil, err
+	}
+
+	return struct{}{}, nil
+}
+
+func (c *Client) newOrder(req *binance.OrderReq) (interface{}, error) {
+	o, err := c.prepareOrder(req)
+	if err != nil {
+		return nil, err
+	}
+	b, err := c.depth(req.Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err = c.place(o, b); err != nil {
+		return nil, err
+	}
+	c.flush()
+
+	return o.response(req.OrderRespType), nil
+}
+
+// place reserves the funds of the order and fills it as taker
+func (c *Client) place(o *order, b *book) error {
+	if c.duplicate(o) {
+		return errDuplicateOrder
+	}
+	switch {
+	case !o.working:
+		if o.triggered(b) {
+			return errImmediateTrigger
+		}
+	case o.typ == binance.OrderTypeLimitMaker:
+		if p, ok := b.best(o.side); ok && crosses(o.side, p, o.price) {
+			return errImmediateMatch
+		}
+	}
+
+	var fills []fill
+	if o.typ == binance.OrderTypeMarket {
+		fills = b.match(o, false)
+	}
+	asset, amount := o.required(fills)
+	if c.free(asset).LessThan(amount) {
+		return errInsufficientBalance
+	}
+
+	c.register(o)
+	// market orders are settled from the free balance right away
+	if o.typ != binance.OrderTypeMarket {
+		o.lock = c.reserve(asset, amount, 1)
+	}
+	c.orderEvent(o, ws.ExecutionTypeNew, nil)
+	if o.working {
+		c.execute(o, b)
+	}
+
+	return nil
+}
+
+func (c *Client) duplicate(o *order) bool {
+	if o.clientID == "" {
+		return false
+	}
+	prev, ok := c.clientIDs[clientKey(o.symbol.Symbol, o.clientID)]
+
+	return ok && prev.open()
+}
+
+func (c *Client) register(o *order) {
+	now := c.now()
+	c.orderID++
+	o.id = c.orderID
+	if o.clientID == "" {
+		o.clientID = "paper-" + strconv.FormatInt(o.id, 10)
+	}
+	o.created, o.updated, o.workingTime = now, now, -1
+	if o.working {
+		o.workingTime = now
+	}
+	c.orders[o.id] = o
+	c.clientIDs[clientKey(o.symbol.Symbol, o.clientID)] = o
+}
+
+// trigger starts working the stop order, the other legs of its list expire
+func (c *Client) trigger(o *order) {
+	now := c.now()
+	o.working = true
+	o.workingTime, o.updated = now, now
+	c.expireSiblings(o)
+}
+
+// finish moves the open order to the final status and releases its funds
+func (c *Client) finish(o *order, status binance.OrderStatus, cancelID string) {
+	o.status = status
+	o.updated = c.now()
+	o.cancelID = cancelID
+	c.release(o)
+	// execution types of canceled and expired orders match their statuses
+	c.orderEvent(o, status, nil)
+	c.listDone(o.list)
+}
+
+func (c *Client) expireSiblings(o *order) {
+	if o.list == nil {
+		return
+	}
+	for _, leg := range o.list.orders {
+		if leg != o && leg.open() {
+			c.finish(leg, binance.OrderStatusExpired, "")
+		}
+	}
+}
+
+func (c *Client) listDone(l *orderList) {
+	if l == nil || !l.open() {
+		return
+	}
+	for _, o := range l.orders {
+		if o.open() {
+			return
+		}
+	}
+	l.status = binance.OCOStatusAllDone
+	l.orderStatus = binance.OCOOrderStatusAllDone
+	l.time = c.now()
+	c.listEvent(l)
+}
+
+func (c *Client) find(symbol string, orderID int64, clientID string) *order {
+	if orderID != 0 {
+		o, ok := c.orders[orderID]
+		if !ok || o.symbol.Symbol != symbol {
+			return nil
+		}
+		return o
+	}
+	if clientID == "" {
+		return nil
+	}
+
+	return c.clientIDs[clientKey(symbol, clientID)]
+}
+
+func (c *Client) queryOrder(req *binance.QueryOrderReq) (*binance.QueryOrder, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	o := c.find(req.Symbol, req.OrderID, req.OrigClientOrderID)
+	if o == nil {
+		return nil, errNoSuchOrder
+	}
+
+	return o.query(), nil
+}
+
+func (c *Client) cancelOrder(req *binance.CancelOrderReq) (*binance.CancelOrder, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	o, err := c.cancelable(req.Symbol, req.OrderID, req.OrigClientOrderID, req.CancelRestrictions)
+	if err != nil {
+		return nil, err
+	}
+	c.cancel(o, req.NewClientOrderID)
+	c.flush()
+
+	return o.canceled(), nil
+}
+
+// cancelable finds the open order satisfying the cancel restrictions
+func (c *Client) cancelable(symbol string, orderID int64, clientID string, restrictions binance.CancelRestriction) (*order, error) {
+	o := c.find(symbol, orderID, clientID)
+	if o == nil || !o.open() {
+		return nil, errCancelRejected
+	}
+	switch restrictions {
+	case binance.CancelRestrictionOnlyNew:
+		if o.status != binance.OrderStatusNew {
+			return nil, errCancelRestricted
+		}
+	case binance.CancelRestrictionOnlyPartiallyFilled:
+		if o.status != binance.OrderStatusPartial {
+			return nil, errCancelRestricted
+		}
+	}
+
+	return o, nil
+}
+
+// cancelReplace cancels the order and places the new one. The new order is placed after a failed cancel
+// only in ALLOW_FAILURE mode, otherwise the error of the failed step is returned
+func (c *Client) cancelReplace(method string, data interface{}) (*binance.CancelReplaceOrder, error) {
+	req, ok := data.(*binance.CancelReplaceOrderReq)
+	if method != fasthttp.MethodPost || !ok {
+		return nil, ErrNotSimulated
+	}
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	o, err := c.prepareOrder(&req.OrderReq)
+	if err != nil {
+		return nil, err
+	}
+	b, err := c.depth(req.Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	defer c.flush()
+
+	resp := &binance.CancelReplaceOrder{
+		CancelResult:   binance.CancelReplaceResultSuccess,
+		NewOrderResult: binance.CancelReplaceResultSuccess,
+	}
+	prev, err := c.cancelable(req.Symbol, req.CancelOrderID, req.CancelOrigClientOrderID, req.CancelRestrictions)
+	switch {
+	case err == nil:
+		c.cancel(prev, req.CancelNewClientOrderID)
+		resp.CancelResponse = *prev.canceled()
+	case req.CancelReplaceMode == binance.CancelReplaceModeAllowFailure:
+		resp.CancelResult = binance.CancelReplaceResultFailure
+	default:
+		return nil, err
+	}
+	if err = c.place(o, b); err != nil {
+		return nil, err
+	}
+	resp.NewOrderResponse = o.full()
+
+	return resp, nil
+}
+
+// allOrders returns the orders of the symbol in any status
+func (c *Client) allOrders(method string, data interface{}) ([]*binance.QueryOrder, error) {
+	req, ok := data.(*binance.AllOrdersReq)
+	if method != fasthttp.MethodGet || !ok {
+		return nil, ErrNotSimulated
+	}
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	limit := req.Limit
+	if limit <= 0 || limit > binance.MaxOrderLimit {
+		limit = binance.DefaultOrderLimit
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var orders []*order
+	for _, o := range c.orders {
+		switch {
+		case o.symbol.Symbol != req.Symbol,
+			req.OrderID != 0 && o.id < req.OrderID,
+			req.StartTime != 0 && o.created < req.StartTime,
+			req.EndTime != 0 && o.created > req.EndTime:
+			continue
+		}
+		orders = append(orders, o)
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].id < orders[j].id
+	})
+	if len(orders) > limit {
+		// the oldest orders are returned starting from the id, otherwise the most recent ones
+		if req.OrderID != 0 {
+			orders = orders[:limit]
+		} else {
+			orders = orders[len(orders)-limit:]
+		}
+	}
+	resp := make([]*binance.QueryOrder, 0, len(orders))
+	for _, o := range orders {
+		resp = append(resp, o.query())
+	}
+
+	return resp, nil
+}
+
+// cancel cancels the order, canceling the order list leg cancels the whole list
+func (c *Client) cancel(o *order, cancelID string) {
+	orders := []*order{o}
+	if o.list != nil {
+		orders = o.list.orders
+	}
+	for _, leg := range orders {
+		if !leg.open() {
+			continue
+		}
+		id := cancelID
+		if id == "" {
+			id = "cancel-" + strconv.FormatInt(leg.id, 10)
+		}
+		c.finish(leg, binance.OrderStatusCanceled, id)
+	}
+}
+
+func (c *Client) openOrders(symbol string) []*order {
+	var orders []*order
+	for _, o := range c.orders {
+		if o.open() && (symbol == "" || o.symbol.Symbol == symbol) {
+			orders = append(orders, o)
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].id < orders[j].id
+	})
+
+	return orders
+}
+
+func (c *Client) doOpenOrders(method string, data interface{}) (interface{}, error) {
+	switch req := data.(type) {
+	case *binance.OpenOrdersReq:
+		if method != fasthttp.MethodGet {
+			break
+		}
+		var symbol string
+		if req != nil {
+			symbol = req.Symbol
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		resp := make([]*binance.QueryOrder, 0)
+		for _, o := range c.openOrders(symbol) {
+			resp = append(resp, o.query())
+		}
+		return resp, nil
+	case *binance.CancelOpenOrdersReq:
+		if method != fasthttp.MethodDelete {
+			break
+		}
+		if req == nil {
+			return nil, binance.ErrNilRequest
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		orders := c.openOrders(req.Symbol)
+		if len(orders) == 0 {
+			return nil, errCancelRejected
+		}
+		resp := make([]*binance.CancelOrder, 0, len(orders))
+		for _, o := range orders {
+			// legs are canceled together with the first leg of their list
+			if o.open() {
+				c.cancel(o, "")
+			}
+			resp = append(resp, o.canceled())
+		}
+		c.flush()
+		return resp, nil
+	}
+
+	return nil, ErrNotSimulated
+}
+
+func (c *Client) newOCO(method string, data interface{}) (*binance.OrderList, error) {
+	req, ok := data.(*binance.OCOReq)
+	if method != fasthttp.MethodPost || !ok {
+		return nil, ErrNotSimulated
+	}
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	s, err := c.symbol(req.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.ValidateOCO(req); err != nil {
+		return nil, filterError(err)
+	}
+
+	limitLeg, err := parseOrder(s, &binance.OrderReq{
+		Symbol:           req.Symbol,
+		Side:             req.Side,
+		Type:             binance.OrderTypeLimitMaker,
+		Quantity:         req.Quantity,
+		Price:            req.Price,
+		NewClientOrderID: req.LimitClientOrderID,
+		StrategyID:       req.LimitStrategyID,
+		StrategyType:     req.LimitStrategyType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	stopReq := &binance.OrderReq{
+		Symbol:           req.Symbol,
+		Side:             req.Side,
+		Type:             binance.OrderTypeStopLoss,
+		Quantity:         req.Quantity,
+		StopPrice:        req.StopPrice,
+		TrailingDelta:    req.TrailingDelta,
+		NewClientOrderID: req.StopClientOrderID,
+		StrategyID:       req.StopStrategyID,
+		StrategyType:     req.StopStrategyType,
+	}
+	if req.StopLimitPrice != "" {
+		stopReq.Type = binance.OrderTypeStopLossLimit
+		stopReq.Price = req.StopLimitPrice
+		stopReq.TimeInForce = req.StopLimitTimeInForce
+	}
+	stopLeg, err := parseOrder(s, stopReq)
+	if err != nil {
+		return nil, err
+	}
+	b, err := c.depth(req.Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	l, err := c.placeList(req.ListClientOrderID, b, stopLeg, limitLeg)
+	if err != nil {
+		return nil, err
+	}
+	c.flush()
+
+	return l.response(true), nil
+}
+
+// placeList places the OCO legs, the legs share the funds reserved for the most expensive leg
+func (c *Client) placeList(clientID string, b *book, legs ...*order) (*orderList, error) {
+	if prev, ok := c.listClientIDs[clientID]; ok && prev.open() {
+		return nil, errDuplicateOrder
+	}
+	var (
+		asset  string
+		amount = decimal.Zero
+	)
+	for _, o := range legs {
+		if c.duplicate(o) {
+			return nil, errDuplicateOrder
+		}
+		if !o.working && o.triggered(b) {
+			return nil, errImmediateTrigger
+		}
+		if p, ok := b.best(o.side); ok && o.typ == binance.OrderTypeLimitMaker && crosses(o.side, p, o.price) {
+			return nil, errImmediateMatch
+		}
+		var required decimal.Decimal
+		asset, required = o.required(nil)
+		amount = decimal.Max(amount, required)
+	}
+	if c.free(asset).LessThan(amount) {
+		return nil, errInsufficientBalance
+	}
+
+	c.listID++
+	l := &orderList{
+		id:          c.listID,
+		clientID:    clientID,
+		symbol:      legs[0].symbol.Symbol,
+		orders:      legs,
+		status:      binance.OCOStatusExecStarted,
+		orderStatus: binance.OCOOrderStatusExecuting,
+		time:        c.now(),
+	}
+	if l.clientID == "" {
+		l.clientID = "paper-list-" + strconv.FormatInt(l.id, 10)
+	}
+	shared := c.reserve(asset, amount, len(legs))
+	for _, o := range legs {
+		o.list, o.lock = l, shared
+		o.resting = o.typ == binance.OrderTypeLimitMaker
+		c.register(o)
+		c.orderEvent(o, ws.ExecutionTypeNew, nil)
+	}
+	c.lists[l.id] = l
+	c.listClientIDs[l.clientID] = l
+	c.listEvent(l)
+
+	return l, nil
+}
+
+func (c *Client) findList(orderListID int64, clientID string) *orderList {
+	if orderListID != 0 {
+		return c.lists[orderListID]
+	}
+
+	return c.listClientIDs[clientID]
+}
+
+func (c *Client) doOCO(method string, data interface{}) (*binance.OrderList, error) {
+	switch req := data.(type) {
+	case *binance.QueryOCOReq:
+		if method != fasthttp.MethodGet {
+			break
+		}
+		if req == nil {
+			return nil, binance.ErrNilRequest
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		l := c.findList(req.OrderListID, req.ListClientOrderID)
+		if l == nil {
+			return nil, errNoSuchList
+		}
+		return l.response(false), nil
+	case *binance.CancelOCOReq:
+		if method != fasthttp.MethodDelete {
+			break
+		}
+		if req == nil {
+			return nil, binance.ErrNilRequest
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		l := c.findList(req.OrderListID, req.ListClientOrderID)
+		if l == nil || l.symbol != req.Symbol || !l.open() {
+			return nil, errCancelRejected
+		}
+		c.cancel(l.orders[0], "")
+		c.flush()
+		return l.response(true), nil
+	}
+
+	return nil, ErrNotSimulated
+}
+
+func (c *Client) openOCO(method string) ([]*binance.OrderList, error) {
+	if method != fasthttp.MethodGet {
+		return nil, ErrNotSimulated
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resp := make([]*binance.OrderList, 0)
+	for _, l := range c.lists {
+		if l.open() {
+			resp = append(resp, l.response(false))
+		}
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].OrderListID < resp[j].OrderListID
+	})
+
+	return resp, nil
+}
+
+// allOCO returns the order lists in any status
+func (c *Client) allOCO(method string, data interface{}) ([]*binance.OrderList, error) {
+	if method != fasthttp.MethodGet {
+		return nil, ErrNotSimulated
+	}
+	req, ok := data.(*binance.AllOCOReq)
+	if !ok || req == nil {
+		req = &binance.AllOCOReq{}
+	}
+	limit := req.Limit
+	if limit <= 0 || limit > binance.MaxOrderLimit {
+		limit = binance.DefaultOrderLimit
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resp := make([]*binance.OrderList, 0)
+	for _, l := range c.lists {
+		switch {
+		case req.FromID != 0 && l.id < req.FromID,
+			req.StartTime != 0 && l.time < req.StartTime,
+			req.EndTime != 0 && l.time > req.EndTime:
+			continue
+		}
+		resp = append(resp, l.response(false))
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].OrderListID < resp[j].OrderListID
+	})
+	if len(resp) > limit {
+		if req.FromID != 0 {
+			resp = resp[:limit]
+		} else {
+			resp = resp[len(resp)-limit:]
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,399 @@
+package paper
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/ws"
+)
+
+const (
+	DefaultDepthLimit   = 100
+	DefaultPollInterval = time.Second
+	// DefaultEventsBuffer is the number of user data events buffered for each stream,
+	// the stream is closed when the reader falls behind
+	DefaultEventsBuffer = 256
+	// ListenKey is returned by the simulated user data stream endpoint
+	ListenKey = "paper"
+)
+
+var (
+	// ErrNotSimulated is returned for private endpoints which aren't simulated, such requests never reach the upstream
+	ErrNotSimulated = errors.New("endpoint is not simulated")
+	// ErrTrailingDelta is returned for trailing stop orders
+	ErrTrailingDelta = errors.New("trailing delta is not simulated")
+)
+
+var (
+	errInsufficientBalance = &binance.APIError{Code: binance.ErrorCodeNewOrderRejected, Msg: "Account has insufficient balance for requested action."}
+	errImmediateMatch      = &binance.APIError{Code: binance.ErrorCodeNewOrderRejected, Msg: "Order would immediately match and take."}
+	errImmediateTrigger    = &binance.APIError{Code: binance.ErrorCodeNewOrderRejected, Msg: "Order would trigger immediately."}
+	errDuplicateOrder      = &binance.APIError{Code: binance.ErrorCodeNewOrderRejected, Msg: "Duplicate order sent."}
+	errNoSuchOrder         = &binance.APIError{Code: binance.ErrorCodeNoSuchOrder, Msg: "Order does not exist."}
+	errNoSuchList          = &binance.APIError{Code: binance.ErrorCodeNoSuchOrder, Msg: "Order list does not exist."}
+	errCancelRejected      = &binance.APIError{Code: binance.ErrorCodeCancelRejected, Msg: "Unknown order sent."}
+	errCancelRestricted    = &binance.APIError{Code: binance.ErrorCodeCancelRejected, Msg: "Order was not canceled due to cancel restrictions."}
+	errInvalidSymbol       = &binance.APIError{Code: binance.ErrorCodeBadSymbol, Msg: "Invalid symbol."}
+)
+
+// Config configures the paper trading client
+type Config struct {
+	Balances        map[string]decimal.Decimal // Balances are the initial free balances by asset
+	MakerCommission decimal.Decimal            // MakerCommission is the maker rate, e.g. 0.001; zero disables the commission
+	TakerCommission decimal.Decimal            // TakerCommission is the taker rate, e.g. 0.001; zero disables the commission
+	Symbols         []*binance.SymbolInfo      // Symbols preloads the symbols info, missing symbols are requested from the upstream
+	DepthLimit      int                        // DepthLimit is the order book depth requested to fill orders
+	PollInterval    time.Duration              // PollInterval is the order book polling interval used by Run
+	EventsBuffer    int
+	Now             func() time.Time
+}
+
+func (c Config) defaults() Config {
+	if c.DepthLimit == 0 {
+		c.DepthLimit = DefaultDepthLimit
+	}
+	if c.PollInterval == 0 {
+		c.PollInterval = DefaultPollInterval
+	}
+	if c.EventsBuffer == 0 {
+		c.EventsBuffer = DefaultEventsBuffer
+	}
+	if c.Now == nil {
+		c.Now = time.Now
+	}
+
+	return c
+}
+
+// Client is a paper trading RestClient.
+// Public endpoints are proxied to the upstream client, while orders, cancel-replace, open and all orders, OCO orders,
+// SOR orders and allocations, account and account trades endpoints are simulated locally: orders are filled against
+// the live order book of the upstream and settled on virtual balances. Other private endpoints return ErrNotSimulated.
+// Remark: each order book snapshot is treated as fresh liquidity, orders don't affect the following snapshots
+type Client struct {
+	upstream binance.RestClient
+	market   *binance.Client
+	config   Config
+
+	mu            sync.Mutex
+	symbols       map[string]*binance.SymbolInfo
+	balances      map[string]*balance
+	changed       map[string]struct{}
+	orders        map[int64]*order
+	clientIDs     map[string]*order
+	lists         map[int64]*orderList
+	listClientIDs map[string]*orderList
+	trades        []*binance.AccountTrade
+	subscribers   map[*subscriber]struct{}
+	orderID       int64
+	tradeID       int64
+	listID        int64
+	updateTime    int64
+}
+
+// NewClient creates a paper trading client on top of the upstream client used for market data
+func NewClient(upstream binance.RestClient, config Config) *Client {
+	cfg := config.defaults()
+	c := &Client{
+		upstream:      upstream,
+		market:        binance.NewCustomClient(upstream),
+		config:        cfg,
+		symbols:       make(map[string]*binance.SymbolInfo, len(cfg.Symbols)),
+		balances:      make(map[string]*balance, len(cfg.Balances)),
+		changed:       make(map[string]struct{}),
+		orders:        make(map[int64]*order),
+		clientIDs:     make(map[string]*order),
+		lists:         make(map[int64]*orderList),
+		listClientIDs: make(map[string]*orderList),
+		subscribers:   make(map[*subscriber]struct{}),
+	}
+	for _, s := range cfg.Symbols {
+		c.symbols[s.Symbol] = s
+	}
+	for asset, free := range cfg.Balances {
+		c.balances[asset] = &balance{free: free}
+	}
+
+	return c
+}
+
+// Do simulates private trading endpoints and proxies public endpoints to the upstream
+func (c *Client) Do(method, endpoint string, data interface{}, sign, stream bool) ([]byte, error) {
+	var (
+		resp interface{}
+		err  error
+	)
+	switch endpoint {
+	case binance.EndpointOrder:
+		resp, err = c.doOrder(method, data)
+	case binance.EndpointOrderTest:
+		resp, err = c.testOrder(method, data)
+	case binance.EndpointCancelReplaceOrder:
+		resp, err = c.cancelReplace(method, data)
+	case binance.EndpointOpenOrders:
+		resp, err = c.doOpenOrders(method, data)
+	case binance.EndpointOrdersAll:
+		resp, err = c.allOrders(method, data)
+	case binance.EndpointOCOOrder:
+		resp, err = c.newOCO(method, data)
+	case binance.EndpointOCOOrders:
+		resp, err = c.doOCO(method, data)
+	case binance.EndpointOpenOCOOrders:
+		resp, err = c.openOCO(method)
+	case binance.EndpointOCOOrdersAll:
+		resp, err = c.allOCO(method, data)
+	case binance.EndpointSOROrder:
+		resp, err = c.newSOROrder(method, data)
+	case binance.EndpointSOROrderTest:
+		resp, err = c.testSOROrder(method, data)
+	case binance.EndpointMyAllocations:
+		resp, err = c.myAllocations(method, data)
+	case binance.EndpointAccount:
+		resp, err = c.account(method)
+	case binance.EndpointAccountTrades:
+		resp, err = c.accountTrades(method, data)
+	case binance.EndpointDataStream:
+		resp = struct{}{}
+		if method == fasthttp.MethodPost {
+			resp = &binance.DataStream{ListenKey: ListenKey}
+		}
+	default:
+		if sign || stream {
+			return nil, ErrNotSimulated
+		}
+		return c.upstream.Do(method, endpoint, data, sign, stream)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(resp)
+}
+
+// SetWindow sets the response window of the upstream client
+func (c *Client) SetWindow(window int) {
+	c.upstream.SetWindow(window)
+}
+
+// UsedWeight returns the weight used by the upstream client
+func (c *Client) UsedWeight() map[string]int64 {
+	return c.upstream.UsedWeight()
+}
+
+// OrderCount is always empty, simulated orders aren't counted by the exchange
+func (c *Client) OrderCount() map[string]int64 {
+	return map[string]int64{}
+}
+
+func (c *Client) RetryAfter() int64 {
+	return c.upstream.RetryAfter()
+}
+
+// Match fills the open orders of the symbol against the current upstream order book
+func (c *Client) Match(symbol string) error {
+	b, err := c.depth(symbol)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.match(symbol, b)
+
+	return nil
+}
+
+// UpdateBook fills the open orders of the symbol against the given order book,
+// e.g. the partial depth received from the websocket
+func (c *Client) UpdateBook(symbol string, depth *binance.Depth) {
+	b := newBook(depth)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.match(symbol, b)
+}
+
+// Run polls the order book of symbols with open orders every PollInterval and fills the orders until the context is done.
+// Polling errors are retried on the next tick
+func (c *Client) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		for _, symbol := range c.activeSymbols() {
+			_ = c.Match(symbol)
+		}
+	}
+}
+
+// Deposit credits the virtual balance and emits the balance update event
+func (c *Client) Deposit(asset string, amount decimal.Decimal) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.credit(asset, amount)
+	now := c.now()
+	c.publish(&ws.BalanceUpdateEvent{
+		EventType:    ws.AccountUpdateEventTypeBalanceUpdate,
+		Asset:        asset,
+		BalanceDelta: amount.String(),
+		Time:         now,
+		ClearTime:    now,
+	})
+	c.flush()
+}
+
+// Balances returns the free and locked virtual balances by asset
+func (c *Client) Balances() []*binance.Balance {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.balanceList(nil)
+}
+
+// Trades returns the simulated trades of all symbols in the order of execution
+func (c *Client) Trades() []*binance.AccountTrade {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]*binance.AccountTrade(nil), c.trades...)
+}
+
+func (c *Client) activeSymbols() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	seen := make(map[string]struct{})
+	var symbols []string
+	for _, o := range c.orders {
+		if _, ok := seen[o.symbol.Symbol]; ok || !o.open() {
+			continue
+		}
+		seen[o.symbol.Symbol] = struct{}{}
+		symbols = append(symbols, o.symbol.Symbol)
+	}
+	sort.Strings(symbols)
+
+	return symbols
+}
+
+func (c *Client) symbol(name string) (*binance.SymbolInfo, error) {
+	c.mu.Lock()
+	s, ok := c.symbols[name]
+	c.mu.Unlock()
+	if ok {
+		return s, nil
+	}
+
+	info, err := c.market.ExchangeInfo(&binance.ExchangeInfoReq{Symbol: name})
+	if err != nil {
+		return nil, err
+	}
+	if s = info.Symbol(name); s == nil {
+		return nil, errInvalidSymbol
+	}
+	c.mu.Lock()
+	c.symbols[name] = s
+	c.mu.Unlock()
+
+	return s, nil
+}
+
+func (c *Client) depth(symbol string) (*book, error) {
+	d, err := c.market.Depth(&binance.DepthReq{Symbol: symbol, Limit: c.config.DepthLimit})
+	if err != nil {
+		return nil, err
+	}
+
+	return newBook(d), nil
+}
+
+func (c *Client) now() int64 {
+	return c.config.Now().UnixMilli()
+}
+
+func (c *Client) account(method string) (*binance.AccountInfo, error) {
+	if method != fasthttp.MethodGet {
+		return nil, ErrNotSimulated
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	bps := decimal.NewFromInt(10000)
+	return &binance.AccountInfo{
+		MakerCommission: int(c.config.MakerCommission.Mul(bps).IntPart()),
+		TakerCommission: int(c.config.TakerCommission.Mul(bps).IntPart()),
+		CommissionRates: binance.CommissionRates{
+			Maker:  c.config.MakerCommission.String(),
+			Taker:  c.config.TakerCommission.String(),
+			Buyer:  "0",
+			Seller: "0",
+		},
+		CanTrade:    true,
+		UpdateTime:  c.updateTime,
+		AccountType: binance.PermissionTypeSpot,
+		Balances:    c.balanceList(nil),
+		Permissions: []binance.PermissionType{binance.PermissionTypeSpot},
+	}, nil
+}
+
+func (c *Client) accountTrades(method string, data interface{}) ([]*binance.AccountTrade, error) {
+	req, ok := data.(*binance.AccountTradesReq)
+	if method != fasthttp.MethodGet || !ok {
+		return nil, ErrNotSimulated
+	}
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	var orderID int64
+	if req.OrderID != "" {
+		var err error
+		if orderID, err = strconv.ParseInt(req.OrderID, 10, 64); err != nil {
+			return nil, errNoSuchOrder
+		}
+	}
+	limit := req.Limit
+	if limit <= 0 || limit > binance.MaxAccountTradesLimit {
+		limit = binance.MaxAccountTradesLimit
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resp := make([]*binance.AccountTrade, 0)
+	for _, t := range c.trades {
+		switch {
+		case t.Symbol != req.Symbol,
+			orderID != 0 && t.OrderID != orderID,
+			req.FromID != 0 && t.ID < req.FromID,
+			req.StartTime != 0 && t.Time < req.StartTime,
+			req.EndTime != 0 && t.Time > req.EndTime:
+			continue
+		}
+		resp = append(resp, t)
+	}
+	if len(resp) > limit {
+		// the oldest trades are returned starting from the id, otherwise the most recent ones
+		if req.FromID != 0 {
+			resp = resp[:limit]
+		} else {
+			resp = resp[len(resp)-limit:]
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,338 @@
+package paper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/segmentio/encoding/json"
+	"github.com/stretchr/testify/suite"
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/binancetest"
+	"github.com/xenking/binance-api/paper"
+	"github.com/xenking/binance-api/ws"
+)
+
+func TestPaperClient(t *testing.T) {
+	suite.Run(t, new(paperTestSuite))
+}
+
+type paperTestSuite struct {
+	suite.Suite
+	depth  string
+	paper  *paper.Client
+	client *binance.Client
+}
+
+func (s *paperTestSuite) SetupTest() {
+	s.depth = `{"lastUpdateId":1,"bids":[["99.00","5"]],"asks":[["100.00","1"],["101.00","2"]]}`
+	upstream := &binancetest.MockClient{Response: func(method, endpoint string, data interface{}, sign, stream bool) ([]byte, error) {
+		switch endpoint {
+		case binance.EndpointExchangeInfo:
+			return json.Marshal(&binance.ExchangeInfo{Symbols: []*binance.SymbolInfo{{
+				Symbol:     "BTCUSDT",
+				Status:     binance.SymbolStatusTrading,
+				BaseAsset:  "BTC",
+				QuoteAsset: "USDT",
+				OrderTypes: []binance.OrderType{
+					binance.OrderTypeLimit, binance.OrderTypeLimitMaker, binance.OrderTypeMarket,
+					binance.OrderTypeStopLoss, binance.OrderTypeStopLossLimit,
+				},
+				OCOAllowed:                 true,
+				QuoteOrderQtyMarketAllowed: true,
+				Filters: []binance.SymbolInfoFilter{
+					{Type: binance.FilterTypeLotSize, MinQty: "0.001", MaxQty: "1000", StepSize: "0.001"},
+				},
+			}}})
+		case binance.EndpointDepth:
+			return []byte(s.depth), nil
+		case binance.EndpointPing:
+			return []byte(`{}`), nil
+		}
+		s.FailNow("unexpected upstream request", endpoint)
+		return nil, nil
+	}}
+	s.paper = paper.NewClient(upstream, paper.Config{
+		Balances: map[string]decimal.Decimal{
+			"USDT": decimal.NewFromInt(1000),
+			"BTC":  decimal.NewFromInt(1),
+		},
+		MakerCommission: decimal.Zero,
+		TakerCommission: decimal.RequireFromString("0.001"),
+	})
+	s.client = binance.NewCustomClient(s.paper)
+}
+
+func (s *paperTestSuite) balances() map[string]*binance.Balance {
+	info, err := s.client.Account()
+	s.Require().NoError(err)
+	resp := make(map[string]*binance.Balance, len(info.Balances))
+	for _, b := range info.Balances {
+		resp[b.Asset] = b
+	}
+
+	return resp
+}
+
+func (s *paperTestSuite) TestMarketOrder() {
+	resp, err := s.client.NewOrderFull(&binance.OrderReq{
+		Symbol:   "BTCUSDT",
+		Side:     binance.OrderSideBuy,
+		Type:     binance.OrderTypeMarket,
+		Quantity: "2",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(binance.OrderStatusFilled, resp.Status)
+	s.Require().Len(resp.Fills, 2)
+	s.Require().Equal("100", resp.Fills[0].Price)
+	s.Require().Equal("101", resp.Fills[1].Price)
+	s.Require().Equal("201", resp.CummulativeQuoteQty)
+
+	balances := s.balances()
+	s.Require().Equal("2.998", balances["BTC"].Free)
+	s.Require().Equal("799", balances["USDT"].Free)
+
+	trades, err := s.client.AccountTrades(&binance.AccountTradesReq{Symbol: "BTCUSDT"})
+	s.Require().NoError(err)
+	s.Require().Len(trades, 2)
+	s.Require().Equal("0.001", trades[0].Commission)
+	s.Require().Equal("BTC", trades[0].CommissionAsset)
+	s.Require().True(trades[0].Buyer)
+
+	// quote order quantity is spent on the step size multiples
+	resp, err = s.client.NewOrderFull(&binance.OrderReq{
+		Symbol:        "BTCUSDT",
+		Side:          binance.OrderSideSell,
+		Type:          binance.OrderTypeMarket,
+		QuoteQuantity: "50",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(binance.OrderStatusFilled, resp.Status)
+	s.Require().Equal("0.505", resp.ExecutedQty)
+}
+
+func (s *paperTestSuite) TestLimitOrderEvents() {
+	stream := s.paper.AccountInfo()
+	defer stream.Close()
+
+	resp, err := s.client.NewOrderFull(&binance.OrderReq{
+		Symbol:           "BTCUSDT",
+		Side:             binance.OrderSideBuy,
+		Type:             binance.OrderTypeLimit,
+		TimeInForce:      binance.TimeInForceGTC,
+		Quantity:         "1",
+		Price:            "99.5",
+		NewClientOrderID: "limit-1",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(binance.OrderStatusNew, resp.Status)
+	s.Require().Equal("99.5", s.balances()["USDT"].Locked)
+
+	s.requireOrderEvent(stream, "limit-1", ws.ExecutionTypeNew, binance.OrderStatusNew)
+	s.requireAccountEvent(stream)
+
+	s.paper.UpdateBook("BTCUSDT", &binance.Depth{
+		Asks: []binance.DepthElem{{Price: decimal.RequireFromString("99"), Quantity: decimal.NewFromInt(5)}},
+	})
+	e := s.requireOrderEvent(stream, "limit-1", ws.ExecutionTypeTrade, binance.OrderStatusFilled)
+	s.Require().Equal("99.5", e.FilledPrice)
+	s.Require().True(e.Maker)
+	s.requireAccountEvent(stream)
+
+	balances := s.balances()
+	s.Require().Equal("900.5", balances["USDT"].Free)
+	s.Require().Equal("0", balances["USDT"].Locked)
+	s.Require().Equal("2", balances["BTC"].Free)
+}
+
+func (s *paperTestSuite) requireOrderEvent(stream *ws.AccountInfo, clientID string, execType, status binance.OrderStatus) *ws.OrderUpdateEvent {
+	s.Require().NoError(stream.NetConn().SetReadDeadline(time.Now().Add(time.Second)))
+	eventType, event, err := stream.Read()
+	s.Require().NoError(err)
+	s.Require().Equal(ws.AccountUpdateEventTypeOrderReport, eventType)
+	e := event.(*ws.OrderUpdateEvent)
+	s.Require().Equal(clientID, e.NewClientOrderID)
+	s.Require().Equal(execType, e.ExecutionType)
+	s.Require().Equal(status, e.Status)
+
+	return e
+}
+
+func (s *paperTestSuite) requireAccountEvent(stream *ws.AccountInfo) *ws.AccountUpdateEvent {
+	s.Require().NoError(stream.NetConn().SetReadDeadline(time.Now().Add(time.Second)))
+	eventType, event, err := stream.Read()
+	s.Require().NoError(err)
+	s.Require().Equal(ws.AccountUpdateEventTypeOutboundAccountPosition, eventType)
+
+	return event.(*ws.AccountUpdateEvent)
+}
+
+func (s *paperTestSuite) TestRejections() {
+	_, err := s.client.NewOrder(&binance.OrderReq{
+		Symbol:      "BTCUSDT",
+		Side:        binance.OrderSideBuy,
+		Type:        binance.OrderTypeLimitMaker,
+		Quantity:    "1",
+		Price:       "100",
+		TimeInForce: binance.TimeInForceGTC,
+	})
+	var apiErr *binance.APIError
+	s.Require().ErrorAs(err, &apiErr)
+	s.Require().Equal(binance.ErrorCodeNewOrderRejected, apiErr.Code)
+
+	_, err = s.client.NewOrder(&binance.OrderReq{
+		Symbol:      "BTCUSDT",
+		Side:        binance.OrderSideBuy,
+		Type:        binance.OrderTypeLimit,
+		TimeInForce: binance.TimeInForceGTC,
+		Quantity:    "20",
+		Price:       "90",
+	})
+	s.Require().ErrorAs(err, &apiErr)
+	s.Require().Equal(binance.ErrorCodeNewOrderRejected, apiErr.Code)
+
+	_, err = s.client.NewOrder(&binance.OrderReq{
+		Symbol:   "BTCUSDT",
+		Side:     binance.OrderSideBuy,
+		Type:     binance.OrderTypeMarket,
+		Quantity: "0.0001",
+	})
+	s.Require().ErrorAs(err, &apiErr)
+	s.Require().Equal(binance.ErrorCodeFilterFailure, apiErr.Code)
+
+	_, err = s.client.MyPreventedMatches(&binance.AccountTradesReq{Symbol: "BTCUSDT"})
+	s.Require().ErrorIs(err, paper.ErrNotSimulated)
+	s.Require().NoError(s.client.Ping())
+}
+
+func (s *paperTestSuite) TestCancelOrder() {
+	_, err := s.client.NewOrder(&binance.OrderReq{
+		Symbol:           "BTCUSDT",
+		Side:             binance.OrderSideSell,
+		Type:             binance.OrderTypeLimit,
+		TimeInForce:      binance.TimeInForceGTC,
+		Quantity:         "0.5",
+		Price:            "120",
+		NewClientOrderID: "sell-1",
+	})
+	s.Require().NoError(err)
+
+	open, err := s.client.OpenOrders(&binance.OpenOrdersReq{Symbol: "BTCUSDT"})
+	s.Require().NoError(err)
+	s.Require().Len(open, 1)
+	s.Require().True(open[0].IsWorking)
+	s.Require().Equal("0.5", s.balances()["BTC"].Locked)
+
+	canceled, err := s.client.CancelOrder(&binance.CancelOrderReq{Symbol: "BTCUSDT", OrigClientOrderID: "sell-1"})
+	s.Require().NoError(err)
+	s.Require().Equal(binance.OrderStatusCanceled, canceled.Status)
+	s.Require().Equal("sell-1", canceled.OrigClientOrderID)
+
+	order, err := s.client.QueryOrder(&binance.QueryOrderReq{Symbol: "BTCUSDT", OrderID: canceled.OrderID})
+	s.Require().NoError(err)
+	s.Require().Equal(binance.OrderStatusCanceled, order.Status)
+	s.Require().Equal("0", s.balances()["BTC"].Locked)
+	s.Require().Equal("1", s.balances()["BTC"].Free)
+
+	_, err = s.client.CancelOrder(&binance.CancelOrderReq{Symbol: "BTCUSDT", OrderID: canceled.OrderID})
+	var apiErr *binance.APIError
+	s.Require().ErrorAs(err, &apiErr)
+	s.Require().Equal(binance.ErrorCodeCancelRejected, apiErr.Code)
+
+	_, err = s.client.QueryOrder(&binance.QueryOrderReq{Symbol: "BTCUSDT", OrderID: 100})
+	s.Require().ErrorAs(err, &apiErr)
+	s.Require().Equal(binance.ErrorCodeNoSuchOrder, apiErr.Code)
+}
+
+func (s *paperTestSuite) TestOCO() {
+	list, err := s.client.NewOCO(&binance.OCOReq{
+		Symbol:         "BTCUSDT",
+		Side:           binance.OrderSideSell,
+		Quantity:       "1",
+		Price:          "110",
+		StopPrice:      "90",
+		StopLimitPrice: "89",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(binance.OCOStatusExecStarted, list.ListStatusType)
+	s.Require().Len(list.OrderReports, 2)
+	s.Require().Equal("1", s.balances()["BTC"].Locked)
+
+	open, err := s.client.OpenOCO()
+	s.Require().NoError(err)
+	s.Require().Len(open, 1)
+
+	s.paper.UpdateBook("BTCUSDT", &binance.Depth{
+		Bids: []binance.DepthElem{{Price: decimal.RequireFromString("89.5"), Quantity: decimal.NewFromInt(5)}},
+		Asks: []binance.DepthElem{{Price: decimal.RequireFromString("90"), Quantity: decimal.NewFromInt(5)}},
+	})
+
+	list, err = s.client.QueryOCO(&binance.QueryOCOReq{OrderListID: list.OrderListID})
+	s.Require().NoError(err)
+	s.Require().Equal(binance.OCOStatusAllDone, list.ListStatusType)
+
+	stop, err := s.client.QueryOrder(&binance.QueryOrderReq{Symbol: "BTCUSDT", OrderID: int64(list.Orders[0].OrderID)})
+	s.Require().NoError(err)
+	s.Require().Equal(binance.OrderStatusFilled, stop.Status)
+	s.Require().Equal("89.5", stop.CummulativeQuoteQty)
+
+	limit, err := s.client.QueryOrder(&binance.QueryOrderReq{Symbol: "BTCUSDT", OrderID: int64(list.Orders[1].OrderID)})
+	s.Require().NoError(err)
+	s.Require().Equal(binance.OrderStatusExpired, limit.Status)
+
+	balances := s.balances()
+	s.Require().Equal("0", balances["BTC"].Free)
+	s.Require().Equal("0", balances["BTC"].Locked)
+	s.Require().Equal("1089.4105", balances["USDT"].Free)
+}
+
+func (s *paperTestSuite) TestCancelReplace() {
+	order, err := s.client.NewOrder(&binance.OrderReq{
+		Symbol:      "BTCUSDT",
+		Side:        binance.OrderSideSell,
+		Type:        binance.OrderTypeLimit,
+		TimeInForce: binance.TimeInForceGTC,
+		Quantity:    "1",
+		Price:       "120",
+	})
+	s.Require().NoError(err)
+
+	newOrder := binance.OrderReq{
+		Symbol:      "BTCUSDT",
+		Side:        binance.OrderSideSell,
+		Type:        binance.OrderTypeLimit,
+		TimeInForce: binance.TimeInForceGTC,
+		Quantity:    "1",
+		Price:       "115",
+	}
+	replaced, err := s.client.CancelReplaceOrder(&binance.CancelReplaceOrderReq{OrderReq: newOrder, CancelOrderID: order.OrderID})
+	s.Require().NoError(err)
+	s.Require().Equal(binance.CancelReplaceResultSuccess, replaced.CancelResult)
+	s.Require().Equal(binance.OrderStatusNew, replaced.NewOrderResponse.Status)
+	// the funds of the canceled order are reserved by the new one
+	s.Require().Equal("1", s.balances()["BTC"].Locked)
+
+	// the canceled order can't be canceled again, the new order is placed in ALLOW_FAILURE mode only
+	_, err = s.client.CancelReplaceOrder(&binance.CancelReplaceOrderReq{OrderReq: newOrder, CancelOrderID: order.OrderID})
+	var apiErr *binance.APIError
+	s.Require().ErrorAs(err, &apiErr)
+	s.Require().Equal(binance.ErrorCodeCancelRejected, apiErr.Code)
+
+	s.paper.Deposit("BTC", decimal.NewFromInt(1))
+	replaced, err = s.client.CancelReplaceOrder(&binance.CancelReplaceOrderReq{
+		OrderReq:          newOrder,
+		CancelOrderID:     order.OrderID,
+		CancelReplaceMode: binance.CancelReplaceModeAllowFailure,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(binance.CancelReplaceResultFailure, replaced.CancelResult)
+	s.Require().Equal(binance.CancelReplaceResultSuccess, replaced.NewOrderResult)
+
+	orders, err := s.client.AllOrders(&binance.AllOrdersReq{Symbol: "BTCUSDT", OrderID: replaced.NewOrderResponse.OrderID - 1})
+	s.Require().NoError(err)
+	s.Require().Len(orders, 2)
+	s.Require().Equal(binance.OrderStatusNew, orders[0].Status)
+	s.Require().Equal(binance.OrderStatusNew, orders[1].Status)
+	s.Require().Equal("2", s.balances()["BTC"].Locked)
+}
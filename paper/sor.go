@@ -0,0 +1,136 @@
+package paper
+
+import (
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+)
+
+const (
+	// workingFloorSOR is the working floor of the orders placed by smart order routing
+	workingFloorSOR = "SOR"
+	// matchTypeSOR is the match type of the fills of the orders placed by smart order routing
+	matchTypeSOR = "ONE_PARTY_TRADE_REPORT"
+)
+
+// sorOrderReq converts the SOR order to the regular one, the only venue of the paper client is the symbol book
+func sorOrderReq(req *binance.SOROrderReq) *binance.OrderReq {
+	return &binance.OrderReq{
+		Symbol:                  req.Symbol,
+		Side:                    req.Side,
+		Type:                    req.Type,
+		TimeInForce:             req.TimeInForce,
+		Quantity:                req.Quantity,
+		Price:                   req.Price,
+		NewClientOrderID:        req.NewClientOrderID,
+		StrategyID:              req.StrategyID,
+		StrategyType:            req.StrategyType,
+		IcebergQty:              req.IcebergQty,
+		OrderRespType:           req.OrderRespType,
+		SelfTradePreventionMode: req.SelfTradePreventionMode,
+	}
+}
+
+func (c *Client) newSOROrder(method string, data interface{}) (*binance.SOROrder, error) {
+	req, ok := data.(*binance.SOROrderReq)
+	if method != fasthttp.MethodPost || !ok {
+		return nil, ErrNotSimulated
+	}
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	o, err := c.prepareOrder(sorOrderReq(req))
+	if err != nil {
+		return nil, err
+	}
+	o.sor = true
+	b, err := c.depth(req.Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err = c.place(o, b); err != nil {
+		return nil, err
+	}
+	c.flush()
+
+	return &binance.SOROrder{OrderRespFull: *o.full(), WorkingFloor: workingFloorSOR, UsedSor: true}, nil
+}
+
+func (c *Client) testSOROrder(method string, data interface{}) (interface{}, error) {
+	req, ok := data.(*binance.SOROrderReq)
+	if method != fasthttp.MethodPost || !ok {
+		return nil, ErrNotSimulated
+	}
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if _, err := c.prepareOrder(sorOrderReq(req)); err != nil {
+		return nil, err
+	}
+
+	return struct{}{}, nil
+}
+
+// myAllocations returns the trades of the SOR orders as allocations
+func (c *Client) myAllocations(method string, data interface{}) ([]*binance.Allocation, error) {
+	req, ok := data.(*binance.MyAllocationsReq)
+	if method != fasthttp.MethodGet || !ok {
+		return nil, ErrNotSimulated
+	}
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	limit := req.Limit
+	if limit <= 0 || limit > binance.MaxAllocationsLimit {
+		limit = binance.DefaultAllocationsLimit
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resp := make([]*binance.Allocation, 0)
+	for _, t := range c.trades {
+		if o, ok := c.orders[t.OrderID]; !ok || !o.sor {
+			continue
+		}
+		switch {
+		case t.Symbol != req.Symbol,
+			req.OrderID != 0 && t.OrderID != req.OrderID,
+			req.FromAllocationID != 0 && t.ID < req.FromAllocationID,
+			req.StartTime != 0 && t.Time < req.StartTime,
+			req.EndTime != 0 && t.Time > req.EndTime:
+			continue
+		}
+		resp = append(resp, &binance.Allocation{
+			Symbol:          t.Symbol,
+			AllocationID:    t.ID,
+			AllocationType:  binance.AllocationTypeSOR,
+			OrderID:         t.OrderID,
+			OrderListID:     t.OrderListID,
+			Price:           t.Price,
+			Qty:             t.Qty,
+			QuoteQty:        t.QuoteQty,
+			Commission:      t.Commission,
+			CommissionAsset: t.CommissionAsset,
+			Time:            t.Time,
+			IsBuyer:         t.Buyer,
+			IsMaker:         t.Maker,
+		})
+	}
+	if len(resp) > limit {
+		// the oldest allocations are returned starting from the id, otherwise the most recent ones
+		if req.FromAllocationID != 0 {
+			resp = resp[:limit]
+		} else {
+			resp = resp[len(resp)-limit:]
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,128 @@
+package portfoliomargin
+
+import (
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+)
+
+// Account get the unified account info with the maintenance margin ratio and the risk status
+func (c *Client) Account() (*Account, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointAccount, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &Account{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// Balance get the balances of all assets of the unified account
+func (c *Client) Balance() ([]*Balance, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointBalance, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*Balance
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// AutoCollection moves the assets of the USD-M and COIN-M wallets, except the negative balances, to the margin wallet
+func (c *Client) AutoCollection() error {
+	_, err := c.Do(fasthttp.MethodPost, EndpointAutoCollection, nil, true, false)
+
+	return err
+}
+
+// AssetCollection moves the asset of the USD-M and COIN-M wallets to the margin wallet
+func (c *Client) AssetCollection(req *AssetCollectionReq) error {
+	if req == nil {
+		return binance.ErrNilRequest
+	}
+	if req.Asset == "" {
+		return binance.ErrEmptyAsset
+	}
+	_, err := c.Do(fasthttp.MethodPost, EndpointAssetCollection, req, true, false)
+
+	return err
+}
+
+// RepayLoan repays the cross margin loan of the asset
+func (c *Client) RepayLoan(req *RepayLoanReq) (*RepayLoan, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Asset == "" {
+		return nil, binance.ErrEmptyAsset
+	}
+	if req.Amount == "" {
+		return nil, binance.ErrEmptyAmount
+	}
+	res, err := c.Do(fasthttp.MethodPost, EndpointRepayLoan, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &RepayLoan{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// RepayFuturesNegativeBalance repays the negative balances of the USD-M and COIN-M wallets from the margin wallet
+func (c *Client) RepayFuturesNegativeBalance() error {
+	_, err := c.Do(fasthttp.MethodPost, EndpointRepayFuturesNegative, nil, true, false)
+
+	return err
+}
+
+// AutoRepayFutures get whether the futures negative balances are repaid automatically
+func (c *Client) AutoRepayFutures() (*AutoRepayFutures, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointRepayFuturesSwitch, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &AutoRepayFutures{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// ChangeAutoRepayFutures enables or disables repaying the futures negative balances automatically
+func (c *Client) ChangeAutoRepayFutures(req *AutoRepayFuturesReq) error {
+	if req == nil {
+		return binance.ErrNilRequest
+	}
+	_, err := c.Do(fasthttp.MethodPost, EndpointRepayFuturesSwitch, req, true, false)
+
+	return err
+}
+
+// DataStream starts a new user data stream of the unified account
+func (c *Client) DataStream() (string, error) {
+	res, err := c.Do(fasthttp.MethodPost, EndpointDataStream, nil, false, true)
+	if err != nil {
+		return "", err
+	}
+	resp := &binance.DataStream{}
+	err = json.Unmarshal(res, resp)
+
+	return resp.ListenKey, err
+}
+
+// DataStreamKeepAlive pings the data stream key to prevent timeout
+func (c *Client) DataStreamKeepAlive(listenKey string) error {
+	_, err := c.Do(fasthttp.MethodPut, EndpointDataStream, binance.DataStream{ListenKey: listenKey}, false, true)
+
+	return err
+}
+
+// DataStreamClose closes the data stream key
+func (c *Client) DataStreamClose(listenKey string) error {
+	_, err := c.Do(fasthttp.MethodDelete, EndpointDataStream, binance.DataStream{ListenKey: listenKey}, false, true)
+
+	return err
+}
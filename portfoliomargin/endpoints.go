@@ -0,0 +1,31 @@
+package portfoliomargin
+
+// Endpoints with NONE security
+const (
+	EndpointPing = "/papi/v1/ping"
+)
+
+// Endpoints with SIGNED security
+const (
+	EndpointBalance              = "/papi/v1/balance"
+	EndpointAccount              = "/papi/v1/account"
+	EndpointUMOrder              = "/papi/v1/um/order"
+	EndpointUMOpenOrders         = "/papi/v1/um/openOrders"
+	EndpointUMAllOpenOrders      = "/papi/v1/um/allOpenOrders"
+	EndpointCMOrder              = "/papi/v1/cm/order"
+	EndpointCMOpenOrders         = "/papi/v1/cm/openOrders"
+	EndpointCMAllOpenOrders      = "/papi/v1/cm/allOpenOrders"
+	EndpointMarginOrder          = "/papi/v1/margin/order"
+	EndpointMarginOpenOrders     = "/papi/v1/margin/openOrders"
+	EndpointMarginAllOpenOrders  = "/papi/v1/margin/allOpenOrders"
+	EndpointAutoCollection       = "/papi/v1/auto-collection"
+	EndpointAssetCollection      = "/papi/v1/asset-collection"
+	EndpointRepayLoan            = "/papi/v1/repayLoan"
+	EndpointRepayFuturesNegative = "/papi/v1/repay-futures-negative-balance"
+	EndpointRepayFuturesSwitch   = "/papi/v1/repay-futures-switch"
+)
+
+// Endpoints with USER_STREAM security
+const (
+	EndpointDataStream = "/papi/v1/listenKey"
+)
@@ -0,0 +1,296 @@
+package portfoliomargin
+
+import (
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/delivery"
+	"github.com/xenking/binance-api/futures"
+	"github.com/xenking/binance-api/margin"
+)
+
+// NewUMOrder sends in a new USD-M futures order and returns the created order
+func (c *Client) NewUMOrder(req *futures.OrderReq) (*futures.Order, error) {
+	if err := validateFuturesOrder(req); err != nil {
+		return nil, err
+	}
+	req.OrderRespType = futures.OrderRespTypeResult
+	res, err := c.Do(fasthttp.MethodPost, EndpointUMOrder, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &futures.Order{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// QueryUMOrder checks an USD-M futures order's status
+func (c *Client) QueryUMOrder(req *futures.QueryOrderReq) (*futures.Order, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	if req.OrderID == 0 && req.OrigClientOrderID == "" {
+		return nil, binance.ErrEmptyOrderID
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointUMOrder, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &futures.Order{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// CancelUMOrder cancel an active USD-M futures order
+func (c *Client) CancelUMOrder(req *futures.CancelOrderReq) (*futures.Order, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	if req.OrderID == 0 && req.OrigClientOrderID == "" {
+		return nil, binance.ErrEmptyOrderID
+	}
+	res, err := c.Do(fasthttp.MethodDelete, EndpointUMOrder, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &futures.Order{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// CancelUMOpenOrders cancel all open USD-M futures orders on a symbol
+func (c *Client) CancelUMOpenOrders(req *futures.CancelOpenOrdersReq) error {
+	if req == nil {
+		return binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return binance.ErrEmptySymbol
+	}
+	_, err := c.Do(fasthttp.MethodDelete, EndpointUMAllOpenOrders, req, true, false)
+
+	return err
+}
+
+// UMOpenOrders get all open USD-M futures orders on a symbol or all symbols when the symbol is not set
+func (c *Client) UMOpenOrders(req *futures.OpenOrdersReq) ([]*futures.Order, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointUMOpenOrders, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*futures.Order
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// NewCMOrder sends in a new COIN-M futures order and returns the created order, the quantity is in contracts
+func (c *Client) NewCMOrder(req *futures.OrderReq) (*delivery.Order, error) {
+	if err := validateFuturesOrder(req); err != nil {
+		return nil, err
+	}
+	req.OrderRespType = futures.OrderRespTypeResult
+	res, err := c.Do(fasthttp.MethodPost, EndpointCMOrder, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &delivery.Order{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// QueryCMOrder checks a COIN-M futures order's status
+func (c *Client) QueryCMOrder(req *futures.QueryOrderReq) (*delivery.Order, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	if req.OrderID == 0 && req.OrigClientOrderID == "" {
+		return nil, binance.ErrEmptyOrderID
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointCMOrder, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &delivery.Order{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// CancelCMOrder cancel an active COIN-M futures order
+func (c *Client) CancelCMOrder(req *futures.CancelOrderReq) (*delivery.Order, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	if req.OrderID == 0 && req.OrigClientOrderID == "" {
+		return nil, binance.ErrEmptyOrderID
+	}
+	res, err := c.Do(fasthttp.MethodDelete, EndpointCMOrder, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &delivery.Order{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// CancelCMOpenOrders cancel all open COIN-M futures orders on a symbol
+func (c *Client) CancelCMOpenOrders(req *futures.CancelOpenOrdersReq) error {
+	if req == nil {
+		return binance.ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return binance.ErrEmptySymbol
+	}
+	_, err := c.Do(fasthttp.MethodDelete, EndpointCMAllOpenOrders, req, true, false)
+
+	return err
+}
+
+// CMOpenOrders get all open COIN-M futures orders on a symbol or pair, all symbols when neither is set
+func (c *Client) CMOpenOrders(req *delivery.OpenOrdersReq) ([]*delivery.Order, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointCMOpenOrders, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*delivery.Order
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// NewMarginOrder sends in a new cross margin order and returns the created full order info.
+// The side effect type borrows the missing amount or repays the debt with the filled amount
+func (c *Client) NewMarginOrder(req *margin.OrderReq) (*margin.Order, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.IsIsolated == margin.IsolatedTrue {
+		return nil, ErrIsolatedUnsupported
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	req.OrderRespType = binance.OrderRespTypeFull
+	res, err := c.Do(fasthttp.MethodPost, EndpointMarginOrder, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &margin.Order{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// QueryMarginOrder checks a cross margin order's status
+func (c *Client) QueryMarginOrder(req *margin.QueryOrderReq) (*margin.QueryOrder, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.IsIsolated == margin.IsolatedTrue {
+		return nil, ErrIsolatedUnsupported
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	if req.OrderID == 0 && req.OrigClientOrderID == "" {
+		return nil, binance.ErrEmptyOrderID
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointMarginOrder, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &margin.QueryOrder{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// CancelMarginOrder cancel an active cross margin order
+func (c *Client) CancelMarginOrder(req *margin.CancelOrderReq) (*margin.CancelOrder, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.IsIsolated == margin.IsolatedTrue {
+		return nil, ErrIsolatedUnsupported
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	if req.OrderID == 0 && req.OrigClientOrderID == "" {
+		return nil, binance.ErrEmptyOrderID
+	}
+	res, err := c.Do(fasthttp.MethodDelete, EndpointMarginOrder, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &margin.CancelOrder{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// CancelMarginOpenOrders cancel all open cross margin orders on a symbol
+func (c *Client) CancelMarginOpenOrders(req *margin.CancelOpenOrdersReq) ([]*margin.CancelOrder, error) {
+	if req == nil {
+		return nil, binance.ErrNilRequest
+	}
+	if req.IsIsolated == margin.IsolatedTrue {
+		return nil, ErrIsolatedUnsupported
+	}
+	if req.Symbol == "" {
+		return nil, binance.ErrEmptySymbol
+	}
+	res, err := c.Do(fasthttp.MethodDelete, EndpointMarginAllOpenOrders, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*margin.CancelOrder
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// MarginOpenOrders get all open cross margin orders on a symbol or all symbols when the symbol is not set
+func (c *Client) MarginOpenOrders(req *margin.OpenOrdersReq) ([]*margin.QueryOrder, error) {
+	if req != nil && req.IsIsolated == margin.IsolatedTrue {
+		return nil, ErrIsolatedUnsupported
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointMarginOpenOrders, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*margin.QueryOrder
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// validateFuturesOrder checks the futures order, the conditional orders are placed by the other endpoints
+func validateFuturesOrder(req *futures.OrderReq) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+	if req.Type != futures.OrderTypeLimit && req.Type != futures.OrderTypeMarket {
+		return ErrInvalidOrderType
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package portfoliomargin
+
+import (
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+)
+
+// BaseHost for portfolio margin addresses
+const BaseHost = "papi.binance.com"
+
+var (
+	ErrInvalidOrderType    = binance.ValidationError{Msg: "only limit and market futures orders are supported"}
+	ErrIsolatedUnsupported = binance.ValidationError{Msg: "isolated margin is not supported by the portfolio margin account"}
+)
+
+// Client is the portfolio margin API client. The USD-M futures, the COIN-M futures and the cross margin
+// share the unified account, its orders are the same as of the futures, delivery and margin clients
+type Client struct {
+	binance.RestClient
+}
+
+// NewRestClient creates the rest client of the portfolio margin host with key and secret
+func NewRestClient(apikey, secret string) binance.RestClient {
+	return binance.NewCustomRestClient(binance.RestClientConfig{APIKey: apikey, APISecret: secret, Host: BaseHost})
+}
+
+// NewClient creates a new portfolio margin client with key and secret
+func NewClient(apikey, secret string) *Client {
+	return &Client{
+		RestClient: NewRestClient(apikey, secret),
+	}
+}
+
+func NewCustomClient(restClient binance.RestClient) *Client {
+	return &Client{
+		RestClient: restClient,
+	}
+}
+
+func (c *Client) ReqWindow(window int) *Client {
+	c.RestClient.SetWindow(window)
+
+	return c
+}
+
+// Ping tests connectivity to the Rest API
+func (c *Client) Ping() error {
+	_, err := c.Do(fasthttp.MethodGet, EndpointPing, nil, false, false)
+
+	return err
+}
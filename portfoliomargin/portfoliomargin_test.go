@@ -0,0 +1,206 @@
+package portfoliomargin_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-querystring/query"
+	"github.com/stretchr/testify/suite"
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/binancetest"
+	"github.com/xenking/binance-api/futures"
+	"github.com/xenking/binance-api/margin"
+	"github.com/xenking/binance-api/portfoliomargin"
+	"github.com/xenking/binance-api/ws"
+)
+
+func TestPortfolioMargin(t *testing.T) {
+	suite.Run(t, new(portfolioMarginTestSuite))
+}
+
+type portfolioMarginTestSuite struct {
+	suite.Suite
+	client *portfoliomargin.Client
+	mock   *binancetest.MockClient
+}
+
+func (s *portfolioMarginTestSuite) SetupTest() {
+	s.mock = &binancetest.MockClient{}
+	s.client = portfoliomargin.NewCustomClient(s.mock)
+}
+
+func (s *portfolioMarginTestSuite) TestAccount() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().True(sign)
+		switch endpoint {
+		case portfoliomargin.EndpointAccount:
+			return []byte(`{"uniMMR":"5167.92171923","accountEquity":"122607.35137903","actualEquity":"73.47428058","accountInitialMargin":"23.72469206","accountMaintMargin":"23.72469206","accountStatus":"NORMAL","virtualMaxWithdrawAmount":"1627523.32459208","totalAvailableBalance":"","totalMarginOpenLoss":"","updateTime":1657707212154}`), nil
+		case portfoliomargin.EndpointBalance:
+			return []byte(`[{"asset":"USDT","totalWalletBalance":"122607.35137903","crossMarginAsset":"92.27530794","crossMarginBorrowed":"10.00000000","crossMarginFree":"100.00000000","crossMarginInterest":"0.72469206","crossMarginLocked":"3.00000000","umWalletBalance":"0.25166395","umUnrealizedPNL":"-1.11049650","cmWalletBalance":"0.00000000","cmUnrealizedPNL":"0.00000000","updateTime":1617939110373,"negativeBalance":"0"}]`), nil
+		case portfoliomargin.EndpointRepayLoan:
+			values, err := query.Values(data)
+			s.Require().NoError(err)
+			s.Require().Equal("USDT", values.Get("asset"))
+			s.Require().Equal("10", values.Get("amount"))
+			return []byte(`{"tranId":100000001}`), nil
+		case portfoliomargin.EndpointAutoCollection, portfoliomargin.EndpointRepayFuturesNegative:
+			s.Require().Equal(fasthttp.MethodPost, method)
+			s.Require().Nil(data)
+			return []byte(`{"msg":"success"}`), nil
+		case portfoliomargin.EndpointRepayFuturesSwitch:
+			if method == fasthttp.MethodGet {
+				return []byte(`{"autoRepay":true}`), nil
+			}
+			values, err := query.Values(data)
+			s.Require().NoError(err)
+			s.Require().Equal("false", values.Get("autoRepay"))
+			return []byte(`{"msg":"success"}`), nil
+		}
+		s.FailNow("unexpected endpoint " + endpoint)
+		return nil, nil
+	}
+	account, err := s.client.Account()
+	s.Require().NoError(err)
+	s.Require().Equal(portfoliomargin.AccountStatusNormal, account.AccountStatus)
+	s.Require().Equal("5167.92171923", account.UniMMR)
+
+	balances, err := s.client.Balance()
+	s.Require().NoError(err)
+	s.Require().Equal("-1.11049650", balances[0].UMUnrealizedPnL)
+	s.Require().Equal("10.00000000", balances[0].CrossMarginBorrowed)
+
+	repay, err := s.client.RepayLoan(&portfoliomargin.RepayLoanReq{Asset: "USDT", Amount: "10"})
+	s.Require().NoError(err)
+	s.Require().EqualValues(100000001, repay.TranID)
+	_, err = s.client.RepayLoan(&portfoliomargin.RepayLoanReq{Asset: "USDT"})
+	s.Require().ErrorIs(err, binance.ErrEmptyAmount)
+
+	s.Require().NoError(s.client.AutoCollection())
+	s.Require().NoError(s.client.RepayFuturesNegativeBalance())
+	s.Require().ErrorIs(s.client.AssetCollection(&portfoliomargin.AssetCollectionReq{}), binance.ErrEmptyAsset)
+
+	autoRepay, err := s.client.AutoRepayFutures()
+	s.Require().NoError(err)
+	s.Require().True(autoRepay.AutoRepay)
+	s.Require().NoError(s.client.ChangeAutoRepayFutures(&portfoliomargin.AutoRepayFuturesReq{}))
+}
+
+func (s *portfolioMarginTestSuite) TestOrders() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().True(sign)
+		s.Require().Equal(fasthttp.MethodPost, method)
+		values, err := query.Values(data)
+		s.Require().NoError(err)
+		switch endpoint {
+		case portfoliomargin.EndpointUMOrder:
+			s.Require().Equal("RESULT", values.Get("newOrderRespType"))
+			return []byte(`{"symbol":"BTCUSDT","orderId":22542179,"status":"NEW","type":"LIMIT","side":"BUY","positionSide":"BOTH","origQty":"0.01","price":"30000","updateTime":1566818724722}`), nil
+		case portfoliomargin.EndpointCMOrder:
+			return []byte(`{"symbol":"BTCUSD_PERP","pair":"BTCUSD","orderId":22542180,"status":"NEW","type":"MARKET","side":"SELL","origQty":"1","cumBase":"0"}`), nil
+		case portfoliomargin.EndpointMarginOrder:
+			s.Require().Equal("MARGIN_BUY", values.Get("sideEffectType"))
+			s.Require().Equal("FULL", values.Get("newOrderRespType"))
+			return []byte(`{"symbol":"BTCUSDT","orderId":28,"status":"FILLED","type":"MARKET","side":"BUY","marginBuyBorrowAmount":"5","marginBuyBorrowAsset":"USDT"}`), nil
+		}
+		s.FailNow("unexpected endpoint " + endpoint)
+		return nil, nil
+	}
+	um, err := s.client.NewUMOrder(&futures.OrderReq{
+		Symbol: "BTCUSDT", Side: binance.OrderSideBuy, Type: futures.OrderTypeLimit, Quantity: "0.01", Price: "30000",
+	})
+	s.Require().NoError(err)
+	s.Require().EqualValues(22542179, um.OrderID)
+
+	cm, err := s.client.NewCMOrder(&futures.OrderReq{
+		Symbol: "BTCUSD_PERP", Side: binance.OrderSideSell, Type: futures.OrderTypeMarket, Quantity: "1",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal("BTCUSD", cm.Pair)
+
+	order, err := s.client.NewMarginOrder(&margin.OrderReq{
+		OrderReq: binance.OrderReq{
+			Symbol: "BTCUSDT", Side: binance.OrderSideBuy, Type: binance.OrderTypeMarket, QuoteQuantity: "100",
+		},
+		SideEffectType: margin.SideEffectTypeMarginBuy,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal("5", order.MarginBuyBorrowAmount)
+
+	_, err = s.client.NewUMOrder(&futures.OrderReq{
+		Symbol: "BTCUSDT", Side: binance.OrderSideSell, Type: futures.OrderTypeStopMarket, Quantity: "0.01", StopPrice: "29000",
+	})
+	s.Require().ErrorIs(err, portfoliomargin.ErrInvalidOrderType)
+	_, err = s.client.NewMarginOrder(&margin.OrderReq{IsIsolated: margin.IsolatedTrue})
+	s.Require().ErrorIs(err, portfoliomargin.ErrIsolatedUnsupported)
+	_, err = s.client.QueryMarginOrder(&margin.QueryOrderReq{Symbol: "BTCUSDT", OrderID: 1, IsIsolated: margin.IsolatedTrue})
+	s.Require().ErrorIs(err, portfoliomargin.ErrIsolatedUnsupported)
+	_, err = s.client.CancelMarginOrder(&margin.CancelOrderReq{Symbol: "BTCUSDT", OrderID: 1, IsIsolated: margin.IsolatedTrue})
+	s.Require().ErrorIs(err, portfoliomargin.ErrIsolatedUnsupported)
+	_, err = s.client.CancelMarginOpenOrders(&margin.CancelOpenOrdersReq{Symbol: "BTCUSDT", IsIsolated: margin.IsolatedTrue})
+	s.Require().ErrorIs(err, portfoliomargin.ErrIsolatedUnsupported)
+	_, err = s.client.MarginOpenOrders(&margin.OpenOrdersReq{Symbol: "BTCUSDT", IsIsolated: margin.IsolatedTrue})
+	s.Require().ErrorIs(err, portfoliomargin.ErrIsolatedUnsupported)
+	_, err = s.client.CancelCMOrder(&futures.CancelOrderReq{Symbol: "BTCUSD_PERP"})
+	s.Require().ErrorIs(err, binance.ErrEmptyOrderID)
+}
+
+func (s *portfolioMarginTestSuite) TestUserDataStream() {
+	server := binancetest.NewServer(binancetest.Config{})
+	defer server.Close()
+	client := portfoliomargin.NewCustomStreamClient(server.StreamURL(), nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, event := range []string{
+		`{"e":"ORDER_TRADE_UPDATE","E":1568879465651,"T":1568879465650,"fs":"UM","o":{"s":"BTCUSDT","c":"TEST","S":"SELL","o":"LIMIT","f":"GTC","q":"0.001","p":"30000","ap":"0","sp":"0","x":"NEW","X":"NEW","i":8886774,"l":"0","z":"0","L":"0","T":1568879465650,"t":0,"m":false,"R":false,"ps":"BOTH","rp":"0"}}`,
+		`{"e":"ACCOUNT_UPDATE","E":1564745798939,"T":1564745798938,"fs":"CM","a":{"m":"ORDER","B":[{"a":"BTC","wb":"1.5","cw":"1.5","bc":"0"}],"P":[{"s":"BTCUSD_PERP","pa":"10","ep":"30000","cr":"0","up":"0","mt":"cross","iw":"0","ps":"BOTH"}]}}`,
+		`{"e":"executionReport","E":1499405658658,"s":"BTCUSDT","c":"mUvoqJxFIILMdfAW5iGSOW","S":"BUY","o":"LIMIT","f":"GTC","q":"1.00000000","p":"0.10264410","x":"NEW","X":"NEW","i":4293153,"T":1499405658657}`,
+		`{"e":"liabilityChange","E":1573200697110,"a":"BTC","t":"BORROW","T":1352286576452864727,"p":"1.03453430","i":"0","l":"1.03476851"}`,
+		`{"e":"riskLevelChange","E":1587727187525,"u":"1.99999999","s":"MARGIN_CALL","eq":"30.23416728","ae":"30.23416728","m":"15.11708371"}`,
+		`{"e":"listenKeyExpired","E":1576653824250}`,
+	} {
+		s.Require().NoError(server.Publish("listenkey", []byte(event)))
+	}
+	userData, err := client.UserData(ctx, "listenkey")
+	s.Require().NoError(err)
+	defer userData.Close()
+
+	et, event, err := userData.Read()
+	s.Require().NoError(err)
+	s.Require().Equal(portfoliomargin.UpdateTypeOrderTrade, et)
+	order := event.(*portfoliomargin.OrderTradeUpdateEvent)
+	s.Require().Equal(portfoliomargin.BusinessUnitUM, order.BusinessUnit)
+	s.Require().Equal("30000", order.Order.Price)
+
+	_, event, err = userData.Read()
+	s.Require().NoError(err)
+	account := event.(*portfoliomargin.AccountUpdateEvent)
+	s.Require().Equal(portfoliomargin.BusinessUnitCM, account.BusinessUnit)
+	s.Require().Equal("10", account.Update.Positions[0].Amount)
+
+	et, event, err = userData.Read()
+	s.Require().NoError(err)
+	s.Require().Equal(portfoliomargin.UpdateTypeExecutionReport, et)
+	s.Require().EqualValues(4293153, event.(*ws.OrderUpdateEvent).OrderID)
+
+	_, event, err = userData.Read()
+	s.Require().NoError(err)
+	liability := event.(*portfoliomargin.LiabilityChangeEvent)
+	s.Require().Equal("BORROW", liability.Type)
+	s.Require().EqualValues(1352286576452864727, liability.TransactionID)
+	s.Require().Equal("1.03476851", liability.TotalLiability)
+
+	_, event, err = userData.Read()
+	s.Require().NoError(err)
+	risk := event.(*portfoliomargin.RiskLevelChangeEvent)
+	s.Require().Equal(portfoliomargin.AccountStatusMarginCall, risk.RiskLevel)
+	s.Require().Equal("15.11708371", risk.MaintenanceMargin)
+
+	et, event, err = userData.Read()
+	s.Require().NoError(err)
+	s.Require().Equal(portfoliomargin.UpdateTypeListenKeyExpired, et)
+	s.Require().IsType([]byte{}, event)
+}
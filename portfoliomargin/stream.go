@@ -0,0 +1,119 @@
+package portfoliomargin
+
+import (
+	"context"
+	"net"
+
+	"github.com/segmentio/encoding/json"
+
+	"github.com/xenking/binance-api/ws"
+)
+
+const DefaultStreamPath = "wss://fstream.binance.com/pm/ws/"
+
+// StreamClient opens the user data stream of the unified account
+type StreamClient struct {
+	client *ws.Client
+}
+
+func NewStreamClient() *StreamClient {
+	return &StreamClient{ws.NewCustomClient(DefaultStreamPath, nil)}
+}
+
+func NewCustomStreamClient(prefix string, conn net.Conn) *StreamClient {
+	return &StreamClient{ws.NewCustomClient(prefix, conn)}
+}
+
+// UserData opens websocket with the futures, margin and risk level updates of the unified account
+func (c *StreamClient) UserData(ctx context.Context, listenKey string) (*UserDataStream, error) {
+	conn, err := c.client.Dial(ctx, listenKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserDataStream{conn}, nil
+}
+
+// UserDataStream is a wrapper for portfolio margin user data websocket
+type UserDataStream struct {
+	ws.Conn
+}
+
+// Read reads a user data message from user data websocket.
+// The futures events are AccountUpdateEvent, OrderTradeUpdateEvent and AccountConfigUpdateEvent,
+// the margin events are ws.OrderUpdateEvent, ws.AccountUpdateEvent, ws.BalanceUpdateEvent, LiabilityChangeEvent
+// and OpenOrderLossEvent, the account risk is RiskLevelChangeEvent.
+// The messages of other types, e.g. listenKeyExpired, are returned as raw bytes
+func (s *UserDataStream) Read() (UpdateType, interface{}, error) {
+	payload, err := s.Conn.ReadRaw()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return decodeUserDataEvent(payload)
+}
+
+// Stream NewStream a user data message from user data websocket to channel,
+// the values are the same as returned by Read
+func (s *UserDataStream) Stream() <-chan interface{} {
+	updates := make(chan interface{})
+	go s.NewStreamRaw(func(payload []byte, err error) error {
+		if err != nil {
+			close(updates)
+			return err
+		}
+		// control frames are handled by the reader
+		if len(payload) == 0 {
+			return nil
+		}
+
+		_, u, err := decodeUserDataEvent(payload)
+		if err != nil {
+			close(updates)
+			return err
+		}
+		updates <- u
+
+		return nil
+	})
+
+	return updates
+}
+
+// decodeUserDataEvent decodes the user data message to the struct of its type,
+// the messages of unknown types are returned as is
+func decodeUserDataEvent(payload []byte) (UpdateType, interface{}, error) {
+	et := UpdateEventType{}
+	if err := json.Unmarshal(payload, &et); err != nil {
+		return "", nil, err
+	}
+
+	var resp interface{}
+	switch et.EventType {
+	case UpdateTypeAccount:
+		resp = &AccountUpdateEvent{}
+	case UpdateTypeOrderTrade:
+		resp = &OrderTradeUpdateEvent{}
+	case UpdateTypeAccountConfig:
+		resp = &AccountConfigUpdateEvent{}
+	case UpdateTypeExecutionReport:
+		resp = &ws.OrderUpdateEvent{}
+	case UpdateTypeOutboundAccountPosition:
+		resp = &ws.AccountUpdateEvent{}
+	case UpdateTypeBalanceUpdate:
+		resp = &ws.BalanceUpdateEvent{}
+	case UpdateTypeLiabilityChange:
+		resp = &LiabilityChangeEvent{}
+	case UpdateTypeOpenOrderLoss:
+		resp = &OpenOrderLossEvent{}
+	case UpdateTypeRiskLevelChange:
+		resp = &RiskLevelChangeEvent{}
+	default:
+		buf := make([]byte, len(payload))
+		copy(buf, payload)
+		return et.EventType, buf, nil
+	}
+	err := json.Unmarshal(payload, resp)
+
+	return et.EventType, resp, err
+}
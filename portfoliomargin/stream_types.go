@@ -0,0 +1,86 @@
+package portfoliomargin
+
+import (
+	"github.com/xenking/binance-api/futures"
+)
+
+// UpdateType represents type of the portfolio margin user data event
+type UpdateType string
+
+const (
+	UpdateTypeAccount                 UpdateType = "ACCOUNT_UPDATE"
+	UpdateTypeOrderTrade              UpdateType = "ORDER_TRADE_UPDATE"
+	UpdateTypeAccountConfig           UpdateType = "ACCOUNT_CONFIG_UPDATE"
+	UpdateTypeExecutionReport         UpdateType = "executionReport"
+	UpdateTypeOutboundAccountPosition UpdateType = "outboundAccountPosition"
+	UpdateTypeBalanceUpdate           UpdateType = "balanceUpdate"
+	UpdateTypeLiabilityChange         UpdateType = "liabilityChange"
+	UpdateTypeOpenOrderLoss           UpdateType = "openOrderLoss"
+	UpdateTypeRiskLevelChange         UpdateType = "riskLevelChange"
+	UpdateTypeListenKeyExpired        UpdateType = "listenKeyExpired"
+)
+
+// BusinessUnit is the futures part of the unified account the event relates to
+type BusinessUnit string
+
+const (
+	BusinessUnitUM BusinessUnit = "UM"
+	BusinessUnitCM BusinessUnit = "CM"
+)
+
+// UpdateEventType is the event type of the user data stream message
+type UpdateEventType struct {
+	EventType UpdateType `json:"e"` // EventType represents the update type
+	Time      int64      `json:"E"` // Time keeps the event time from matching the case-insensitive type field
+}
+
+// AccountUpdateEvent represents the USD-M or COIN-M futures balances and positions changed by the event
+type AccountUpdateEvent struct {
+	futures.AccountUpdateEvent
+	BusinessUnit BusinessUnit `json:"fs"` // BusinessUnit is either UM or CM
+}
+
+// OrderTradeUpdateEvent represents the USD-M or COIN-M futures order change or the trade
+type OrderTradeUpdateEvent struct {
+	futures.OrderTradeUpdateEvent
+	BusinessUnit BusinessUnit `json:"fs"` // BusinessUnit is either UM or CM
+}
+
+// AccountConfigUpdateEvent represents the leverage change of the USD-M or COIN-M futures symbol
+type AccountConfigUpdateEvent struct {
+	futures.AccountConfigUpdateEvent
+	BusinessUnit BusinessUnit `json:"fs"` // BusinessUnit is either UM or CM
+}
+
+// LiabilityChangeEvent represents the borrowed or the interest accrued cross margin liability
+type LiabilityChangeEvent struct {
+	EventType      UpdateType `json:"e"` // EventType represents the update type
+	Time           int64      `json:"E"` // Time represents the event time
+	Asset          string     `json:"a"` // Asset is the liability asset
+	Type           string     `json:"t"` // Type is the liability type, e.g. BORROW
+	TransactionID  int64      `json:"T"` // TransactionID is the liability transaction id
+	Principal      string     `json:"p"` // Principal is the borrowed principal
+	Interest       string     `json:"i"` // Interest is the interest
+	TotalLiability string     `json:"l"` // TotalLiability is the total liability of the asset
+}
+
+// OpenOrderLossEvent represents the losses of the open cross margin orders
+type OpenOrderLossEvent struct {
+	EventType UpdateType `json:"e"` // EventType represents the update type
+	Time      int64      `json:"E"` // Time represents the event time
+	Losses    []struct {
+		Asset  string `json:"a"` // Asset is the loss asset
+		Amount string `json:"o"` // Amount is the loss amount, negative
+	} `json:"O"` // Losses are the open order losses by the asset
+}
+
+// RiskLevelChangeEvent represents the change of the unified account risk level
+type RiskLevelChangeEvent struct {
+	EventType         UpdateType    `json:"e"`  // EventType represents the update type
+	Time              int64         `json:"E"`  // Time represents the event time
+	UniMMR            string        `json:"u"`  // UniMMR is the unified maintenance margin ratio
+	RiskLevel         AccountStatus `json:"s"`  // RiskLevel is the account status, e.g. MARGIN_CALL
+	AccountEquity     string        `json:"eq"` // AccountEquity is the equity in USD
+	ActualEquity      string        `json:"ae"` // ActualEquity is the equity without the collateral rate in USD
+	MaintenanceMargin string        `json:"m"`  // MaintenanceMargin is the total maintenance margin in USD
+}
@@ -0,0 +1,66 @@
+package portfoliomargin
+
+// Balance is the asset balance of the unified account split by the cross margin, USD-M and COIN-M wallets
+type Balance struct {
+	Asset               string `json:"asset"`
+	TotalWalletBalance  string `json:"totalWalletBalance"`
+	CrossMarginAsset    string `json:"crossMarginAsset"`
+	CrossMarginBorrowed string `json:"crossMarginBorrowed"`
+	CrossMarginFree     string `json:"crossMarginFree"`
+	CrossMarginInterest string `json:"crossMarginInterest"`
+	CrossMarginLocked   string `json:"crossMarginLocked"`
+	UMWalletBalance     string `json:"umWalletBalance"`
+	UMUnrealizedPnL     string `json:"umUnrealizedPNL"`
+	CMWalletBalance     string `json:"cmWalletBalance"`
+	CMUnrealizedPnL     string `json:"cmUnrealizedPNL"`
+	NegativeBalance     string `json:"negativeBalance"`
+	UpdateTime          int64  `json:"updateTime"`
+}
+
+// AccountStatus is the risk status of the unified account
+type AccountStatus string
+
+const (
+	AccountStatusNormal            AccountStatus = "NORMAL"
+	AccountStatusMarginCall        AccountStatus = "MARGIN_CALL"
+	AccountStatusSupplyMargin      AccountStatus = "SUPPLY_MARGIN"
+	AccountStatusReduceOnly        AccountStatus = "REDUCE_ONLY"
+	AccountStatusActiveLiquidation AccountStatus = "ACTIVE_LIQUIDATION"
+	AccountStatusForceLiquidation  AccountStatus = "FORCE_LIQUIDATION"
+	AccountStatusBankrupted        AccountStatus = "BANKRUPTED"
+)
+
+// Account is the unified account info, the equity and the margins are in USD
+type Account struct {
+	UniMMR                   string        `json:"uniMMR"` // UniMMR is the unified maintenance margin ratio
+	AccountEquity            string        `json:"accountEquity"`
+	ActualEquity             string        `json:"actualEquity"` // ActualEquity is the equity without the collateral rate
+	AccountInitialMargin     string        `json:"accountInitialMargin"`
+	AccountMaintMargin       string        `json:"accountMaintMargin"`
+	AccountStatus            AccountStatus `json:"accountStatus"`
+	VirtualMaxWithdrawAmount string        `json:"virtualMaxWithdrawAmount"`
+	TotalAvailableBalance    string        `json:"totalAvailableBalance"`
+	TotalMarginOpenLoss      string        `json:"totalMarginOpenLoss"`
+	UpdateTime               int64         `json:"updateTime"`
+}
+
+type AssetCollectionReq struct {
+	Asset string `url:"asset"`
+}
+
+type RepayLoanReq struct {
+	Asset  string `url:"asset"`
+	Amount string `url:"amount"`
+}
+
+type RepayLoan struct {
+	TranID int64 `json:"tranId"`
+}
+
+type AutoRepayFuturesReq struct {
+	AutoRepay bool `url:"autoRepay"` // AutoRepay enables repaying the futures negative balance automatically
+}
+
+type AutoRepayFutures struct {
+	AutoRepay bool `json:"autoRepay"`
+}
@@ -0,0 +1,292 @@
+package recorder
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/segmentio/encoding/json"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/ws"
+)
+
+const (
+	DefaultMaxFileSize    = 256 << 20
+	DefaultFlushInterval  = time.Second
+	DefaultReconnectDelay = time.Second
+)
+
+// Stream is the recorded market data stream
+type Stream string
+
+const (
+	StreamTrades     Stream = "trade"
+	StreamAggTrades  Stream = "aggTrade"
+	StreamDiffDepth  Stream = "depth"
+	StreamBookTicker Stream = "bookTicker"
+	StreamKlines     Stream = "kline"
+)
+
+// Format is the format of the recorded files
+type Format string
+
+const (
+	FormatJSONL Format = "jsonl"
+	FormatCSV   Format = "csv"
+)
+
+var (
+	ErrEmptySymbols = errors.New("symbols are empty")
+	ErrEmptyStreams = errors.New("streams are empty")
+	ErrEmptyDir     = errors.New("directory is empty")
+	ErrStream       = errors.New("unknown stream")
+	ErrFormat       = errors.New("unknown format")
+)
+
+// Config configures the recorder
+type Config struct {
+	Dir            string   // Dir is the root directory, files are written to Dir/SYMBOL/YYYY-MM-DD/
+	Symbols        []string // Symbols are recorded for each of the Streams
+	Streams        []Stream
+	Format         Format                // Format defaults to FormatJSONL
+	Compress       bool                  // Compress writes gzip compressed files
+	MaxFileSize    int64                 // MaxFileSize is the uncompressed size of the file to rotate at, files are also rotated daily
+	KlineInterval  binance.KlineInterval // KlineInterval is the interval of StreamKlines, defaults to 1m
+	DepthFrequency ws.FrequencyType      // DepthFrequency is the update speed of StreamDiffDepth, defaults to 1000ms
+	FlushInterval  time.Duration         // FlushInterval is the interval of flushing the buffered records to files
+	ReconnectDelay time.Duration         // ReconnectDelay is the delay between reconnects of the dropped stream
+	Now            func() time.Time
+}
+
+func (c Config) defaults() Config {
+	if c.Format == "" {
+		c.Format = FormatJSONL
+	}
+	if c.MaxFileSize == 0 {
+		c.MaxFileSize = DefaultMaxFileSize
+	}
+	if c.KlineInterval == "" {
+		c.KlineInterval = binance.KlineInterval1min
+	}
+	if c.DepthFrequency == "" {
+		c.DepthFrequency = ws.Frequency1000ms
+	}
+	if c.FlushInterval == 0 {
+		c.FlushInterval = DefaultFlushInterval
+	}
+	if c.ReconnectDelay == 0 {
+		c.ReconnectDelay = DefaultReconnectDelay
+	}
+	if c.Now == nil {
+		c.Now = time.Now
+	}
+
+	return c
+}
+
+func (c Config) validate() error {
+	switch {
+	case c.Dir == "":
+		return ErrEmptyDir
+	case len(c.Symbols) == 0:
+		return ErrEmptySymbols
+	case len(c.Streams) == 0:
+		return ErrEmptyStreams
+	case c.Format != FormatJSONL && c.Format != FormatCSV:
+		return errors.Wrap(ErrFormat, string(c.Format))
+	}
+	for _, s := range c.Streams {
+		if _, ok := streamColumns[s]; !ok {
+			return errors.Wrap(ErrStream, string(s))
+		}
+	}
+
+	return nil
+}
+
+// Record is the line of the JSON Lines file.
+// Gap records mark the time the stream was dropped, records between the gap and the next record are missed
+type Record struct {
+	Recv   int64           `json:"recv"`            // Recv is the local receive time in ms
+	Event  int64           `json:"event,omitempty"` // Event is the exchange event time in ms, book ticker updates have no event time
+	Symbol string          `json:"symbol"`
+	Stream Stream          `json:"stream"`
+	Gap    bool            `json:"gap,omitempty"`
+	Reason string          `json:"reason,omitempty"` // Reason is the error which dropped the stream
+	Data   json.RawMessage `json:"data,omitempty"`   // Data is the raw event
+}
+
+// Recorder subscribes to the market data streams and writes them to files
+type Recorder struct {
+	client *ws.Client
+	config Config
+
+	mu      sync.Mutex
+	writers []*writer
+}
+
+// New creates a recorder of the streams of the client
+func New(client *ws.Client, config Config) (*Recorder, error) {
+	cfg := config.defaults()
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	return &Recorder{client: client, config: cfg}, nil
+}
+
+// Run records the streams until the context is done, dropped streams are reconnected with gap markers.
+// Files are flushed and closed before returning
+func (r *Recorder) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for _, symbol := range r.config.Symbols {
+		for _, stream := range r.config.Streams {
+			w := newWriter(r.config, strings.ToUpper(symbol), stream)
+			r.mu.Lock()
+			r.writers = append(r.writers, w)
+			r.mu.Unlock()
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				r.record(ctx, w)
+			}()
+		}
+	}
+
+	ticker := time.NewTicker(r.config.FlushInterval)
+	defer ticker.Stop()
+	for done := false; !done; {
+		select {
+		case <-ctx.Done():
+			done = true
+		case <-ticker.C:
+			_ = r.flush()
+		}
+	}
+	wg.Wait()
+
+	var err error
+	r.mu.Lock()
+	for _, w := range r.writers {
+		if closeErr := w.close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	r.writers = nil
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	return ctx.Err()
+}
+
+// Flush writes the buffered records to files
+func (r *Recorder) Flush() error {
+	return r.flush()
+}
+
+func (r *Recorder) flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var err error
+	for _, w := range r.writers {
+		if flushErr := w.flush(); flushErr != nil && err == nil {
+			err = flushErr
+		}
+	}
+
+	return err
+}
+
+// record reads the stream and reconnects it until the context is done
+func (r *Recorder) record(ctx context.Context, w *writer) {
+	connected := true
+	for ctx.Err() == nil {
+		conn, err := r.dial(ctx, w.symbol, w.stream)
+		if err != nil {
+			// the gap is marked once per outage
+			if connected {
+				connected = false
+				w.gap(r.config.Now(), err)
+			}
+			r.wait(ctx)
+			continue
+		}
+		connected = true
+
+		stop := make(chan struct{})
+		go func() {
+			select {
+			case <-ctx.Done():
+				conn.Close()
+			case <-stop:
+			}
+		}()
+		for {
+			payload, err := conn.ReadRaw()
+			if err != nil {
+				if ctx.Err() == nil {
+					connected = false
+					w.gap(r.config.Now(), err)
+				}
+				break
+			}
+			w.write(r.config.Now(), payload)
+		}
+		close(stop)
+		conn.Close()
+		r.wait(ctx)
+	}
+}
+
+func (r *Recorder) wait(ctx context.Context) {
+	t := time.NewTimer(r.config.ReconnectDelay)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+	case <-t.C:
+	}
+}
+
+func (r *Recorder) dial(ctx context.Context, symbol string, stream Stream) (*ws.Conn, error) {
+	switch stream {
+	case StreamTrades:
+		s, err := r.client.Trades(ctx, symbol)
+		if err != nil {
+			return nil, err
+		}
+		return &s.Conn, nil
+	case StreamAggTrades:
+		s, err := r.client.AggTrades(ctx, symbol)
+		if err != nil {
+			return nil, err
+		}
+		return &s.Conn, nil
+	case StreamDiffDepth:
+		s, err := r.client.DiffDepth(ctx, symbol, r.config.DepthFrequency)
+		if err != nil {
+			return nil, err
+		}
+		return &s.Conn, nil
+	case StreamBookTicker:
+		s, err := r.client.IndividualBookTicker(ctx, symbol)
+		if err != nil {
+			return nil, err
+		}
+		return &s.Conn, nil
+	case StreamKlines:
+		s, err := r.client.Klines(ctx, symbol, r.config.KlineInterval)
+		if err != nil {
+			return nil, err
+		}
+		return &s.Conn, nil
+	}
+
+	return nil, errors.Wrap(ErrStream, string(stream))
+}
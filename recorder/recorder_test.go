@@ -0,0 +1,252 @@
+package recorder_test
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/go-faster/errors"
+	gobws "github.com/gobwas/ws"
+	"github.com/gobwas/ws/wsutil"
+	"github.com/segmentio/encoding/json"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/xenking/binance-api/recorder"
+	"github.com/xenking/binance-api/ws"
+)
+
+func TestRecorder(t *testing.T) {
+	suite.Run(t, new(recorderTestSuite))
+}
+
+// streamServer sends the scripted frames to the n-th connection of the stream,
+// the connection is dropped after the frames unless it's the last script
+type streamServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	scripts map[string][][]string
+	conns   map[string]int
+	done    chan struct{}
+}
+
+func newStreamServer(scripts map[string][][]string) *streamServer {
+	s := &streamServer{scripts: scripts, conns: make(map[string]int), done: make(chan struct{})}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+
+	return s
+}
+
+func (s *streamServer) serve(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/ws/")
+	conn, _, _, err := gobws.UpgradeHTTP(r, w)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	s.mu.Lock()
+	scripts := s.scripts[name]
+	n := s.conns[name]
+	s.conns[name]++
+	s.mu.Unlock()
+	if n < len(scripts) {
+		for _, frame := range scripts[n] {
+			if err = wsutil.WriteServerText(conn, []byte(frame)); err != nil {
+				return
+			}
+		}
+		// the client replies with pong to every frame, the frames are received once pongs are read
+		for range scripts[n] {
+			if _, err = gobws.ReadFrame(conn); err != nil {
+				return
+			}
+		}
+		if n < len(scripts)-1 {
+			return
+		}
+	}
+	go func() {
+		_, _ = io.Copy(io.Discard, conn)
+	}()
+	<-s.done
+}
+
+func (s *streamServer) Close() {
+	close(s.done)
+	s.Server.CloseClientConnections()
+	s.Server.Close()
+}
+
+func (s *streamServer) client() *ws.Client {
+	return ws.NewCustomClient("ws"+strings.TrimPrefix(s.URL, "http")+"/ws/", nil)
+}
+
+type recorderTestSuite struct {
+	suite.Suite
+	dir string
+}
+
+func (s *recorderTestSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+}
+
+const (
+	trade1 = `{"e":"trade","E":1000,"s":"BTCUSDT","t":1,"p":"100.0","q":"1.0","T":999,"m":true}`
+	trade2 = `{"e":"trade","E":2000,"s":"BTCUSDT","t":2,"p":"101.0","q":"2.0","T":1999,"m":false}`
+	trade3 = `{"e":"trade","E":5000,"s":"BTCUSDT","t":5,"p":"102.0","q":"3.0","T":4999,"m":false}`
+	depth1 = `{"e":"depthUpdate","E":1500,"s":"BTCUSDT","U":10,"u":12,"b":[["99.0","1.5"]],"a":[["100.0","2"],["101.0","0"]]}`
+)
+
+func (s *recorderTestSuite) run(server *streamServer, config recorder.Config, ready func() bool) {
+	r, err := recorder.New(server.client(), config)
+	s.Require().NoError(err)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- r.Run(ctx)
+	}()
+	s.Eventually(ready, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	s.Require().ErrorIs(<-done, context.Canceled)
+}
+
+func (s *recorderTestSuite) TestJSONL() {
+	server := newStreamServer(map[string][][]string{
+		"btcusdt@trade": {{trade1, trade2}, {trade3}},
+	})
+	defer server.Close()
+
+	date := time.Now().UTC().Format(recorder.DateLayout)
+	path := filepath.Join(s.dir, recorder.FileName("BTCUSDT", recorder.StreamTrades, date, 0, recorder.FormatJSONL, false))
+	s.run(server, recorder.Config{
+		Dir:            s.dir,
+		Symbols:        []string{"btcusdt"},
+		Streams:        []recorder.Stream{recorder.StreamTrades},
+		FlushInterval:  10 * time.Millisecond,
+		ReconnectDelay: 10 * time.Millisecond,
+	}, func() bool {
+		return len(s.readLines(path, false)) == 4
+	})
+
+	lines := s.readLines(path, false)
+	s.Require().Len(lines, 4)
+	var records []*recorder.Record
+	for _, line := range lines {
+		r := &recorder.Record{}
+		s.Require().NoError(json.Unmarshal([]byte(line), r))
+		records = append(records, r)
+	}
+	s.Require().EqualValues(1000, records[0].Event)
+	s.Require().NotZero(records[0].Recv)
+	s.Require().Equal(trade1, string(records[0].Data))
+	s.Require().Equal(trade2, string(records[1].Data))
+	// the dropped stream is marked before the records of the next connection
+	s.Require().True(records[2].Gap)
+	s.Require().NotEmpty(records[2].Reason)
+	s.Require().Equal(trade3, string(records[3].Data))
+	s.Require().Equal("BTCUSDT", records[3].Symbol)
+	s.Require().Equal(recorder.StreamTrades, records[3].Stream)
+}
+
+func (s *recorderTestSuite) TestCSV() {
+	server := newStreamServer(map[string][][]string{
+		"btcusdt@depth@1000ms": {{depth1}},
+		"btcusdt@trade":        {{trade1, trade2}},
+	})
+	defer server.Close()
+
+	date := time.Now().UTC().Format(recorder.DateLayout)
+	depthPath := filepath.Join(s.dir, recorder.FileName("BTCUSDT", recorder.StreamDiffDepth, date, 0, recorder.FormatCSV, true))
+	// every row exceeds the size limit and rotates the file
+	tradePath := filepath.Join(s.dir, recorder.FileName("BTCUSDT", recorder.StreamTrades, date, 1, recorder.FormatCSV, true))
+	s.run(server, recorder.Config{
+		Dir:           s.dir,
+		Symbols:       []string{"BTCUSDT"},
+		Streams:       []recorder.Stream{recorder.StreamDiffDepth, recorder.StreamTrades},
+		Format:        recorder.FormatCSV,
+		Compress:      true,
+		MaxFileSize:   1,
+		FlushInterval: 10 * time.Millisecond,
+	}, func() bool {
+		return len(s.readLines(depthPath, true)) == 4 && len(s.readLines(tradePath, true)) == 2
+	})
+
+	rows := s.readCSV(depthPath)
+	s.Require().Equal([]string{"recv_time", "event_time", "kind", "first_update_id", "final_update_id", "side", "price", "quantity"}, rows[0])
+	s.Require().Equal([]string{"1500", "data", "10", "12", "bid", "99", "1.5"}, rows[1][1:])
+	s.Require().Equal([]string{"ask", "101", "0"}, rows[3][5:])
+
+	rows = s.readCSV(tradePath)
+	s.Require().Len(rows, 2)
+	s.Require().Equal([]string{"2000", "data", "2", "101.0", "2.0", "1999", "0", "0", "false"}, rows[1][1:])
+}
+
+func (s *recorderTestSuite) TestRotateError() {
+	server := newStreamServer(map[string][][]string{
+		"btcusdt@trade": {{trade1}},
+	})
+	defer server.Close()
+
+	// the file in place of the symbol directory fails the lookup of the existing files
+	s.Require().NoError(os.WriteFile(filepath.Join(s.dir, "BTCUSDT"), nil, 0o644))
+	r, err := recorder.New(server.client(), recorder.Config{
+		Dir:           s.dir,
+		Symbols:       []string{"BTCUSDT"},
+		Streams:       []recorder.Stream{recorder.StreamTrades},
+		FlushInterval: 10 * time.Millisecond,
+	})
+	s.Require().NoError(err)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- r.Run(ctx)
+	}()
+	s.Eventually(func() bool {
+		return errors.Is(r.Flush(), syscall.ENOTDIR)
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	s.Require().ErrorIs(<-done, syscall.ENOTDIR)
+}
+
+// readLines reads the lines of the file, the compressed file may be not closed yet
+func (s *recorderTestSuite) readLines(path string, compressed bool) []string {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if compressed {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil
+		}
+		r = gz
+	}
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	return lines
+}
+
+func (s *recorderTestSuite) readCSV(path string) [][]string {
+	rows, err := csv.NewReader(strings.NewReader(strings.Join(s.readLines(path, true), "\n"))).ReadAll()
+	s.Require().NoError(err)
+
+	return rows
+}
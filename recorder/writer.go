@@ -0,0 +1,310 @@
+package recorder
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/segmentio/encoding/json"
+
+	"github.com/xenking/binance-api/ws"
+)
+
+// DateLayout is the layout of the date partition directories
+const DateLayout = "2006-01-02"
+
+// Columns of the CSV files are the common columns followed by the stream columns.
+// The kind column is either "data" or "gap", gap rows hold the reason in the first stream column
+var commonColumns = []string{"recv_time", "event_time", "kind"}
+
+var streamColumns = map[Stream][]string{
+	StreamTrades:     {"trade_id", "price", "quantity", "trade_time", "buyer_order_id", "seller_order_id", "maker"},
+	StreamAggTrades:  {"agg_trade_id", "price", "quantity", "first_trade_id", "last_trade_id", "trade_time", "maker"},
+	StreamDiffDepth:  {"first_update_id", "final_update_id", "side", "price", "quantity"},
+	StreamBookTicker: {"update_id", "bid_price", "bid_qty", "ask_price", "ask_qty"},
+	StreamKlines: {
+		"start_time", "end_time", "interval", "open", "high", "low", "close",
+		"volume", "quote_volume", "trades", "final",
+	},
+}
+
+const (
+	kindData = "data"
+	kindGap  = "gap"
+)
+
+// FileName returns the path of the recorded file relative to the root directory
+func FileName(symbol string, stream Stream, date string, seq int, format Format, compress bool) string {
+	name := fmt.Sprintf("%s-%04d.%s", stream, seq, format)
+	if compress {
+		name += ".gz"
+	}
+
+	return filepath.Join(symbol, date, name)
+}
+
+// writer writes the records of the symbol stream to the rotated files
+type writer struct {
+	config Config
+	symbol string
+	stream Stream
+
+	mu   sync.Mutex
+	file *os.File
+	gz   *gzip.Writer
+	buf  *bufio.Writer
+	csv  *csv.Writer
+	date string
+	seq  int
+	size int64
+	err  error // err is the first write error
+}
+
+func newWriter(config Config, symbol string, stream Stream) *writer {
+	return &writer{config: config, symbol: symbol, stream: stream}
+}
+
+// write records the raw event, undecodable events are recorded as gaps
+func (w *writer) write(now time.Time, payload []byte) {
+	event, rows, err := decode(w.stream, payload, w.config.Format == FormatCSV)
+	if err != nil {
+		w.gap(now, errors.Wrap(err, "decode event"))
+		return
+	}
+	recv := now.UnixMilli()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.config.Format == FormatCSV {
+		// rows of the event are kept in the same file
+		if !w.rotate(now) {
+			return
+		}
+		for _, row := range rows {
+			w.writeRow(append([]string{format(recv), format(event), kindData}, row...))
+		}
+		return
+	}
+	w.writeRecord(now, &Record{Recv: recv, Event: event, Symbol: w.symbol, Stream: w.stream, Data: payload})
+}
+
+// gap records the marker of the dropped stream
+func (w *writer) gap(now time.Time, reason error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	recv := now.UnixMilli()
+	if w.config.Format == FormatCSV {
+		row := make([]string, len(commonColumns)+len(streamColumns[w.stream]))
+		row[0], row[2], row[3] = format(recv), kindGap, reason.Error()
+		if w.rotate(now) {
+			w.writeRow(row)
+		}
+		return
+	}
+	w.writeRecord(now, &Record{Recv: recv, Symbol: w.symbol, Stream: w.stream, Gap: true, Reason: reason.Error()})
+}
+
+func (w *writer) writeRecord(now time.Time, r *Record) {
+	line, err := json.Marshal(r)
+	if err != nil {
+		w.fail(err)
+		return
+	}
+	if !w.rotate(now) {
+		return
+	}
+	line = append(line, '\n')
+	n, err := w.buf.Write(line)
+	w.size += int64(n)
+	w.fail(err)
+}
+
+func (w *writer) writeRow(row []string) {
+	for _, v := range row {
+		w.size += int64(len(v)) + 1
+	}
+	w.fail(w.csv.Write(row))
+}
+
+func (w *writer) fail(err error) {
+	if err != nil && w.err == nil {
+		w.err = err
+	}
+}
+
+// rotate opens the next file on the date change or when the file size exceeds the limit
+func (w *writer) rotate(now time.Time) bool {
+	date := now.UTC().Format(DateLayout)
+	if w.file != nil && w.date == date && w.size < w.config.MaxFileSize {
+		return true
+	}
+	if err := w.closeFile(); err != nil {
+		w.fail(err)
+	}
+	if w.date != date {
+		w.date, w.seq = date, 0
+	}
+
+	// files of the previous runs aren't overwritten
+	var path string
+	for {
+		path = filepath.Join(w.config.Dir, FileName(w.symbol, w.stream, date, w.seq, w.config.Format, w.config.Compress))
+		w.seq++
+		_, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			break
+		}
+		if err != nil {
+			w.fail(err)
+			return false
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		w.fail(err)
+		return false
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		w.fail(err)
+		return false
+	}
+
+	var out io.Writer = f
+	w.file, w.size = f, 0
+	if w.config.Compress {
+		w.gz = gzip.NewWriter(f)
+		out = w.gz
+	}
+	w.buf = bufio.NewWriter(out)
+	if w.config.Format == FormatCSV {
+		w.csv = csv.NewWriter(w.buf)
+		w.fail(w.csv.Write(append(append([]string{}, commonColumns...), streamColumns[w.stream]...)))
+	}
+
+	return true
+}
+
+func (w *writer) flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return w.err
+	}
+	if w.csv != nil {
+		w.csv.Flush()
+		w.fail(w.csv.Error())
+	}
+	w.fail(w.buf.Flush())
+	if w.gz != nil {
+		w.fail(w.gz.Flush())
+	}
+
+	return w.err
+}
+
+func (w *writer) close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.fail(w.closeFile())
+
+	return w.err
+}
+
+func (w *writer) closeFile() error {
+	if w.file == nil {
+		return nil
+	}
+	var err error
+	if w.csv != nil {
+		w.csv.Flush()
+		err = w.csv.Error()
+	}
+	if flushErr := w.buf.Flush(); err == nil {
+		err = flushErr
+	}
+	if w.gz != nil {
+		if closeErr := w.gz.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if closeErr := w.file.Close(); err == nil {
+		err = closeErr
+	}
+	w.file, w.gz, w.buf, w.csv = nil, nil, nil, nil
+
+	return err
+}
+
+// decode returns the event time of the raw event and the CSV rows when requested
+func decode(stream Stream, payload []byte, rows bool) (int64, [][]string, error) {
+	switch stream {
+	case StreamTrades:
+		u := &ws.TradeUpdate{}
+		if err := json.Unmarshal(payload, u); err != nil {
+			return 0, nil, err
+		}
+		return u.Time, [][]string{{
+			format(u.TradeID), u.Price, u.Quantity, format(u.TradeTime),
+			strconv.Itoa(u.BuyerID), strconv.Itoa(u.SellerID), strconv.FormatBool(u.Maker),
+		}}, nil
+	case StreamAggTrades:
+		u := &ws.AggTradeUpdate{}
+		if err := json.Unmarshal(payload, u); err != nil {
+			return 0, nil, err
+		}
+		return u.Time, [][]string{{
+			format(u.TradeID), u.Price, u.Quantity, format(u.FirstBreakDownTradeID),
+			format(u.LastBreakDownTradeID), format(u.TradeTime), strconv.FormatBool(u.Maker),
+		}}, nil
+	case StreamDiffDepth:
+		u := &ws.DepthUpdate{}
+		if err := json.Unmarshal(payload, u); err != nil {
+			return 0, nil, err
+		}
+		if !rows {
+			return u.Time, nil, nil
+		}
+		first, final := format(u.FirstUpdateID), format(u.FinalUpdateID)
+		resp := make([][]string, 0, len(u.Bids)+len(u.Asks))
+		for _, l := range u.Bids {
+			resp = append(resp, []string{first, final, "bid", l.Price.String(), l.Quantity.String()})
+		}
+		for _, l := range u.Asks {
+			resp = append(resp, []string{first, final, "ask", l.Price.String(), l.Quantity.String()})
+		}
+		return u.Time, resp, nil
+	case StreamBookTicker:
+		u := &ws.IndividualBookTickerUpdate{}
+		if err := json.Unmarshal(payload, u); err != nil {
+			return 0, nil, err
+		}
+		return 0, [][]string{{strconv.Itoa(u.UpdateID), u.BidPrice, u.BidQty, u.AskPrice, u.AskQty}}, nil
+	case StreamKlines:
+		u := &ws.KlinesUpdate{}
+		if err := json.Unmarshal(payload, u); err != nil {
+			return 0, nil, err
+		}
+		k := &u.Kline
+		return u.Time, [][]string{{
+			format(k.StartTime), format(k.EndTime), string(k.Interval), k.OpenPrice, k.High, k.Low, k.ClosePrice,
+			k.Volume, k.VolumeQuote, strconv.Itoa(k.Trades), strconv.FormatBool(k.Final),
+		}}, nil
+	}
+
+	return 0, nil, errors.Wrap(ErrStream, string(stream))
+}
+
+func format(v int64) string {
+	return strconv.FormatInt(v, 10)
+}
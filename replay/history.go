@@ -0,0 +1,180 @@
+package replay
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/recorder"
+	"github.com/xenking/binance-api/ws"
+)
+
+// AggTradesWindow is the maximal time window of the aggregated trades request without the trade id
+const AggTradesWindow = time.Hour
+
+// KlinesSource requests the klines history page by page and emits them as final kline updates,
+// the event time of the update is the close time of the kline
+type KlinesSource struct {
+	client   *binance.Client
+	symbol   string
+	interval binance.KlineInterval
+	from     int64
+	to       int64
+	page     []*ws.KlinesUpdate
+	done     bool
+}
+
+// NewKlinesSource creates the source of the symbol klines opened between from and to
+func NewKlinesSource(client *binance.Client, symbol string, interval binance.KlineInterval, from, to time.Time) *KlinesSource {
+	return &KlinesSource{
+		client:   client,
+		symbol:   strings.ToUpper(symbol),
+		interval: interval,
+		from:     from.UnixMilli(),
+		to:       to.UnixMilli(),
+	}
+}
+
+// Next returns the next kline, the next page is requested when the current is over
+func (s *KlinesSource) Next() (*Event, error) {
+	for len(s.page) == 0 {
+		if s.done || s.from > s.to {
+			return nil, io.EOF
+		}
+		klines, err := s.client.Klines(&binance.KlinesReq{
+			Symbol:    s.symbol,
+			Interval:  s.interval,
+			Limit:     binance.MaxKlinesLimit,
+			StartTime: s.from,
+			EndTime:   s.to,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(klines) < binance.MaxKlinesLimit {
+			s.done = true
+		}
+		for _, k := range klines {
+			if k.OpenTime < s.from || k.OpenTime > s.to {
+				continue
+			}
+			s.page = append(s.page, s.update(k))
+			s.from = k.OpenTime + 1
+		}
+		if len(klines) > 0 && len(s.page) == 0 {
+			// the page doesn't move forward
+			s.done = true
+		}
+	}
+	u := s.page[0]
+	s.page = s.page[1:]
+
+	return &Event{Time: u.Time, Symbol: s.symbol, Stream: recorder.StreamKlines, Value: u}, nil
+}
+
+func (s *KlinesSource) update(k *binance.Kline) *ws.KlinesUpdate {
+	u := &ws.KlinesUpdate{EventType: ws.UpdateTypeKline, Time: k.CloseTime, Symbol: s.symbol}
+	u.Kline.StartTime = k.OpenTime
+	u.Kline.EndTime = k.CloseTime
+	u.Kline.Symbol = s.symbol
+	u.Kline.Interval = s.interval
+	u.Kline.OpenPrice = k.OpenPrice.String()
+	u.Kline.ClosePrice = k.ClosePrice.String()
+	u.Kline.High = k.HighPrice.String()
+	u.Kline.Low = k.LowPrice.String()
+	u.Kline.Volume = k.Volume.String()
+	u.Kline.Trades = k.Trades
+	u.Kline.Final = true
+	u.Kline.VolumeQuote = k.QuoteAssetVolume.String()
+	u.Kline.VolumeActiveBuy = k.TakerBuyBaseAssetVolume.String()
+	u.Kline.VolumeQuoteActiveBuy = k.TakerBuyQuoteAssetVolume.String()
+
+	return u
+}
+
+// AggTradesSource requests the aggregated trades history page by page.
+// The first trade is found by the time window, the next pages are requested by the trade id
+type AggTradesSource struct {
+	client *binance.Client
+	symbol string
+	from   int64
+	to     int64
+	nextID int64
+	page   []*ws.AggTradeUpdate
+	done   bool
+}
+
+// NewAggTradesSource creates the source of the symbol aggregated trades between from and to
+func NewAggTradesSource(client *binance.Client, symbol string, from, to time.Time) *AggTradesSource {
+	return &AggTradesSource{
+		client: client,
+		symbol: strings.ToUpper(symbol),
+		from:   from.UnixMilli(),
+		to:     to.UnixMilli(),
+	}
+}
+
+// Next returns the next aggregated trade, the next page is requested when the current is over
+func (s *AggTradesSource) Next() (*Event, error) {
+	for len(s.page) == 0 {
+		if s.done {
+			return nil, io.EOF
+		}
+		if err := s.request(); err != nil {
+			return nil, err
+		}
+	}
+	u := s.page[0]
+	s.page = s.page[1:]
+
+	return &Event{Time: u.Time, Symbol: s.symbol, Stream: recorder.StreamAggTrades, Value: u}, nil
+}
+
+func (s *AggTradesSource) request() error {
+	req := &binance.AggregatedTradeReq{Symbol: s.symbol, Limit: binance.MaxTradesLimit}
+	if s.nextID == 0 {
+		if s.from > s.to {
+			s.done = true
+			return nil
+		}
+		req.StartTime = s.from
+		req.EndTime = s.from + AggTradesWindow.Milliseconds() - 1
+		if req.EndTime > s.to {
+			req.EndTime = s.to
+		}
+		// the empty window is skipped
+		s.from = req.EndTime + 1
+	} else {
+		req.FromID = s.nextID
+	}
+
+	trades, err := s.client.AggregatedTrades(req)
+	if err != nil {
+		return err
+	}
+	if s.nextID != 0 && len(trades) == 0 {
+		s.done = true
+	}
+	for _, t := range trades {
+		if t.Timestamp > s.to {
+			s.done = true
+			break
+		}
+		s.page = append(s.page, &ws.AggTradeUpdate{
+			EventType:             ws.UpdateTypeAggTrades,
+			Time:                  t.Timestamp,
+			Symbol:                s.symbol,
+			TradeID:               t.TradeID,
+			Price:                 t.Price,
+			Quantity:              t.Quantity,
+			FirstBreakDownTradeID: t.FirstTradeID,
+			LastBreakDownTradeID:  t.LastTradeID,
+			TradeTime:             t.Timestamp,
+			Maker:                 t.Maker,
+		})
+		s.nextID = t.TradeID + 1
+	}
+
+	return nil
+}
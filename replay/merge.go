@@ -0,0 +1,83 @@
+package replay
+
+import (
+	"container/heap"
+	"io"
+)
+
+// merged is the source merging the sources by the event time,
+// events with the same time are ordered by the source position
+type merged struct {
+	sources []Source
+	heads   eventHeap
+	started bool
+}
+
+// Merge creates the source of the events of all sources ordered by the event time.
+// Each of the sources must be ordered by time
+func Merge(sources ...Source) Source {
+	return &merged{sources: sources}
+}
+
+func (m *merged) Next() (*Event, error) {
+	if !m.started {
+		m.started = true
+		for i := range m.sources {
+			if err := m.push(i); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(m.heads) == 0 {
+		return nil, io.EOF
+	}
+	head := heap.Pop(&m.heads).(sourceEvent)
+	if err := m.push(head.source); err != nil {
+		return nil, err
+	}
+
+	return head.event, nil
+}
+
+func (m *merged) push(i int) error {
+	e, err := m.sources[i].Next()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	heap.Push(&m.heads, sourceEvent{event: e, source: i})
+
+	return nil
+}
+
+type sourceEvent struct {
+	event  *Event
+	source int
+}
+
+type eventHeap []sourceEvent
+
+func (h eventHeap) Len() int { return len(h) }
+
+func (h eventHeap) Less(i, j int) bool {
+	if h[i].event.Time != h[j].event.Time {
+		return h[i].event.Time < h[j].event.Time
+	}
+
+	return h[i].source < h[j].source
+}
+
+func (h eventHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *eventHeap) Push(x interface{}) { *h = append(*h, x.(sourceEvent)) }
+
+func (h *eventHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+
+	return x
+}
@@ -0,0 +1,183 @@
+package replay
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-faster/errors"
+
+	"github.com/xenking/binance-api/recorder"
+	"github.com/xenking/binance-api/ws"
+)
+
+const (
+	SpeedMax      = 0 // SpeedMax plays the events as fast as they are consumed
+	SpeedRealtime = 1 // SpeedRealtime plays the events with the recorded delays
+)
+
+// ErrRunning is returned when the player is subscribed or run after it was started
+var ErrRunning = errors.New("player is already running")
+
+// Config configures the player
+type Config struct {
+	// Speed is the multiplier of the recorded delays between events, e.g. 10 plays an hour in 6 minutes.
+	// Zero plays the events without delays
+	Speed float64
+	// OnGap is called for the gap markers of the recorded streams
+	OnGap func(e *Event)
+}
+
+// Player plays the source events through the channels of the same types as the ws streams.
+// Channels are unbuffered and closed when Run returns, events without subscribers are skipped
+type Player struct {
+	source Source
+	config Config
+
+	mu      sync.Mutex
+	subs    map[subKey]*subscription
+	running bool
+}
+
+type subKey struct {
+	symbol string
+	stream recorder.Stream
+}
+
+type subscription struct {
+	send  func(ctx context.Context, v interface{}) bool
+	close func()
+}
+
+// NewPlayer creates a player of the source
+func NewPlayer(source Source, config Config) *Player {
+	return &Player{source: source, config: config, subs: make(map[subKey]*subscription)}
+}
+
+// Trades returns the channel of the symbol trades like ws.Trades.Stream
+func (p *Player) Trades(symbol string) (<-chan *ws.TradeUpdate, error) {
+	return subscribe[ws.TradeUpdate](p, symbol, recorder.StreamTrades)
+}
+
+// AggTrades returns the channel of the symbol aggregated trades like ws.AggTrades.Stream
+func (p *Player) AggTrades(symbol string) (<-chan *ws.AggTradeUpdate, error) {
+	return subscribe[ws.AggTradeUpdate](p, symbol, recorder.StreamAggTrades)
+}
+
+// DiffDepth returns the channel of the symbol depth updates like ws.DiffDepth.Stream
+func (p *Player) DiffDepth(symbol string) (<-chan *ws.DepthUpdate, error) {
+	return subscribe[ws.DepthUpdate](p, symbol, recorder.StreamDiffDepth)
+}
+
+// IndividualBookTicker returns the channel of the symbol book ticker like ws.IndividualBookTicker.Stream
+func (p *Player) IndividualBookTicker(symbol string) (<-chan *ws.IndividualBookTickerUpdate, error) {
+	return subscribe[ws.IndividualBookTickerUpdate](p, symbol, recorder.StreamBookTicker)
+}
+
+// Klines returns the channel of the symbol klines like ws.Klines.Stream
+func (p *Player) Klines(symbol string) (<-chan *ws.KlinesUpdate, error) {
+	return subscribe[ws.KlinesUpdate](p, symbol, recorder.StreamKlines)
+}
+
+func subscribe[T any](p *Player, symbol string, stream recorder.Stream) (<-chan *T, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.running {
+		return nil, ErrRunning
+	}
+	key := subKey{symbol: strings.ToUpper(symbol), stream: stream}
+	if _, ok := p.subs[key]; ok {
+		return nil, errors.Errorf("%s %s is already subscribed", key.symbol, stream)
+	}
+	updates := make(chan *T)
+	p.subs[key] = &subscription{
+		send: func(ctx context.Context, v interface{}) bool {
+			u, ok := v.(*T)
+			if !ok {
+				return true
+			}
+			select {
+			case updates <- u:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		},
+		close: func() { close(updates) },
+	}
+
+	return updates, nil
+}
+
+// Run plays the source until its end or the context is done.
+// The channels are closed before returning, nil is returned at the end of the source
+func (p *Player) Run(ctx context.Context) error {
+	p.mu.Lock()
+	if p.running {
+		p.mu.Unlock()
+		return ErrRunning
+	}
+	p.running = true
+	p.mu.Unlock()
+	defer func() {
+		for _, s := range p.subs {
+			s.close()
+		}
+	}()
+
+	var (
+		start time.Time
+		first int64
+		timer *time.Timer
+	)
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+	for n := 0; ; n++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		e, err := p.source.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if p.config.Speed > 0 {
+			if n == 0 {
+				start, first = time.Now(), e.Time
+			}
+			delay := time.Until(start.Add(time.Duration(float64(time.Duration(e.Time-first)*time.Millisecond) / p.config.Speed)))
+			if delay > 0 {
+				if timer == nil {
+					timer = time.NewTimer(delay)
+				} else {
+					timer.Reset(delay)
+				}
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-timer.C:
+				}
+			}
+		}
+
+		if e.Gap {
+			if p.config.OnGap != nil {
+				p.config.OnGap(e)
+			}
+			continue
+		}
+		s, ok := p.subs[subKey{symbol: strings.ToUpper(e.Symbol), stream: e.Stream}]
+		if ok && !s.send(ctx, e.Value) {
+			return ctx.Err()
+		}
+	}
+}
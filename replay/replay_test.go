@@ -0,0 +1,231 @@
+package replay_test
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/binancetest"
+	"github.com/xenking/binance-api/recorder"
+	"github.com/xenking/binance-api/replay"
+	"github.com/xenking/binance-api/ws"
+)
+
+func TestReplay(t *testing.T) {
+	suite.Run(t, new(replayTestSuite))
+}
+
+type replayTestSuite struct {
+	suite.Suite
+	dir  string
+	date time.Time
+}
+
+func (s *replayTestSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+	s.date = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+}
+
+const (
+	tradesJSONL = `{"recv":1001,"event":1000,"symbol":"BTCUSDT","stream":"trade","data":{"e":"trade","E":1000,"s":"BTCUSDT","t":1,"p":"100.0","q":"1.0","T":999,"m":true}}
+{"recv":1600,"symbol":"BTCUSDT","stream":"trade","gap":true,"reason":"EOF"}
+{"recv":3001,"event":3000,"symbol":"BTCUSDT","stream":"trade","data":{"e":"trade","E":3000,"s":"BTCUSDT","t":3,"p":"101.0","q":"2.0","T":2999,"m":false}}
+`
+	depthCSV = `recv_time,event_time,kind,first_update_id,final_update_id,side,price,quantity
+1501,1500,data,10,12,bid,99,1.5
+1501,1500,data,10,12,ask,101,0
+2501,2500,data,13,13,ask,100,2
+`
+)
+
+func (s *replayTestSuite) write(symbol string, stream recorder.Stream, seq int, format recorder.Format, compress bool, data string) string {
+	path := filepath.Join(s.dir, recorder.FileName(symbol, stream, s.date.Format(recorder.DateLayout), seq, format, compress))
+	s.Require().NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+	f, err := os.Create(path)
+	s.Require().NoError(err)
+	defer f.Close()
+
+	var w io.Writer = f
+	if compress {
+		gz := gzip.NewWriter(f)
+		defer gz.Close()
+		w = gz
+	}
+	_, err = io.WriteString(w, data)
+	s.Require().NoError(err)
+
+	return path
+}
+
+func (s *replayTestSuite) source() replay.Source {
+	s.write("BTCUSDT", recorder.StreamTrades, 0, recorder.FormatJSONL, false, tradesJSONL)
+	s.write("ETHUSDT", recorder.StreamDiffDepth, 0, recorder.FormatCSV, true, depthCSV)
+
+	trades, err := replay.Files(s.dir, "btcusdt", recorder.StreamTrades, s.date, s.date)
+	s.Require().NoError(err)
+	s.Require().Len(trades, 1)
+	depth, err := replay.Files(s.dir, "ETHUSDT", recorder.StreamDiffDepth, s.date, s.date.Add(time.Hour))
+	s.Require().NoError(err)
+	s.Require().Len(depth, 1)
+
+	return replay.Merge(replay.NewFileSource(trades...), replay.NewFileSource(depth...))
+}
+
+func (s *replayTestSuite) TestMerge() {
+	source := s.source()
+
+	var times []int64
+	var streams []recorder.Stream
+	for {
+		e, err := source.Next()
+		if err == io.EOF {
+			break
+		}
+		s.Require().NoError(err)
+		times = append(times, e.Time)
+		streams = append(streams, e.Stream)
+	}
+	s.Require().Equal([]int64{1000, 1500, 1600, 2500, 3000}, times)
+	s.Require().Equal([]recorder.Stream{
+		recorder.StreamTrades, recorder.StreamDiffDepth, recorder.StreamTrades, recorder.StreamDiffDepth, recorder.StreamTrades,
+	}, streams)
+}
+
+func (s *replayTestSuite) TestPlayer() {
+	var gaps []*replay.Event
+	player := replay.NewPlayer(s.source(), replay.Config{
+		Speed: 100,
+		OnGap: func(e *replay.Event) {
+			gaps = append(gaps, e)
+		},
+	})
+	trades, err := player.Trades("btcusdt")
+	s.Require().NoError(err)
+	depth, err := player.DiffDepth("ETHUSDT")
+	s.Require().NoError(err)
+	_, err = player.Trades("BTCUSDT")
+	s.Require().Error(err)
+
+	done := make(chan error, 1)
+	start := time.Now()
+	go func() {
+		done <- player.Run(context.Background())
+	}()
+
+	var order []string
+	var depthUpdates []*ws.DepthUpdate
+	for trades != nil || depth != nil {
+		select {
+		case u, ok := <-trades:
+			if !ok {
+				trades = nil
+				continue
+			}
+			order = append(order, u.Price)
+		case u, ok := <-depth:
+			if !ok {
+				depth = nil
+				continue
+			}
+			order = append(order, u.Symbol)
+			depthUpdates = append(depthUpdates, u)
+		}
+	}
+	s.Require().NoError(<-done)
+	// 2 seconds of the recording are played in 20ms
+	s.Require().GreaterOrEqual(time.Since(start), 20*time.Millisecond)
+
+	s.Require().Equal([]string{"100.0", "ETHUSDT", "ETHUSDT", "101.0"}, order)
+	s.Require().Len(depthUpdates[0].Bids, 1)
+	s.Require().Len(depthUpdates[0].Asks, 1)
+	s.Require().Equal("99", depthUpdates[0].Bids[0].Price.String())
+	s.Require().EqualValues(12, depthUpdates[0].FinalUpdateID)
+	s.Require().Len(gaps, 1)
+	s.Require().Equal("EOF", gaps[0].Reason)
+
+	_, err = player.Klines("BTCUSDT")
+	s.Require().ErrorIs(err, replay.ErrRunning)
+}
+
+func (s *replayTestSuite) TestPlayerCancel() {
+	player := replay.NewPlayer(s.source(), replay.Config{Speed: replay.SpeedRealtime})
+	trades, err := player.Trades("BTCUSDT")
+	s.Require().NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- player.Run(ctx)
+	}()
+	u := <-trades
+	s.Require().EqualValues(1, u.TradeID)
+	cancel()
+	s.Require().ErrorIs(<-done, context.Canceled)
+	_, ok := <-trades
+	s.Require().False(ok)
+}
+
+func (s *replayTestSuite) TestHistory() {
+	var aggRequests []binance.AggregatedTradeReq
+	client := binance.NewCustomClient(&binancetest.MockClient{Response: func(method, endpoint string, data interface{}, sign, stream bool) ([]byte, error) {
+		switch endpoint {
+		case binance.EndpointKlines:
+			return []byte(`[[60000,"1.0","2.0","0.5","1.5","10",119999,"15",3,"5","7","0"],` +
+				`[120000,"1.5","1.6","1.4","1.6","1",179999,"1.5",1,"0","0","0"]]`), nil
+		case binance.EndpointAggTrades:
+			req := data.(*binance.AggregatedTradeReq)
+			aggRequests = append(aggRequests, *req)
+			if req.FromID == 0 {
+				return []byte(`[{"a":1,"p":"1.0","q":"1","f":1,"l":1,"T":61000,"m":true},` +
+					`{"a":2,"p":"1.1","q":"2","f":2,"l":3,"T":62000,"m":false}]`), nil
+			}
+			return []byte(`[{"a":3,"p":"1.2","q":"1","f":4,"l":4,"T":150000,"m":true},` +
+				`{"a":4,"p":"1.3","q":"1","f":5,"l":5,"T":190000,"m":true}]`), nil
+		}
+		s.FailNow("unexpected request", endpoint)
+		return nil, nil
+	}})
+	from, to := time.UnixMilli(60000), time.UnixMilli(179999)
+
+	klines := replay.NewKlinesSource(client, "btcusdt", binance.KlineInterval1min, from, to)
+	aggTrades := replay.NewAggTradesSource(client, "btcusdt", from, to)
+	source := replay.Merge(klines, aggTrades)
+
+	var events []*replay.Event
+	for {
+		e, err := source.Next()
+		if err == io.EOF {
+			break
+		}
+		s.Require().NoError(err)
+		events = append(events, e)
+	}
+	s.Require().Len(events, 5)
+	var ids []string
+	for _, e := range events {
+		switch u := e.Value.(type) {
+		case *ws.KlinesUpdate:
+			s.Require().True(u.Kline.Final)
+			s.Require().Equal(binance.KlineInterval1min, u.Kline.Interval)
+			ids = append(ids, "k"+strings.TrimSuffix(u.Kline.ClosePrice, ".0"))
+		case *ws.AggTradeUpdate:
+			s.Require().Equal("BTCUSDT", u.Symbol)
+			ids = append(ids, "a"+u.Price)
+		}
+	}
+	s.Require().Equal([]string{"a1.0", "a1.1", "k1.5", "a1.2", "k1.6"}, ids)
+
+	s.Require().Len(aggRequests, 2)
+	s.Require().EqualValues(60000, aggRequests[0].StartTime)
+	s.Require().EqualValues(179999, aggRequests[0].EndTime)
+	s.Require().EqualValues(3, aggRequests[1].FromID)
+	s.Require().Zero(aggRequests[1].StartTime)
+}
@@ -0,0 +1,420 @@
+package replay
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/csv"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/segmentio/encoding/json"
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/recorder"
+	"github.com/xenking/binance-api/ws"
+)
+
+// MaxLineSize is the maximal size of the recorded JSON line
+const MaxLineSize = 16 << 20
+
+// ErrFileName is returned for the files which don't follow the recorder layout
+var ErrFileName = errors.New("file name doesn't match the recorder layout")
+
+// Event is the market data event.
+// Value is one of *ws.TradeUpdate, *ws.AggTradeUpdate, *ws.DepthUpdate, *ws.IndividualBookTickerUpdate
+// and *ws.KlinesUpdate, gap events have no value
+type Event struct {
+	Time   int64 // Time is the exchange event time in ms or the receive time for events without it
+	Recv   int64 // Recv is the local receive time in ms, zero for the history requested by REST
+	Symbol string
+	Stream recorder.Stream
+	Gap    bool
+	Reason string
+	Value  interface{}
+}
+
+// Source reads the events ordered by time, io.EOF is returned after the last event
+type Source interface {
+	Next() (*Event, error)
+}
+
+// Files returns the recorded files of the symbol stream between the dates sorted in the recording order
+func Files(dir, symbol string, stream recorder.Stream, from, to time.Time) ([]string, error) {
+	symbol = strings.ToUpper(symbol)
+	var files []string
+	for date := from.UTC().Truncate(24 * time.Hour); !date.After(to.UTC()); date = date.Add(24 * time.Hour) {
+		matches, err := filepath.Glob(filepath.Join(dir, symbol, date.Format(recorder.DateLayout), string(stream)+"-*"))
+		if err != nil {
+			return nil, err
+		}
+		// sequence numbers are zero padded
+		sort.Strings(matches)
+		files = append(files, matches...)
+	}
+
+	return files, nil
+}
+
+// FileSource reads the files written by the recorder one after another,
+// the format and compression are detected by the file extension
+type FileSource struct {
+	paths []string
+	file  *os.File
+	next  func() (*Event, error)
+}
+
+// NewFileSource creates the source of the recorded files of the same symbol and stream
+func NewFileSource(paths ...string) *FileSource {
+	return &FileSource{paths: paths}
+}
+
+// Next returns the next recorded event
+func (s *FileSource) Next() (*Event, error) {
+	for {
+		if s.next == nil {
+			if len(s.paths) == 0 {
+				return nil, io.EOF
+			}
+			if err := s.open(s.paths[0]); err != nil {
+				return nil, err
+			}
+			s.paths = s.paths[1:]
+		}
+		e, err := s.next()
+		if err == io.EOF {
+			if err = s.Close(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		return e, err
+	}
+}
+
+// Close closes the current file
+func (s *FileSource) Close() error {
+	s.next = nil
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+
+	return err
+}
+
+func (s *FileSource) open(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	s.file = f
+
+	var r io.Reader = bufio.NewReader(f)
+	name := filepath.Base(path)
+	if strings.HasSuffix(name, ".gz") {
+		if r, err = gzip.NewReader(r); err != nil {
+			return errors.Wrap(err, path)
+		}
+		name = strings.TrimSuffix(name, ".gz")
+	}
+
+	switch filepath.Ext(name) {
+	case "." + string(recorder.FormatJSONL):
+		s.next = jsonlReader(r)
+	case "." + string(recorder.FormatCSV):
+		// CSV rows don't hold the symbol and the stream, they are taken from the path
+		dir := filepath.Dir(filepath.Dir(path))
+		idx := strings.LastIndexByte(name, '-')
+		if idx < 0 {
+			return errors.Wrap(ErrFileName, path)
+		}
+		s.next, err = csvReader(r, filepath.Base(dir), recorder.Stream(name[:idx]))
+		if err != nil {
+			return errors.Wrap(err, path)
+		}
+	default:
+		return errors.Wrap(ErrFileName, path)
+	}
+
+	return nil
+}
+
+func jsonlReader(r io.Reader) func() (*Event, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), MaxLineSize)
+
+	return func() (*Event, error) {
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			if len(line) == 0 {
+				continue
+			}
+			rec := &recorder.Record{}
+			if err := json.Unmarshal(line, rec); err != nil {
+				return nil, errors.Wrap(err, "decode record")
+			}
+			e := &Event{
+				Time:   rec.Event,
+				Recv:   rec.Recv,
+				Symbol: rec.Symbol,
+				Stream: rec.Stream,
+				Gap:    rec.Gap,
+				Reason: rec.Reason,
+			}
+			if e.Time == 0 {
+				e.Time = e.Recv
+			}
+			if !e.Gap {
+				v, err := decode(rec.Stream, rec.Data)
+				if err != nil {
+					return nil, err
+				}
+				e.Value = v
+			}
+			return e, nil
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+
+		return nil, io.EOF
+	}
+}
+
+func decode(stream recorder.Stream, data []byte) (interface{}, error) {
+	var v interface{}
+	switch stream {
+	case recorder.StreamTrades:
+		v = &ws.TradeUpdate{}
+	case recorder.StreamAggTrades:
+		v = &ws.AggTradeUpdate{}
+	case recorder.StreamDiffDepth:
+		v = &ws.DepthUpdate{}
+	case recorder.StreamBookTicker:
+		v = &ws.IndividualBookTickerUpdate{}
+	case recorder.StreamKlines:
+		v = &ws.KlinesUpdate{}
+	default:
+		return nil, errors.Wrap(recorder.ErrStream, string(stream))
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, errors.Wrapf(err, "decode %s event", stream)
+	}
+
+	return v, nil
+}
+
+// csvRow is the CSV record with the common columns parsed
+type csvRow struct {
+	recv   int64
+	event  int64
+	gap    bool
+	fields []string
+}
+
+func csvReader(r io.Reader, symbol string, stream recorder.Stream) (func() (*Event, error), error) {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = false
+	if _, err := cr.Read(); err != nil {
+		if err == io.EOF {
+			return func() (*Event, error) { return nil, io.EOF }, nil
+		}
+		return nil, err
+	}
+
+	var pending *csvRow
+	read := func() (*csvRow, error) {
+		if pending != nil {
+			row := pending
+			pending = nil
+			return row, nil
+		}
+		fields, err := cr.Read()
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) < 4 {
+			return nil, errors.Wrap(csv.ErrFieldCount, "csv row")
+		}
+		row := &csvRow{gap: fields[2] == "gap", fields: fields[3:]}
+		if row.recv, err = strconv.ParseInt(fields[0], 10, 64); err != nil {
+			return nil, err
+		}
+		if fields[1] != "" {
+			if row.event, err = strconv.ParseInt(fields[1], 10, 64); err != nil {
+				return nil, err
+			}
+		}
+		return row, nil
+	}
+
+	return func() (*Event, error) {
+		row, err := read()
+		if err != nil {
+			return nil, err
+		}
+		e := &Event{Time: row.event, Recv: row.recv, Symbol: symbol, Stream: stream, Gap: row.gap}
+		if e.Time == 0 {
+			e.Time = e.Recv
+		}
+		if row.gap {
+			e.Reason = row.fields[0]
+			return e, nil
+		}
+		if stream != recorder.StreamDiffDepth {
+			e.Value, err = parseRow(stream, symbol, row)
+			return e, err
+		}
+
+		// levels of the depth update are written as consecutive rows
+		u, err := depthRow(symbol, row, nil)
+		if err != nil {
+			return nil, err
+		}
+		for {
+			next, err := read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			if next.gap || next.recv != row.recv || next.fields[0] != row.fields[0] || next.fields[1] != row.fields[1] {
+				pending = next
+				break
+			}
+			if _, err = depthRow(symbol, next, u); err != nil {
+				return nil, err
+			}
+		}
+		e.Value = u
+		return e, nil
+	}, nil
+}
+
+func depthRow(symbol string, row *csvRow, u *ws.DepthUpdate) (*ws.DepthUpdate, error) {
+	f := row.fields
+	if len(f) < 5 {
+		return nil, errors.Wrap(csv.ErrFieldCount, "depth row")
+	}
+	if u == nil {
+		u = &ws.DepthUpdate{EventType: ws.UpdateTypeDepth, Time: row.event, Symbol: symbol}
+		var err error
+		if u.FirstUpdateID, err = strconv.ParseInt(f[0], 10, 64); err != nil {
+			return nil, err
+		}
+		if u.FinalUpdateID, err = strconv.ParseInt(f[1], 10, 64); err != nil {
+			return nil, err
+		}
+	}
+	if f[2] == "" {
+		return u, nil
+	}
+	price, err := decimal.NewFromString(f[3])
+	if err != nil {
+		return nil, err
+	}
+	qty, err := decimal.NewFromString(f[4])
+	if err != nil {
+		return nil, err
+	}
+	l := binance.DepthElem{Price: price, Quantity: qty}
+	if f[2] == "bid" {
+		u.Bids = append(u.Bids, l)
+	} else {
+		u.Asks = append(u.Asks, l)
+	}
+
+	return u, nil
+}
+
+// fieldParser parses the columns of the row keeping the first error
+type fieldParser struct {
+	fields []string
+	err    error
+}
+
+func (p *fieldParser) int64(i int) int64 {
+	if p.err != nil {
+		return 0
+	}
+	var v int64
+	v, p.err = strconv.ParseInt(p.fields[i], 10, 64)
+
+	return v
+}
+
+func (p *fieldParser) int(i int) int {
+	return int(p.int64(i))
+}
+
+func (p *fieldParser) bool(i int) bool {
+	if p.err != nil {
+		return false
+	}
+	var v bool
+	v, p.err = strconv.ParseBool(p.fields[i])
+
+	return v
+}
+
+var rowColumns = map[recorder.Stream]int{
+	recorder.StreamTrades:     7,
+	recorder.StreamAggTrades:  7,
+	recorder.StreamBookTicker: 5,
+	recorder.StreamKlines:     11,
+}
+
+func parseRow(stream recorder.Stream, symbol string, row *csvRow) (interface{}, error) {
+	n, ok := rowColumns[stream]
+	if !ok {
+		return nil, errors.Wrap(recorder.ErrStream, string(stream))
+	}
+	if len(row.fields) < n {
+		return nil, errors.Wrapf(csv.ErrFieldCount, "%s row", stream)
+	}
+	f := row.fields
+	p := &fieldParser{fields: f}
+
+	var v interface{}
+	switch stream {
+	case recorder.StreamTrades:
+		v = &ws.TradeUpdate{
+			EventType: ws.UpdateTypeTrades, Symbol: symbol, Time: row.event,
+			TradeID: p.int64(0), Price: f[1], Quantity: f[2], TradeTime: p.int64(3),
+			BuyerID: p.int(4), SellerID: p.int(5), Maker: p.bool(6),
+		}
+	case recorder.StreamAggTrades:
+		v = &ws.AggTradeUpdate{
+			EventType: ws.UpdateTypeAggTrades, Symbol: symbol, Time: row.event,
+			TradeID: p.int64(0), Price: f[1], Quantity: f[2], FirstBreakDownTradeID: p.int64(3),
+			LastBreakDownTradeID: p.int64(4), TradeTime: p.int64(5), Maker: p.bool(6),
+		}
+	case recorder.StreamBookTicker:
+		v = &ws.IndividualBookTickerUpdate{
+			UpdateID: p.int(0), Symbol: symbol, BidPrice: f[1], BidQty: f[2], AskPrice: f[3], AskQty: f[4],
+		}
+	case recorder.StreamKlines:
+		u := &ws.KlinesUpdate{EventType: ws.UpdateTypeKline, Time: row.event, Symbol: symbol}
+		k := &u.Kline
+		k.Symbol = symbol
+		k.StartTime, k.EndTime, k.Interval = p.int64(0), p.int64(1), binance.KlineInterval(f[2])
+		k.OpenPrice, k.High, k.Low, k.ClosePrice = f[3], f[4], f[5], f[6]
+		k.Volume, k.VolumeQuote, k.Trades, k.Final = f[7], f[8], p.int(9), p.bool(10)
+		v = u
+	}
+	if p.err != nil {
+		return nil, errors.Wrapf(p.err, "parse %s row", stream)
+	}
+
+	return v, nil
+}
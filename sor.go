@@ -0,0 +1,82 @@
+package binance
+
+import (
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+)
+
+// NewSOROrder places an order using smart order routing
+func (c *Client) NewSOROrder(req *SOROrderReq) (*SOROrder, error) {
+	if err := validateSOROrderReq(req); err != nil {
+		return nil, err
+	}
+	if req.OrderRespType == "" {
+		req.OrderRespType = OrderRespTypeFull
+	}
+	res, err := c.Do(fasthttp.MethodPost, EndpointSOROrder, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &SOROrder{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// TestSOROrder tests new order creation using smart order routing. Validates the order but does not send it into the matching engine
+func (c *Client) TestSOROrder(req *SOROrderReq) error {
+	if err := validateSOROrderReq(req); err != nil {
+		return err
+	}
+	_, err := c.Do(fasthttp.MethodPost, EndpointSOROrderTest, req, true, false)
+
+	return err
+}
+
+// MyAllocations get allocations resulting from SOR order placement
+func (c *Client) MyAllocations(req *MyAllocationsReq) ([]*Allocation, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.Symbol == "" {
+		return nil, ErrEmptySymbol
+	}
+	if req.Limit < 0 || req.Limit > MaxAllocationsLimit {
+		req.Limit = DefaultAllocationsLimit
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointMyAllocations, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*Allocation
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+func validateSOROrderReq(req *SOROrderReq) error {
+	if req == nil {
+		return ErrNilRequest
+	}
+	if req.Type != OrderTypeLimit && req.Type != OrderTypeMarket {
+		return ErrInvalidOrderType
+	}
+	if req.Quantity == "" {
+		return ErrEmptyQuantity
+	}
+	orderReq := &OrderReq{
+		Symbol:       req.Symbol,
+		Side:         req.Side,
+		Type:         req.Type,
+		TimeInForce:  req.TimeInForce,
+		Quantity:     req.Quantity,
+		Price:        req.Price,
+		StrategyType: req.StrategyType,
+	}
+	if err := orderReq.Validate(); err != nil {
+		return err
+	}
+	req.TimeInForce = orderReq.TimeInForce
+
+	return nil
+}
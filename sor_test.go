@@ -0,0 +1,126 @@
+package binance_test
+
+import (
+	"math/rand"
+
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+)
+
+type mockedSORTestSuite struct {
+	mockedTestSuite
+}
+
+func (s *mockedSORTestSuite) TestNewSOROrder() {
+	var expected *binance.SOROrder
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(fasthttp.MethodPost, method)
+		s.Require().Equal(binance.EndpointSOROrder, endpoint)
+		s.Require().IsType(&binance.SOROrderReq{}, data)
+		req := data.(*binance.SOROrderReq)
+		s.Require().EqualValues(binance.OrderRespTypeFull, req.OrderRespType)
+		s.Require().Equal(binance.TimeInForceGTC, req.TimeInForce)
+		expected = &binance.SOROrder{
+			OrderRespFull: binance.OrderRespFull{
+				Symbol:       req.Symbol,
+				OrderID:      int64(rand.Uint32()),
+				TransactTime: int64(rand.Uint32()),
+				Price:        req.Price,
+				OrigQty:      req.Quantity,
+				ExecutedQty:  req.Quantity,
+				Status:       binance.OrderStatusFilled,
+				Type:         req.Type,
+				Side:         req.Side,
+				Fills: []binance.OrderRespFullFill{{
+					Price:           req.Price,
+					Qty:             req.Quantity,
+					Commission:      "0",
+					CommissionAsset: "BTC",
+					MatchType:       "ONE_PARTY_TRADE_REPORT",
+					AllocID:         int64(rand.Uint32()),
+				}},
+			},
+			WorkingFloor: "SOR",
+			UsedSor:      true,
+		}
+		return json.Marshal(expected)
+	}
+
+	actual, e := s.client.NewSOROrder(&binance.SOROrderReq{
+		Symbol:   "BTCUSDT",
+		Side:     binance.OrderSideBuy,
+		Type:     binance.OrderTypeLimit,
+		Quantity: "0.5",
+		Price:    "31000",
+	})
+	s.Require().NoError(e)
+	s.Require().EqualValues(expected, actual)
+}
+
+func (s *mockedSORTestSuite) TestTestSOROrder() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(binance.EndpointSOROrderTest, endpoint)
+		return []byte("{}"), nil
+	}
+
+	e := s.client.TestSOROrder(&binance.SOROrderReq{
+		Symbol:   "BTCUSDT",
+		Side:     binance.OrderSideBuy,
+		Type:     binance.OrderTypeMarket,
+		Quantity: "0.5",
+	})
+	s.Require().NoError(e)
+
+	e = s.client.TestSOROrder(&binance.SOROrderReq{
+		Symbol:   "BTCUSDT",
+		Side:     binance.OrderSideBuy,
+		Type:     binance.OrderTypeStopLoss,
+		Quantity: "0.5",
+	})
+	s.Require().ErrorIs(e, binance.ErrInvalidOrderType)
+}
+
+func (s *mockedSORTestSuite) TestMyAllocations() {
+	var expected []*binance.Allocation
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(fasthttp.MethodGet, method)
+		s.Require().Equal(binance.EndpointMyAllocations, endpoint)
+		s.Require().IsType(&binance.MyAllocationsReq{}, data)
+		req := data.(*binance.MyAllocationsReq)
+		s.Require().Equal(binance.DefaultAllocationsLimit, req.Limit)
+		expected = []*binance.Allocation{{
+			Symbol:          req.Symbol,
+			AllocationID:    int64(rand.Uint32()),
+			AllocationType:  binance.AllocationTypeSOR,
+			OrderID:         int64(rand.Uint32()),
+			OrderListID:     -1,
+			Price:           "1.00000000",
+			Qty:             "5.00000000",
+			QuoteQty:        "5.00000000",
+			Commission:      "0.00000000",
+			CommissionAsset: "BTC",
+			Time:            int64(rand.Uint32()),
+			IsBuyer:         true,
+			IsAllocator:     false,
+		}}
+		return json.Marshal(expected)
+	}
+
+	actual, e := s.client.MyAllocations(&binance.MyAllocationsReq{Symbol: "BTCUSDT", Limit: 5000})
+	s.Require().NoError(e)
+	s.Require().EqualValues(expected, actual)
+}
+
+func (s *mockedSORTestSuite) TestExchangeInfoSORs() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		return []byte(`{"timezone":"UTC","serverTime":1,"symbols":[],"sors":[{"baseAsset":"BTC","symbols":["BTCUSDT","BTCUSDC"]}]}`), nil
+	}
+
+	info, e := s.client.ExchangeInfo(nil)
+	s.Require().NoError(e)
+	s.Require().Len(info.SORs, 1)
+	s.Require().Equal("BTC", info.SORs[0].BaseAsset)
+	s.Require().Equal([]string{"BTCUSDT", "BTCUSDC"}, info.SORs[0].Symbols)
+}
@@ -0,0 +1,193 @@
+package binance
+
+import (
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+)
+
+// NewSubAccountClients creates the clients of the sub-accounts keyed by their emails.
+// The clients share the config and the HTTP client, so the process uses one connection pool for all the sub-accounts,
+// while every client signs the requests with its own key
+func NewSubAccountClients(keys map[string]SubAccountKey, config RestClientConfig) map[string]*Client {
+	config = config.defaults()
+	clients := make(map[string]*Client, len(keys))
+	for email, key := range keys {
+		config.APIKey, config.APISecret = key.APIKey, key.APISecret
+		clients[email] = NewCustomClient(NewCustomRestClient(config))
+	}
+
+	return clients
+}
+
+// SubAccounts get the page of the sub-accounts of the master account
+func (c *Client) SubAccounts(req *SubAccountsReq) ([]*SubAccount, error) {
+	if req == nil {
+		req = &SubAccountsReq{}
+	}
+	if req.Limit < 0 || req.Limit > MaxSubAccountsLimit {
+		req.Limit = DefaultSubAccountsLimit
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointSubAccounts, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := struct {
+		SubAccounts []*SubAccount `json:"subAccounts"`
+	}{}
+	err = json.Unmarshal(res, &resp)
+
+	return resp.SubAccounts, err
+}
+
+// CreateSubAccount creates the virtual sub-account
+func (c *Client) CreateSubAccount(req *CreateSubAccountReq) (*CreatedSubAccount, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.SubAccountString == "" {
+		return nil, ErrEmptyEmail
+	}
+	res, err := c.Do(fasthttp.MethodPost, EndpointSubAccountCreate, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &CreatedSubAccount{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// SubAccountAssets get the spot balances of the sub-account
+func (c *Client) SubAccountAssets(req *SubAccountAssetsReq) ([]*SubAccountBalance, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.Email == "" {
+		return nil, ErrEmptyEmail
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointSubAccountAssets, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := struct {
+		Balances []*SubAccountBalance `json:"balances"`
+	}{}
+	err = json.Unmarshal(res, &resp)
+
+	return resp.Balances, err
+}
+
+// SubAccountSpotSummary get the BTC valuation of the spot wallets of the sub-accounts
+func (c *Client) SubAccountSpotSummary(req *SubAccountSpotSummaryReq) (*SubAccountSpotSummary, error) {
+	if req == nil {
+		req = &SubAccountSpotSummaryReq{}
+	}
+	if req.Size < 0 || req.Size > MaxSubAccountSummarySize {
+		req.Size = DefaultSubAccountSummarySize
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointSubAccountSpotSummary, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &SubAccountSpotSummary{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// SubAccountTransfer transfers the asset between the wallets of the master account and the sub-accounts
+func (c *Client) SubAccountTransfer(req *SubAccountTransferReq) (*SubAccountTransfer, error) {
+	switch {
+	case req == nil:
+		return nil, ErrNilRequest
+	case req.FromAccountType == "", req.ToAccountType == "":
+		return nil, ErrEmptyTransferType
+	case req.Asset == "":
+		return nil, ErrEmptyAsset
+	case req.Amount == "":
+		return nil, ErrEmptyAmount
+	}
+	res, err := c.Do(fasthttp.MethodPost, EndpointSubAccountTransfer, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &SubAccountTransfer{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// SubAccountTransferHistory get the page of the universal transfers of the master account and the sub-accounts
+func (c *Client) SubAccountTransferHistory(req *SubAccountTransferHistoryReq) (*SubAccountTransferHistory, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointSubAccountTransfer, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &SubAccountTransferHistory{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// SubAccountIPRestriction get the trusted ips of the api key of the sub-account
+func (c *Client) SubAccountIPRestriction(req *SubAccountAPIKeyReq) (*SubAccountIPRestriction, error) {
+	switch {
+	case req == nil:
+		return nil, ErrNilRequest
+	case req.Email == "":
+		return nil, ErrEmptyEmail
+	case req.SubAccountAPIKey == "":
+		return nil, ErrEmptyAPIKey
+	}
+
+	return c.subAccountIPRestriction(fasthttp.MethodGet, EndpointSubAccountIPRestriction, req)
+}
+
+// SetSubAccountIPRestriction restricts the api key of the sub-account to the trusted ips or lifts the restriction
+func (c *Client) SetSubAccountIPRestriction(req *SubAccountIPRestrictionReq) (*SubAccountIPRestriction, error) {
+	if err := validateSubAccountIPRestrictionReq(req); err != nil {
+		return nil, err
+	}
+	if req.Status == "" {
+		req.Status = IPRestrictionStatusRestricted
+	}
+
+	return c.subAccountIPRestriction(fasthttp.MethodPost, EndpointSubAccountSetIPRestriction, req)
+}
+
+// DeleteSubAccountIPList removes the ips from the trusted ips of the api key of the sub-account
+func (c *Client) DeleteSubAccountIPList(req *SubAccountIPRestrictionReq) (*SubAccountIPRestriction, error) {
+	if err := validateSubAccountIPRestrictionReq(req); err != nil {
+		return nil, err
+	}
+	if req.IPAddress == "" {
+		return nil, ErrEmptyAddress
+	}
+	req.Status = ""
+
+	return c.subAccountIPRestriction(fasthttp.MethodDelete, EndpointSubAccountIPList, req)
+}
+
+func (c *Client) subAccountIPRestriction(method, endpoint string, req interface{}) (*SubAccountIPRestriction, error) {
+	res, err := c.Do(method, endpoint, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &SubAccountIPRestriction{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+func validateSubAccountIPRestrictionReq(req *SubAccountIPRestrictionReq) error {
+	switch {
+	case req == nil:
+		return ErrNilRequest
+	case req.Email == "":
+		return ErrEmptyEmail
+	case req.SubAccountAPIKey == "":
+		return ErrEmptyAPIKey
+	}
+
+	return nil
+}
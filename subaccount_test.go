@@ -0,0 +1,97 @@
+package binance_test
+
+import (
+	"github.com/google/go-querystring/query"
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+)
+
+type mockedSubAccountTestSuite struct {
+	mockedTestSuite
+}
+
+func (s *mockedSubAccountTestSuite) TestNewSubAccountClients() {
+	clients := binance.NewSubAccountClients(map[string]binance.SubAccountKey{
+		"alpha@example.com": {APIKey: "alpha", APISecret: "alpha-secret"},
+		"beta@example.com":  {APIKey: "beta", APISecret: "beta-secret"},
+	}, binance.RestClientConfig{})
+	s.Require().Len(clients, 2)
+	s.Require().NotNil(clients["alpha@example.com"])
+	s.Require().NotSame(clients["alpha@example.com"].RestClient, clients["beta@example.com"].RestClient)
+}
+
+func (s *mockedSubAccountTestSuite) TestSubAccountAssets() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(fasthttp.MethodGet, method)
+		s.Require().Equal(binance.EndpointSubAccountAssets, endpoint)
+		s.Require().Equal("alpha@example.com", data.(*binance.SubAccountAssetsReq).Email)
+		return []byte(`{"balances":[{"freeze":0,"withdrawing":0,"asset":"ADA","free":10000,"locked":0},{"freeze":0,"withdrawing":0,"asset":"BTC","free":1.5,"locked":0.25}]}`), nil
+	}
+	balances, err := s.client.SubAccountAssets(&binance.SubAccountAssetsReq{Email: "alpha@example.com"})
+	s.Require().NoError(err)
+	s.Require().Len(balances, 2)
+	s.Require().Equal("1.5", balances[1].Free.String())
+	s.Require().Equal("0.25", balances[1].Locked.String())
+
+	_, err = s.client.SubAccountAssets(&binance.SubAccountAssetsReq{})
+	s.Require().ErrorIs(err, binance.ErrEmptyEmail)
+}
+
+func (s *mockedSubAccountTestSuite) TestSubAccountTransfer() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(fasthttp.MethodPost, method)
+		s.Require().Equal(binance.EndpointSubAccountTransfer, endpoint)
+		values, err := query.Values(data)
+		s.Require().NoError(err)
+		s.Require().False(values.Has("fromEmail"))
+		s.Require().Equal("beta@example.com", values.Get("toEmail"))
+		s.Require().Equal("USDT_FUTURE", values.Get("toAccountType"))
+		return []byte(`{"tranId":11945860693,"clientTranId":"rebalance-1"}`), nil
+	}
+	transfer, err := s.client.SubAccountTransfer(&binance.SubAccountTransferReq{
+		ToEmail: "beta@example.com", FromAccountType: binance.SubAccountWalletSpot, ToAccountType: binance.SubAccountWalletUSDTFuture,
+		ClientTranID: "rebalance-1", Asset: "USDT", Amount: "100",
+	})
+	s.Require().NoError(err)
+	s.Require().EqualValues(11945860693, transfer.TranID)
+
+	_, err = s.client.SubAccountTransfer(&binance.SubAccountTransferReq{FromAccountType: binance.SubAccountWalletSpot, Asset: "USDT", Amount: "1"})
+	s.Require().ErrorIs(err, binance.ErrEmptyTransferType)
+}
+
+func (s *mockedSubAccountTestSuite) TestSubAccountIPRestriction() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		switch endpoint {
+		case binance.EndpointSubAccountSetIPRestriction:
+			s.Require().Equal(fasthttp.MethodPost, method)
+			values, err := query.Values(data)
+			s.Require().NoError(err)
+			s.Require().Equal("2", values.Get("status"))
+			s.Require().Equal("69.210.67.14,8.34.21.10", values.Get("ipAddress"))
+			return []byte(`{"status":"2","ipList":["69.210.67.14","8.34.21.10"],"updateTime":1636371437000,"apiKey":"k5V49ldtn4tszj6W3hystegdfvmGbqDzjmkCtpTvC0G74WhK7yd4rfCTo4lShf"}`), nil
+		case binance.EndpointSubAccountIPList:
+			s.Require().Equal(fasthttp.MethodDelete, method)
+			values, err := query.Values(data)
+			s.Require().NoError(err)
+			s.Require().False(values.Has("status"))
+			return []byte(`{"ipRestrict":"true","ipList":["69.210.67.14"],"updateTime":1636371437000,"apiKey":"k5V49ldtn4tszj6W3hystegdfvmGbqDzjmkCtpTvC0G74WhK7yd4rfCTo4lShf"}`), nil
+		}
+		s.FailNow("unexpected endpoint " + endpoint)
+		return nil, nil
+	}
+	restriction, err := s.client.SetSubAccountIPRestriction(&binance.SubAccountIPRestrictionReq{
+		Email: "alpha@example.com", SubAccountAPIKey: "key", IPAddress: "69.210.67.14,8.34.21.10",
+	})
+	s.Require().NoError(err)
+	s.Require().Len(restriction.IPList, 2)
+
+	restriction, err = s.client.DeleteSubAccountIPList(&binance.SubAccountIPRestrictionReq{
+		Email: "alpha@example.com", SubAccountAPIKey: "key", Status: binance.IPRestrictionStatusRestricted, IPAddress: "8.34.21.10",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"69.210.67.14"}, restriction.IPList)
+
+	_, err = s.client.SubAccountIPRestriction(&binance.SubAccountAPIKeyReq{Email: "alpha@example.com"})
+	s.Require().ErrorIs(err, binance.ErrEmptyAPIKey)
+}
@@ -0,0 +1,570 @@
+package tracker
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-faster/errors"
+	"github.com/xenking/decimal"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/ws"
+)
+
+const (
+	DefaultPollInterval = 5 * time.Second
+	// DefaultUnknownOrderMisses is the number of consecutive polls the order must be unknown to the exchange to be rejected
+	DefaultUnknownOrderMisses = 3
+)
+
+var (
+	// ErrUnknownOrder is returned when the client order id wasn't registered
+	ErrUnknownOrder = errors.New("order is not tracked")
+	// ErrStatusUnreachable is returned by Wait when the order reached a final status different from the awaited one
+	ErrStatusUnreachable = errors.New("order reached final status")
+)
+
+// Fill represents a single trade of the tracked order
+type Fill struct {
+	TradeID         int64
+	Price           decimal.Decimal
+	Qty             decimal.Decimal
+	QuoteQty        decimal.Decimal
+	Commission      decimal.Decimal
+	CommissionAsset string
+	Time            int64
+	Maker           bool
+}
+
+// Order is a snapshot of the tracked order state
+type Order struct {
+	Symbol        string
+	ClientOrderID string
+	OrderID       int64
+	OrderListID   int64
+	Side          binance.OrderSide
+	Type          binance.OrderType
+	Status        binance.OrderStatus
+	Price         decimal.Decimal
+	OrigQty       decimal.Decimal
+	ExecutedQty   decimal.Decimal
+	QuoteQty      decimal.Decimal // QuoteQty is the cumulative quote asset transacted quantity
+	Fills         []Fill
+	Commissions   map[string]decimal.Decimal // Commissions are the fills commissions summed by asset
+	Reject        binance.OrderFailure
+	UpdateTime    int64
+}
+
+// AvgPrice returns the average fill price or zero if nothing was executed
+func (o *Order) AvgPrice() decimal.Decimal {
+	if o.ExecutedQty.IsZero() {
+		return decimal.Zero
+	}
+
+	return o.QuoteQty.Div(o.ExecutedQty)
+}
+
+// List is a snapshot of the tracked order list state
+type List struct {
+	Symbol            string
+	ListClientOrderID string
+	OrderListID       int64
+	ContingencyType   binance.ContingencyType
+	ListStatusType    binance.OCOStatus
+	ListOrderStatus   binance.OrderStatus
+	ClientOrderIDs    []string
+	UpdateTime        int64
+}
+
+// IsFinal checks whether the order status can't change anymore
+func IsFinal(status binance.OrderStatus) bool {
+	switch status {
+	case binance.OrderStatusFilled, binance.OrderStatusCanceled, binance.OrderStatusRejected,
+		binance.OrderStatusExpired, binance.OrderStatusExpiredInMatch:
+		return true
+	}
+
+	return false
+}
+
+// statusRank orders statuses so that updates never move the order backwards
+func statusRank(status binance.OrderStatus) int {
+	switch status {
+	case binance.OrderStatusNew:
+		return 1
+	case binance.OrderStatusPartial:
+		return 2
+	case binance.OrderStatusPending:
+		return 3
+	case "":
+		return 0
+	}
+	if IsFinal(status) {
+		return 4
+	}
+
+	return 0
+}
+
+type trackedOrder struct {
+	Order
+	tradeIDs map[int64]struct{}
+	changed  chan struct{}
+	misses   int // misses counts the consecutive polls the exchange didn't know the order
+}
+
+func (o *trackedOrder) snapshot() Order {
+	s := o.Order
+	s.Fills = append([]Fill(nil), o.Fills...)
+	s.Commissions = make(map[string]decimal.Decimal, len(o.Commissions))
+	for k, v := range o.Commissions {
+		s.Commissions[k] = v
+	}
+
+	return s
+}
+
+// notify wakes up all waiters of the order
+func (o *trackedOrder) notify() {
+	close(o.changed)
+	o.changed = make(chan struct{})
+}
+
+// setStatus applies the status transition and reports whether it was accepted
+func (o *trackedOrder) setStatus(status binance.OrderStatus, updateTime int64) bool {
+	if status == "" || IsFinal(o.Status) {
+		return false
+	}
+	if statusRank(status) < statusRank(o.Status) {
+		return false
+	}
+	o.Status = status
+	if updateTime > o.UpdateTime {
+		o.UpdateTime = updateTime
+	}
+
+	return true
+}
+
+func (o *trackedOrder) addFill(f Fill) bool {
+	if _, ok := o.tradeIDs[f.TradeID]; ok {
+		return false
+	}
+	o.tradeIDs[f.TradeID] = struct{}{}
+	o.Fills = append(o.Fills, f)
+	if f.CommissionAsset != "" {
+		o.Commissions[f.CommissionAsset] = o.Commissions[f.CommissionAsset].Add(f.Commission)
+	}
+
+	return true
+}
+
+// ErrorHandler receives the polling errors of the tracked order
+type ErrorHandler func(clientOrderID string, err error)
+
+// OrderTracker follows registered orders through the user data stream and falls back
+// to polling QueryOrder while the stream is down
+type OrderTracker struct {
+	client        *binance.Client
+	pollInterval  time.Duration
+	unknownMisses int
+	errorHandler  ErrorHandler
+
+	mu     sync.Mutex
+	orders map[string]*trackedOrder
+	lists  map[string]*List
+}
+
+// NewOrderTracker creates a tracker which uses the client for polling
+func NewOrderTracker(client *binance.Client) *OrderTracker {
+	return &OrderTracker{
+		client:        client,
+		pollInterval:  DefaultPollInterval,
+		unknownMisses: DefaultUnknownOrderMisses,
+		orders:        make(map[string]*trackedOrder),
+		lists:         make(map[string]*List),
+	}
+}
+
+// PollInterval sets the interval of QueryOrder polling used while the stream is down
+func (t *OrderTracker) PollInterval(interval time.Duration) *OrderTracker {
+	t.pollInterval = interval
+
+	return t
+}
+
+// UnknownOrderMisses sets the number of consecutive polls the order must be unknown to the exchange to be rejected.
+// Orders are registered before they are placed, so the first misses are expected while the order is in flight
+func (t *OrderTracker) UnknownOrderMisses(misses int) *OrderTracker {
+	t.unknownMisses = misses
+
+	return t
+}
+
+// OnError sets the handler of the polling errors, e.g. to log them. Failed queries are retried on the next poll
+func (t *OrderTracker) OnError(handler ErrorHandler) *OrderTracker {
+	t.errorHandler = handler
+
+	return t
+}
+
+// Register starts tracking the order by its client order id
+func (t *OrderTracker) Register(symbol, clientOrderID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.register(symbol, clientOrderID)
+}
+
+// RegisterList starts tracking the order list by its list client order id.
+// Orders of the list are registered once their list status is received
+func (t *OrderTracker) RegisterList(symbol, listClientOrderID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.lists[listClientOrderID]; !ok {
+		t.lists[listClientOrderID] = &List{Symbol: symbol, ListClientOrderID: listClientOrderID}
+	}
+}
+
+// Unregister stops tracking the order
+func (t *OrderTracker) Unregister(clientOrderID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if o, ok := t.orders[clientOrderID]; ok {
+		delete(t.orders, clientOrderID)
+		o.notify()
+	}
+}
+
+// Order returns the tracked order snapshot
+func (t *OrderTracker) Order(clientOrderID string) (Order, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	o, ok := t.orders[clientOrderID]
+	if !ok {
+		return Order{}, false
+	}
+
+	return o.snapshot(), true
+}
+
+// List returns the tracked order list snapshot
+func (t *OrderTracker) List(listClientOrderID string) (List, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	l, ok := t.lists[listClientOrderID]
+	if !ok {
+		return List{}, false
+	}
+	s := *l
+	s.ClientOrderIDs = append([]string(nil), l.ClientOrderIDs...)
+
+	return s, true
+}
+
+// Wait blocks until the order reaches the status.
+// Waiting for non-final status also returns once the open order moved past it,
+// ErrStatusUnreachable is returned if the order ended with the other final status
+func (t *OrderTracker) Wait(ctx context.Context, clientOrderID string, status binance.OrderStatus) (Order, error) {
+	for {
+		t.mu.Lock()
+		o, ok := t.orders[clientOrderID]
+		if !ok {
+			t.mu.Unlock()
+			return Order{}, ErrUnknownOrder
+		}
+		snapshot, changed := o.snapshot(), o.changed
+		t.mu.Unlock()
+
+		switch {
+		case snapshot.Status == status:
+			return snapshot, nil
+		case IsFinal(snapshot.Status):
+			return snapshot, errors.Wrap(ErrStatusUnreachable, string(snapshot.Status))
+		case !IsFinal(status) && statusRank(status) > 0 && statusRank(snapshot.Status) > statusRank(status):
+			return snapshot, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return snapshot, ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// HandleOrderUpdate applies the executionReport event to the tracked order
+func (t *OrderTracker) HandleOrderUpdate(e *ws.OrderUpdateEvent) {
+	if e == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	clientOrderID := e.NewClientOrderID
+	// Cancel reports carry the new cancel request id in "c" and the original order id in "C"
+	if e.OrigClientOrderID != "" {
+		clientOrderID = e.OrigClientOrderID
+	}
+	o, ok := t.orders[clientOrderID]
+	if !ok {
+		return
+	}
+
+	changed := false
+	if o.OrderID == 0 {
+		o.OrderID = e.OrderID
+		o.OrderListID = e.OrderListID
+		o.Side = e.Side
+		o.Type = e.OrderType
+		o.Price = parseDecimal(e.Price)
+		o.OrigQty = parseDecimal(e.OrigQty)
+		changed = true
+	}
+	if e.ExecutionType == ws.ExecutionTypeTrade {
+		changed = o.addFill(Fill{
+			TradeID:         e.TradeID,
+			Price:           parseDecimal(e.FilledPrice),
+			Qty:             parseDecimal(e.FilledQty),
+			QuoteQty:        parseDecimal(e.QuoteFilledQty),
+			Commission:      parseDecimal(e.Commission),
+			CommissionAsset: e.CommissionAsset,
+			Time:            e.TradeTime,
+			Maker:           e.Maker,
+		}) || changed
+	}
+	if executed := parseDecimal(e.TotalFilledQty); executed.GreaterThan(o.ExecutedQty) {
+		o.ExecutedQty = executed
+		o.QuoteQty = parseDecimal(e.QuoteTotalFilledQty)
+		changed = true
+	}
+	if e.Error != "" && e.Error != binance.OrderFailureNone {
+		o.Reject = e.Error
+	}
+	changed = o.setStatus(e.Status, e.Time) || changed
+
+	if changed {
+		o.notify()
+	}
+}
+
+// HandleListStatus applies the listStatus event to the tracked order list and registers its orders
+func (t *OrderTracker) HandleListStatus(e *ws.OCOOrderUpdateEvent) {
+	if e == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	l, ok := t.lists[e.OCOClientOrderID]
+	if !ok {
+		return
+	}
+	l.OrderListID = e.OrderListID
+	l.ContingencyType = e.ContingencyType
+	l.ListStatusType = e.OCOStatus
+	l.ListOrderStatus = e.OrderStatus
+	l.UpdateTime = e.TransactTime
+	l.ClientOrderIDs = l.ClientOrderIDs[:0]
+	for _, lo := range e.Orders {
+		l.ClientOrderIDs = append(l.ClientOrderIDs, lo.ClientOrderID)
+		o := t.register(lo.Symbol, lo.ClientOrderID)
+		if o.OrderID == 0 {
+			o.OrderID = lo.OrderID
+			o.OrderListID = e.OrderListID
+		}
+	}
+}
+
+// Run consumes the user data stream until the context is done.
+// When the stream is nil or fails, the tracker switches to polling QueryOrder for unfinished orders.
+// To resume streaming after a reconnect, cancel the context and call Run with the new stream
+func (t *OrderTracker) Run(ctx context.Context, stream *ws.AccountInfo) error {
+	streamErr := make(chan error, 1)
+	if stream != nil {
+		go func() {
+			streamErr <- t.consume(ctx, stream)
+		}()
+	}
+	streamUp := stream != nil
+
+	ticker := time.NewTicker(t.pollInterval)
+	defer ticker.Stop()
+
+	if !streamUp {
+		t.Poll()
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-streamErr:
+			streamUp = false
+			t.Poll()
+		case <-ticker.C:
+			if !streamUp {
+				t.Poll()
+			}
+		}
+	}
+}
+
+func (t *OrderTracker) consume(ctx context.Context, stream *ws.AccountInfo) error {
+	for ctx.Err() == nil {
+		_, event, err := stream.Read()
+		if err != nil {
+			return err
+		}
+		switch e := event.(type) {
+		case *ws.OrderUpdateEvent:
+			t.HandleOrderUpdate(e)
+		case *ws.OCOOrderUpdateEvent:
+			t.HandleListStatus(e)
+		}
+	}
+
+	return ctx.Err()
+}
+
+// Poll queries all unfinished orders and their trades via REST.
+// Errors are passed to the error handler. Orders without order id which stay unknown to the exchange
+// for UnknownOrderMisses consecutive polls are rejected with UNKNOWN_ORDER
+func (t *OrderTracker) Poll() {
+	t.mu.Lock()
+	pending := make([]binance.QueryOrderReq, 0, len(t.orders))
+	for id, o := range t.orders {
+		if !IsFinal(o.Status) {
+			pending = append(pending, binance.QueryOrderReq{Symbol: o.Symbol, OrigClientOrderID: id})
+		}
+	}
+	t.mu.Unlock()
+
+	for i := range pending {
+		clientOrderID := pending[i].OrigClientOrderID
+		resp, err := t.client.QueryOrder(&pending[i])
+		var apiErr *binance.APIError
+		switch {
+		case err == nil:
+			err = t.applyQuery(resp)
+		case errors.As(err, &apiErr) && apiErr.Code == binance.ErrorCodeNoSuchOrder:
+			t.miss(clientOrderID)
+		}
+		if err != nil && t.errorHandler != nil {
+			t.errorHandler(clientOrderID, err)
+		}
+	}
+}
+
+// miss counts the poll of the order unknown to the exchange and rejects the order which was never placed.
+// Orders with order id were accepted by the exchange, so they stay pending until their status is known
+func (t *OrderTracker) miss(clientOrderID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	o, ok := t.orders[clientOrderID]
+	if !ok || o.OrderID != 0 {
+		return
+	}
+	o.misses++
+	if o.misses < t.unknownMisses || !o.setStatus(binance.OrderStatusRejected, 0) {
+		return
+	}
+	o.Reject = binance.OrderFailureUnknownOrder
+	o.notify()
+}
+
+func (t *OrderTracker) applyQuery(q *binance.QueryOrder) error {
+	t.mu.Lock()
+	o, ok := t.orders[q.ClientOrderID]
+	fetchTrades := ok && parseDecimal(q.ExecutedQty).GreaterThan(o.filledQty())
+	t.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	// Trades are fetched before the status is applied, so waiters see the fills together with the final status.
+	// The query isn't applied without them and is retried on the next poll
+	var trades []*binance.AccountTrade
+	if fetchTrades {
+		var err error
+		trades, err = t.client.AccountTrades(&binance.AccountTradesReq{
+			Symbol:  q.Symbol,
+			OrderID: strconv.FormatInt(q.OrderID, 10),
+		})
+		if err != nil {
+			return errors.Wrap(err, "account trades")
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	o.OrderID = q.OrderID
+	o.OrderListID = q.OrderListID
+	o.Side = q.Side
+	o.Type = q.Type
+	o.Price = parseDecimal(q.Price)
+	o.OrigQty = parseDecimal(q.OrigQty)
+	for _, tr := range trades {
+		o.addFill(Fill{
+			TradeID:         tr.ID,
+			Price:           parseDecimal(tr.Price),
+			Qty:             parseDecimal(tr.Qty),
+			QuoteQty:        parseDecimal(tr.QuoteQty),
+			Commission:      parseDecimal(tr.Commission),
+			CommissionAsset: tr.CommissionAsset,
+			Time:            tr.Time,
+			Maker:           tr.Maker,
+		})
+	}
+	if executed := parseDecimal(q.ExecutedQty); executed.GreaterThan(o.ExecutedQty) {
+		o.ExecutedQty = executed
+		o.QuoteQty = parseDecimal(q.CummulativeQuoteQty)
+	}
+	o.setStatus(q.Status, q.UpdateTime)
+	o.notify()
+
+	return nil
+}
+
+func (o *trackedOrder) filledQty() decimal.Decimal {
+	sum := decimal.Zero
+	for i := range o.Fills {
+		sum = sum.Add(o.Fills[i].Qty)
+	}
+
+	return sum
+}
+
+func (t *OrderTracker) register(symbol, clientOrderID string) *trackedOrder {
+	if o, ok := t.orders[clientOrderID]; ok {
+		return o
+	}
+	o := &trackedOrder{
+		Order: Order{
+			Symbol:        symbol,
+			ClientOrderID: clientOrderID,
+			Commissions:   make(map[string]decimal.Decimal),
+		},
+		tradeIDs: make(map[int64]struct{}),
+		changed:  make(chan struct{}),
+	}
+	t.orders[clientOrderID] = o
+
+	return o
+}
+
+func parseDecimal(s string) decimal.Decimal {
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Zero
+	}
+
+	return d
+}
@@ -0,0 +1,313 @@
+package tracker_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/segmentio/encoding/json"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/xenking/binance-api"
+	"github.com/xenking/binance-api/binancetest"
+	"github.com/xenking/binance-api/tracker"
+	"github.com/xenking/binance-api/ws"
+)
+
+func TestOrderTracker(t *testing.T) {
+	suite.Run(t, new(trackerTestSuite))
+}
+
+type trackerTestSuite struct {
+	suite.Suite
+	mock    *binancetest.MockClient
+	tracker *tracker.OrderTracker
+}
+
+func (s *trackerTestSuite) SetupTest() {
+	s.mock = &binancetest.MockClient{}
+	s.tracker = tracker.NewOrderTracker(binance.NewCustomClient(s.mock)).PollInterval(10 * time.Millisecond)
+}
+
+func (s *trackerTestSuite) TestOrderUpdates() {
+	s.tracker.Register("LTCBTC", "order-1")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	done := make(chan tracker.Order)
+	go func() {
+		o, err := s.tracker.Wait(ctx, "order-1", binance.OrderStatusFilled)
+		s.Require().NoError(err)
+		done <- o
+	}()
+
+	s.tracker.HandleOrderUpdate(&ws.OrderUpdateEvent{
+		NewClientOrderID: "order-1",
+		Symbol:           "LTCBTC",
+		OrderID:          1,
+		Side:             binance.OrderSideBuy,
+		OrderType:        binance.OrderTypeLimit,
+		OrigQty:          "2",
+		Price:            "0.1",
+		ExecutionType:    ws.ExecutionTypeNew,
+		Status:           binance.OrderStatusNew,
+	})
+	partial := &ws.OrderUpdateEvent{
+		NewClientOrderID:    "order-1",
+		OrderID:             1,
+		ExecutionType:       ws.ExecutionTypeTrade,
+		Status:              binance.OrderStatusPartial,
+		TradeID:             10,
+		FilledQty:           "1",
+		FilledPrice:         "0.1",
+		QuoteFilledQty:      "0.1",
+		TotalFilledQty:      "1",
+		QuoteTotalFilledQty: "0.1",
+		Commission:          "0.001",
+		CommissionAsset:     "LTC",
+	}
+	s.tracker.HandleOrderUpdate(partial)
+	// duplicated events must not be counted twice
+	s.tracker.HandleOrderUpdate(partial)
+
+	o, err := s.tracker.Wait(ctx, "order-1", binance.OrderStatusNew)
+	s.Require().NoError(err)
+	s.Require().Equal(binance.OrderStatusPartial, o.Status)
+
+	s.tracker.HandleOrderUpdate(&ws.OrderUpdateEvent{
+		NewClientOrderID:    "order-1",
+		OrderID:             1,
+		ExecutionType:       ws.ExecutionTypeTrade,
+		Status:              binance.OrderStatusFilled,
+		TradeID:             11,
+		FilledQty:           "1",
+		FilledPrice:         "0.3",
+		QuoteFilledQty:      "0.3",
+		TotalFilledQty:      "2",
+		QuoteTotalFilledQty: "0.4",
+		Commission:          "0.002",
+		CommissionAsset:     "LTC",
+	})
+	// stale event can't move the order backwards
+	s.tracker.HandleOrderUpdate(&ws.OrderUpdateEvent{
+		NewClientOrderID: "order-1",
+		ExecutionType:    ws.ExecutionTypeNew,
+		Status:           binance.OrderStatusNew,
+	})
+
+	select {
+	case o = <-done:
+	case <-ctx.Done():
+		s.FailNow("timeout")
+	}
+	s.Require().Equal(binance.OrderStatusFilled, o.Status)
+	s.Require().Len(o.Fills, 2)
+	s.Require().Equal("2", o.ExecutedQty.String())
+	s.Require().Equal("0.2", o.AvgPrice().String())
+	s.Require().Equal("0.003", o.Commissions["LTC"].String())
+}
+
+func (s *trackerTestSuite) TestWaitUnreachable() {
+	s.tracker.Register("LTCBTC", "order-2")
+	s.tracker.HandleOrderUpdate(&ws.OrderUpdateEvent{
+		NewClientOrderID:  "cancel-2",
+		OrigClientOrderID: "order-2",
+		ExecutionType:     ws.ExecutionTypeExpired,
+		Status:            binance.OrderStatusExpiredInMatch,
+	})
+
+	o, err := s.tracker.Wait(context.Background(), "order-2", binance.OrderStatusFilled)
+	s.Require().ErrorIs(err, tracker.ErrStatusUnreachable)
+	s.Require().Equal(binance.OrderStatusExpiredInMatch, o.Status)
+
+	_, err = s.tracker.Wait(context.Background(), "unknown", binance.OrderStatusFilled)
+	s.Require().ErrorIs(err, tracker.ErrUnknownOrder)
+}
+
+func (s *trackerTestSuite) TestListStatus() {
+	s.tracker.RegisterList("LTCBTC", "list-1")
+	s.tracker.HandleListStatus(&ws.OCOOrderUpdateEvent{
+		Symbol:           "LTCBTC",
+		OrderListID:      5,
+		ContingencyType:  binance.ContingencyTypeOCO,
+		OCOStatus:        binance.OCOStatusExecStarted,
+		OrderStatus:      binance.OrderStatus(binance.OCOOrderStatusExecuting),
+		OCOClientOrderID: "list-1",
+		Orders: []ws.OCOOrderUpdateEventOrder{
+			{Symbol: "LTCBTC", ClientOrderID: "leg-1", OrderID: 1},
+			{Symbol: "LTCBTC", ClientOrderID: "leg-2", OrderID: 2},
+		},
+	})
+
+	l, ok := s.tracker.List("list-1")
+	s.Require().True(ok)
+	s.Require().Equal([]string{"leg-1", "leg-2"}, l.ClientOrderIDs)
+
+	o, ok := s.tracker.Order("leg-2")
+	s.Require().True(ok)
+	s.Require().EqualValues(2, o.OrderID)
+	s.Require().EqualValues(5, o.OrderListID)
+}
+
+func (s *trackerTestSuite) TestPollingFallback() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign, stream bool) ([]byte, error) {
+		switch endpoint {
+		case binance.EndpointOrder:
+			s.Require().IsType(&binance.QueryOrderReq{}, data)
+			req := data.(*binance.QueryOrderReq)
+			return json.Marshal(&binance.QueryOrder{
+				Symbol:              req.Symbol,
+				OrderID:             7,
+				ClientOrderID:       req.OrigClientOrderID,
+				Price:               "0.1",
+				OrigQty:             "1",
+				ExecutedQty:         "1",
+				CummulativeQuoteQty: "0.1",
+				Status:              binance.OrderStatusFilled,
+				Type:                binance.OrderTypeLimit,
+				Side:                binance.OrderSideSell,
+			})
+		case binance.EndpointAccountTrades:
+			s.Require().IsType(&binance.AccountTradesReq{}, data)
+			s.Require().Equal("7", data.(*binance.AccountTradesReq).OrderID)
+			return json.Marshal([]*binance.AccountTrade{{
+				ID:              100,
+				OrderID:         7,
+				Symbol:          "LTCBTC",
+				Price:           "0.1",
+				Qty:             "1",
+				QuoteQty:        "0.1",
+				Commission:      "0.0001",
+				CommissionAsset: "BTC",
+			}})
+		}
+		s.FailNow("unexpected endpoint", endpoint)
+		return nil, nil
+	}
+	s.tracker.Register("LTCBTC", "order-3")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	go s.tracker.Run(ctx, nil) //nolint:errcheck // stopped by context
+
+	o, err := s.tracker.Wait(ctx, "order-3", binance.OrderStatusFilled)
+	s.Require().NoError(err)
+	s.Require().EqualValues(7, o.OrderID)
+	s.Require().Equal("0.0001", o.Commissions["BTC"].String())
+	s.Require().Len(o.Fills, 1)
+}
+
+func (s *trackerTestSuite) TestPollingErrors() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign, stream bool) ([]byte, error) {
+		switch endpoint {
+		case binance.EndpointOrder:
+			req := data.(*binance.QueryOrderReq)
+			if req.OrigClientOrderID == "order-4" {
+				return nil, &binance.APIError{Code: binance.ErrorCodeNoSuchOrder, Msg: "Order does not exist."}
+			}
+			return json.Marshal(&binance.QueryOrder{
+				Symbol:              req.Symbol,
+				OrderID:             8,
+				ClientOrderID:       req.OrigClientOrderID,
+				OrigQty:             "1",
+				ExecutedQty:         "1",
+				CummulativeQuoteQty: "0.1",
+				Status:              binance.OrderStatusFilled,
+			})
+		case binance.EndpointAccountTrades:
+			return nil, &binance.APIError{Code: binance.ErrorCodeTimeout, Msg: "timeout"}
+		}
+		s.FailNow("unexpected endpoint", endpoint)
+		return nil, nil
+	}
+	failed := make(map[string]error)
+	s.tracker.UnknownOrderMisses(2).OnError(func(clientOrderID string, err error) {
+		failed[clientOrderID] = err
+	})
+	s.tracker.Register("LTCBTC", "order-4")
+	s.tracker.Register("LTCBTC", "order-5")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	type result struct {
+		order tracker.Order
+		err   error
+	}
+	waited := make(chan result, 1)
+	go func() {
+		o, err := s.tracker.Wait(ctx, "order-4", binance.OrderStatusFilled)
+		waited <- result{o, err}
+	}()
+	s.tracker.Poll()
+	// the first miss may be the order in flight
+	o, ok := s.tracker.Order("order-4")
+	s.Require().True(ok)
+	s.Require().Empty(o.Status)
+	s.tracker.Poll()
+
+	// the waiter is notified of the rejected order
+	res := <-waited
+	s.Require().ErrorIs(res.err, tracker.ErrStatusUnreachable)
+	s.Require().Equal(binance.OrderStatusRejected, res.order.Status)
+	s.Require().Equal(binance.OrderFailureUnknownOrder, res.order.Reject)
+
+	var apiErr *binance.APIError
+	s.Require().ErrorAs(failed["order-4"], &apiErr)
+	s.Require().Equal(binance.ErrorCodeNoSuchOrder, apiErr.Code)
+	s.Require().ErrorAs(failed["order-5"], &apiErr)
+	s.Require().Equal(binance.ErrorCodeTimeout, apiErr.Code)
+
+	// the query isn't applied without the trades of the order
+	o, ok = s.tracker.Order("order-5")
+	s.Require().True(ok)
+	s.Require().Empty(o.Status)
+	s.Require().True(o.ExecutedQty.IsZero())
+}
+
+func (s *trackerTestSuite) TestPollBeforePlacement() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign, stream bool) ([]byte, error) {
+		s.Require().Equal(binance.EndpointOrder, endpoint)
+		return nil, &binance.APIError{Code: binance.ErrorCodeNoSuchOrder, Msg: "Order does not exist."}
+	}
+	s.tracker.UnknownOrderMisses(2)
+	s.tracker.Register("LTCBTC", "order-6")
+
+	// polled before the order response arrives
+	s.tracker.Poll()
+	o, ok := s.tracker.Order("order-6")
+	s.Require().True(ok)
+	s.Require().Empty(o.Status)
+	s.Require().Empty(o.Reject)
+
+	s.tracker.HandleOrderUpdate(&ws.OrderUpdateEvent{
+		NewClientOrderID: "order-6",
+		Symbol:           "LTCBTC",
+		OrderID:          6,
+		OrigQty:          "1",
+		ExecutionType:    ws.ExecutionTypeNew,
+		Status:           binance.OrderStatusNew,
+	})
+	// the placed order isn't visible to the query yet
+	s.tracker.Poll()
+	s.tracker.Poll()
+	o, _ = s.tracker.Order("order-6")
+	s.Require().Equal(binance.OrderStatusNew, o.Status)
+
+	s.tracker.HandleOrderUpdate(&ws.OrderUpdateEvent{
+		NewClientOrderID:    "order-6",
+		OrderID:             6,
+		ExecutionType:       ws.ExecutionTypeTrade,
+		Status:              binance.OrderStatusFilled,
+		TradeID:             12,
+		FilledQty:           "1",
+		FilledPrice:         "0.1",
+		QuoteFilledQty:      "0.1",
+		TotalFilledQty:      "1",
+		QuoteTotalFilledQty: "0.1",
+	})
+	o, _ = s.tracker.Order("order-6")
+	s.Require().Equal(binance.OrderStatusFilled, o.Status)
+	s.Require().Empty(o.Reject)
+	s.Require().Len(o.Fills, 1)
+}
@@ -2,6 +2,7 @@ package binance
 
 import (
 	"bytes"
+	"time"
 
 	"github.com/valyala/fasthttp"
 	"github.com/xenking/decimal"
@@ -158,6 +159,66 @@ type OrderRespFullFill struct {
 	Commission      string `json:"commission"`
 	CommissionAsset string `json:"commissionAsset"`
 	TradeID         int64  `json:"tradeId"`
+	MatchType       string `json:"matchType,omitempty"` // MatchType is set for SOR fills, e.g. ONE_PARTY_TRADE_REPORT
+	AllocID         int64  `json:"allocId,omitempty"`   // AllocID is set for SOR fills
+}
+
+// SOROrderReq represents the request for placing an order using smart order routing.
+// Remark: only LIMIT and MARKET orders are supported, quoteOrderQty is not supported
+type SOROrderReq struct {
+	Symbol                  string                  `url:"symbol"`
+	Side                    OrderSide               `url:"side"`
+	Type                    OrderType               `url:"type"`
+	TimeInForce             TimeInForce             `url:"timeInForce,omitempty"`
+	Quantity                string                  `url:"quantity"`
+	Price                   string                  `url:"price,omitempty"`
+	NewClientOrderID        string                  `url:"newClientOrderId,omitempty"`
+	StrategyID              int                     `url:"strategyId,omitempty"`
+	StrategyType            int                     `url:"strategyType,omitempty"` // Should be more than 1000000
+	IcebergQty              string                  `url:"icebergQty,omitempty"`
+	OrderRespType           OrderRespType           `url:"newOrderRespType,omitempty"`
+	SelfTradePreventionMode SelfTradePreventionMode `url:"selfTradePreventionMode,omitempty"`
+}
+
+type SOROrder struct {
+	OrderRespFull
+	WorkingFloor string `json:"workingFloor"`
+	UsedSor      bool   `json:"usedSor"`
+}
+
+type AllocationType string
+
+const AllocationTypeSOR AllocationType = "SOR"
+
+const (
+	DefaultAllocationsLimit = 500
+	MaxAllocationsLimit     = 1000
+)
+
+type MyAllocationsReq struct {
+	Symbol           string `url:"symbol"`
+	StartTime        int64  `url:"startTime,omitempty"`
+	EndTime          int64  `url:"endTime,omitempty"`
+	FromAllocationID int64  `url:"fromAllocationId,omitempty"`
+	Limit            int    `url:"limit,omitempty"` // Limit is the maximal number of elements to receive. Default 500; Max 1000
+	OrderID          int64  `url:"orderId,omitempty"`
+}
+
+type Allocation struct {
+	Symbol          string         `json:"symbol"`
+	AllocationID    int64          `json:"allocationId"`
+	AllocationType  AllocationType `json:"allocationType"`
+	OrderID         int64          `json:"orderId"`
+	OrderListID     int64          `json:"orderListId"`
+	Price           string         `json:"price"`
+	Qty             string         `json:"qty"`
+	QuoteQty        string         `json:"quoteQty"`
+	Commission      string         `json:"commission"`
+	CommissionAsset string         `json:"commissionAsset"`
+	Time            int64          `json:"time"`
+	IsBuyer         bool           `json:"isBuyer"`
+	IsMaker         bool           `json:"isMaker"`
+	IsAllocator     bool           `json:"isAllocator"`
 }
 
 type ServerTime struct {
@@ -185,6 +246,30 @@ const (
 	KlineInterval1month KlineInterval = "1M"
 )
 
+var klineIntervalDurations = map[KlineInterval]time.Duration{
+	KlineInterval1sec:   time.Second,
+	KlineInterval1min:   time.Minute,
+	KlineInterval3min:   3 * time.Minute,
+	KlineInterval5min:   5 * time.Minute,
+	KlineInterval15min:  15 * time.Minute,
+	KlineInterval30min:  30 * time.Minute,
+	KlineInterval1hour:  time.Hour,
+	KlineInterval2hour:  2 * time.Hour,
+	KlineInterval4hour:  4 * time.Hour,
+	KlineInterval6hour:  6 * time.Hour,
+	KlineInterval8hour:  8 * time.Hour,
+	KlineInterval12hour: 12 * time.Hour,
+	KlineInterval1day:   24 * time.Hour,
+	KlineInterval3day:   3 * 24 * time.Hour,
+	KlineInterval1week:  7 * 24 * time.Hour,
+}
+
+// Duration returns the length of the kline interval.
+// The month interval has no fixed length and returns zero as the unknown intervals do
+func (i KlineInterval) Duration() time.Duration {
+	return klineIntervalDurations[i]
+}
+
 const (
 	DefaultDepthLimit = 100
 	MaxDepthLimit     = 5000
@@ -655,6 +740,76 @@ type CancelReplaceOrder struct {
 	NewOrderResult   CancelReplaceResult `json:"newOrderResult"`
 }
 
+// AmendOrderReq represents the request for reducing the order quantity keeping its priority in the order book
+// Remark: Either OrderID or OrigClientOrderID must be set
+type AmendOrderReq struct {
+	Symbol            string `url:"symbol"`
+	OrderID           int64  `url:"orderId,omitempty"`
+	OrigClientOrderID string `url:"origClientOrderId,omitempty"`
+	NewClientOrderID  string `url:"newClientOrderId,omitempty"`
+	NewQty            string `url:"newQty"` // NewQty must be greater than 0 and less than the order quantity
+	CurrentQty        string `url:"-"`      // CurrentQty is not sent, if set NewQty is checked to be lower before sending
+}
+
+type AmendedOrder struct {
+	Symbol                  string                  `json:"symbol"`
+	OrderID                 int64                   `json:"orderId"`
+	OrderListID             int64                   `json:"orderListId"`
+	OrigClientOrderID       string                  `json:"origClientOrderId"`
+	ClientOrderID           string                  `json:"clientOrderId"`
+	Price                   string                  `json:"price"`
+	Qty                     string                  `json:"qty"`
+	ExecutedQty             string                  `json:"executedQty"`
+	PreventedQty            string                  `json:"preventedQty"`
+	QuoteOrderQty           string                  `json:"quoteOrderQty"`
+	CumulativeQuoteQty      string                  `json:"cumulativeQuoteQty"`
+	Status                  OrderStatus             `json:"status"`
+	TimeInForce             TimeInForce             `json:"timeInForce"`
+	Type                    OrderType               `json:"type"`
+	Side                    OrderSide               `json:"side"`
+	WorkingTime             int64                   `json:"workingTime"`
+	SelfTradePreventionMode SelfTradePreventionMode `json:"selfTradePreventionMode"`
+}
+
+type AmendOrderListStatus struct {
+	OrderListID       int64                `json:"orderListId"`
+	ContingencyType   ContingencyType      `json:"contingencyType"`
+	ListOrderStatus   OCOOrderStatus       `json:"listOrderStatus"`
+	ListClientOrderID string               `json:"listClientOrderId"`
+	Symbol            string               `json:"symbol"`
+	Orders            []OCOOrderStatusResp `json:"orders"`
+}
+
+type AmendOrder struct {
+	TransactTime int64                 `json:"transactTime"`
+	ExecutionID  int64                 `json:"executionId"`
+	AmendedOrder AmendedOrder          `json:"amendedOrder"`
+	ListStatus   *AmendOrderListStatus `json:"listStatus,omitempty"` // ListStatus is set only for orders in an order list
+}
+
+const (
+	DefaultAmendmentsLimit = 500
+	MaxAmendmentsLimit     = 1000
+)
+
+type OrderAmendmentsReq struct {
+	Symbol          string `url:"symbol"`
+	OrderID         int64  `url:"orderId"`
+	FromExecutionID int64  `url:"fromExecutionId,omitempty"`
+	Limit           int    `url:"limit,omitempty"` // Limit is the maximal number of elements to receive. Default 500; Max 1000
+}
+
+type OrderAmendment struct {
+	Symbol            string `json:"symbol"`
+	OrderID           int64  `json:"orderId"`
+	ExecutionID       int64  `json:"executionId"`
+	OrigClientOrderID string `json:"origClientOrderId"`
+	NewClientOrderID  string `json:"newClientOrderId"`
+	OrigQty           string `json:"origQty"`
+	NewQty            string `json:"newQty"`
+	Time              int64  `json:"time"`
+}
+
 type OpenOrdersReq struct {
 	Symbol string `url:"symbol,omitempty"`
 }
@@ -701,7 +856,104 @@ type OCOReq struct {
 	SelfTradePreventionMode SelfTradePreventionMode `url:"selfTradePreventionMode,omitempty"`
 }
 
-type OCOOrder struct {
+// OrderListOCOReq represents the request for the new OCO order list format.
+// The above leg is placed above the current price and the below leg under it
+type OrderListOCOReq struct {
+	Symbol                  string                  `url:"symbol"`
+	ListClientOrderID       string                  `url:"listClientOrderId,omitempty"`
+	Side                    OrderSide               `url:"side"`
+	Quantity                string                  `url:"quantity"`
+	AboveType               OrderType               `url:"aboveType"` // STOP_LOSS_LIMIT, STOP_LOSS, LIMIT_MAKER, TAKE_PROFIT or TAKE_PROFIT_LIMIT
+	AboveClientOrderID      string                  `url:"aboveClientOrderId,omitempty"`
+	AboveIcebergQty         string                  `url:"aboveIcebergQty,omitempty"`
+	AbovePrice              string                  `url:"abovePrice,omitempty"`
+	AboveStopPrice          string                  `url:"aboveStopPrice,omitempty"`
+	AboveTrailingDelta      int64                   `url:"aboveTrailingDelta,omitempty"`
+	AboveTimeInForce        TimeInForce             `url:"aboveTimeInForce,omitempty"`
+	AboveStrategyID         int                     `url:"aboveStrategyId,omitempty"`
+	AboveStrategyType       int                     `url:"aboveStrategyType,omitempty"` // Should be more than 1000000
+	BelowType               OrderType               `url:"belowType"`                   // STOP_LOSS, STOP_LOSS_LIMIT, TAKE_PROFIT or TAKE_PROFIT_LIMIT
+	BelowClientOrderID      string                  `url:"belowClientOrderId,omitempty"`
+	BelowIcebergQty         string                  `url:"belowIcebergQty,omitempty"`
+	BelowPrice              string                  `url:"belowPrice,omitempty"`
+	BelowStopPrice          string                  `url:"belowStopPrice,omitempty"`
+	BelowTrailingDelta      int64                   `url:"belowTrailingDelta,omitempty"`
+	BelowTimeInForce        TimeInForce             `url:"belowTimeInForce,omitempty"`
+	BelowStrategyID         int                     `url:"belowStrategyId,omitempty"`
+	BelowStrategyType       int                     `url:"belowStrategyType,omitempty"` // Should be more than 1000000
+	OrderRespType           OrderRespType           `url:"newOrderRespType,omitempty"`
+	SelfTradePreventionMode SelfTradePreventionMode `url:"selfTradePreventionMode,omitempty"`
+}
+
+// OrderListOTOReq represents the request for the one-triggers-the-other order list.
+// The pending order is placed only when the working order is fully filled
+type OrderListOTOReq struct {
+	Symbol                  string                  `url:"symbol"`
+	ListClientOrderID       string                  `url:"listClientOrderId,omitempty"`
+	OrderRespType           OrderRespType           `url:"newOrderRespType,omitempty"`
+	SelfTradePreventionMode SelfTradePreventionMode `url:"selfTradePreventionMode,omitempty"`
+	WorkingType             OrderType               `url:"workingType"` // LIMIT or LIMIT_MAKER
+	WorkingSide             OrderSide               `url:"workingSide"`
+	WorkingClientOrderID    string                  `url:"workingClientOrderId,omitempty"`
+	WorkingPrice            string                  `url:"workingPrice"`
+	WorkingQuantity         string                  `url:"workingQuantity"`
+	WorkingIcebergQty       string                  `url:"workingIcebergQty,omitempty"`
+	WorkingTimeInForce      TimeInForce             `url:"workingTimeInForce,omitempty"`
+	WorkingStrategyID       int                     `url:"workingStrategyId,omitempty"`
+	WorkingStrategyType     int                     `url:"workingStrategyType,omitempty"` // Should be more than 1000000
+	PendingType             OrderType               `url:"pendingType"`
+	PendingSide             OrderSide               `url:"pendingSide"`
+	PendingClientOrderID    string                  `url:"pendingClientOrderId,omitempty"`
+	PendingPrice            string                  `url:"pendingPrice,omitempty"`
+	PendingStopPrice        string                  `url:"pendingStopPrice,omitempty"`
+	PendingTrailingDelta    int64                   `url:"pendingTrailingDelta,omitempty"`
+	PendingQuantity         string                  `url:"pendingQuantity"`
+	PendingIcebergQty       string                  `url:"pendingIcebergQty,omitempty"`
+	PendingTimeInForce      TimeInForce             `url:"pendingTimeInForce,omitempty"`
+	PendingStrategyID       int                     `url:"pendingStrategyId,omitempty"`
+	PendingStrategyType     int                     `url:"pendingStrategyType,omitempty"` // Should be more than 1000000
+}
+
+// OrderListOTOCOReq represents the request for the one-triggers-one-cancels-the-other order list.
+// The pending OCO pair is placed only when the working order is fully filled
+type OrderListOTOCOReq struct {
+	Symbol                    string                  `url:"symbol"`
+	ListClientOrderID         string                  `url:"listClientOrderId,omitempty"`
+	OrderRespType             OrderRespType           `url:"newOrderRespType,omitempty"`
+	SelfTradePreventionMode   SelfTradePreventionMode `url:"selfTradePreventionMode,omitempty"`
+	WorkingType               OrderType               `url:"workingType"` // LIMIT or LIMIT_MAKER
+	WorkingSide               OrderSide               `url:"workingSide"`
+	WorkingClientOrderID      string                  `url:"workingClientOrderId,omitempty"`
+	WorkingPrice              string                  `url:"workingPrice"`
+	WorkingQuantity           string                  `url:"workingQuantity"`
+	WorkingIcebergQty         string                  `url:"workingIcebergQty,omitempty"`
+	WorkingTimeInForce        TimeInForce             `url:"workingTimeInForce,omitempty"`
+	WorkingStrategyID         int                     `url:"workingStrategyId,omitempty"`
+	WorkingStrategyType       int                     `url:"workingStrategyType,omitempty"` // Should be more than 1000000
+	PendingSide               OrderSide               `url:"pendingSide"`
+	PendingQuantity           string                  `url:"pendingQuantity"`
+	PendingAboveType          OrderType               `url:"pendingAboveType"` // STOP_LOSS_LIMIT, STOP_LOSS, LIMIT_MAKER, TAKE_PROFIT or TAKE_PROFIT_LIMIT
+	PendingAboveClientOrderID string                  `url:"pendingAboveClientOrderId,omitempty"`
+	PendingAbovePrice         string                  `url:"pendingAbovePrice,omitempty"`
+	PendingAboveStopPrice     string                  `url:"pendingAboveStopPrice,omitempty"`
+	PendingAboveTrailingDelta int64                   `url:"pendingAboveTrailingDelta,omitempty"`
+	PendingAboveIcebergQty    string                  `url:"pendingAboveIcebergQty,omitempty"`
+	PendingAboveTimeInForce   TimeInForce             `url:"pendingAboveTimeInForce,omitempty"`
+	PendingAboveStrategyID    int                     `url:"pendingAboveStrategyId,omitempty"`
+	PendingAboveStrategyType  int                     `url:"pendingAboveStrategyType,omitempty"` // Should be more than 1000000
+	PendingBelowType          OrderType               `url:"pendingBelowType,omitempty"`         // STOP_LOSS, STOP_LOSS_LIMIT, TAKE_PROFIT or TAKE_PROFIT_LIMIT
+	PendingBelowClientOrderID string                  `url:"pendingBelowClientOrderId,omitempty"`
+	PendingBelowPrice         string                  `url:"pendingBelowPrice,omitempty"`
+	PendingBelowStopPrice     string                  `url:"pendingBelowStopPrice,omitempty"`
+	PendingBelowTrailingDelta int64                   `url:"pendingBelowTrailingDelta,omitempty"`
+	PendingBelowIcebergQty    string                  `url:"pendingBelowIcebergQty,omitempty"`
+	PendingBelowTimeInForce   TimeInForce             `url:"pendingBelowTimeInForce,omitempty"`
+	PendingBelowStrategyID    int                     `url:"pendingBelowStrategyId,omitempty"`
+	PendingBelowStrategyType  int                     `url:"pendingBelowStrategyType,omitempty"` // Should be more than 1000000
+}
+
+// OrderList represents any order list: OCO, OTO or OTOCO
+type OrderList struct {
 	Symbol            string               `json:"symbol"`
 	OrderListID       int64                `json:"orderListId"`
 	ContingencyType   ContingencyType      `json:"contingencyType"`
@@ -713,6 +965,9 @@ type OCOOrder struct {
 	OrderReports      []OrderRespFull      `json:"orderReports"`
 }
 
+// OCOOrder is kept for compatibility, OCO lists share the OrderList model
+type OCOOrder = OrderList
+
 type OCOOrderStatusResp struct {
 	Symbol        string `json:"symbol"`
 	OrderID       int    `json:"orderId"`
@@ -763,16 +1018,51 @@ const (
 )
 
 type AccountInfo struct {
-	MakerCommission  int              `json:"makerCommission"`
-	TakerCommission  int              `json:"takerCommission"`
-	BuyerCommission  int              `json:"buyerCommission"`
-	SellerCommission int              `json:"sellerCommission"`
-	CanTrade         bool             `json:"canTrade"`
-	CanWithdraw      bool             `json:"canWithdraw"`
-	CanDeposit       bool             `json:"canDeposit"`
-	AccountType      PermissionType   `json:"accountType"`
-	Balances         []*Balance       `json:"balances"`
-	Permissions      []PermissionType `json:"permissions"`
+	MakerCommission            int              `json:"makerCommission"` // MakerCommission in basis points
+	TakerCommission            int              `json:"takerCommission"` // TakerCommission in basis points
+	BuyerCommission            int              `json:"buyerCommission"`
+	SellerCommission           int              `json:"sellerCommission"`
+	CommissionRates            CommissionRates  `json:"commissionRates"`
+	CanTrade                   bool             `json:"canTrade"`
+	CanWithdraw                bool             `json:"canWithdraw"`
+	CanDeposit                 bool             `json:"canDeposit"`
+	Brokered                   bool             `json:"brokered"`
+	RequireSelfTradePrevention bool             `json:"requireSelfTradePrevention"`
+	PreventSor                 bool             `json:"preventSor"`
+	UpdateTime                 int64            `json:"updateTime"`
+	AccountType                PermissionType   `json:"accountType"`
+	Balances                   []*Balance       `json:"balances"`
+	Permissions                []PermissionType `json:"permissions"`
+	UID                        int64            `json:"uid"`
+}
+
+// CommissionRates represents commission rates as fractions, e.g. 0.001 is 0.1%
+type CommissionRates struct {
+	Maker  string `json:"maker"`
+	Taker  string `json:"taker"`
+	Buyer  string `json:"buyer"`
+	Seller string `json:"seller"`
+}
+
+type AccountCommissionReq struct {
+	Symbol string `url:"symbol"`
+}
+
+type CommissionDiscount struct {
+	EnabledForAccount bool   `json:"enabledForAccount"`
+	EnabledForSymbol  bool   `json:"enabledForSymbol"`
+	DiscountAsset     string `json:"discountAsset"`
+	Discount          string `json:"discount"` // Discount is the multiplier of the standard commission paid in DiscountAsset
+}
+
+// AccountCommission represents current account commission rates for the symbol.
+// Remark: the discount applies only to the standard commission
+type AccountCommission struct {
+	Symbol             string             `json:"symbol"`
+	StandardCommission CommissionRates    `json:"standardCommission"`
+	SpecialCommission  CommissionRates    `json:"specialCommission"`
+	TaxCommission      CommissionRates    `json:"taxCommission"`
+	Discount           CommissionDiscount `json:"discount"`
 }
 
 const MaxAccountTradesLimit = 500
@@ -816,7 +1106,7 @@ type MyPreventedMatchesReq struct {
 
 type AggregatedTradeReq struct {
 	Symbol    string `url:"symbol"`              // Symbol is the symbol to fetch data for
-	FromID    int64  `url:"fromId"`              // FromID to get aggregate trades from INCLUSIVE.
+	FromID    int64  `url:"fromId,omitempty"`    // FromID to get aggregate trades from INCLUSIVE.
 	StartTime int64  `url:"startTime,omitempty"` // StartTime timestamp in ms to get aggregate trades from INCLUSIVE.
 	EndTime   int64  `url:"endTime,omitempty"`   // EndTime timestamp in ms to get aggregate trades until INCLUSIVE.
 	Limit     int    `url:"limit,omitempty"`     // Limit is the maximal number of elements to receive. Default 500; Max 1000
@@ -845,6 +1135,13 @@ type ExchangeInfo struct {
 	RateLimits      []*RateLimit      `json:"rateLimits"`
 	ExchangeFilters []*ExchangeFilter `json:"exchangeFilters"`
 	Symbols         []*SymbolInfo     `json:"symbols"`
+	SORs            []*SOR            `json:"sors,omitempty"`
+}
+
+// SOR describes the symbols of the base asset eligible for smart order routing
+type SOR struct {
+	BaseAsset string   `json:"baseAsset"`
+	Symbols   []string `json:"symbols"`
 }
 
 type RateLimitType string
@@ -949,6 +1246,8 @@ const (
 	FilterTypeMaxNumAlgoOrders    FilterType = "MAX_NUM_ALGO_ORDERS"
 	FilterTypeMaxNumIcebergOrders FilterType = "MAX_NUM_ICEBERG_ORDERS"
 	FilterTypeMaxPosition         FilterType = "MAX_POSITION"
+	FilterTypeNotional            FilterType = "NOTIONAL"
+	FilterTypeTrailingDelta       FilterType = "TRAILING_DELTA"
 )
 
 type SymbolInfoFilter struct {
@@ -969,10 +1268,15 @@ type SymbolInfoFilter struct {
 	MaxQty   string `json:"maxQty"`
 	StepSize string `json:"stepSize"`
 
-	// MIN_NOTIONAL parameter
+	// MIN_NOTIONAL or NOTIONAL parameter
 	MinNotional   string `json:"minNotional"`
 	ApplyToMarket bool   `json:"applyToMarket"`
 
+	// NOTIONAL parameter
+	MaxNotional      string `json:"maxNotional"`
+	ApplyMinToMarket bool   `json:"applyMinToMarket"`
+	ApplyMaxToMarket bool   `json:"applyMaxToMarket"`
+
 	// ICEBERG_PARTS parameter
 	IcebergLimit int `json:"limit"`
 
@@ -1015,6 +1319,7 @@ type ContingencyType string
 
 const (
 	ContingencyTypeOCO ContingencyType = "OCO"
+	ContingencyTypeOTO ContingencyType = "OTO"
 )
 
 type SelfTradePreventionMode string
@@ -1025,3 +1330,862 @@ const (
 	SelfTradePreventionModeExpireMaker SelfTradePreventionMode = "EXPIRE_MAKER"
 	SelfTradePreventionModeExpireBoth  SelfTradePreventionMode = "EXPIRE_BOTH"
 )
+
+type CoinInfo struct {
+	Coin              string         `json:"coin"`
+	Name              string         `json:"name"`
+	DepositAllEnable  bool           `json:"depositAllEnable"`
+	WithdrawAllEnable bool           `json:"withdrawAllEnable"`
+	Free              string         `json:"free"`
+	Freeze            string         `json:"freeze"`
+	Ipoable           string         `json:"ipoable"`
+	Ipoing            string         `json:"ipoing"`
+	IsLegalMoney      bool           `json:"isLegalMoney"`
+	Locked            string         `json:"locked"`
+	Storage           string         `json:"storage"`
+	Trading           bool           `json:"trading"`
+	Withdrawing       string         `json:"withdrawing"`
+	NetworkList       []*CoinNetwork `json:"networkList"`
+}
+
+type CoinNetwork struct {
+	Network                 string `json:"network"`
+	Coin                    string `json:"coin"`
+	Name                    string `json:"name"`
+	IsDefault               bool   `json:"isDefault"`
+	AddressRegex            string `json:"addressRegex"`
+	MemoRegex               string `json:"memoRegex"`
+	DepositEnable           bool   `json:"depositEnable"`
+	DepositDesc             string `json:"depositDesc"` // DepositDesc is shown only when the deposit is disabled
+	WithdrawEnable          bool   `json:"withdrawEnable"`
+	WithdrawDesc            string `json:"withdrawDesc"` // WithdrawDesc is shown only when the withdrawal is disabled
+	WithdrawFee             string `json:"withdrawFee"`
+	WithdrawMin             string `json:"withdrawMin"`
+	WithdrawMax             string `json:"withdrawMax"`
+	WithdrawIntegerMultiple string `json:"withdrawIntegerMultiple"`
+	MinConfirm              int    `json:"minConfirm"`    // MinConfirm is the number of confirmations to credit the balance
+	UnLockConfirm           int    `json:"unLockConfirm"` // UnLockConfirm is the number of confirmations to unlock the withdrawal
+	SpecialTips             string `json:"specialTips"`
+	SameAddress             bool   `json:"sameAddress"` // SameAddress means the memo is required
+	EstimatedArrivalTime    int64  `json:"estimatedArrivalTime"`
+	Busy                    bool   `json:"busy"`
+	ContractAddress         string `json:"contractAddress"`
+	ContractAddressURL      string `json:"contractAddressUrl"`
+}
+
+type DepositAddressReq struct {
+	Coin    string `url:"coin"`
+	Network string `url:"network,omitempty"` // Network is the default network of the coin when not set
+	Amount  string `url:"amount,omitempty"`  // Amount is required by the lightning network
+}
+
+type DepositAddress struct {
+	Address string `json:"address"`
+	Coin    string `json:"coin"`
+	Tag     string `json:"tag"`
+	URL     string `json:"url"`
+}
+
+type DepositStatus int
+
+const (
+	DepositStatusPending          DepositStatus = 0
+	DepositStatusSuccess          DepositStatus = 1
+	DepositStatusRejected         DepositStatus = 2
+	DepositStatusCreditedLocked   DepositStatus = 6 // DepositStatusCreditedLocked is credited but can't be withdrawn yet
+	DepositStatusWrong            DepositStatus = 7
+	DepositStatusWaitingUserCheck DepositStatus = 8
+)
+
+const (
+	DefaultWalletHistoryLimit = 1000
+	MaxWalletHistoryLimit     = 1000
+)
+
+// DepositHistoryReq represents the request for the deposits history.
+// Remark: the time span is at most 90 days, the last 90 days are returned when the time is not set
+type DepositHistoryReq struct {
+	Coin          string         `url:"coin,omitempty"`
+	Status        *DepositStatus `url:"status,omitempty"`
+	StartTime     int64          `url:"startTime,omitempty"`
+	EndTime       int64          `url:"endTime,omitempty"`
+	Offset        int            `url:"offset,omitempty"`
+	Limit         int            `url:"limit,omitempty"` // Limit is the maximal number of elements to receive. Default 1000; Max 1000
+	TxID          string         `url:"txId,omitempty"`
+	IncludeSource bool           `url:"includeSource,omitempty"` // IncludeSource adds the source address of the deposit
+}
+
+type Deposit struct {
+	ID            string        `json:"id"`
+	Amount        string        `json:"amount"`
+	Coin          string        `json:"coin"`
+	Network       string        `json:"network"`
+	Status        DepositStatus `json:"status"`
+	Address       string        `json:"address"`
+	AddressTag    string        `json:"addressTag"`
+	TxID          string        `json:"txId"`
+	InsertTime    int64         `json:"insertTime"`
+	CompleteTime  int64         `json:"completeTime"`
+	TransferType  int           `json:"transferType"` // TransferType is 1 for the internal transfer and 0 for the external one
+	ConfirmTimes  string        `json:"confirmTimes"`
+	UnlockConfirm int           `json:"unlockConfirm"`
+	WalletType    int           `json:"walletType"`
+	SourceAddress string        `json:"sourceAddress"`
+}
+
+// WalletType is the wallet the withdrawal is paid from
+type WalletType int
+
+const (
+	WalletTypeSpot    WalletType = 0
+	WalletTypeFunding WalletType = 1
+)
+
+type WithdrawReq struct {
+	Coin               string     `url:"coin"`
+	WithdrawOrderID    string     `url:"withdrawOrderId,omitempty"` // WithdrawOrderID is the client id of the withdrawal
+	Network            string     `url:"network,omitempty"`
+	Address            string     `url:"address"`
+	AddressTag         string     `url:"addressTag,omitempty"`
+	Amount             string     `url:"amount"`
+	TransactionFeeFlag bool       `url:"transactionFeeFlag,omitempty"` // TransactionFeeFlag charges the fee of the internal transfer to the receiver
+	Name               string     `url:"name,omitempty"`               // Name is the description of the address
+	WalletType         WalletType `url:"walletType,omitempty"`
+}
+
+type Withdraw struct {
+	ID string `json:"id"`
+}
+
+type WithdrawStatus int
+
+const (
+	WithdrawStatusEmailSent        WithdrawStatus = 0
+	WithdrawStatusCancelled        WithdrawStatus = 1
+	WithdrawStatusAwaitingApproval WithdrawStatus = 2
+	WithdrawStatusRejected         WithdrawStatus = 3
+	WithdrawStatusProcessing       WithdrawStatus = 4
+	WithdrawStatusFailure          WithdrawStatus = 5
+	WithdrawStatusCompleted        WithdrawStatus = 6
+)
+
+// WithdrawHistoryReq represents the request for the withdrawals history.
+// Remark: the time span is at most 90 days, the last 90 days are returned when the time is not set
+type WithdrawHistoryReq struct {
+	Coin            string          `url:"coin,omitempty"`
+	WithdrawOrderID string          `url:"withdrawOrderId,omitempty"`
+	Status          *WithdrawStatus `url:"status,omitempty"`
+	Offset          int             `url:"offset,omitempty"`
+	Limit           int             `url:"limit,omitempty"`  // Limit is the maximal number of elements to receive. Default 1000; Max 1000
+	IDList          string          `url:"idList,omitempty"` // IDList is the comma separated list of up to 45 withdrawal ids
+	StartTime       int64           `url:"startTime,omitempty"`
+	EndTime         int64           `url:"endTime,omitempty"`
+}
+
+type WithdrawRecord struct {
+	ID              string         `json:"id"`
+	Amount          string         `json:"amount"`
+	TransactionFee  string         `json:"transactionFee"`
+	Coin            string         `json:"coin"`
+	Status          WithdrawStatus `json:"status"`
+	Address         string         `json:"address"`
+	TxID            string         `json:"txId"`
+	ApplyTime       string         `json:"applyTime"` // ApplyTime is in the UTC "2006-01-02 15:04:05" format
+	Network         string         `json:"network"`
+	TransferType    int            `json:"transferType"` // TransferType is 1 for the internal transfer and 0 for the external one
+	WithdrawOrderID string         `json:"withdrawOrderId"`
+	Info            string         `json:"info"` // Info is the reason of the failure
+	ConfirmNo       int            `json:"confirmNo"`
+	WalletType      WalletType     `json:"walletType"`
+	TxKey           string         `json:"txKey"`
+	CompleteTime    string         `json:"completeTime"`
+}
+
+// TransferType is the direction of the universal transfer between the wallets
+type TransferType string
+
+const (
+	TransferTypeMainUMFuture                 TransferType = "MAIN_UMFUTURE"
+	TransferTypeMainCMFuture                 TransferType = "MAIN_CMFUTURE"
+	TransferTypeMainMargin                   TransferType = "MAIN_MARGIN"
+	TransferTypeMainFunding                  TransferType = "MAIN_FUNDING"
+	TransferTypeMainOption                   TransferType = "MAIN_OPTION"
+	TransferTypeUMFutureMain                 TransferType = "UMFUTURE_MAIN"
+	TransferTypeUMFutureMargin               TransferType = "UMFUTURE_MARGIN"
+	TransferTypeUMFutureFunding              TransferType = "UMFUTURE_FUNDING"
+	TransferTypeCMFutureMain                 TransferType = "CMFUTURE_MAIN"
+	TransferTypeCMFutureMargin               TransferType = "CMFUTURE_MARGIN"
+	TransferTypeCMFutureFunding              TransferType = "CMFUTURE_FUNDING"
+	TransferTypeMarginMain                   TransferType = "MARGIN_MAIN"
+	TransferTypeMarginUMFuture               TransferType = "MARGIN_UMFUTURE"
+	TransferTypeMarginCMFuture               TransferType = "MARGIN_CMFUTURE"
+	TransferTypeMarginIsolatedMargin         TransferType = "MARGIN_ISOLATEDMARGIN"
+	TransferTypeMarginFunding                TransferType = "MARGIN_FUNDING"
+	TransferTypeIsolatedMarginMargin         TransferType = "ISOLATEDMARGIN_MARGIN"
+	TransferTypeIsolatedMarginIsolatedMargin TransferType = "ISOLATEDMARGIN_ISOLATEDMARGIN"
+	TransferTypeFundingMain                  TransferType = "FUNDING_MAIN"
+	TransferTypeFundingUMFuture              TransferType = "FUNDING_UMFUTURE"
+	TransferTypeFundingCMFuture              TransferType = "FUNDING_CMFUTURE"
+	TransferTypeFundingMargin                TransferType = "FUNDING_MARGIN"
+	TransferTypeOptionMain                   TransferType = "OPTION_MAIN"
+)
+
+type UniversalTransferReq struct {
+	Type       TransferType `url:"type"`
+	Asset      string       `url:"asset"`
+	Amount     string       `url:"amount"`
+	FromSymbol string       `url:"fromSymbol,omitempty"` // FromSymbol is required when transferring from the isolated margin
+	ToSymbol   string       `url:"toSymbol,omitempty"`   // ToSymbol is required when transferring to the isolated margin
+}
+
+type UniversalTransfer struct {
+	TranID int64 `json:"tranId"`
+}
+
+const (
+	DefaultTransferHistorySize = 10
+	MaxTransferHistorySize     = 100
+)
+
+type UniversalTransferHistoryReq struct {
+	Type       TransferType `url:"type"`
+	StartTime  int64        `url:"startTime,omitempty"`
+	EndTime    int64        `url:"endTime,omitempty"`
+	Current    int          `url:"current,omitempty"` // Current is the page number starting from 1
+	Size       int          `url:"size,omitempty"`    // Size is the page size. Default 10; Max 100
+	FromSymbol string       `url:"fromSymbol,omitempty"`
+	ToSymbol   string       `url:"toSymbol,omitempty"`
+}
+
+type UniversalTransferHistory struct {
+	Total int                        `json:"total"`
+	Rows  []*UniversalTransferRecord `json:"rows"`
+}
+
+type UniversalTransferRecord struct {
+	Asset     string       `json:"asset"`
+	Amount    string       `json:"amount"`
+	Type      TransferType `json:"type"`
+	Status    string       `json:"status"` // Status is PENDING, CONFIRMED or FAILED
+	TranID    int64        `json:"tranId"`
+	Timestamp int64        `json:"timestamp"`
+}
+
+const (
+	DefaultAssetDividendLimit = 20
+	MaxAssetDividendLimit     = 500
+)
+
+type AssetDividendReq struct {
+	Asset     string `url:"asset,omitempty"`
+	StartTime int64  `url:"startTime,omitempty"`
+	EndTime   int64  `url:"endTime,omitempty"`
+	Limit     int    `url:"limit,omitempty"` // Limit is the maximal number of elements to receive. Default 20; Max 500
+}
+
+type AssetDividends struct {
+	Total int              `json:"total"`
+	Rows  []*AssetDividend `json:"rows"`
+}
+
+type AssetDividend struct {
+	ID      int64  `json:"id"`
+	Amount  string `json:"amount"`
+	Asset   string `json:"asset"`
+	DivTime int64  `json:"divTime"`
+	EnInfo  string `json:"enInfo"` // EnInfo is the name of the distribution, e.g. BNB vault
+	TranID  int64  `json:"tranId"`
+}
+
+// DustAssets represents the assets which can be converted to BNB
+type DustAssets struct {
+	Details            []*DustAsset `json:"details"`
+	TotalTransferBtc   string       `json:"totalTransferBtc"`
+	TotalTransferBNB   string       `json:"totalTransferBNB"`
+	DribbletPercentage string       `json:"dribbletPercentage"` // DribbletPercentage is the commission of the conversion
+}
+
+type DustAsset struct {
+	Asset            string `json:"asset"`
+	AssetFullName    string `json:"assetFullName"`
+	AmountFree       string `json:"amountFree"`
+	ToBTC            string `json:"toBTC"`
+	ToBNB            string `json:"toBNB"`
+	ToBNBOffExchange string `json:"toBNBOffExchange"`
+	Exchange         string `json:"exchange"`
+}
+
+type DustReq struct {
+	Assets []string `url:"asset"`
+}
+
+type Dust struct {
+	TotalServiceCharge string                `json:"totalServiceCharge"`
+	TotalTransfered    string                `json:"totalTransfered"`
+	TransferResult     []*DustTransferResult `json:"transferResult"`
+}
+
+type DustTransferResult struct {
+	Amount              string `json:"amount"`
+	FromAsset           string `json:"fromAsset"`
+	OperateTime         int64  `json:"operateTime"`
+	ServiceChargeAmount string `json:"serviceChargeAmount"`
+	TranID              int64  `json:"tranId"`
+	TransferedAmount    string `json:"transferedAmount"`
+}
+
+type AssetDetailReq struct {
+	Asset string `url:"asset,omitempty"`
+}
+
+type AssetDetail struct {
+	MinWithdrawAmount string          `json:"minWithdrawAmount"`
+	DepositStatus     bool            `json:"depositStatus"`
+	WithdrawFee       decimal.Decimal `json:"withdrawFee"` // WithdrawFee is sent as the number
+	WithdrawStatus    bool            `json:"withdrawStatus"`
+	DepositTip        string          `json:"depositTip"` // DepositTip is the reason of the disabled deposit
+}
+
+const (
+	DefaultSubAccountsLimit = 1
+	MaxSubAccountsLimit     = 200
+)
+
+type SubAccountsReq struct {
+	Email    string `url:"email,omitempty"`
+	IsFreeze bool   `url:"isFreeze,omitempty"`
+	Page     int    `url:"page,omitempty"`  // Page is the page number starting from 1
+	Limit    int    `url:"limit,omitempty"` // Limit is the maximal number of elements to receive. Default 1; Max 200
+}
+
+type SubAccount struct {
+	Email                       string `json:"email"`
+	IsFreeze                    bool   `json:"isFreeze"`
+	CreateTime                  int64  `json:"createTime"`
+	IsManagedSubAccount         bool   `json:"isManagedSubAccount"`
+	IsAssetManagementSubAccount bool   `json:"isAssetManagementSubAccount"`
+}
+
+type CreateSubAccountReq struct {
+	SubAccountString string `url:"subAccountString"` // SubAccountString is the prefix of the created virtual email
+}
+
+type CreatedSubAccount struct {
+	Email string `json:"email"`
+}
+
+type SubAccountAssetsReq struct {
+	Email string `url:"email"`
+}
+
+// SubAccountBalance is the spot balance of the sub-account, the amounts are sent as numbers
+type SubAccountBalance struct {
+	Asset       string          `json:"asset"`
+	Free        decimal.Decimal `json:"free"`
+	Locked      decimal.Decimal `json:"locked"`
+	Freeze      decimal.Decimal `json:"freeze"`
+	Withdrawing decimal.Decimal `json:"withdrawing"`
+}
+
+const (
+	DefaultSubAccountSummarySize = 10
+	MaxSubAccountSummarySize     = 20
+)
+
+type SubAccountSpotSummaryReq struct {
+	Email string `url:"email,omitempty"`
+	Page  int    `url:"page,omitempty"` // Page is the page number starting from 1
+	Size  int    `url:"size,omitempty"` // Size is the page size. Default 10; Max 20
+}
+
+// SubAccountSpotSummary is the BTC valuation of the spot wallets of the sub-accounts
+type SubAccountSpotSummary struct {
+	TotalCount              int                    `json:"totalCount"`
+	MasterAccountTotalAsset string                 `json:"masterAccountTotalAsset"`
+	SubAccounts             []*SubAccountSpotAsset `json:"spotSubUserAssetBtcVoList"`
+}
+
+type SubAccountSpotAsset struct {
+	Email      string `json:"email"`
+	TotalAsset string `json:"totalAsset"`
+}
+
+// SubAccountWallet is the account type of the transfer between the master account and the sub-accounts
+type SubAccountWallet string
+
+const (
+	SubAccountWalletSpot           SubAccountWallet = "SPOT"
+	SubAccountWalletUSDTFuture     SubAccountWallet = "USDT_FUTURE"
+	SubAccountWalletCoinFuture     SubAccountWallet = "COIN_FUTURE"
+	SubAccountWalletMargin         SubAccountWallet = "MARGIN"
+	SubAccountWalletIsolatedMargin SubAccountWallet = "ISOLATED_MARGIN"
+)
+
+// SubAccountTransferReq transfers the asset between the master account and the sub-accounts.
+// The master account is used when the email is not set
+type SubAccountTransferReq struct {
+	FromEmail       string           `url:"fromEmail,omitempty"`
+	ToEmail         string           `url:"toEmail,omitempty"`
+	FromAccountType SubAccountWallet `url:"fromAccountType"`
+	ToAccountType   SubAccountWallet `url:"toAccountType"`
+	ClientTranID    string           `url:"clientTranId,omitempty"`
+	Symbol          string           `url:"symbol,omitempty"` // Symbol is required by the isolated margin
+	Asset           string           `url:"asset"`
+	Amount          string           `url:"amount"`
+}
+
+type SubAccountTransfer struct {
+	TranID       int64  `json:"tranId"`
+	ClientTranID string `json:"clientTranId"`
+}
+
+type SubAccountTransferHistoryReq struct {
+	FromEmail    string `url:"fromEmail,omitempty"`
+	ToEmail      string `url:"toEmail,omitempty"`
+	ClientTranID string `url:"clientTranId,omitempty"`
+	StartTime    int64  `url:"startTime,omitempty"`
+	EndTime      int64  `url:"endTime,omitempty"`
+	Page         int    `url:"page,omitempty"`  // Page is the page number starting from 1
+	Limit        int    `url:"limit,omitempty"` // Limit is the maximal number of elements to receive. Default 500; Max 500
+}
+
+type SubAccountTransferHistory struct {
+	Result     []*SubAccountTransferRecord `json:"result"`
+	TotalCount int                         `json:"totalCount"`
+}
+
+type SubAccountTransferRecord struct {
+	TranID          int64            `json:"tranId"`
+	FromEmail       string           `json:"fromEmail"`
+	ToEmail         string           `json:"toEmail"`
+	Asset           string           `json:"asset"`
+	Amount          string           `json:"amount"`
+	CreateTimeStamp int64            `json:"createTimeStamp"`
+	FromAccountType SubAccountWallet `json:"fromAccountType"`
+	ToAccountType   SubAccountWallet `json:"toAccountType"`
+	Status          string           `json:"status"`
+	ClientTranID    string           `json:"clientTranId"`
+}
+
+type SubAccountAPIKeyReq struct {
+	Email            string `url:"email"`
+	SubAccountAPIKey string `url:"subAccountApiKey"`
+}
+
+type IPRestrictionStatus string
+
+const (
+	IPRestrictionStatusUnrestricted IPRestrictionStatus = "1"
+	IPRestrictionStatusRestricted   IPRestrictionStatus = "2" // IPRestrictionStatusRestricted allows only the trusted ips
+)
+
+type SubAccountIPRestrictionReq struct {
+	Email            string              `url:"email"`
+	SubAccountAPIKey string              `url:"subAccountApiKey"`
+	Status           IPRestrictionStatus `url:"status,omitempty"`    // Status is used only to set the restriction
+	IPAddress        string              `url:"ipAddress,omitempty"` // IPAddress is the comma separated list of the ips
+}
+
+type SubAccountIPRestriction struct {
+	IPRestrict string   `json:"ipRestrict"` // IPRestrict is "true" when only the trusted ips are allowed
+	IPList     []string `json:"ipList"`
+	UpdateTime int64    `json:"updateTime"`
+	APIKey     string   `json:"apiKey"`
+}
+
+// SubAccountKey is the api key of the sub-account
+type SubAccountKey struct {
+	APIKey    string
+	APISecret string
+}
+
+const (
+	DefaultEarnPageSize = 10
+	MaxEarnPageSize     = 100
+)
+
+type EarnProductsReq struct {
+	Asset   string `url:"asset,omitempty"`
+	Current int    `url:"current,omitempty"` // Current is the page number starting from 1
+	Size    int    `url:"size,omitempty"`    // Size is the page size. Default 10; Max 100
+}
+
+type FlexibleProducts struct {
+	Rows  []*FlexibleProduct `json:"rows"`
+	Total int                `json:"total"`
+}
+
+type FlexibleProduct struct {
+	ProductID                  string            `json:"productId"`
+	Asset                      string            `json:"asset"`
+	LatestAnnualPercentageRate string            `json:"latestAnnualPercentageRate"`
+	TierAnnualPercentageRate   map[string]string `json:"tierAnnualPercentageRate"` // TierAnnualPercentageRate is the bonus rate of the amount range, e.g. "0-5BTC"
+	AirDropPercentageRate      string            `json:"airDropPercentageRate"`
+	CanPurchase                bool              `json:"canPurchase"`
+	CanRedeem                  bool              `json:"canRedeem"`
+	IsSoldOut                  bool              `json:"isSoldOut"`
+	Hot                        bool              `json:"hot"`
+	MinPurchaseAmount          string            `json:"minPurchaseAmount"`
+	SubscriptionStartTime      int64             `json:"subscriptionStartTime"`
+	Status                     string            `json:"status"` // Status is PREHEATING, PURCHASING or END
+}
+
+type LockedProducts struct {
+	Rows  []*LockedProduct `json:"rows"`
+	Total int              `json:"total"`
+}
+
+type LockedProduct struct {
+	ProjectID string              `json:"projectId"`
+	Detail    LockedProductDetail `json:"detail"`
+	Quota     LockedProductQuota  `json:"quota"`
+}
+
+type LockedProductDetail struct {
+	Asset                 string `json:"asset"`
+	RewardAsset           string `json:"rewardAsset"`
+	Duration              int    `json:"duration"` // Duration is the lock period in days
+	Renewable             bool   `json:"renewable"`
+	IsSoldOut             bool   `json:"isSoldOut"`
+	APR                   string `json:"apr"`
+	Status                string `json:"status"`
+	SubscriptionStartTime int64  `json:"subscriptionStartTime"`
+	ExtraRewardAsset      string `json:"extraRewardAsset"`
+	ExtraRewardAPR        string `json:"extraRewardAPR"`
+}
+
+type LockedProductQuota struct {
+	TotalPersonalQuota string `json:"totalPersonalQuota"`
+	Minimum            string `json:"minimum"`
+}
+
+// EarnAccount is the wallet the subscription is paid from or the redemption is paid to
+type EarnAccount string
+
+const (
+	EarnAccountSpot EarnAccount = "SPOT"
+	EarnAccountFund EarnAccount = "FUND"
+	EarnAccountAll  EarnAccount = "ALL" // EarnAccountAll uses the spot wallet first and the funding wallet for the rest
+)
+
+type FlexibleSubscribeReq struct {
+	ProductID     string      `url:"productId"`
+	Amount        string      `url:"amount"`
+	AutoSubscribe *bool       `url:"autoSubscribe,omitempty"` // AutoSubscribe is enabled by default
+	SourceAccount EarnAccount `url:"sourceAccount,omitempty"`
+}
+
+type LockedSubscribeReq struct {
+	ProjectID     string      `url:"projectId"`
+	Amount        string      `url:"amount"`
+	AutoSubscribe *bool       `url:"autoSubscribe,omitempty"` // AutoSubscribe is enabled by default
+	SourceAccount EarnAccount `url:"sourceAccount,omitempty"`
+	RedeemTo      EarnAccount `url:"redeemTo,omitempty"`
+}
+
+type EarnSubscription struct {
+	PurchaseID int64  `json:"purchaseId"`
+	PositionID string `json:"positionId"` // PositionID is set for the locked products
+	Success    bool   `json:"success"`
+}
+
+// Remark: Either Amount or RedeemAll must be set
+type FlexibleRedeemReq struct {
+	ProductID   string      `url:"productId"`
+	RedeemAll   bool        `url:"redeemAll,omitempty"`
+	Amount      string      `url:"amount,omitempty"`
+	DestAccount EarnAccount `url:"destAccount,omitempty"`
+}
+
+type LockedRedeemReq struct {
+	PositionID string `url:"positionId"`
+}
+
+type EarnRedemption struct {
+	RedeemID int64 `json:"redeemId"`
+	Success  bool  `json:"success"`
+}
+
+type FlexiblePositionsReq struct {
+	Asset     string `url:"asset,omitempty"`
+	ProductID string `url:"productId,omitempty"`
+	Current   int    `url:"current,omitempty"` // Current is the page number starting from 1
+	Size      int    `url:"size,omitempty"`    // Size is the page size. Default 10; Max 100
+}
+
+type FlexiblePositions struct {
+	Rows  []*FlexiblePosition `json:"rows"`
+	Total int                 `json:"total"`
+}
+
+type FlexiblePosition struct {
+	ProductID                      string            `json:"productId"`
+	Asset                          string            `json:"asset"`
+	TotalAmount                    string            `json:"totalAmount"`
+	TierAnnualPercentageRate       map[string]string `json:"tierAnnualPercentageRate"`
+	LatestAnnualPercentageRate     string            `json:"latestAnnualPercentageRate"`
+	YesterdayAirdropPercentageRate string            `json:"yesterdayAirdropPercentageRate"`
+	AirDropAsset                   string            `json:"airDropAsset"`
+	CanRedeem                      bool              `json:"canRedeem"` // CanRedeem means the position can be redeemed instantly
+	CollateralAmount               string            `json:"collateralAmount"`
+	YesterdayRealTimeRewards       string            `json:"yesterdayRealTimeRewards"`
+	CumulativeBonusRewards         string            `json:"cumulativeBonusRewards"`
+	CumulativeRealTimeRewards      string            `json:"cumulativeRealTimeRewards"`
+	CumulativeTotalRewards         string            `json:"cumulativeTotalRewards"`
+	AutoSubscribe                  bool              `json:"autoSubscribe"`
+}
+
+type LockedPositionsReq struct {
+	Asset      string `url:"asset,omitempty"`
+	PositionID string `url:"positionId,omitempty"`
+	ProjectID  string `url:"projectId,omitempty"`
+	Current    int    `url:"current,omitempty"` // Current is the page number starting from 1
+	Size       int    `url:"size,omitempty"`    // Size is the page size. Default 10; Max 100
+}
+
+type LockedPositions struct {
+	Rows  []*LockedPosition `json:"rows"`
+	Total int               `json:"total"`
+}
+
+type LockedPosition struct {
+	PositionID        int64  `json:"positionId"`
+	ParentPositionID  int64  `json:"parentPositionId"`
+	ProjectID         string `json:"projectId"`
+	Asset             string `json:"asset"`
+	Amount            string `json:"amount"`
+	PurchaseTime      string `json:"purchaseTime"`
+	Duration          string `json:"duration"`
+	AccrualDays       string `json:"accrualDays"`
+	RewardAsset       string `json:"rewardAsset"`
+	APY               string `json:"APY"`
+	RewardAmt         string `json:"rewardAmt"`
+	ExtraRewardAsset  string `json:"extraRewardAsset"`
+	ExtraRewardAPR    string `json:"extraRewardAPR"`
+	EstExtraRewardAmt string `json:"estExtraRewardAmt"`
+	NextPay           string `json:"nextPay"`
+	NextPayDate       string `json:"nextPayDate"`
+	PayPeriod         string `json:"payPeriod"`
+	RedeemAmountEarly string `json:"redeemAmountEarly"`
+	RewardsEndDate    string `json:"rewardsEndDate"`
+	DeliverDate       string `json:"deliverDate"`
+	RedeemPeriod      string `json:"redeemPeriod"`
+	RedeemingAmt      string `json:"redeemingAmt"`
+	RedeemTo          string `json:"redeemTo"`
+	CanRedeemEarly    bool   `json:"canRedeemEarly"`
+	CanFastRedemption bool   `json:"canFastRedemption"`
+	AutoSubscribe     bool   `json:"autoSubscribe"`
+	Type              string `json:"type"` // Type is AUTO or NORMAL
+	Status            string `json:"status"`
+	CanReStake        bool   `json:"canReStake"`
+}
+
+// EarnRewardType is the kind of the flexible product rewards
+type EarnRewardType string
+
+const (
+	EarnRewardTypeBonus    EarnRewardType = "BONUS"    // EarnRewardTypeBonus is the tiered bonus
+	EarnRewardTypeRealTime EarnRewardType = "REALTIME" // EarnRewardTypeRealTime is the real-time APR reward
+	EarnRewardTypeRewards  EarnRewardType = "REWARDS"  // EarnRewardTypeRewards is the historical reward
+	EarnRewardTypeAll      EarnRewardType = "ALL"
+)
+
+type FlexibleRewardsReq struct {
+	Type      EarnRewardType `url:"type"`
+	ProductID string         `url:"productId,omitempty"`
+	Asset     string         `url:"asset,omitempty"`
+	StartTime int64          `url:"startTime,omitempty"`
+	EndTime   int64          `url:"endTime,omitempty"`
+	Current   int            `url:"current,omitempty"` // Current is the page number starting from 1
+	Size      int            `url:"size,omitempty"`    // Size is the page size. Default 10; Max 100
+}
+
+type FlexibleRewards struct {
+	Rows  []*FlexibleReward `json:"rows"`
+	Total int               `json:"total"`
+}
+
+type FlexibleReward struct {
+	Asset     string         `json:"asset"`
+	Rewards   string         `json:"rewards"`
+	ProjectID string         `json:"projectId"`
+	Type      EarnRewardType `json:"type"`
+	Time      int64          `json:"time"`
+}
+
+type LockedRewardsReq struct {
+	PositionID string `url:"positionId,omitempty"`
+	Asset      string `url:"asset,omitempty"`
+	StartTime  int64  `url:"startTime,omitempty"`
+	EndTime    int64  `url:"endTime,omitempty"`
+	Current    int    `url:"current,omitempty"` // Current is the page number starting from 1
+	Size       int    `url:"size,omitempty"`    // Size is the page size. Default 10; Max 100
+}
+
+type LockedRewards struct {
+	Rows  []*LockedReward `json:"rows"`
+	Total int             `json:"total"`
+}
+
+type LockedReward struct {
+	PositionID string `json:"positionId"`
+	Time       int64  `json:"time"`
+	Asset      string `json:"asset"`
+	LockPeriod string `json:"lockPeriod"`
+	Amount     string `json:"amount"`
+	Type       string `json:"type"`
+}
+
+type FlexibleRateHistoryReq struct {
+	ProductID string `url:"productId"`
+	AprPeriod string `url:"aprPeriod,omitempty"` // AprPeriod is DAY or YEAR. Default DAY
+	StartTime int64  `url:"startTime,omitempty"`
+	EndTime   int64  `url:"endTime,omitempty"`
+	Current   int    `url:"current,omitempty"` // Current is the page number starting from 1
+	Size      int    `url:"size,omitempty"`    // Size is the page size. Default 10; Max 100
+}
+
+type FlexibleRateHistory struct {
+	Rows  []*FlexibleRate `json:"rows"`
+	Total int             `json:"total"`
+}
+
+type FlexibleRate struct {
+	ProductID            string `json:"productId"`
+	Asset                string `json:"asset"`
+	AnnualPercentageRate string `json:"annualPercentageRate"`
+	Time                 int64  `json:"time"`
+}
+
+type ConvertExchangeInfoReq struct {
+	FromAsset string `url:"fromAsset,omitempty"`
+	ToAsset   string `url:"toAsset,omitempty"`
+}
+
+type ConvertPair struct {
+	FromAsset          string `json:"fromAsset"`
+	ToAsset            string `json:"toAsset"`
+	FromAssetMinAmount string `json:"fromAssetMinAmount"`
+	FromAssetMaxAmount string `json:"fromAssetMaxAmount"`
+	ToAssetMinAmount   string `json:"toAssetMinAmount"`
+	ToAssetMaxAmount   string `json:"toAssetMaxAmount"`
+}
+
+// ConvertWallet is the wallet the converted asset is paid from
+type ConvertWallet string
+
+const (
+	ConvertWalletSpot        ConvertWallet = "SPOT"
+	ConvertWalletFunding     ConvertWallet = "FUNDING"
+	ConvertWalletSpotFunding ConvertWallet = "SPOT_FUNDING"
+)
+
+// ConvertValidTime is the time the quote can be accepted in
+type ConvertValidTime string
+
+const (
+	ConvertValidTime10s ConvertValidTime = "10s"
+	ConvertValidTime30s ConvertValidTime = "30s"
+	ConvertValidTime1m  ConvertValidTime = "1m"
+	ConvertValidTime2m  ConvertValidTime = "2m"
+)
+
+// ConvertQuoteReq represents the request for the quote of the conversion.
+// Remark: Either FromAmount or ToAmount must be set
+type ConvertQuoteReq struct {
+	FromAsset  string           `url:"fromAsset"`
+	ToAsset    string           `url:"toAsset"`
+	FromAmount string           `url:"fromAmount,omitempty"`
+	ToAmount   string           `url:"toAmount,omitempty"`
+	WalletType ConvertWallet    `url:"walletType,omitempty"`
+	ValidTime  ConvertValidTime `url:"validTime,omitempty"` // ValidTime is 10s by default
+}
+
+type ConvertQuote struct {
+	QuoteID        string `json:"quoteId"`
+	Ratio          string `json:"ratio"`
+	InverseRatio   string `json:"inverseRatio"`
+	ValidTimestamp int64  `json:"validTimestamp"` // ValidTimestamp is the time in ms the quote expires at
+	ToAmount       string `json:"toAmount"`
+	FromAmount     string `json:"fromAmount"`
+}
+
+// Expired checks whether the quote can't be accepted at the time
+func (q *ConvertQuote) Expired(now time.Time) bool {
+	return now.UnixMilli() >= q.ValidTimestamp
+}
+
+type ConvertAcceptReq struct {
+	QuoteID string `url:"quoteId"`
+}
+
+type ConvertOrderStatusType string
+
+const (
+	ConvertOrderStatusProcess       ConvertOrderStatusType = "PROCESS"
+	ConvertOrderStatusAcceptSuccess ConvertOrderStatusType = "ACCEPT_SUCCESS"
+	ConvertOrderStatusSuccess       ConvertOrderStatusType = "SUCCESS"
+	ConvertOrderStatusFail          ConvertOrderStatusType = "FAIL"
+)
+
+// Final checks whether the conversion is either succeeded or failed
+func (s ConvertOrderStatusType) Final() bool {
+	return s == ConvertOrderStatusSuccess || s == ConvertOrderStatusFail
+}
+
+type ConvertAccept struct {
+	OrderID     string                 `json:"orderId"`
+	CreateTime  int64                  `json:"createTime"`
+	OrderStatus ConvertOrderStatusType `json:"orderStatus"`
+}
+
+// Remark: Either OrderID or QuoteID must be set
+type ConvertOrderStatusReq struct {
+	OrderID string `url:"orderId,omitempty"`
+	QuoteID string `url:"quoteId,omitempty"`
+}
+
+type ConvertOrder struct {
+	QuoteID      string                 `json:"quoteId"`
+	OrderID      int64                  `json:"orderId"`
+	OrderStatus  ConvertOrderStatusType `json:"orderStatus"`
+	FromAsset    string                 `json:"fromAsset"`
+	FromAmount   string                 `json:"fromAmount"`
+	ToAsset      string                 `json:"toAsset"`
+	ToAmount     string                 `json:"toAmount"`
+	Ratio        string                 `json:"ratio"`
+	InverseRatio string                 `json:"inverseRatio"`
+	CreateTime   int64                  `json:"createTime"`
+}
+
+const (
+	DefaultConvertTradeFlowLimit = 100
+	MaxConvertTradeFlowLimit     = 1000
+)
+
+// ConvertTradeFlowReq represents the request for the conversions history.
+// Remark: the time span is at most 30 days
+type ConvertTradeFlowReq struct {
+	StartTime int64 `url:"startTime"`
+	EndTime   int64 `url:"endTime"`
+	Limit     int   `url:"limit,omitempty"` // Limit is the maximal number of elements to receive. Default 100; Max 1000
+}
+
+type ConvertTradeFlow struct {
+	List      []*ConvertOrder `json:"list"`
+	StartTime int64           `json:"startTime"`
+	EndTime   int64           `json:"endTime"`
+	Limit     int             `json:"limit"`
+	MoreData  bool            `json:"moreData"`
+}
+
+// BNBBurnReq toggles paying the spot fees and the margin interest with BNB, the unset options are not changed
+type BNBBurnReq struct {
+	SpotBNBBurn     *bool `url:"spotBNBBurn,omitempty"`
+	InterestBNBBurn *bool `url:"interestBNBBurn,omitempty"`
+}
+
+type BNBBurn struct {
+	SpotBNBBurn     bool `json:"spotBNBBurn"`
+	InterestBNBBurn bool `json:"interestBNBBurn"`
+}
@@ -0,0 +1,271 @@
+package binance
+
+import (
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+)
+
+// EnableWithdraw allows the client to send withdrawals, Withdraw fails with ErrWithdrawDisabled by default
+// so the key with the withdrawal permission can't move funds out by mistake
+func (c *Client) EnableWithdraw(enabled bool) *Client {
+	c.withdraw = enabled
+
+	return c
+}
+
+// Coins get the information of the coins and their deposit and withdrawal networks
+func (c *Client) Coins() ([]*CoinInfo, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointCoins, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*CoinInfo
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// DepositAddress get the deposit address of the coin on the network
+func (c *Client) DepositAddress(req *DepositAddressReq) (*DepositAddress, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.Coin == "" {
+		return nil, ErrEmptyCoin
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointDepositAddress, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &DepositAddress{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// DepositHistory get the page of the deposits history
+func (c *Client) DepositHistory(req *DepositHistoryReq) ([]*Deposit, error) {
+	if req == nil {
+		req = &DepositHistoryReq{}
+	}
+	if req.Limit <= 0 || req.Limit > MaxWalletHistoryLimit {
+		req.Limit = DefaultWalletHistoryLimit
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointDepositHistory, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*Deposit
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// AllDeposits get all deposits of the request time span page by page starting from the request offset
+func (c *Client) AllDeposits(req *DepositHistoryReq) ([]*Deposit, error) {
+	var r DepositHistoryReq
+	if req != nil {
+		r = *req
+	}
+	var resp []*Deposit
+	for {
+		page, err := c.DepositHistory(&r)
+		if err != nil {
+			return resp, err
+		}
+		resp = append(resp, page...)
+		if len(page) < r.Limit {
+			return resp, nil
+		}
+		r.Offset += len(page)
+	}
+}
+
+// Withdraw submits the withdrawal, the client must be allowed to withdraw with EnableWithdraw
+func (c *Client) Withdraw(req *WithdrawReq) (*Withdraw, error) {
+	if !c.withdraw {
+		return nil, ErrWithdrawDisabled
+	}
+	switch {
+	case req == nil:
+		return nil, ErrNilRequest
+	case req.Coin == "":
+		return nil, ErrEmptyCoin
+	case req.Address == "":
+		return nil, ErrEmptyAddress
+	case req.Amount == "":
+		return nil, ErrEmptyAmount
+	}
+	res, err := c.Do(fasthttp.MethodPost, EndpointWithdraw, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &Withdraw{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// WithdrawHistory get the page of the withdrawals history
+func (c *Client) WithdrawHistory(req *WithdrawHistoryReq) ([]*WithdrawRecord, error) {
+	if req == nil {
+		req = &WithdrawHistoryReq{}
+	}
+	if req.Limit <= 0 || req.Limit > MaxWalletHistoryLimit {
+		req.Limit = DefaultWalletHistoryLimit
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointWithdrawHistory, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp []*WithdrawRecord
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
+
+// AllWithdrawals get all withdrawals of the request time span page by page starting from the request offset
+func (c *Client) AllWithdrawals(req *WithdrawHistoryReq) ([]*WithdrawRecord, error) {
+	var r WithdrawHistoryReq
+	if req != nil {
+		r = *req
+	}
+	var resp []*WithdrawRecord
+	for {
+		page, err := c.WithdrawHistory(&r)
+		if err != nil {
+			return resp, err
+		}
+		resp = append(resp, page...)
+		if len(page) < r.Limit {
+			return resp, nil
+		}
+		r.Offset += len(page)
+	}
+}
+
+// UniversalTransfer transfers the asset between the wallets of the account
+func (c *Client) UniversalTransfer(req *UniversalTransferReq) (*UniversalTransfer, error) {
+	switch {
+	case req == nil:
+		return nil, ErrNilRequest
+	case req.Type == "":
+		return nil, ErrEmptyTransferType
+	case req.Asset == "":
+		return nil, ErrEmptyAsset
+	case req.Amount == "":
+		return nil, ErrEmptyAmount
+	}
+	res, err := c.Do(fasthttp.MethodPost, EndpointUniversalTransfer, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &UniversalTransfer{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// UniversalTransferHistory get the page of the transfers of the type
+func (c *Client) UniversalTransferHistory(req *UniversalTransferHistoryReq) (*UniversalTransferHistory, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if req.Type == "" {
+		return nil, ErrEmptyTransferType
+	}
+	if req.Current <= 0 {
+		req.Current = 1
+	}
+	if req.Size <= 0 || req.Size > MaxTransferHistorySize {
+		req.Size = DefaultTransferHistorySize
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointUniversalTransfer, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &UniversalTransferHistory{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// AllUniversalTransfers get all transfers of the type page by page starting from the request page
+func (c *Client) AllUniversalTransfers(req *UniversalTransferHistoryReq) ([]*UniversalTransferRecord, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	r := *req
+	var resp []*UniversalTransferRecord
+	for {
+		page, err := c.UniversalTransferHistory(&r)
+		if err != nil {
+			return resp, err
+		}
+		resp = append(resp, page.Rows...)
+		if len(page.Rows) < r.Size || r.Current*r.Size >= page.Total {
+			return resp, nil
+		}
+		r.Current++
+	}
+}
+
+// AssetDividends get the distributions of the assets, e.g. airdrops and savings interest
+func (c *Client) AssetDividends(req *AssetDividendReq) (*AssetDividends, error) {
+	if req == nil {
+		req = &AssetDividendReq{}
+	}
+	if req.Limit <= 0 || req.Limit > MaxAssetDividendLimit {
+		req.Limit = DefaultAssetDividendLimit
+	}
+	res, err := c.Do(fasthttp.MethodGet, EndpointAssetDividend, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &AssetDividends{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// DustAssets get the small balances which can be converted to BNB
+func (c *Client) DustAssets() (*DustAssets, error) {
+	res, err := c.Do(fasthttp.MethodPost, EndpointDustAssets, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &DustAssets{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// Dust converts the small balances of the assets to BNB
+func (c *Client) Dust(req *DustReq) (*Dust, error) {
+	if req == nil {
+		return nil, ErrNilRequest
+	}
+	if len(req.Assets) == 0 {
+		return nil, ErrEmptyAsset
+	}
+	res, err := c.Do(fasthttp.MethodPost, EndpointDust, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	resp := &Dust{}
+	err = json.Unmarshal(res, resp)
+
+	return resp, err
+}
+
+// AssetDetail get the deposit and withdrawal details of the asset or all assets when the asset is not set
+func (c *Client) AssetDetail(req *AssetDetailReq) (map[string]*AssetDetail, error) {
+	res, err := c.Do(fasthttp.MethodGet, EndpointAssetDetail, req, true, false)
+	if err != nil {
+		return nil, err
+	}
+	var resp map[string]*AssetDetail
+	err = json.Unmarshal(res, &resp)
+
+	return resp, err
+}
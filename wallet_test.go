@@ -0,0 +1,123 @@
+package binance_test
+
+import (
+	"strconv"
+
+	"github.com/google/go-querystring/query"
+	"github.com/segmentio/encoding/json"
+	"github.com/valyala/fasthttp"
+
+	"github.com/xenking/binance-api"
+)
+
+type mockedWalletTestSuite struct {
+	mockedTestSuite
+}
+
+func (s *mockedWalletTestSuite) TestWithdraw() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(fasthttp.MethodPost, method)
+		s.Require().Equal(binance.EndpointWithdraw, endpoint)
+		s.Require().True(sign)
+		return []byte(`{"id":"7213fea8e94b4a5593d507237e5a555b"}`), nil
+	}
+	req := &binance.WithdrawReq{Coin: "USDT", Network: "TRX", Address: "TXLAQ63Xg1NAzckPwKHvzw7CSEmLMEqcdj", Amount: "10"}
+	_, err := s.client.Withdraw(req)
+	s.Require().ErrorIs(err, binance.ErrWithdrawDisabled)
+
+	s.client.EnableWithdraw(true)
+	defer s.client.EnableWithdraw(false)
+	resp, err := s.client.Withdraw(req)
+	s.Require().NoError(err)
+	s.Require().Equal("7213fea8e94b4a5593d507237e5a555b", resp.ID)
+
+	_, err = s.client.Withdraw(&binance.WithdrawReq{Coin: "USDT", Amount: "10"})
+	s.Require().ErrorIs(err, binance.ErrEmptyAddress)
+}
+
+func (s *mockedWalletTestSuite) TestAllDeposits() {
+	var offsets []int
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(binance.EndpointDepositHistory, endpoint)
+		req := data.(*binance.DepositHistoryReq)
+		s.Require().Equal(2, req.Limit)
+		values, err := query.Values(req)
+		s.Require().NoError(err)
+		s.Require().Equal("1", values.Get("status"))
+		offsets = append(offsets, req.Offset)
+		deposits := make([]*binance.Deposit, 0, req.Limit)
+		for i := req.Offset; i < 5 && len(deposits) < req.Limit; i++ {
+			deposits = append(deposits, &binance.Deposit{ID: strconv.Itoa(i), Status: binance.DepositStatusSuccess})
+		}
+		return json.Marshal(deposits)
+	}
+	status := binance.DepositStatusSuccess
+	req := &binance.DepositHistoryReq{Status: &status, Limit: 2}
+	deposits, err := s.client.AllDeposits(req)
+	s.Require().NoError(err)
+	s.Require().Len(deposits, 5)
+	s.Require().Equal("4", deposits[4].ID)
+	s.Require().Equal([]int{0, 2, 4}, offsets)
+	// the pages are requested with the copy of the request
+	s.Require().Zero(req.Offset)
+}
+
+func (s *mockedWalletTestSuite) TestAllUniversalTransfers() {
+	var pages []int
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		s.Require().Equal(fasthttp.MethodGet, method)
+		s.Require().Equal(binance.EndpointUniversalTransfer, endpoint)
+		req := data.(*binance.UniversalTransferHistoryReq)
+		s.Require().Equal(binance.DefaultTransferHistorySize, req.Size)
+		pages = append(pages, req.Current)
+		resp := &binance.UniversalTransferHistory{Total: 20}
+		for i := 0; i < req.Size; i++ {
+			resp.Rows = append(resp.Rows, &binance.UniversalTransferRecord{Asset: "USDT", Type: req.Type, TranID: int64((req.Current-1)*req.Size + i)})
+		}
+		return json.Marshal(resp)
+	}
+	req := &binance.UniversalTransferHistoryReq{Type: binance.TransferTypeMainUMFuture}
+	transfers, err := s.client.AllUniversalTransfers(req)
+	s.Require().NoError(err)
+	s.Require().Len(transfers, 20)
+	s.Require().EqualValues(19, transfers[19].TranID)
+	s.Require().Equal([]int{1, 2}, pages)
+	s.Require().Zero(req.Current)
+	s.Require().Zero(req.Size)
+
+	_, err = s.client.UniversalTransfer(&binance.UniversalTransferReq{Asset: "USDT", Amount: "1"})
+	s.Require().ErrorIs(err, binance.ErrEmptyTransferType)
+}
+
+func (s *mockedWalletTestSuite) TestCoinsAndDust() {
+	s.mock.Response = func(method, endpoint string, data interface{}, sign bool, stream bool) ([]byte, error) {
+		switch endpoint {
+		case binance.EndpointCoins:
+			return []byte(`[{"coin":"BTC","depositAllEnable":true,"free":"0.08074558","freeze":"0","ipoable":"0","ipoing":"0","isLegalMoney":false,"locked":"0","name":"Bitcoin","networkList":[{"addressRegex":"^(bnb1)[0-9a-z]{38}$","coin":"BTC","depositEnable":true,"isDefault":false,"memoRegex":"^[0-9A-Za-z\\-_]{1,120}$","minConfirm":1,"name":"BEP2","network":"BNB","specialTips":"","unLockConfirm":0,"withdrawEnable":true,"withdrawFee":"0.00000220","withdrawIntegerMultiple":"0.00000001","withdrawMax":"9999999999.99999999","withdrawMin":"0.00000440","sameAddress":true,"estimatedArrivalTime":25,"busy":false}],"storage":"0","trading":true,"withdrawAllEnable":true,"withdrawing":"0"}]`), nil
+		case binance.EndpointDust:
+			values, err := query.Values(data)
+			s.Require().NoError(err)
+			s.Require().Equal([]string{"ADA", "TRX"}, values["asset"])
+			return []byte(`{"totalServiceCharge":"0.02102542","totalTransfered":"1.05127099","transferResult":[{"amount":"0.03000000","fromAsset":"ETH","operateTime":1563368549307,"serviceChargeAmount":"0.00500000","tranId":2970932918,"transferedAmount":"0.25000000"}]}`), nil
+		case binance.EndpointAssetDetail:
+			return []byte(`{"CTR":{"minWithdrawAmount":"70.00000000","depositStatus":false,"withdrawFee":35,"withdrawStatus":true,"depositTip":"Delisted, Deposit Suspended"}}`), nil
+		}
+		s.FailNow("unexpected endpoint " + endpoint)
+		return nil, nil
+	}
+	coins, err := s.client.Coins()
+	s.Require().NoError(err)
+	s.Require().Equal("BNB", coins[0].NetworkList[0].Network)
+	s.Require().True(coins[0].NetworkList[0].SameAddress)
+
+	dust, err := s.client.Dust(&binance.DustReq{Assets: []string{"ADA", "TRX"}})
+	s.Require().NoError(err)
+	s.Require().Equal("1.05127099", dust.TotalTransfered)
+	_, err = s.client.Dust(&binance.DustReq{})
+	s.Require().ErrorIs(err, binance.ErrEmptyAsset)
+
+	details, err := s.client.AssetDetail(nil)
+	s.Require().NoError(err)
+	s.Require().False(details["CTR"].DepositStatus)
+	s.Require().Equal("35", details["CTR"].WithdrawFee.String())
+}
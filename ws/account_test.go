@@ -473,3 +473,50 @@ func (s *accountTestSuite) TestAccountInfo_OCOOrdersStream() {
 	err = info.Close()
 	s.Require().NoError(err)
 }
+
+func (s *accountTestSuite) TestAccountInfo_OrderAmendment() {
+	expected := &ws.OrderUpdateEvent{
+		EventType:         ws.AccountUpdateEventTypeOrderReport,
+		Symbol:            "ETHBTC",
+		NewClientOrderID:  "amended-id",
+		OrigClientOrderID: "original-id",
+		Side:              "BUY",
+		OrderType:         "LIMIT",
+		TimeInForce:       "GTC",
+		OrigQty:           "0.5",
+		Price:             "3400",
+		ExecutionType:     ws.ExecutionTypeReplaced,
+		Status:            "NEW",
+		Time:              int64(rand.Uint32()),
+		OrderCreatedTime:  int64(rand.Uint32()),
+		OrderID:           int64(rand.Uint32()),
+		IsWorking:         true,
+		WorkingTime:       int64(rand.Uint32()),
+	}
+
+	key, err := s.api.DataStream()
+	s.Require().NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	info, err := s.ws.AccountInfo(ctx, key)
+	s.Require().NoError(err)
+
+	stream := info.OrdersStream()
+	s.expected <- expected
+	actual := <-stream
+	s.Require().EqualValues(expected, actual)
+	s.Require().True(actual.IsAmendment())
+	close(s.expected)
+
+	s.mock.Callback = func(method, endpoint string, data interface{}, sign, stream bool) ([]byte, error) {
+		s.Require().IsType(binance.DataStream{}, data)
+		return nil, nil
+	}
+
+	err = s.api.DataStreamClose(key)
+	s.Require().NoError(err)
+	err = info.Close()
+	s.Require().NoError(err)
+}
@@ -1,6 +1,7 @@
 package ws
 
 import (
+	"bufio"
 	"context"
 	"net"
 	"strings"
@@ -157,12 +158,45 @@ func (c *Client) AccountInfo(ctx context.Context, listenKey string) (*AccountInf
 	return &AccountInfo{NewConn(wsc)}, nil
 }
 
+// Dial opens websocket with the stream of the given path, e.g. the streams of other markets served
+// under the client stream path. The messages are read with the Conn methods
+func (c *Client) Dial(ctx context.Context, path string) (Conn, error) {
+	wsc, err := newWSClient(ctx, c.conn, c.StreamPath, path)
+	if err != nil {
+		return Conn{}, err
+	}
+
+	return NewConn(wsc), nil
+}
+
 func newWSClient(ctx context.Context, conn net.Conn, paths ...string) (net.Conn, error) {
 	path := strings.Join(paths, "")
 	if conn != nil {
 		_, err := ws.Upgrade(conn)
 		return conn, err
 	}
-	newConn, _, _, err := ws.Dial(ctx, path)
-	return newConn, err
+	newConn, br, _, err := ws.Dial(ctx, path)
+	if err != nil || br == nil {
+		return newConn, err
+	}
+	// frames received together with the handshake response are left in the buffer
+	return &bufferedConn{Conn: newConn, r: br}, nil
+}
+
+// bufferedConn reads the data buffered during the handshake before reading from the connection
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) {
+	if c.r != nil {
+		if c.r.Buffered() > 0 {
+			return c.r.Read(p)
+		}
+		ws.PutReader(c.r)
+		c.r = nil
+	}
+
+	return c.Conn.Read(p)
 }
@@ -26,6 +26,17 @@ const (
 	AccountUpdateEventTypeOCOReport               AccountUpdateEventType = "listStatus"
 )
 
+// Execution types of the order update event
+const (
+	ExecutionTypeNew             binance.OrderStatus = "NEW"
+	ExecutionTypeCanceled        binance.OrderStatus = "CANCELED"
+	ExecutionTypeReplaced        binance.OrderStatus = "REPLACED"
+	ExecutionTypeRejected        binance.OrderStatus = "REJECTED"
+	ExecutionTypeTrade           binance.OrderStatus = "TRADE"
+	ExecutionTypeExpired         binance.OrderStatus = "EXPIRED"
+	ExecutionTypeTradePrevention binance.OrderStatus = "TRADE_PREVENTION"
+)
+
 // FrequencyType is a interval for Depth update
 type FrequencyType string
 
@@ -239,6 +250,8 @@ type BalanceUpdateEvent struct {
 }
 
 // OrderUpdateEvent represents the incoming messages for account orders websocket updates
+// Remark: Orders amended with keep priority are reported with REPLACED execution type,
+// OrigClientOrderID holds the client order id before the amendment and OrigQty the amended quantity
 type OrderUpdateEvent struct {
 	EventType           AccountUpdateEventType `json:"e"` // EventType represents the update type
 	Symbol              string                 `json:"s"` // Symbol represents the symbol related to the update
@@ -271,6 +284,13 @@ type OrderUpdateEvent struct {
 	StrategyID          int                    `json:"j"` // Strategy ID; This is only visible if the strategyId parameter was provided upon order placement
 	StrategyType        int                    `json:"J"` // Strategy Type; This is only visible if the strategyType parameter was provided upon order placement
 	Maker               bool                   `json:"m"` // Maker represents whether buyer is maker or not
+	IsWorking           bool                   `json:"w"` // IsWorking represents whether the order is on the book
+	WorkingTime         int64                  `json:"W"` // WorkingTime is the time when the order started working on the book
+}
+
+// IsAmendment checks whether the event reports the order amended with keep priority
+func (e *OrderUpdateEvent) IsAmendment() bool {
+	return e.ExecutionType == ExecutionTypeReplaced
 }
 
 type OCOOrderUpdateEvent struct {